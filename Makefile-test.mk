@@ -27,6 +27,8 @@ endif
 
 GO_CMD ?= go
 GO_TEST_FLAGS ?= -race
+# Number of runs of the parallel scheduling tests under the race detector.
+PARALLEL_SCHEDULING_TEST_COUNT ?= 5
 version_pkg = sigs.k8s.io/kueue/pkg/version
 LD_FLAGS += -X '$(version_pkg).GitVersion=$(GIT_TAG)'
 LD_FLAGS += -X '$(version_pkg).GitCommit=$(shell git rev-parse HEAD)'
@@ -82,6 +84,13 @@ LEADERWORKERSET_VERSION = $(shell $(GO_CMD) list -m -f "{{.Version}}" sigs.k8s.i
 test: gotestsum ## Run tests.
 	$(GOTESTSUM) --junitfile $(ARTIFACTS)/junit.xml -- $(GOFLAGS) $(GO_TEST_FLAGS) $(shell $(GO_CMD) list ./... | grep -v '/test/') -coverpkg=./... -coverprofile $(ARTIFACTS)/cover.out
 
+# The scheduler tests of the parallel scheduling always run under the race
+# detector, regardless of GO_TEST_FLAGS. They aren't part of the test target,
+# CI runs them in a separate job (.github/workflows/parallel-scheduling.yaml).
+.PHONY: test-parallel-scheduling
+test-parallel-scheduling: ## Run the parallel scheduling tests under the race detector.
+	$(GO_CMD) test $(GOFLAGS) -race -count=$(PARALLEL_SCHEDULING_TEST_COUNT) -run 'TestSchedule$$/parallel' ./pkg/scheduler/
+
 .PHONY: test-integration
 test-integration: gomod-download envtest ginkgo dep-crds kueuectl ginkgo-top ## Run integration tests for all singlecluster suites.
 	KUBEBUILDER_ASSETS="$(shell $(ENVTEST) use $(ENVTEST_K8S_VERSION) -p path)" \
@@ -216,6 +225,11 @@ test-performance-scheduler-once: gotestsum run-performance-scheduler
 		--cmdStats=$(SCALABILITY_RUN_DIR)/minimalkueue.stats.yaml \
 		--range=$(PROJECT_DIR)/test/performance/scheduler/default_rangespec.yaml
 
+BENCHTIME ?= 1x
+.PHONY: bench-performance-scheduler
+bench-performance-scheduler: ## Run the scheduler benchmarks.
+	$(GO_CMD) test -run='^$$' -bench=. -benchtime=$(BENCHTIME) ./test/performance/scheduler/benchmark
+
 PERFORMANCE_RETRY_COUNT?=2
 .PHONY: test-performance-scheduler
 test-performance-scheduler:
@@ -95,9 +95,16 @@ type Configuration struct {
 	// FairSharing controls the fair sharing semantics across the cluster.
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
 
+	// GracefulPreemption controls the graceful stop of the preempted workloads.
+	// It's only relevant when the GracefulPreemption feature gate is enabled.
+	GracefulPreemption *GracefulPreemption `json:"gracefulPreemption,omitempty"`
+
 	// Resources provides additional configuration options for handling the resources.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Scheduler controls the plugins extending the scheduler.
+	Scheduler *Scheduler `json:"scheduler,omitempty"`
+
 	// FeatureGates is a map of feature names to bools that allows to override the
 	// default enablement status of a feature. The map cannot be used in conjunction
 	// with passing the list of features via the command line argument "--feature-gates"
@@ -265,6 +272,41 @@ type MultiKueue struct {
 	// Defaults to 15 minutes.
 	// +optional
 	WorkerLostTimeout *metav1.Duration `json:"workerLostTimeout,omitempty"`
+
+	// FailoverPolicy defines when the workloads are moved away from the worker
+	// clusters which become unreachable, and when the worker clusters that
+	// fail repeatedly stop receiving new workloads.
+	// It applies when the MultiKueueFailover feature gate is enabled.
+	// +optional
+	FailoverPolicy *MultiKueueFailoverPolicy `json:"failoverPolicy,omitempty"`
+}
+
+type MultiKueueFailoverPolicy struct {
+	// UnreachableTimeout defines the time after which a workload that got quota
+	// reservation in a worker cluster that became unreachable is dispatched to
+	// the other worker clusters. The workload keeps its quota reservation in
+	// the manager cluster while it's dispatched again.
+	// It only has effect if lower than WorkerLostTimeout.
+	//
+	// Defaults to 5 minutes.
+	// +optional
+	UnreachableTimeout *metav1.Duration `json:"unreachableTimeout,omitempty"`
+
+	// CordonThreshold defines the number of times the connection to a worker
+	// cluster can fail within the CordonWindow before the worker cluster is
+	// cordoned. A cordoned worker cluster keeps the workloads it already
+	// has, but doesn't receive new ones.
+	//
+	// Defaults to 3. If 0, the worker clusters are never cordoned.
+	// +optional
+	CordonThreshold *int32 `json:"cordonThreshold,omitempty"`
+
+	// CordonWindow defines the time window in which the connection failures
+	// of a worker cluster are counted.
+	//
+	// Defaults to 10 minutes.
+	// +optional
+	CordonWindow *metav1.Duration `json:"cordonWindow,omitempty"`
 }
 
 type RequeuingStrategy struct {
@@ -464,4 +506,109 @@ type FairSharing struct {
 	//   newest start time first.
 	// The default strategy is ["LessThanOrEqualToFinalShare", "LessThanInitialShare"].
 	PreemptionStrategies []PreemptionStrategy `json:"preemptionStrategies,omitempty"`
+
+	// usageHalfLifeTime, when set, makes fair sharing account for the resources
+	// consumed in the past, instead of only the current usage.
+	// The resource-seconds consumed by the ClusterQueues, and by the LocalQueues
+	// of ClusterQueues using UsageBasedAdmissionFairSharing, are accumulated in
+	// their status, where they decay by half after each usageHalfLifeTime.
+	// The ClusterQueues and Cohorts which consumed the least resources above
+	// their nominal quotas in the past are considered first for admission.
+	// +optional
+	UsageHalfLifeTime *metav1.Duration `json:"usageHalfLifeTime,omitempty"`
+
+	// usageSamplingInterval is how often the consumed resources are
+	// accumulated in the status of the ClusterQueues and LocalQueues.
+	// Only relevant when usageHalfLifeTime is set.
+	// Defaults to 5 minutes.
+	// +optional
+	UsageSamplingInterval *metav1.Duration `json:"usageSamplingInterval,omitempty"`
+}
+
+type Scheduler struct {
+	// plugins lists the plugins enabled at each extension point of the
+	// scheduler. The plugins need to be registered in the scheduler.
+	// +optional
+	Plugins *SchedulerPlugins `json:"plugins,omitempty"`
+
+	// decisionLog records, for each workload evaluated in a scheduling
+	// cycle, the flavors tried, the flavor fungibility decisions, the
+	// preemption targets and the outcome, to the configured sinks.
+	// It's only relevant when the SchedulingDecisionLog feature gate is
+	// enabled.
+	// +optional
+	DecisionLog *SchedulerDecisionLog `json:"decisionLog,omitempty"`
+}
+
+type SchedulerDecisionLog struct {
+	// file is the path of the file the decisions are appended to, one JSON
+	// object per line. When the file grows above 100MiB, it's renamed with
+	// the ".1" suffix, replacing the previous one, and a new file is started.
+	// The decisions are dropped when the file can't keep up.
+	// +optional
+	File *string `json:"file,omitempty"`
+
+	// httpEndpoint is the URL the decisions of each scheduling cycle are
+	// sent to, with a POST request of JSON objects separated by new lines.
+	// The decisions are dropped when the endpoint can't keep up.
+	// +optional
+	HTTPEndpoint *string `json:"httpEndpoint,omitempty"`
+
+	// memoryCapacity is the number of the latest decisions kept in memory,
+	// which are served by the schedulingdecisions subresources of the
+	// workloads and the ClusterQueues in the visibility API, when the
+	// VisibilityOnDemand feature gate is enabled.
+	// Set to 0 to not keep the decisions in memory.
+	// Defaults to 1000.
+	// +optional
+	MemoryCapacity *int32 `json:"memoryCapacity,omitempty"`
+}
+
+type SchedulerPlugins struct {
+	// flavorFilter plugins can reject a resource flavor for the resources of
+	// a pod set, on top of the taints and the node affinity.
+	// +optional
+	FlavorFilter []SchedulerPlugin `json:"flavorFilter,omitempty"`
+
+	// flavorScore plugins score the resource flavors for the resources of a
+	// pod set. When set, the flavors of a resource group are all evaluated,
+	// instead of stopping at the first one satisfying the flavor fungibility
+	// policy, and the flavor with the highest weighted score is chosen among
+	// the flavors satisfying the policy.
+	// +optional
+	FlavorScore []SchedulerPlugin `json:"flavorScore,omitempty"`
+
+	// queueSort plugin orders the workloads nominated in a scheduling cycle,
+	// after the workloads fitting within the nominal quota of their
+	// ClusterQueue, instead of the priority and the queueing time.
+	// At most one plugin can be set.
+	// +optional
+	QueueSort []SchedulerPlugin `json:"queueSort,omitempty"`
+
+	// postFilter plugins select the workloads to preempt for a workload which
+	// doesn't fit. They run in order, before the built-in preemption, and the
+	// first plugin returning workloads to preempt ends the evaluation.
+	// +optional
+	PostFilter []SchedulerPlugin `json:"postFilter,omitempty"`
+}
+
+type SchedulerPlugin struct {
+	// name is the name of the plugin.
+	Name string `json:"name"`
+
+	// weight of the scores of the plugin. Only relevant for the flavorScore
+	// plugins.
+	// Defaults to 1.
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+type GracefulPreemption struct {
+	// timeout is the grace period given to a preempted workload to
+	// acknowledge the graceful stop, for example after saving a checkpoint.
+	// The workload keeps its quota during the grace period, and it's evicted
+	// once the job acknowledges the graceful stop or the grace period expires.
+	// Defaults to 5 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
@@ -50,9 +50,16 @@ const (
 	DefaultMultiKueueGCInterval                         = time.Minute
 	DefaultMultiKueueOrigin                             = "multikueue"
 	DefaultMultiKueueWorkerLostTimeout                  = 15 * time.Minute
+	DefaultMultiKueueUnreachableTimeout                 = 5 * time.Minute
+	DefaultMultiKueueCordonThreshold            int32   = 3
+	DefaultMultiKueueCordonWindow                       = 10 * time.Minute
 	DefaultRequeuingBackoffBaseSeconds                  = 60
 	DefaultRequeuingBackoffMaxSeconds                   = 3600
 	DefaultResourceTransformationStrategy               = Retain
+	DefaultFairSharingUsageSamplingInterval             = 5 * time.Minute
+	DefaultGracefulPreemptionTimeout                    = 5 * time.Minute
+	DefaultSchedulerPluginWeight                int32   = 1
+	DefaultSchedulerDecisionLogMemoryCapacity   int32   = 1000
 )
 
 func getOperatorNamespace() string {
@@ -207,9 +214,37 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	if cfg.MultiKueue.WorkerLostTimeout == nil {
 		cfg.MultiKueue.WorkerLostTimeout = &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout}
 	}
+	if cfg.MultiKueue.FailoverPolicy == nil {
+		cfg.MultiKueue.FailoverPolicy = &MultiKueueFailoverPolicy{}
+	}
+	if cfg.MultiKueue.FailoverPolicy.UnreachableTimeout == nil {
+		cfg.MultiKueue.FailoverPolicy.UnreachableTimeout = &metav1.Duration{Duration: DefaultMultiKueueUnreachableTimeout}
+	}
+	if cfg.MultiKueue.FailoverPolicy.CordonThreshold == nil {
+		cfg.MultiKueue.FailoverPolicy.CordonThreshold = ptr.To(DefaultMultiKueueCordonThreshold)
+	}
+	if cfg.MultiKueue.FailoverPolicy.CordonWindow == nil {
+		cfg.MultiKueue.FailoverPolicy.CordonWindow = &metav1.Duration{Duration: DefaultMultiKueueCordonWindow}
+	}
 	if fs := cfg.FairSharing; fs != nil && fs.Enable && len(fs.PreemptionStrategies) == 0 {
 		fs.PreemptionStrategies = []PreemptionStrategy{LessThanOrEqualToFinalShare, LessThanInitialShare}
 	}
+	if fs := cfg.FairSharing; fs != nil && fs.UsageHalfLifeTime != nil && fs.UsageSamplingInterval == nil {
+		fs.UsageSamplingInterval = &metav1.Duration{Duration: DefaultFairSharingUsageSamplingInterval}
+	}
+	if s := cfg.Scheduler; s != nil && s.Plugins != nil {
+		for i := range s.Plugins.FlavorScore {
+			if s.Plugins.FlavorScore[i].Weight == nil {
+				s.Plugins.FlavorScore[i].Weight = ptr.To[int32](DefaultSchedulerPluginWeight)
+			}
+		}
+	}
+	if s := cfg.Scheduler; s != nil && s.DecisionLog != nil && s.DecisionLog.MemoryCapacity == nil {
+		s.DecisionLog.MemoryCapacity = ptr.To(DefaultSchedulerDecisionLogMemoryCapacity)
+	}
+	if gp := cfg.GracefulPreemption; gp != nil && gp.Timeout == nil {
+		gp.Timeout = &metav1.Duration{Duration: DefaultGracefulPreemptionTimeout}
+	}
 
 	if cfg.Resources != nil {
 		for idx := range cfg.Resources.Transformations {
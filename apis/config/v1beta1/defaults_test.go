@@ -92,10 +92,17 @@ func TestSetDefaults_Configuration(t *testing.T) {
 		},
 	}
 
+	defaultFailoverPolicy := &MultiKueueFailoverPolicy{
+		UnreachableTimeout: &metav1.Duration{Duration: DefaultMultiKueueUnreachableTimeout},
+		CordonThreshold:    ptr.To(DefaultMultiKueueCordonThreshold),
+		CordonWindow:       &metav1.Duration{Duration: DefaultMultiKueueCordonWindow},
+	}
+
 	defaultMultiKueue := &MultiKueue{
 		GCInterval:        &metav1.Duration{Duration: DefaultMultiKueueGCInterval},
 		Origin:            ptr.To(DefaultMultiKueueOrigin),
 		WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
+		FailoverPolicy:    defaultFailoverPolicy,
 	}
 
 	podsReadyTimeout := metav1.Duration{Duration: defaultPodsReadyTimeout}
@@ -521,6 +528,9 @@ func TestSetDefaults_Configuration(t *testing.T) {
 					GCInterval:        &metav1.Duration{Duration: time.Second},
 					Origin:            ptr.To("multikueue-manager1"),
 					WorkerLostTimeout: &metav1.Duration{Duration: time.Minute},
+					FailoverPolicy: &MultiKueueFailoverPolicy{
+						UnreachableTimeout: &metav1.Duration{Duration: 30 * time.Second},
+					},
 				},
 			},
 			want: &Configuration{
@@ -536,6 +546,11 @@ func TestSetDefaults_Configuration(t *testing.T) {
 					GCInterval:        &metav1.Duration{Duration: time.Second},
 					Origin:            ptr.To("multikueue-manager1"),
 					WorkerLostTimeout: &metav1.Duration{Duration: time.Minute},
+					FailoverPolicy: &MultiKueueFailoverPolicy{
+						UnreachableTimeout: &metav1.Duration{Duration: 30 * time.Second},
+						CordonThreshold:    ptr.To(DefaultMultiKueueCordonThreshold),
+						CordonWindow:       &metav1.Duration{Duration: DefaultMultiKueueCordonWindow},
+					},
 				},
 				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
 			},
@@ -563,6 +578,7 @@ func TestSetDefaults_Configuration(t *testing.T) {
 					GCInterval:        &metav1.Duration{},
 					Origin:            ptr.To("multikueue-manager1"),
 					WorkerLostTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+					FailoverPolicy:    defaultFailoverPolicy,
 				},
 				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
 			},
@@ -593,6 +609,123 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				},
 			},
 		},
+		"add default fair sharing usage sampling interval when usage half-life time is set": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				FairSharing: &FairSharing{
+					Enable:            true,
+					UsageHalfLifeTime: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				FairSharing: &FairSharing{
+					Enable:                true,
+					PreemptionStrategies:  []PreemptionStrategy{LessThanOrEqualToFinalShare, LessThanInitialShare},
+					UsageHalfLifeTime:     &metav1.Duration{Duration: time.Hour},
+					UsageSamplingInterval: &metav1.Duration{Duration: DefaultFairSharingUsageSamplingInterval},
+				},
+			},
+		},
+		"add default graceful preemption timeout": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				GracefulPreemption: &GracefulPreemption{},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				GracefulPreemption: &GracefulPreemption{
+					Timeout: &metav1.Duration{Duration: DefaultGracefulPreemptionTimeout},
+				},
+			},
+		},
+		"scheduler flavorScore plugin weight": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				Scheduler: &Scheduler{
+					Plugins: &SchedulerPlugins{
+						FlavorScore: []SchedulerPlugin{
+							{Name: "a"},
+							{Name: "b", Weight: ptr.To[int32](3)},
+						},
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				Scheduler: &Scheduler{
+					Plugins: &SchedulerPlugins{
+						FlavorScore: []SchedulerPlugin{
+							{Name: "a", Weight: ptr.To[int32](DefaultSchedulerPluginWeight)},
+							{Name: "b", Weight: ptr.To[int32](3)},
+						},
+					},
+				},
+			},
+		},
+		"scheduler decisionLog memory capacity": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				Scheduler: &Scheduler{
+					DecisionLog: &SchedulerDecisionLog{
+						File: ptr.To("/var/log/kueue/decisions.jsonl"),
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				Scheduler: &Scheduler{
+					DecisionLog: &SchedulerDecisionLog{
+						File:           ptr.To("/var/log/kueue/decisions.jsonl"),
+						MemoryCapacity: ptr.To(DefaultSchedulerDecisionLogMemoryCapacity),
+					},
+				},
+			},
+		},
 		"resources.transformations strategy": {
 			original: &Configuration{
 				InternalCertManagement: &InternalCertManagement{
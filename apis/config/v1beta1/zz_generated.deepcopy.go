@@ -118,11 +118,21 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(FairSharing)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GracefulPreemption != nil {
+		in, out := &in.GracefulPreemption, &out.GracefulPreemption
+		*out = new(GracefulPreemption)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(Scheduler)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = make(map[string]bool, len(*in))
@@ -263,6 +273,16 @@ func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 		*out = make([]PreemptionStrategy, len(*in))
 		copy(*out, *in)
 	}
+	if in.UsageHalfLifeTime != nil {
+		in, out := &in.UsageHalfLifeTime, &out.UsageHalfLifeTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UsageSamplingInterval != nil {
+		in, out := &in.UsageSamplingInterval, &out.UsageSamplingInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairSharing.
@@ -275,6 +295,26 @@ func (in *FairSharing) DeepCopy() *FairSharing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulPreemption) DeepCopyInto(out *GracefulPreemption) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulPreemption.
+func (in *GracefulPreemption) DeepCopy() *GracefulPreemption {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulPreemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Integrations) DeepCopyInto(out *Integrations) {
 	*out = *in
@@ -358,6 +398,11 @@ func (in *MultiKueue) DeepCopyInto(out *MultiKueue) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.FailoverPolicy != nil {
+		in, out := &in.FailoverPolicy, &out.FailoverPolicy
+		*out = new(MultiKueueFailoverPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueue.
@@ -370,6 +415,36 @@ func (in *MultiKueue) DeepCopy() *MultiKueue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueFailoverPolicy) DeepCopyInto(out *MultiKueueFailoverPolicy) {
+	*out = *in
+	if in.UnreachableTimeout != nil {
+		in, out := &in.UnreachableTimeout, &out.UnreachableTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CordonThreshold != nil {
+		in, out := &in.CordonThreshold, &out.CordonThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CordonWindow != nil {
+		in, out := &in.CordonWindow, &out.CordonWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueFailoverPolicy.
+func (in *MultiKueueFailoverPolicy) DeepCopy() *MultiKueueFailoverPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueFailoverPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIntegrationOptions) DeepCopyInto(out *PodIntegrationOptions) {
 	*out = *in
@@ -504,6 +579,124 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scheduler) DeepCopyInto(out *Scheduler) {
+	*out = *in
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = new(SchedulerPlugins)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DecisionLog != nil {
+		in, out := &in.DecisionLog, &out.DecisionLog
+		*out = new(SchedulerDecisionLog)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scheduler.
+func (in *Scheduler) DeepCopy() *Scheduler {
+	if in == nil {
+		return nil
+	}
+	out := new(Scheduler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerDecisionLog) DeepCopyInto(out *SchedulerDecisionLog) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPEndpoint != nil {
+		in, out := &in.HTTPEndpoint, &out.HTTPEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.MemoryCapacity != nil {
+		in, out := &in.MemoryCapacity, &out.MemoryCapacity
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerDecisionLog.
+func (in *SchedulerDecisionLog) DeepCopy() *SchedulerDecisionLog {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerDecisionLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerPlugin) DeepCopyInto(out *SchedulerPlugin) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerPlugin.
+func (in *SchedulerPlugin) DeepCopy() *SchedulerPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerPlugins) DeepCopyInto(out *SchedulerPlugins) {
+	*out = *in
+	if in.FlavorFilter != nil {
+		in, out := &in.FlavorFilter, &out.FlavorFilter
+		*out = make([]SchedulerPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FlavorScore != nil {
+		in, out := &in.FlavorScore, &out.FlavorScore
+		*out = make([]SchedulerPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueueSort != nil {
+		in, out := &in.QueueSort, &out.QueueSort
+		*out = make([]SchedulerPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostFilter != nil {
+		in, out := &in.PostFilter, &out.PostFilter
+		*out = make([]SchedulerPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerPlugins.
+func (in *SchedulerPlugins) DeepCopy() *SchedulerPlugins {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerPlugins)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WaitForPodsReady) DeepCopyInto(out *WaitForPodsReady) {
 	*out = *in
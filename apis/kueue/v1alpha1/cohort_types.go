@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -64,8 +66,54 @@ type CohortSpec struct {
 	FairSharing *kueuebeta.FairSharing `json:"fairSharing,omitempty"`
 }
 
+// CohortStatus defines the observed state of Cohort
+type CohortStatus struct {
+	// flavorsReservation are the reserved quotas, by flavor, currently in
+	// use by the workloads assigned to the ClusterQueues in this Cohort's
+	// subtree.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	FlavorsReservation []CohortFlavorUsage `json:"flavorsReservation,omitempty"`
+
+	// fairSharing contains the information about the current status of fair sharing.
+	// +optional
+	FairSharing *kueuebeta.FairSharingStatus `json:"fairSharing,omitempty"`
+}
+
+type CohortFlavorUsage struct {
+	// name of the flavor.
+	Name kueuebeta.ResourceFlavorReference `json:"name"`
+
+	// resources lists the quota usage for the resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []CohortResourceUsage `json:"resources"`
+}
+
+type CohortResourceUsage struct {
+	// name of the resource
+	Name corev1.ResourceName `json:"name"`
+
+	// subtreeQuota is the quota available to the Cohort's subtree. It is
+	// the sum of the Cohort's own nominalQuota and the quota lent by its
+	// children, constrained by their lendingLimits.
+	SubtreeQuota resource.Quantity `json:"subtreeQuota,omitempty"`
+
+	// total is the quantity of the subtreeQuota which is in use,
+	// including the amount borrowed from the parent Cohort.
+	Total resource.Quantity `json:"total,omitempty"`
+
+	// borrowed is the quantity of quota that is borrowed from the parent
+	// Cohort. In other words, it's the usage that is over the subtreeQuota.
+	Borrowed resource.Quantity `json:"borrowed,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
 
 // Cohort is the Schema for the cohorts API. Using Hierarchical
 // Cohorts (any Cohort which has a parent) with Fair Sharing
@@ -74,7 +122,8 @@ type Cohort struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec CohortSpec `json:"spec,omitempty"`
+	Spec   CohortSpec   `json:"spec,omitempty"`
+	Status CohortStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,144 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const (
+	// ReservationLabel is the label key, in the Job and its Workload, that
+	// holds the name of the Reservation whose capacity the Workload can use.
+	ReservationLabel = "kueue.x-k8s.io/reservation"
+
+	// ReservationActive indicates whether the reserved capacity can be used
+	// by the workloads naming the Reservation.
+	ReservationActive = "Active"
+
+	// ReservationScheduledReason indicates that the time window of the
+	// Reservation didn't start and the capacity isn't held back yet.
+	ReservationScheduledReason = "Scheduled"
+	// ReservationDrainingReason indicates that the capacity is held back from
+	// the other workloads, ahead of the time window of the Reservation.
+	ReservationDrainingReason = "Draining"
+	// ReservationInWindowReason indicates that the time window of the
+	// Reservation started.
+	ReservationInWindowReason = "InWindow"
+	// ReservationExpiredReason indicates that the time window of the
+	// Reservation ended.
+	ReservationExpiredReason = "Expired"
+)
+
+// ReservationSpec defines the desired state of Reservation
+// +kubebuilder:validation:XValidation:rule="has(self.clusterQueue) != has(self.cohort)", message="exactly one of clusterQueue and cohort must be set"
+type ReservationSpec struct {
+	// clusterQueue is the name of the ClusterQueue whose quota is reserved.
+	// +optional
+	ClusterQueue kueuebeta.ClusterQueueReference `json:"clusterQueue,omitempty"`
+
+	// cohort is the name of the Cohort whose quota is reserved. The
+	// reserved capacity can be used by the workloads naming the
+	// Reservation in any ClusterQueue of the Cohort's subtree.
+	// +optional
+	Cohort kueuebeta.CohortReference `json:"cohort,omitempty"`
+
+	// flavors are the reserved quantities, by flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Flavors []ReservedFlavor `json:"flavors"`
+
+	// startTime is the time at which the reserved capacity becomes
+	// available to the workloads naming the Reservation.
+	StartTime metav1.Time `json:"startTime"`
+
+	// duration is the length of the time window, starting at startTime,
+	// during which the capacity is reserved.
+	Duration metav1.Duration `json:"duration"`
+
+	// drainDuration is the time, before startTime, during which the
+	// reserved capacity is held back from the admission of new workloads,
+	// so that the workloads using it finish before the window starts.
+	// Defaults to 0.
+	// +optional
+	DrainDuration *metav1.Duration `json:"drainDuration,omitempty"`
+}
+
+type ReservedFlavor struct {
+	// name of the flavor.
+	Name kueuebeta.ResourceFlavorReference `json:"name"`
+
+	// resources are the reserved quantities of the resources of the flavor.
+	Resources corev1.ResourceList `json:"resources"`
+}
+
+// ReservationStatus defines the observed state of Reservation
+type ReservationStatus struct {
+	// conditions hold the latest available observations of the Reservation
+	// current state.
+	//
+	// The type of the condition could be:
+	//
+	// - Active: the time window of the Reservation started, and the
+	//   reserved capacity can be used by the workloads naming it.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="ClusterQueue",JSONPath=".spec.clusterQueue",type=string,description="ClusterQueue whose quota is reserved"
+//+kubebuilder:printcolumn:name="Cohort",JSONPath=".spec.cohort",type=string,description="Cohort whose quota is reserved"
+//+kubebuilder:printcolumn:name="Start",JSONPath=".spec.startTime",type=date,description="Start of the time window"
+//+kubebuilder:printcolumn:name="Duration",JSONPath=".spec.duration",type=string,description="Length of the time window"
+//+kubebuilder:printcolumn:name="Active",JSONPath=".status.conditions[?(@.type=='Active')].status",type=string,description="Whether the time window started"
+
+// Reservation is the Schema for the reservations API. A Reservation holds
+// back quota of a ClusterQueue or a Cohort, during a time window, for the
+// workloads naming it.
+type Reservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservationSpec   `json:"spec,omitempty"`
+	Status ReservationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReservationList contains a list of Reservation
+type ReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Reservation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Reservation{}, &ReservationList{})
+}
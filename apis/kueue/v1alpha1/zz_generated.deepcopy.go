@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
@@ -31,6 +33,7 @@ func (in *Cohort) DeepCopyInto(out *Cohort) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cohort.
@@ -51,6 +54,28 @@ func (in *Cohort) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CohortFlavorUsage) DeepCopyInto(out *CohortFlavorUsage) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]CohortResourceUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortFlavorUsage.
+func (in *CohortFlavorUsage) DeepCopy() *CohortFlavorUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(CohortFlavorUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CohortList) DeepCopyInto(out *CohortList) {
 	*out = *in
@@ -83,6 +108,24 @@ func (in *CohortList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CohortResourceUsage) DeepCopyInto(out *CohortResourceUsage) {
+	*out = *in
+	out.SubtreeQuota = in.SubtreeQuota.DeepCopy()
+	out.Total = in.Total.DeepCopy()
+	out.Borrowed = in.Borrowed.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortResourceUsage.
+func (in *CohortResourceUsage) DeepCopy() *CohortResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(CohortResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CohortSpec) DeepCopyInto(out *CohortSpec) {
 	*out = *in
@@ -110,6 +153,165 @@ func (in *CohortSpec) DeepCopy() *CohortSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CohortStatus) DeepCopyInto(out *CohortStatus) {
+	*out = *in
+	if in.FlavorsReservation != nil {
+		in, out := &in.FlavorsReservation, &out.FlavorsReservation
+		*out = make([]CohortFlavorUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FairSharing != nil {
+		in, out := &in.FairSharing, &out.FairSharing
+		*out = new(v1beta1.FairSharingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CohortStatus.
+func (in *CohortStatus) DeepCopy() *CohortStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CohortStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reservation) DeepCopyInto(out *Reservation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reservation.
+func (in *Reservation) DeepCopy() *Reservation {
+	if in == nil {
+		return nil
+	}
+	out := new(Reservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Reservation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationList) DeepCopyInto(out *ReservationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Reservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationList.
+func (in *ReservationList) DeepCopy() *ReservationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReservationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationSpec) DeepCopyInto(out *ReservationSpec) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]ReservedFlavor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	out.Duration = in.Duration
+	if in.DrainDuration != nil {
+		in, out := &in.DrainDuration, &out.DrainDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSpec.
+func (in *ReservationSpec) DeepCopy() *ReservationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationStatus) DeepCopyInto(out *ReservationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationStatus.
+func (in *ReservationStatus) DeepCopy() *ReservationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservedFlavor) DeepCopyInto(out *ReservedFlavor) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedFlavor.
+func (in *ReservedFlavor) DeepCopy() *ReservedFlavor {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservedFlavor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Topology) DeepCopyInto(out *Topology) {
 	*out = *in
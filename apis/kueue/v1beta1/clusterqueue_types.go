@@ -100,6 +100,45 @@ type ClusterQueueSpec struct {
 	// +kubebuilder:default={}
 	FlavorFungibility *FlavorFungibility `json:"flavorFungibility,omitempty"`
 
+	// flavorSelectionPolicy indicates how the flavors of a resource group are
+	// chosen for the resources of a workload. Possible values are:
+	//
+	// - InOrder (default): the flavors are tried in the order of the resource
+	// group, following the flavorFungibility.
+	// - LowestCost: all the flavors are evaluated, and the flavor with the
+	// lowest cost, as per the costs of the ResourceFlavors, is chosen among
+	// the flavors satisfying the flavorFungibility. When preempting, the
+	// workloads freeing the cheapest resources are preempted first among the
+	// workloads of the same priority.
+	//
+	// Requires the CostAwareFlavorSelection feature gate.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=InOrder;LowestCost
+	FlavorSelectionPolicy FlavorSelectionPolicy `json:"flavorSelectionPolicy,omitempty"`
+
+	// priorityAging raises the effective priority of the pending workloads
+	// of the ClusterQueue with the time they wait, so that the workloads with
+	// a low priority aren't starved by the workloads with a higher priority.
+	// The effective priority is used to order the pending workloads.
+	//
+	// Requires the PriorityAging feature gate.
+	//
+	// +optional
+	PriorityAging *PriorityAging `json:"priorityAging,omitempty"`
+
+	// maxAdmittedWorkloads is the maximum number of workloads that can
+	// reserve quota in the ClusterQueue at the same time, regardless of the
+	// resources they request. When the limit is reached, a pending workload
+	// can only reserve quota by preempting another workload, as per the
+	// withinClusterQueue preemption policy.
+	//
+	// Requires the MaxAdmittedWorkloads feature gate.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
+
 	// +kubebuilder:default={}
 	Preemption *ClusterQueuePreemption `json:"preemption,omitempty"`
 
@@ -132,6 +171,46 @@ type ClusterQueueSpec struct {
 	// if FairSharing is enabled in the Kueue configuration.
 	// +optional
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+
+	// admissionScope indicates how the workloads from the LocalQueues
+	// pointing to this ClusterQueue are ordered for admission.
+	//
+	// Requires the AdmissionFairSharing feature gate.
+	//
+	// +optional
+	AdmissionScope *AdmissionScope `json:"admissionScope,omitempty"`
+}
+
+// AdmissionMode is the mode used to order the workloads of the LocalQueues
+// in a ClusterQueue.
+type AdmissionMode string
+
+const (
+	// UsageBasedAdmissionFairSharing orders the heads of the LocalQueues by
+	// their share of the ClusterQueue usage, divided by their weights.
+	UsageBasedAdmissionFairSharing AdmissionMode = "UsageBasedAdmissionFairSharing"
+
+	// NoAdmissionFairSharing orders all the workloads in the ClusterQueue
+	// by priority and timestamp, regardless of their LocalQueues.
+	NoAdmissionFairSharing AdmissionMode = "NoAdmissionFairSharing"
+)
+
+// AdmissionScope defines how the workloads from the LocalQueues of a
+// ClusterQueue are ordered for admission.
+type AdmissionScope struct {
+	// admissionMode indicates how the workloads are ordered. Possible values are:
+	//
+	// - UsageBasedAdmissionFairSharing: the next workload to be admitted comes
+	// from the LocalQueue with the lowest share of the ClusterQueue usage,
+	// that is, the maximum among the resources of the ratio of the usage of
+	// the LocalQueue to the nominal quota of the ClusterQueue, divided by the
+	// weight of the LocalQueue. Within a LocalQueue, workloads are ordered by
+	// priority and timestamp.
+	// - NoAdmissionFairSharing: workloads are ordered by priority and timestamp.
+	//
+	// +kubebuilder:validation:Enum=UsageBasedAdmissionFairSharing;NoAdmissionFairSharing
+	// +kubebuilder:default=UsageBasedAdmissionFairSharing
+	AdmissionMode AdmissionMode `json:"admissionMode,omitempty"`
 }
 
 // AdmissionChecksStrategy defines a strategy for a AdmissionCheck.
@@ -245,8 +324,82 @@ type ResourceQuota struct {
 	// This field is in beta stage and is enabled by default.
 	// +optional
 	LendingLimit *resource.Quantity `json:"lendingLimit,omitempty"`
+
+	// schedules is a list of time windows during which the nominalQuota,
+	// borrowingLimit and lendingLimit for the [flavor, resource] combination
+	// take different values. The first schedule, in list order, whose window
+	// contains the current time applies. Outside of all the windows, the
+	// values above apply.
+	// There could be up to 8 schedules.
+	// This field is in alpha stage and requires the QuotaSchedules feature gate.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	Schedules []QuotaSchedule `json:"schedules,omitempty"`
+}
+
+// QuotaSchedule overrides the quota of a [flavor, resource] combination during
+// a recurring time window.
+type QuotaSchedule struct {
+	// name identifies the schedule within the list of schedules of the resource.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// daysOfWeek lists the days on which the window starts.
+	// If empty, the window starts every day.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=7
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+
+	// startTime is the time of the day, in the 24-hour "HH:MM" format, at which
+	// the window starts.
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	StartTime string `json:"startTime"`
+
+	// endTime is the time of the day, in the 24-hour "HH:MM" format, at which
+	// the window ends. If endTime is not after startTime, the window ends on
+	// the following day.
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	EndTime string `json:"endTime"`
+
+	// timeZone is the name of the IANA time zone in which startTime and endTime
+	// are interpreted, for example "Europe/Warsaw". Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// nominalQuota replaces the nominalQuota of the resource while the window
+	// is active. If null, the nominalQuota of the resource applies.
+	// +optional
+	NominalQuota *resource.Quantity `json:"nominalQuota,omitempty"`
+
+	// borrowingLimit replaces the borrowingLimit of the resource while the
+	// window is active. If null, the borrowingLimit of the resource applies.
+	// +optional
+	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
+
+	// lendingLimit replaces the lendingLimit of the resource while the window
+	// is active. If null, the lendingLimit of the resource applies.
+	// +optional
+	LendingLimit *resource.Quantity `json:"lendingLimit,omitempty"`
 }
 
+// DayOfWeek is a day of the week.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type DayOfWeek string
+
+const (
+	Monday    DayOfWeek = "Monday"
+	Tuesday   DayOfWeek = "Tuesday"
+	Wednesday DayOfWeek = "Wednesday"
+	Thursday  DayOfWeek = "Thursday"
+	Friday    DayOfWeek = "Friday"
+	Saturday  DayOfWeek = "Saturday"
+	Sunday    DayOfWeek = "Sunday"
+)
+
 // ResourceFlavorReference is the name of the ResourceFlavor.
 // +kubebuilder:validation:MaxLength=253
 // +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
@@ -305,6 +458,11 @@ type ClusterQueueStatus struct {
 	// FairSharing contains the information about the current status of fair sharing.
 	// +optional
 	FairSharing *FairSharingStatus `json:"fairSharing,omitempty"`
+
+	// maxAdmittedWorkloadsUsage is the number of workloads counted against
+	// maxAdmittedWorkloads. It's only set when maxAdmittedWorkloads is set.
+	// +optional
+	MaxAdmittedWorkloadsUsage *int32 `json:"maxAdmittedWorkloadsUsage,omitempty"`
 }
 
 type ClusterQueuePendingWorkloadsStatus struct {
@@ -366,6 +524,45 @@ const (
 	PreemptionPolicyLowerOrNewerEqualPriority PreemptionPolicy = "LowerOrNewerEqualPriority"
 )
 
+// PriorityAging defines how the effective priority of a pending workload is
+// raised with its wait time. The wait time is measured from the creation or
+// the last requeueing of the workload.
+type PriorityAging struct {
+	// interval is the wait time after which the effective priority is raised
+	// by the increment.
+	Interval metav1.Duration `json:"interval"`
+
+	// increment is added to the effective priority for every interval of
+	// wait time.
+	// +kubebuilder:validation:Minimum=1
+	Increment int32 `json:"increment"`
+
+	// maxIncrement caps the raise of the effective priority over the
+	// priority of the workload.
+	// +kubebuilder:validation:Minimum=0
+	MaxIncrement int32 `json:"maxIncrement"`
+
+	// useInPreemption indicates whether the effective priority of a pending
+	// workload is used to select the workloads it can preempt. Otherwise,
+	// only the priority of the workload is used.
+	// Defaults to false.
+	// +optional
+	UseInPreemption bool `json:"useInPreemption,omitempty"`
+}
+
+// FlavorSelectionPolicy is the policy used to choose the flavors of a
+// resource group.
+type FlavorSelectionPolicy string
+
+const (
+	// InOrderFlavorSelection tries the flavors in the order of the resource
+	// group.
+	InOrderFlavorSelection FlavorSelectionPolicy = "InOrder"
+
+	// LowestCostFlavorSelection chooses the flavors with the lowest cost.
+	LowestCostFlavorSelection FlavorSelectionPolicy = "LowestCost"
+)
+
 type FlavorFungibilityPolicy string
 
 const (
@@ -459,6 +656,15 @@ type ClusterQueuePreemption struct {
 	// +kubebuilder:default=Never
 	// +kubebuilder:validation:Enum=Never;LowerPriority;LowerOrNewerEqualPriority
 	WithinClusterQueue PreemptionPolicy `json:"withinClusterQueue,omitempty"`
+
+	// minimumRuntimeSeconds is the time, in seconds, that the Workloads
+	// admitted in the ClusterQueue run before they can be preempted.
+	// The minimumRuntimeSeconds of the Workload, if set, takes precedence.
+	// This field requires the PreemptionMinimumRuntime feature gate to be enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinimumRuntimeSeconds *int32 `json:"minimumRuntimeSeconds,omitempty"`
 }
 
 type BorrowWithinCohortPolicy string
@@ -16,10 +16,14 @@ limitations under the License.
 
 package v1beta1
 
-import "k8s.io/apimachinery/pkg/api/resource"
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
-// FairSharing contains the properties of the ClusterQueue or Cohort,
-// when participating in FairSharing.
+// FairSharing contains the properties of the ClusterQueue, Cohort or
+// LocalQueue, when participating in FairSharing.
 type FairSharing struct {
 	// weight gives a comparative advantage to this ClusterQueue
 	// or Cohort when competing for unused resources in the
@@ -31,6 +35,10 @@ type FairSharing struct {
 	// with the highest share.  A zero weight implies infinite
 	// share value, meaning that this Node will always be at
 	// disadvantage against other ClusterQueues and Cohorts.
+	//
+	// For a LocalQueue, the weight gives a comparative advantage
+	// when competing with the other LocalQueues of a ClusterQueue
+	// using UsageBasedAdmissionFairSharing.
 	// +kubebuilder:default=1
 	Weight *resource.Quantity `json:"weight,omitempty"`
 }
@@ -44,4 +52,22 @@ type FairSharingStatus struct {
 	// weight of zero, this will return 9223372036854775807, the
 	// maximum possible share value.
 	WeightedShare int64 `json:"weightedShare"`
+
+	// consumedResources holds the resources consumed in the past, when fair
+	// sharing accounts for the historical usage.
+	// +optional
+	ConsumedResources *ConsumedResources `json:"consumedResources,omitempty"`
+}
+
+// ConsumedResources holds the resource-seconds consumed by a ClusterQueue
+// or a LocalQueue, decayed according to the half-life time configured for
+// fair sharing.
+type ConsumedResources struct {
+	// resources is the decayed sum of the resources reserved by the admitted
+	// workloads over time, in resource-seconds.
+	Resources corev1.ResourceList `json:"resources"`
+
+	// lastUpdate is the time when the resources were accumulated for the
+	// last time.
+	LastUpdate metav1.Time `json:"lastUpdate"`
 }
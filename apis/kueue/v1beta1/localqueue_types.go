@@ -41,6 +41,58 @@ type LocalQueueSpec struct {
 	// +kubebuilder:validation:Enum=None;Hold;HoldAndDrain
 	// +kubebuilder:default="None"
 	StopPolicy *StopPolicy `json:"stopPolicy,omitempty"`
+
+	// quotas limits the resources, per flavor, that the workloads submitted
+	// to this LocalQueue can reserve in the ClusterQueue. The resources that
+	// are not listed are only limited by the quota of the ClusterQueue.
+	//
+	// Requires the LocalQueueQuotas feature gate.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Quotas []LocalQueueFlavorQuotas `json:"quotas,omitempty"`
+
+	// fairSharing defines the properties of the LocalQueue when competing
+	// with the other LocalQueues of its ClusterQueue, when the ClusterQueue
+	// uses UsageBasedAdmissionFairSharing.
+	//
+	// Requires the AdmissionFairSharing feature gate.
+	//
+	// +optional
+	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+
+	// maxAdmittedWorkloads is the maximum number of workloads submitted to
+	// this LocalQueue that can reserve quota in the ClusterQueue at the same
+	// time, regardless of the resources they request.
+	//
+	// Requires the MaxAdmittedWorkloads feature gate.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAdmittedWorkloads *int32 `json:"maxAdmittedWorkloads,omitempty"`
+}
+
+type LocalQueueFlavorQuotas struct {
+	// name of this flavor.
+	Name ResourceFlavorReference `json:"name"`
+
+	// resources is the list of maximum usages, for the resources of this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:MinItems=1
+	Resources []LocalQueueResourceQuota `json:"resources"`
+}
+
+type LocalQueueResourceQuota struct {
+	// name of this resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// max is the maximum quantity of this resource, in this flavor, that the
+	// workloads submitted to this LocalQueue can reserve at a time.
+	Max resource.Quantity `json:"max"`
 }
 
 // ClusterQueueReference is the name of the ClusterQueue.
@@ -122,6 +174,17 @@ type LocalQueueStatus struct {
 	// +kubebuilder:validation:MaxItems=16
 	// +optional
 	Flavors []LocalQueueFlavorStatus `json:"flavors,omitempty"`
+
+	// fairSharing contains the information about the current status of
+	// admission fair sharing, when the ClusterQueue uses
+	// UsageBasedAdmissionFairSharing.
+	// +optional
+	FairSharing *FairSharingStatus `json:"fairSharing,omitempty"`
+
+	// maxAdmittedWorkloadsUsage is the number of workloads counted against
+	// maxAdmittedWorkloads. It's only set when maxAdmittedWorkloads is set.
+	// +optional
+	MaxAdmittedWorkloadsUsage *int32 `json:"maxAdmittedWorkloadsUsage,omitempty"`
 }
 
 const (
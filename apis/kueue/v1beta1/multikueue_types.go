@@ -24,10 +24,20 @@ const (
 	MultiKueueConfigSecretKey = "kubeconfig"
 	MultiKueueClusterActive   = "Active"
 
+	// MultiKueueClusterCordoned is the condition indicating that the
+	// connection to the cluster failed repeatedly, and the cluster doesn't
+	// receive new workloads.
+	MultiKueueClusterCordoned = "Cordoned"
+
 	// MultiKueueOriginLabel is a label used to track the creator
 	// of multikueue remote objects.
 	MultiKueueOriginLabel = "kueue.x-k8s.io/multikueue-origin"
 
+	// MultiKueueAbandonedClustersAnnotation is an annotation of the workloads
+	// listing, comma separated, the worker clusters the workload failed over
+	// from, whose copies of the workload are yet to be deleted.
+	MultiKueueAbandonedClustersAnnotation = "kueue.x-k8s.io/multikueue-abandoned-clusters"
+
 	// MultiKueueControllerName is the name used by the MultiKueue
 	// admission check controller.
 	MultiKueueControllerName = "kueue.x-k8s.io/multikueue"
@@ -107,6 +117,72 @@ type MultiKueueConfigSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=10
 	Clusters []string `json:"clusters"`
+
+	// dispatcher determines to which of the clusters the workloads are
+	// dispatched, and when.
+	// Defaults to dispatching the workloads to all the clusters at once.
+	// +optional
+	Dispatcher *MultiKueueDispatcher `json:"dispatcher,omitempty"`
+}
+
+// MultiKueueDispatcherName is the name of a strategy used to dispatch the
+// workloads to the worker clusters.
+// +kubebuilder:validation:MaxLength=316
+type MultiKueueDispatcherName string
+
+const (
+	// MultiKueueDispatcherAllAtOnce creates the workload in all the clusters
+	// at once, and keeps it in the first one that reserves quota.
+	MultiKueueDispatcherAllAtOnce MultiKueueDispatcherName = "AllAtOnce"
+
+	// MultiKueueDispatcherIncremental creates the workload in a batch of
+	// clusters, in the order in which they are listed, and adds the next batch
+	// of clusters each time the round timeout elapses without the workload
+	// reserving quota.
+	MultiKueueDispatcherIncremental MultiKueueDispatcherName = "Incremental"
+
+	// MultiKueueDispatcherFreeCapacity works like Incremental, but orders the
+	// clusters by the free capacity of the ClusterQueue that the workload
+	// would use in each of them, relative to the requests of the workload.
+	MultiKueueDispatcherFreeCapacity MultiKueueDispatcherName = "FreeCapacity"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.name in ['AllAtOnce', 'Incremental', 'FreeCapacity'] || self.name.contains('/')", message="name must be AllAtOnce, Incremental, FreeCapacity or a domain-prefixed path"
+
+// MultiKueueDispatcher configures how the workloads are dispatched to the
+// worker clusters.
+type MultiKueueDispatcher struct {
+	// name of the dispatching strategy, one of AllAtOnce, Incremental or
+	// FreeCapacity.
+	// The name of a custom cluster selector registered in the kueue manager
+	// can also be used. Such name must be a domain-prefixed path, such as
+	// acme.io/selector.
+	//
+	// +kubebuilder:default=AllAtOnce
+	Name MultiKueueDispatcherName `json:"name"`
+
+	// incremental configures the batches of clusters, for the Incremental and
+	// FreeCapacity dispatchers.
+	// +optional
+	Incremental *MultiKueueIncrementalDispatcher `json:"incremental,omitempty"`
+}
+
+// MultiKueueIncrementalDispatcher configures the batches of clusters to which
+// the workloads are dispatched.
+type MultiKueueIncrementalDispatcher struct {
+	// batchSize is the number of clusters added in each round.
+	// Defaults to 3.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// roundTimeout is the time after which the next batch of clusters is
+	// added, if the workload didn't reserve quota in any of the clusters.
+	// Defaults to 5 minutes.
+	//
+	// +optional
+	RoundTimeout *metav1.Duration `json:"roundTimeout,omitempty"`
 }
 
 // +genclient
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -101,6 +102,37 @@ type ResourceFlavorSpec struct {
 	//
 	// +optional
 	TopologyName *TopologyReference `json:"topologyName,omitempty"`
+
+	// costs are the prices of the resources of the ResourceFlavor.
+	// They are used by the ClusterQueues with the LowestCost
+	// flavorSelectionPolicy to choose the lowest-cost flavors, and to compute
+	// the cost of the admitted workloads.
+	// Requires the CostAwareFlavorSelection feature gate.
+	//
+	// costs can be up to 16 elements.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Costs []ResourceCost `json:"costs,omitempty"`
+}
+
+// ResourceCost is the price of a resource of a ResourceFlavor.
+type ResourceCost struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// price of a unit of the resource, in an arbitrary currency shared by
+	// all the ResourceFlavors.
+	Price resource.Quantity `json:"price"`
+
+	// unit is the quantity of the resource the price applies to, for
+	// example 1Gi for memory.
+	// Defaults to 1.
+	//
+	// +optional
+	Unit *resource.Quantity `json:"unit,omitempty"`
 }
 
 // +kubebuilder:object:root=true
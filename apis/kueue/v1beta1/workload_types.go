@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -86,6 +87,18 @@ type WorkloadSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=1
 	MaximumExecutionTimeSeconds *int32 `json:"maximumExecutionTimeSeconds,omitempty"`
+
+	// minimumRuntimeSeconds if provided, determines the time, in seconds, the
+	// workload runs after being admitted before it can be preempted. It takes
+	// precedence over the minimumRuntimeSeconds of the ClusterQueue.
+	//
+	// When the workload is created for a job, it's copied from the
+	// workloadPriorityClass of the job.
+	// This field requires the PreemptionMinimumRuntime feature gate to be enabled.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinimumRuntimeSeconds *int32 `json:"minimumRuntimeSeconds,omitempty"`
 }
 
 // PodSetTopologyRequest defines the topology request for a PodSet.
@@ -129,6 +142,14 @@ type Admission struct {
 	// +listMapKey=name
 	// +kubebuilder:validation:MaxItems=8
 	PodSetAssignments []PodSetAssignment `json:"podSetAssignments"`
+
+	// cost is the cost of the resources assigned to the workload, as per the
+	// costs of the assigned ResourceFlavors.
+	// Only set with the CostAwareFlavorSelection feature gate, when any of
+	// the assigned ResourceFlavors has costs.
+	//
+	// +optional
+	Cost *resource.Quantity `json:"cost,omitempty"`
 }
 
 // PodSetReference is the name of a PodSet.
@@ -359,6 +380,13 @@ type WorkloadStatus struct {
 	//
 	// +optional
 	AccumulatedPastExexcutionTimeSeconds *int32 `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+
+	// clusterName is the name of the MultiKueue worker cluster in which the
+	// workload got quota reservation.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	ClusterName *string `json:"clusterName,omitempty"`
 }
 
 type RequeueState struct {
@@ -503,6 +531,28 @@ const (
 	// WorkloadDeactivationTarget means that the Workload should be deactivated.
 	// This condition is temporary, so it should be removed after deactivation.
 	WorkloadDeactivationTarget = "DeactivationTarget"
+
+	// WorkloadPreemptionPending means that the Workload was selected for
+	// preemption, and its quota is released once the job acknowledges the
+	// graceful stop, or the grace period expires.
+	// While the condition is True, its reason and message are those of the
+	// preemption.
+	WorkloadPreemptionPending = "PreemptionPending"
+)
+
+// Reasons for the WorkloadPreemptionPending condition set to False.
+const (
+	// WorkloadGracefulStopAcknowledged indicates that the job acknowledged
+	// the graceful stop, for example after saving a checkpoint.
+	WorkloadGracefulStopAcknowledged = "GracefulStopAcknowledged"
+
+	// WorkloadGracePeriodExpired indicates that the job didn't acknowledge
+	// the graceful stop within the grace period.
+	WorkloadGracePeriodExpired = "GracePeriodExpired"
+
+	// WorkloadGracefulStopUnsupported indicates that the job doesn't support
+	// the graceful stop.
+	WorkloadGracefulStopUnsupported = "GracefulStopUnsupported"
 )
 
 // Reasons for the WorkloadPreempted condition.
@@ -560,6 +610,10 @@ const (
 	// because spec.active is set to false.
 	WorkloadDeactivated = "Deactivated"
 
+	// WorkloadEvictedByWorkloadGroup indicates that the workload was evicted
+	// because another member of its workload group was evicted.
+	WorkloadEvictedByWorkloadGroup = "WorkloadGroup"
+
 	// WorkloadReactivated indicates that the workload was requeued because
 	// spec.active is set to true after deactivation.
 	WorkloadReactivated = "Reactivated"
@@ -576,6 +630,14 @@ const (
 	// local queue was restarted after being stopped.
 	WorkloadLocalQueueRestarted = "LocalQueueRestarted"
 
+	// WorkloadWaitingForDependencies indicates that the workload isn't queued
+	// until the workloads it depends on finish successfully.
+	WorkloadWaitingForDependencies = "WaitingForDependencies"
+
+	// WorkloadDependenciesFinished indicates that the workload was queued
+	// because the workloads it depends on finished successfully.
+	WorkloadDependenciesFinished = "DependenciesFinished"
+
 	// WorkloadRequeuingLimitExceeded indicates that the workload exceeded max number
 	// of re-queuing retries.
 	WorkloadRequeuingLimitExceeded = "RequeuingLimitExceeded"
@@ -609,6 +671,14 @@ const (
 
 	// WorkloadFinishedReasonOutOfSync indicates that the prebuilt workload is not in sync with its parent job.
 	WorkloadFinishedReasonOutOfSync = "OutOfSync"
+
+	// WorkloadFinishedReasonSliceReplaced indicates that the workload slice was replaced
+	// by another slice of the same elastic job, which got admitted.
+	WorkloadFinishedReasonSliceReplaced = "WorkloadSliceReplaced"
+
+	// WorkloadFinishedReasonDependencyFailed indicates that the workload finished
+	// without running because a workload it depends on failed.
+	WorkloadFinishedReasonDependencyFailed = "DependencyFailed"
 )
 
 // +genclient
@@ -41,6 +41,14 @@ type WorkloadPriorityClass struct {
 	// when this workloadPriorityClass should be used.
 	// +optional
 	Description string `json:"description,omitempty"`
+
+	// minimumRuntimeSeconds is the time, in seconds, that the workloads of
+	// this workloadPriorityClass run before they can be preempted.
+	// Like the value, it's copied to the workloads when they are created.
+	// This field requires the PreemptionMinimumRuntime feature gate to be enabled.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinimumRuntimeSeconds *int32 `json:"minimumRuntimeSeconds,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -36,6 +36,11 @@ func (in *Admission) DeepCopyInto(out *Admission) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Admission.
@@ -234,6 +239,21 @@ func (in *AdmissionChecksStrategy) DeepCopy() *AdmissionChecksStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionScope) DeepCopyInto(out *AdmissionScope) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionScope.
+func (in *AdmissionScope) DeepCopy() *AdmissionScope {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BorrowWithinCohort) DeepCopyInto(out *BorrowWithinCohort) {
 	*out = *in
@@ -357,6 +377,11 @@ func (in *ClusterQueuePreemption) DeepCopyInto(out *ClusterQueuePreemption) {
 		*out = new(BorrowWithinCohort)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MinimumRuntimeSeconds != nil {
+		in, out := &in.MinimumRuntimeSeconds, &out.MinimumRuntimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueuePreemption.
@@ -389,6 +414,16 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(FlavorFungibility)
 		**out = **in
 	}
+	if in.PriorityAging != nil {
+		in, out := &in.PriorityAging, &out.PriorityAging
+		*out = new(PriorityAging)
+		**out = **in
+	}
+	if in.MaxAdmittedWorkloads != nil {
+		in, out := &in.MaxAdmittedWorkloads, &out.MaxAdmittedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Preemption != nil {
 		in, out := &in.Preemption, &out.Preemption
 		*out = new(ClusterQueuePreemption)
@@ -414,6 +449,11 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(FairSharing)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdmissionScope != nil {
+		in, out := &in.AdmissionScope, &out.AdmissionScope
+		*out = new(AdmissionScope)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueSpec.
@@ -458,6 +498,11 @@ func (in *ClusterQueueStatus) DeepCopyInto(out *ClusterQueueStatus) {
 	if in.FairSharing != nil {
 		in, out := &in.FairSharing, &out.FairSharing
 		*out = new(FairSharingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxAdmittedWorkloadsUsage != nil {
+		in, out := &in.MaxAdmittedWorkloadsUsage, &out.MaxAdmittedWorkloadsUsage
+		*out = new(int32)
 		**out = **in
 	}
 }
@@ -472,6 +517,29 @@ func (in *ClusterQueueStatus) DeepCopy() *ClusterQueueStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumedResources) DeepCopyInto(out *ConsumedResources) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumedResources.
+func (in *ConsumedResources) DeepCopy() *ConsumedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 	*out = *in
@@ -495,6 +563,11 @@ func (in *FairSharing) DeepCopy() *FairSharing {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FairSharingStatus) DeepCopyInto(out *FairSharingStatus) {
 	*out = *in
+	if in.ConsumedResources != nil {
+		in, out := &in.ConsumedResources, &out.ConsumedResources
+		*out = new(ConsumedResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairSharingStatus.
@@ -608,6 +681,28 @@ func (in *LocalQueue) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueFlavorQuotas) DeepCopyInto(out *LocalQueueFlavorQuotas) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]LocalQueueResourceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueFlavorQuotas.
+func (in *LocalQueueFlavorQuotas) DeepCopy() *LocalQueueFlavorQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueFlavorQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueFlavorStatus) DeepCopyInto(out *LocalQueueFlavorStatus) {
 	*out = *in
@@ -696,6 +791,22 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueResourceQuota) DeepCopyInto(out *LocalQueueResourceQuota) {
+	*out = *in
+	out.Max = in.Max.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueResourceQuota.
+func (in *LocalQueueResourceQuota) DeepCopy() *LocalQueueResourceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueResourceUsage) DeepCopyInto(out *LocalQueueResourceUsage) {
 	*out = *in
@@ -720,6 +831,23 @@ func (in *LocalQueueSpec) DeepCopyInto(out *LocalQueueSpec) {
 		*out = new(StopPolicy)
 		**out = **in
 	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]LocalQueueFlavorQuotas, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FairSharing != nil {
+		in, out := &in.FairSharing, &out.FairSharing
+		*out = new(FairSharing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxAdmittedWorkloads != nil {
+		in, out := &in.MaxAdmittedWorkloads, &out.MaxAdmittedWorkloads
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueSpec.
@@ -763,6 +891,16 @@ func (in *LocalQueueStatus) DeepCopyInto(out *LocalQueueStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FairSharing != nil {
+		in, out := &in.FairSharing, &out.FairSharing
+		*out = new(FairSharingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxAdmittedWorkloadsUsage != nil {
+		in, out := &in.MaxAdmittedWorkloadsUsage, &out.MaxAdmittedWorkloadsUsage
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueStatus.
@@ -938,6 +1076,11 @@ func (in *MultiKueueConfigSpec) DeepCopyInto(out *MultiKueueConfigSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Dispatcher != nil {
+		in, out := &in.Dispatcher, &out.Dispatcher
+		*out = new(MultiKueueDispatcher)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueConfigSpec.
@@ -950,6 +1093,51 @@ func (in *MultiKueueConfigSpec) DeepCopy() *MultiKueueConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueDispatcher) DeepCopyInto(out *MultiKueueDispatcher) {
+	*out = *in
+	if in.Incremental != nil {
+		in, out := &in.Incremental, &out.Incremental
+		*out = new(MultiKueueIncrementalDispatcher)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueDispatcher.
+func (in *MultiKueueDispatcher) DeepCopy() *MultiKueueDispatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueDispatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiKueueIncrementalDispatcher) DeepCopyInto(out *MultiKueueIncrementalDispatcher) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RoundTimeout != nil {
+		in, out := &in.RoundTimeout, &out.RoundTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueIncrementalDispatcher.
+func (in *MultiKueueIncrementalDispatcher) DeepCopy() *MultiKueueIncrementalDispatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiKueueIncrementalDispatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSet) DeepCopyInto(out *PodSet) {
 	*out = *in
@@ -1120,6 +1308,22 @@ func (in *PodSetUpdate) DeepCopy() *PodSetUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityAging) DeepCopyInto(out *PriorityAging) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityAging.
+func (in *PriorityAging) DeepCopy() *PriorityAging {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityAging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProvisioningRequestConfig) DeepCopyInto(out *ProvisioningRequestConfig) {
 	*out = *in
@@ -1240,6 +1444,46 @@ func (in *ProvisioningRequestRetryStrategy) DeepCopy() *ProvisioningRequestRetry
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaSchedule) DeepCopyInto(out *QuotaSchedule) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]DayOfWeek, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.NominalQuota != nil {
+		in, out := &in.NominalQuota, &out.NominalQuota
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.BorrowingLimit != nil {
+		in, out := &in.BorrowingLimit, &out.BorrowingLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LendingLimit != nil {
+		in, out := &in.LendingLimit, &out.LendingLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaSchedule.
+func (in *QuotaSchedule) DeepCopy() *QuotaSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReclaimablePod) DeepCopyInto(out *ReclaimablePod) {
 	*out = *in
@@ -1279,6 +1523,27 @@ func (in *RequeueState) DeepCopy() *RequeueState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceCost) DeepCopyInto(out *ResourceCost) {
+	*out = *in
+	out.Price = in.Price.DeepCopy()
+	if in.Unit != nil {
+		in, out := &in.Unit, &out.Unit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceCost.
+func (in *ResourceCost) DeepCopy() *ResourceCost {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceCost)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceFlavor) DeepCopyInto(out *ResourceFlavor) {
 	*out = *in
@@ -1366,6 +1631,13 @@ func (in *ResourceFlavorSpec) DeepCopyInto(out *ResourceFlavorSpec) {
 		*out = new(TopologyReference)
 		**out = **in
 	}
+	if in.Costs != nil {
+		in, out := &in.Costs, &out.Costs
+		*out = make([]ResourceCost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorSpec.
@@ -1419,6 +1691,13 @@ func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]QuotaSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuota.
@@ -1559,6 +1838,11 @@ func (in *WorkloadPriorityClass) DeepCopyInto(out *WorkloadPriorityClass) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.MinimumRuntimeSeconds != nil {
+		in, out := &in.MinimumRuntimeSeconds, &out.MinimumRuntimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPriorityClass.
@@ -1636,6 +1920,11 @@ func (in *WorkloadSpec) DeepCopyInto(out *WorkloadSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MinimumRuntimeSeconds != nil {
+		in, out := &in.MinimumRuntimeSeconds, &out.MinimumRuntimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpec.
@@ -1692,6 +1981,11 @@ func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ClusterName != nil {
+		in, out := &in.ClusterName, &out.ClusterName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
@@ -28,66 +28,86 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                     schema_pkg_apis_meta_v1_APIGroup(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                 schema_pkg_apis_meta_v1_APIGroupList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                  schema_pkg_apis_meta_v1_APIResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":              schema_pkg_apis_meta_v1_APIResourceList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                  schema_pkg_apis_meta_v1_APIVersions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                 schema_pkg_apis_meta_v1_ApplyOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                    schema_pkg_apis_meta_v1_Condition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                schema_pkg_apis_meta_v1_CreateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                     schema_pkg_apis_meta_v1_Duration(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldSelectorRequirement":     schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                     schema_pkg_apis_meta_v1_FieldsV1(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                   schema_pkg_apis_meta_v1_GetOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                    schema_pkg_apis_meta_v1_GroupKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                schema_pkg_apis_meta_v1_GroupResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                 schema_pkg_apis_meta_v1_GroupVersion(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":     schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":             schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":         schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                schema_pkg_apis_meta_v1_InternalEvent(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                schema_pkg_apis_meta_v1_LabelSelector(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":     schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                         schema_pkg_apis_meta_v1_List(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                     schema_pkg_apis_meta_v1_ListMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                  schema_pkg_apis_meta_v1_ListOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":           schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                    schema_pkg_apis_meta_v1_MicroTime(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                   schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":               schema_pkg_apis_meta_v1_OwnerReference(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":        schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":    schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                        schema_pkg_apis_meta_v1_Patch(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                 schema_pkg_apis_meta_v1_PatchOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                schema_pkg_apis_meta_v1_Preconditions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                    schema_pkg_apis_meta_v1_RootPaths(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":    schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                       schema_pkg_apis_meta_v1_Status(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                  schema_pkg_apis_meta_v1_StatusCause(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                schema_pkg_apis_meta_v1_StatusDetails(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                        schema_pkg_apis_meta_v1_Table(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":        schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                 schema_pkg_apis_meta_v1_TableOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                     schema_pkg_apis_meta_v1_TableRow(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":            schema_pkg_apis_meta_v1_TableRowCondition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                         schema_pkg_apis_meta_v1_Time(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                    schema_pkg_apis_meta_v1_Timestamp(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                     schema_pkg_apis_meta_v1_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                   schema_pkg_apis_meta_v1_WatchEvent(ref),
-		"k8s.io/apimachinery/pkg/runtime.RawExtension":                      schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                          schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/runtime.Unknown":                           schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		"k8s.io/apimachinery/pkg/version.Info":                              schema_k8sio_apimachinery_pkg_version_Info(ref),
-		"sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueue":            schema_kueue_apis_visibility_v1beta1_ClusterQueue(ref),
-		"sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueueList":        schema_kueue_apis_visibility_v1beta1_ClusterQueueList(ref),
-		"sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueue":              schema_kueue_apis_visibility_v1beta1_LocalQueue(ref),
-		"sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueueList":          schema_kueue_apis_visibility_v1beta1_LocalQueueList(ref),
-		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkload":         schema_kueue_apis_visibility_v1beta1_PendingWorkload(ref),
-		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadOptions":  schema_kueue_apis_visibility_v1beta1_PendingWorkloadOptions(ref),
-		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary": schema_kueue_apis_visibility_v1beta1_PendingWorkloadsSummary(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                      schema_pkg_apis_meta_v1_APIGroup(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                  schema_pkg_apis_meta_v1_APIGroupList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                   schema_pkg_apis_meta_v1_APIResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":               schema_pkg_apis_meta_v1_APIResourceList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                   schema_pkg_apis_meta_v1_APIVersions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                  schema_pkg_apis_meta_v1_ApplyOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                     schema_pkg_apis_meta_v1_Condition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                 schema_pkg_apis_meta_v1_CreateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                 schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                      schema_pkg_apis_meta_v1_Duration(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldSelectorRequirement":      schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                      schema_pkg_apis_meta_v1_FieldsV1(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                    schema_pkg_apis_meta_v1_GetOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                     schema_pkg_apis_meta_v1_GroupKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                 schema_pkg_apis_meta_v1_GroupResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                  schema_pkg_apis_meta_v1_GroupVersion(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":      schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":              schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":          schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                 schema_pkg_apis_meta_v1_InternalEvent(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                 schema_pkg_apis_meta_v1_LabelSelector(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":      schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                          schema_pkg_apis_meta_v1_List(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                      schema_pkg_apis_meta_v1_ListMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                   schema_pkg_apis_meta_v1_ListOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":            schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                     schema_pkg_apis_meta_v1_MicroTime(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                    schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                schema_pkg_apis_meta_v1_OwnerReference(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":         schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":     schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                         schema_pkg_apis_meta_v1_Patch(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                  schema_pkg_apis_meta_v1_PatchOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                 schema_pkg_apis_meta_v1_Preconditions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                     schema_pkg_apis_meta_v1_RootPaths(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":     schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                        schema_pkg_apis_meta_v1_Status(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                   schema_pkg_apis_meta_v1_StatusCause(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                 schema_pkg_apis_meta_v1_StatusDetails(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                         schema_pkg_apis_meta_v1_Table(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":         schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                  schema_pkg_apis_meta_v1_TableOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                      schema_pkg_apis_meta_v1_TableRow(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":             schema_pkg_apis_meta_v1_TableRowCondition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                          schema_pkg_apis_meta_v1_Time(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                     schema_pkg_apis_meta_v1_Timestamp(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                      schema_pkg_apis_meta_v1_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                 schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                    schema_pkg_apis_meta_v1_WatchEvent(ref),
+		"k8s.io/apimachinery/pkg/runtime.RawExtension":                       schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                           schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/runtime.Unknown":                            schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		"k8s.io/apimachinery/pkg/version.Info":                               schema_k8sio_apimachinery_pkg_version_Info(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmittedWorkload":         schema_kueue_apis_visibility_v1beta1_AdmittedWorkload(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmittedWorkloadOptions":  schema_kueue_apis_visibility_v1beta1_AdmittedWorkloadOptions(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmittedWorkloadsSummary": schema_kueue_apis_visibility_v1beta1_AdmittedWorkloadsSummary(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.BlockingResource":         schema_kueue_apis_visibility_v1beta1_BlockingResource(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueue":             schema_kueue_apis_visibility_v1beta1_ClusterQueue(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueueList":         schema_kueue_apis_visibility_v1beta1_ClusterQueueList(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.FlavorAttempt":            schema_kueue_apis_visibility_v1beta1_FlavorAttempt(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.FlavorBlocker":            schema_kueue_apis_visibility_v1beta1_FlavorBlocker(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueue":               schema_kueue_apis_visibility_v1beta1_LocalQueue(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueueList":           schema_kueue_apis_visibility_v1beta1_LocalQueueList(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkload":          schema_kueue_apis_visibility_v1beta1_PendingWorkload(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadOptions":   schema_kueue_apis_visibility_v1beta1_PendingWorkloadOptions(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary":  schema_kueue_apis_visibility_v1beta1_PendingWorkloadsSummary(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetBlockers":           schema_kueue_apis_visibility_v1beta1_PodSetBlockers(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetDecision":           schema_kueue_apis_visibility_v1beta1_PodSetDecision(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetFlavors":            schema_kueue_apis_visibility_v1beta1_PodSetFlavors(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetUsage":              schema_kueue_apis_visibility_v1beta1_PodSetUsage(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionDryRun":         schema_kueue_apis_visibility_v1beta1_PreemptionDryRun(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionTarget":         schema_kueue_apis_visibility_v1beta1_PreemptionTarget(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.ProtectedWorkload":        schema_kueue_apis_visibility_v1beta1_ProtectedWorkload(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.ResourceBlockers":         schema_kueue_apis_visibility_v1beta1_ResourceBlockers(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.ResourceGroupDecision":    schema_kueue_apis_visibility_v1beta1_ResourceGroupDecision(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.SchedulingDecision":       schema_kueue_apis_visibility_v1beta1_SchedulingDecision(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.SchedulingDecisions":      schema_kueue_apis_visibility_v1beta1_SchedulingDecisions(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.Workload":                 schema_kueue_apis_visibility_v1beta1_Workload(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.WorkloadList":             schema_kueue_apis_visibility_v1beta1_WorkloadList(ref),
+		"sigs.k8s.io/kueue/apis/visibility/v1beta1.WorkloadReference":        schema_kueue_apis_visibility_v1beta1_WorkloadReference(ref),
 	}
 }
 
@@ -2687,11 +2707,92 @@ func schema_k8sio_apimachinery_pkg_version_Info(ref common.ReferenceCallback) co
 	}
 }
 
-func schema_kueue_apis_visibility_v1beta1_ClusterQueue(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kueue_apis_visibility_v1beta1_AdmittedWorkload(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "AdmittedWorkload is a user-facing representation of a workload reserving quota in a ClusterQueue.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority indicates the workload's priority",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"localQueueName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalQueueName indicates the name of the LocalQueue the workload is submitted to",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterQueue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterQueue indicates the name of the ClusterQueue the workload reserves quota in",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podSets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSets contains the flavors assigned to each of the workload's pod sets, and the resources they use",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetUsage"),
+									},
+								},
+							},
+						},
+					},
+					"borrowing": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Borrowing indicates whether the ClusterQueue borrows quota from its cohort for any of the flavors and resources used by the workload",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"quotaReservationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QuotaReservationTime indicates when the workload reserved quota",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"admissionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdmissionTime indicates when the workload was admitted. It is unset while the workload waits for its admission checks.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"priority", "localQueueName", "clusterQueue", "podSets", "borrowing", "quotaReservationTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetUsage"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_AdmittedWorkloadOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AdmittedWorkloadOptions are query params used in the admitted workloads visibility queries",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -2707,32 +2808,41 @@ func schema_kueue_apis_visibility_v1beta1_ClusterQueue(ref common.ReferenceCallb
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"offset": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "Offset indicates position of the first admitted workload that should be fetched, starting from 0. 0 by default",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"pendingWorkloadsSummary": {
+					"limit": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary"),
+							Description: "Limit indicates max number of admitted workloads that should be fetched. 1000 by default",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"cohort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cohort indicates whether to fetch the workloads reserving quota in all the ClusterQueues of the cohort of the ClusterQueue, rather than only in the ClusterQueue. It is ignored for LocalQueues. false by default",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"pendingWorkloadsSummary"},
+				Required: []string{"offset"},
 			},
 		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary"},
 	}
 }
 
-func schema_kueue_apis_visibility_v1beta1_ClusterQueueList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kueue_apis_visibility_v1beta1_AdmittedWorkloadsSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "AdmittedWorkloadsSummary contains a list of workloads reserving quota in the context of the query (within LocalQueue, ClusterQueue or its cohort).",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -2751,7 +2861,7 @@ func schema_kueue_apis_visibility_v1beta1_ClusterQueueList(ref common.ReferenceC
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
 					"items": {
@@ -2761,7 +2871,7 @@ func schema_kueue_apis_visibility_v1beta1_ClusterQueueList(ref common.ReferenceC
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueue"),
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmittedWorkload"),
 									},
 								},
 							},
@@ -2772,11 +2882,57 @@ func schema_kueue_apis_visibility_v1beta1_ClusterQueueList(ref common.ReferenceC
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueue"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmittedWorkload"},
 	}
 }
 
-func schema_kueue_apis_visibility_v1beta1_LocalQueue(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kueue_apis_visibility_v1beta1_BlockingResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlockingResource is a resource of a flavor without enough unused quota for some of the pending workloads of a ClusterQueue.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"flavor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flavor indicates the name of the flavor",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource indicates the name of the resource",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"workloads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workloads indicates the number of pending workloads which don't fit because of the unused quota for the resource in the flavor",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxShortfall": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxShortfall indicates the largest quantity missing for one of these workloads to fit",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"flavor", "resource", "workloads", "maxShortfall"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_ClusterQueue(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -2817,7 +2973,7 @@ func schema_kueue_apis_visibility_v1beta1_LocalQueue(ref common.ReferenceCallbac
 	}
 }
 
-func schema_kueue_apis_visibility_v1beta1_LocalQueueList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kueue_apis_visibility_v1beta1_ClusterQueueList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -2850,7 +3006,7 @@ func schema_kueue_apis_visibility_v1beta1_LocalQueueList(ref common.ReferenceCal
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueue"),
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueue"),
 									},
 								},
 							},
@@ -2861,70 +3017,115 @@ func schema_kueue_apis_visibility_v1beta1_LocalQueueList(ref common.ReferenceCal
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueue"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueue"},
 	}
 }
 
-func schema_kueue_apis_visibility_v1beta1_PendingWorkload(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kueue_apis_visibility_v1beta1_FlavorAttempt(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PendingWorkload is a user-facing representation of a pending workload that summarizes the relevant information for position in the cluster queue.",
+				Description: "FlavorAttempt is the evaluation of a flavor for the resources of a resource group.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"metadata": {
+					"flavor": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Description: "Flavor indicates the name of the flavor",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"priority": {
+					"mode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Priority indicates the workload's priority",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Mode indicates whether the resources fit in the flavor. It is one of Fit, Preempt or NoFit.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"localQueueName": {
+					"borrowing": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LocalQueueName indicates the name of the LocalQueue the workload is submitted to",
+							Description: "Borrowing indicates whether the resources need to borrow quota from the cohort",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why the flavor can't be used, or doesn't fit, if any",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"flavor", "mode"},
+			},
+		},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_FlavorBlocker(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FlavorBlocker is the evaluation of a flavor for a resource requested by a pod set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the name of the flavor",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"positionInClusterQueue": {
+					"requested": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PositionInClusterQueue indicates the workload's position in the ClusterQueue, starting from 0",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Requested indicates the quantity of the resource requested by the pod set, including the quantity assigned to the flavor for the previous pod sets of the workload. It is unset when the flavor doesn't exist.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 						},
 					},
-					"positionInLocalQueue": {
+					"available": {
 						SchemaProps: spec.SchemaProps{
-							Description: "PositionInLocalQueue indicates the workload's position in the LocalQueue, starting from 0",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Available indicates the unused quota for the resource in the flavor, including the quota the ClusterQueue can borrow from its cohort, and capped by the unused quota of the LocalQueue. It is unset when the flavor doesn't exist.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"borrowable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Borrowable indicates the part of the available quota which is borrowed from the cohort. It is unset when the flavor doesn't exist.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason indicates why the flavor can't be assigned to the resource. It is unset when the flavor can be assigned. It is one of: - Taints: the pod set doesn't tolerate the taints of the flavor. - NodeAffinity: the node selector or the node affinity of the pod set\n  doesn't match the labels of the flavor.\n- Quota: there isn't enough unused quota for the resource. - Topology: the flavor doesn't support the topology requested by the\n  pod set, or its topology domains don't have enough capacity.\n- Plugin: a scheduler plugin rejected the flavor. - NotFound: the ResourceFlavor doesn't exist.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why the flavor can't be assigned",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
-				Required: []string{"priority", "localQueueName", "positionInClusterQueue", "positionInLocalQueue"},
+				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
-func schema_kueue_apis_visibility_v1beta1_PendingWorkloadOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kueue_apis_visibility_v1beta1_LocalQueue(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PendingWorkloadOptions are query params used in the visibility queries",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -2940,34 +3141,32 @@ func schema_kueue_apis_visibility_v1beta1_PendingWorkloadOptions(ref common.Refe
 							Format:      "",
 						},
 					},
-					"offset": {
+					"metadata": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Offset indicates position of the first pending workload that should be fetched, starting from 0. 0 by default",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 						},
 					},
-					"limit": {
+					"pendingWorkloadsSummary": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limit indicates max number of pending workloads that should be fetched. 1000 by default",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Default: map[string]interface{}{},
+							Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary"),
 						},
 					},
 				},
-				Required: []string{"offset"},
+				Required: []string{"pendingWorkloadsSummary"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary"},
 	}
 }
 
-func schema_kueue_apis_visibility_v1beta1_PendingWorkloadsSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kueue_apis_visibility_v1beta1_LocalQueueList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PendingWorkloadsSummary contains a list of pending workloads in the context of the query (within LocalQueue or ClusterQueue).",
-				Type:        []string{"object"},
+				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -2986,7 +3185,7 @@ func schema_kueue_apis_visibility_v1beta1_PendingWorkloadsSummary(ref common.Ref
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 						},
 					},
 					"items": {
@@ -2996,7 +3195,7 @@ func schema_kueue_apis_visibility_v1beta1_PendingWorkloadsSummary(ref common.Ref
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkload"),
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueue"),
 									},
 								},
 							},
@@ -3007,6 +3206,982 @@ func schema_kueue_apis_visibility_v1beta1_PendingWorkloadsSummary(ref common.Ref
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkload"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.LocalQueue"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PendingWorkload(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PendingWorkload is a user-facing representation of a pending workload that summarizes the relevant information for position in the cluster queue.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority indicates the workload's priority",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"effectivePriority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EffectivePriority indicates the workload's priority raised by the priority aging of the ClusterQueue. It is unset when the ClusterQueue has no priority aging.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"localQueueName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalQueueName indicates the name of the LocalQueue the workload is submitted to",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"positionInClusterQueue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PositionInClusterQueue indicates the workload's position in the ClusterQueue, starting from 0",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"positionInLocalQueue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PositionInLocalQueue indicates the workload's position in the LocalQueue, starting from 0",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"estimatedStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedStartTime indicates when the workload is expected to start, assuming the workloads ahead of it in the ClusterQueue start first, as the workloads reserving quota finish. It is unset when the start time can't be estimated, or the StartTimeEstimation feature gate is disabled.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"estimateConfidence": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimateConfidence indicates the confidence in the estimated start time. It is one of: - High: the estimate relies on the maximum execution times of the workloads. - Medium: the estimate relies on the average runtime of the workloads\n  which finished in the LocalQueues.\n- Low: the workload needs to borrow quota from the cohort, which other\n  ClusterQueues may use first.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"inadmissibleReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InadmissibleReason explains why the workload doesn't fit in the unused quota of the ClusterQueue. It is unset when the workload fits, or the InadmissibilityReasons feature gate is disabled. It is only evaluated for the first 1000 workloads of a response.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"blockers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Blockers break down, for each pod set and resource, why the flavors of the ClusterQueue can't be assigned to the workload. It is unset when the workload fits, or the InadmissibilityReasons feature gate is disabled. It is only evaluated for the first 1000 workloads of a response.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetBlockers"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"priority", "localQueueName", "positionInClusterQueue", "positionInLocalQueue"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetBlockers"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PendingWorkloadOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PendingWorkloadOptions are query params used in the visibility queries",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"offset": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Offset indicates position of the first pending workload that should be fetched, starting from 0. 0 by default",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limit indicates max number of pending workloads that should be fetched. 1000 by default",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"offset"},
+			},
+		},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PendingWorkloadsSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PendingWorkloadsSummary contains a list of pending workloads in the context of the query (within LocalQueue or ClusterQueue).",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkload"),
+									},
+								},
+							},
+						},
+					},
+					"topBlockingResources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopBlockingResources lists the resources of the flavors lacking unused quota for the most pending workloads of the ClusterQueue, first, among the first 1000 pending workloads. It is only set for ClusterQueues, when the InadmissibilityReasons feature gate is enabled.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.BlockingResource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.BlockingResource", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkload"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PodSetBlockers(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodSetBlockers contains the flavors evaluated for each of the resources requested by a pod set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the name of the pod set",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources contains, for each resource requested by the pod set, the flavors evaluated",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.ResourceBlockers"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/kueue/apis/visibility/v1beta1.ResourceBlockers"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PodSetDecision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodSetDecision contains the flavors evaluated and chosen for the resources of a pod set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the name of the pod set",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceGroups contains, for each of the resource groups requested by the pod set, the flavors evaluated and the flavor chosen",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.ResourceGroupDecision"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/kueue/apis/visibility/v1beta1.ResourceGroupDecision"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PodSetFlavors(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodSetFlavors contains the flavors chosen for the resources of a pod set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the name of the pod set",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count indicates the number of pods considered for the pod set",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flavors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flavors indicates the flavor chosen for each of the resources requested by the pod set",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode indicates the outcome of the flavor assignment for the pod set. It is one of Fit, Preempt or NoFit.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why flavors couldn't be assigned to the pod set, if any",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "count", "mode"},
+			},
+		},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PodSetUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PodSetUsage contains the flavors assigned to the resources of a pod set, and the resources it uses.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the name of the pod set",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count indicates the number of pods the quota is reserved for",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"flavors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flavors indicates the flavor assigned to each of the resources requested by the pod set",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resourceUsage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceUsage indicates the total resources used by the pods of the pod set",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "count"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PreemptionDryRun(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PreemptionDryRun contains the result of simulating the admission of a pending workload into its ClusterQueue, without reserving any quota or evicting any workload.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"clusterQueue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterQueue indicates the name of the ClusterQueue the workload is queued in",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode indicates the outcome of the flavor assignment, considering all the pod sets. It is one of Fit, Preempt or NoFit.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"admissible": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Admissible indicates whether the workload could be admitted, either because it fits, or because enough workloads can be preempted.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"podSets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSets contains the flavors chosen for each of the workload's pod sets",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetFlavors"),
+									},
+								},
+							},
+						},
+					},
+					"preemptionTargets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreemptionTargets contains the admitted workloads that would be preempted in order to admit the workload",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionTarget"),
+									},
+								},
+							},
+						},
+					},
+					"protectedWorkloads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProtectedWorkloads contains the admitted workloads that can't be preempted yet, because they didn't run for their minimum runtime",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.ProtectedWorkload"),
+									},
+								},
+							},
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why the flavors couldn't be assigned to the pod sets, if any",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"clusterQueue", "mode", "admissible", "podSets", "preemptionTargets"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetFlavors", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionTarget", "sigs.k8s.io/kueue/apis/visibility/v1beta1.ProtectedWorkload"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_PreemptionTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PreemptionTarget is a user-facing representation of an admitted workload which would be preempted.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"clusterQueue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterQueue indicates the name of the ClusterQueue the workload is admitted in",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority indicates the workload's priority",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason indicates why the workload would be preempted. It is one of InClusterQueue, InCohortReclamation, InCohortFairSharing or InCohortReclaimWhileBorrowing.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"clusterQueue", "priority", "reason"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_ProtectedWorkload(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProtectedWorkload is a user-facing representation of an admitted workload which can't be preempted until it runs for its minimum runtime.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"clusterQueue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterQueue indicates the name of the ClusterQueue the workload is admitted in",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority indicates the workload's priority",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"protectedUntil": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProtectedUntil indicates when the workload's protection from preemption ends",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"clusterQueue", "priority", "protectedUntil"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_ResourceBlockers(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceBlockers contains the flavors evaluated for a resource requested by a pod set, in the order they were evaluated.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the name of the resource",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"flavors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flavors contains the evaluation of each flavor for the resource",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.FlavorBlocker"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/kueue/apis/visibility/v1beta1.FlavorBlocker"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_ResourceGroupDecision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceGroupDecision contains the flavors evaluated and the flavor chosen for the resources of a resource group requested by a pod set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources indicates the resources of the group requested by the pod set",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"attempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Attempts contains the flavors evaluated, in order",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.FlavorAttempt"),
+									},
+								},
+							},
+						},
+					},
+					"flavor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flavor indicates the flavor chosen, if any",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason indicates why the flavor was chosen, following the flavor fungibility of the ClusterQueue. It is one of: - Fit: the flavor fits without borrowing. - Borrow: the flavor fits by borrowing, and whenCanBorrow is Borrow. - Preempt: the flavor requires preemption, and whenCanPreempt is Preempt. - BestMode: no flavor satisfies the flavor fungibility, the flavor with\n  the best mode was chosen among all of them.\n- Score: the flavors were scored by cost or by plugins.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"resources"},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/kueue/apis/visibility/v1beta1.FlavorAttempt"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_SchedulingDecision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SchedulingDecision is the record of the evaluation of a pending workload in a scheduling cycle.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time indicates when the scheduling cycle started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"schedulingCycle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SchedulingCycle indicates the number of the scheduling cycle since the scheduler started",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"workload": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workload indicates the workload evaluated",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.WorkloadReference"),
+						},
+					},
+					"clusterQueue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterQueue indicates the name of the ClusterQueue the workload is queued in",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority indicates the workload's priority, raised by the priority aging of the ClusterQueue, if any",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode indicates the outcome of the flavor assignment, considering all the pod sets. It is one of Fit, Preempt or NoFit.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"borrowing": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Borrowing indicates whether the flavor assignment borrows quota from the cohort",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"podSets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSets contains the flavors evaluated and chosen for each of the workload's pod sets",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetDecision"),
+									},
+								},
+							},
+						},
+					},
+					"preemptionTargets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreemptionTargets contains the admitted workloads to preempt in order to admit the workload",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionTarget"),
+									},
+								},
+							},
+						},
+					},
+					"outcome": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Outcome indicates the result of the scheduling cycle for the workload. It is one of: - Admitted: the workload reserved quota. - Preempting: the preemption of the targets was issued, the workload\n  waits for them to be evicted.\n- Skipped: the workload fitted, but no longer fits after admitting other\n  workloads in the cycle, or it shares preemption targets with them.\n- Inadmissible: the workload doesn't fit.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains the outcome, if any",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"time", "schedulingCycle", "workload", "clusterQueue", "priority", "mode", "borrowing", "outcome"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PodSetDecision", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionTarget", "sigs.k8s.io/kueue/apis/visibility/v1beta1.WorkloadReference"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_SchedulingDecisions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SchedulingDecisions contains the latest scheduling decisions recorded for a workload or a ClusterQueue, oldest first.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.SchedulingDecision"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.SchedulingDecision"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_Workload(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"preemptionDryRun": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionDryRun"),
+						},
+					},
+				},
+				Required: []string{"preemptionDryRun"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionDryRun"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_WorkloadList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/kueue/apis/visibility/v1beta1.Workload"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta", "sigs.k8s.io/kueue/apis/visibility/v1beta1.Workload"},
+	}
+}
+
+func schema_kueue_apis_visibility_v1beta1_WorkloadReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkloadReference identifies a workload.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace indicates the namespace of the workload",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name indicates the name of the workload",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"namespace", "name"},
+			},
+		},
 	}
 }
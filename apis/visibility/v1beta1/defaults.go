@@ -35,3 +35,11 @@ func SetDefaults_PendingWorkloadOptions(obj *PendingWorkloadOptions) {
 		obj.Limit = defaultPendingWorkloadsLimit
 	}
 }
+
+//nolint:revive // format required by generated code for defaulting
+func SetDefaults_AdmittedWorkloadOptions(obj *AdmittedWorkloadOptions) {
+	defaultAdmittedWorkloadsLimit := int64(1000)
+	if obj.Limit == 0 {
+		obj.Limit = defaultAdmittedWorkloadsLimit
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,6 +27,8 @@ import (
 // +k8s:openapi-gen=true
 // +genclient:nonNamespaced
 // +genclient:method=GetPendingWorkloadsSummary,verb=get,subresource=pendingworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary
+// +genclient:method=GetAdmittedWorkloadsSummary,verb=get,subresource=admittedworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmittedWorkloadsSummary
+// +genclient:method=GetSchedulingDecisions,verb=get,subresource=schedulingdecisions,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.SchedulingDecisions
 type ClusterQueue struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -44,6 +48,7 @@ type ClusterQueueList struct {
 // +kubebuilder:object:root=true
 // +k8s:openapi-gen=true
 // +genclient:method=GetPendingWorkloadsSummary,verb=get,subresource=pendingworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary
+// +genclient:method=GetAdmittedWorkloadsSummary,verb=get,subresource=admittedworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmittedWorkloadsSummary
 type LocalQueue struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -59,6 +64,26 @@ type LocalQueueList struct {
 	Items []LocalQueue `json:"items"`
 }
 
+// +genclient
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +genclient:method=GetPreemptionDryRun,verb=get,subresource=preemptiondryrun,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionDryRun
+// +genclient:method=GetSchedulingDecisions,verb=get,subresource=schedulingdecisions,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.SchedulingDecisions
+type Workload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	PreemptionDryRun PreemptionDryRun `json:"preemptionDryRun"`
+}
+
+// +kubebuilder:object:root=true
+type WorkloadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Workload `json:"items"`
+}
+
 // PendingWorkload is a user-facing representation of a pending workload that summarizes the relevant information for
 // position in the cluster queue.
 type PendingWorkload struct {
@@ -67,6 +92,12 @@ type PendingWorkload struct {
 	// Priority indicates the workload's priority
 	Priority int32 `json:"priority"`
 
+	// EffectivePriority indicates the workload's priority raised by the
+	// priority aging of the ClusterQueue. It is unset when the ClusterQueue
+	// has no priority aging.
+	// +optional
+	EffectivePriority *int32 `json:"effectivePriority,omitempty"`
+
 	// LocalQueueName indicates the name of the LocalQueue the workload is submitted to
 	LocalQueueName string `json:"localQueueName"`
 
@@ -75,6 +106,118 @@ type PendingWorkload struct {
 
 	// PositionInLocalQueue indicates the workload's position in the LocalQueue, starting from 0
 	PositionInLocalQueue int32 `json:"positionInLocalQueue"`
+
+	// EstimatedStartTime indicates when the workload is expected to start,
+	// assuming the workloads ahead of it in the ClusterQueue start first, as
+	// the workloads reserving quota finish. It is unset when the start time
+	// can't be estimated, or the StartTimeEstimation feature gate is disabled.
+	// +optional
+	EstimatedStartTime *metav1.Time `json:"estimatedStartTime,omitempty"`
+
+	// EstimateConfidence indicates the confidence in the estimated start time.
+	// It is one of:
+	// - High: the estimate relies on the maximum execution times of the workloads.
+	// - Medium: the estimate relies on the average runtime of the workloads
+	//   which finished in the LocalQueues.
+	// - Low: the workload needs to borrow quota from the cohort, which other
+	//   ClusterQueues may use first.
+	// +optional
+	EstimateConfidence string `json:"estimateConfidence,omitempty"`
+
+	// InadmissibleReason explains why the workload doesn't fit in the unused
+	// quota of the ClusterQueue. It is unset when the workload fits, or the
+	// InadmissibilityReasons feature gate is disabled. It is only evaluated
+	// for the first 1000 workloads of a response.
+	// +optional
+	InadmissibleReason string `json:"inadmissibleReason,omitempty"`
+
+	// Blockers break down, for each pod set and resource, why the flavors of
+	// the ClusterQueue can't be assigned to the workload. It is unset when
+	// the workload fits, or the InadmissibilityReasons feature gate is disabled.
+	// It is only evaluated for the first 1000 workloads of a response.
+	// +optional
+	Blockers []PodSetBlockers `json:"blockers,omitempty"`
+}
+
+// PodSetBlockers contains the flavors evaluated for each of the resources
+// requested by a pod set.
+type PodSetBlockers struct {
+	// Name indicates the name of the pod set
+	Name string `json:"name"`
+
+	// Resources contains, for each resource requested by the pod set, the
+	// flavors evaluated
+	Resources []ResourceBlockers `json:"resources,omitempty"`
+}
+
+// ResourceBlockers contains the flavors evaluated for a resource requested by
+// a pod set, in the order they were evaluated.
+type ResourceBlockers struct {
+	// Name indicates the name of the resource
+	Name corev1.ResourceName `json:"name"`
+
+	// Flavors contains the evaluation of each flavor for the resource
+	Flavors []FlavorBlocker `json:"flavors,omitempty"`
+}
+
+// FlavorBlocker is the evaluation of a flavor for a resource requested by a
+// pod set.
+type FlavorBlocker struct {
+	// Name indicates the name of the flavor
+	Name string `json:"name"`
+
+	// Requested indicates the quantity of the resource requested by the pod
+	// set, including the quantity assigned to the flavor for the previous
+	// pod sets of the workload. It is unset when the flavor doesn't exist.
+	// +optional
+	Requested *resource.Quantity `json:"requested,omitempty"`
+
+	// Available indicates the unused quota for the resource in the flavor,
+	// including the quota the ClusterQueue can borrow from its cohort, and
+	// capped by the unused quota of the LocalQueue. It is unset when the
+	// flavor doesn't exist.
+	// +optional
+	Available *resource.Quantity `json:"available,omitempty"`
+
+	// Borrowable indicates the part of the available quota which is borrowed
+	// from the cohort. It is unset when the flavor doesn't exist.
+	// +optional
+	Borrowable *resource.Quantity `json:"borrowable,omitempty"`
+
+	// Reason indicates why the flavor can't be assigned to the resource. It
+	// is unset when the flavor can be assigned. It is one of:
+	// - Taints: the pod set doesn't tolerate the taints of the flavor.
+	// - NodeAffinity: the node selector or the node affinity of the pod set
+	//   doesn't match the labels of the flavor.
+	// - Quota: there isn't enough unused quota for the resource.
+	// - Topology: the flavor doesn't support the topology requested by the
+	//   pod set, or its topology domains don't have enough capacity.
+	// - Plugin: a scheduler plugin rejected the flavor.
+	// - NotFound: the ResourceFlavor doesn't exist.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message explains why the flavor can't be assigned
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BlockingResource is a resource of a flavor without enough unused quota for
+// some of the pending workloads of a ClusterQueue.
+type BlockingResource struct {
+	// Flavor indicates the name of the flavor
+	Flavor string `json:"flavor"`
+
+	// Resource indicates the name of the resource
+	Resource corev1.ResourceName `json:"resource"`
+
+	// Workloads indicates the number of pending workloads which don't fit
+	// because of the unused quota for the resource in the flavor
+	Workloads int32 `json:"workloads"`
+
+	// MaxShortfall indicates the largest quantity missing for one of these
+	// workloads to fit
+	MaxShortfall resource.Quantity `json:"maxShortfall"`
 }
 
 // +k8s:openapi-gen=true
@@ -87,6 +230,279 @@ type PendingWorkloadsSummary struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Items []PendingWorkload `json:"items"`
+
+	// TopBlockingResources lists the resources of the flavors lacking unused
+	// quota for the most pending workloads of the ClusterQueue, first, among
+	// the first 1000 pending workloads. It is only set for ClusterQueues,
+	// when the InadmissibilityReasons feature gate is enabled.
+	// +optional
+	TopBlockingResources []BlockingResource `json:"topBlockingResources,omitempty"`
+}
+
+// AdmittedWorkload is a user-facing representation of a workload reserving
+// quota in a ClusterQueue.
+type AdmittedWorkload struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Priority indicates the workload's priority
+	Priority int32 `json:"priority"`
+
+	// LocalQueueName indicates the name of the LocalQueue the workload is submitted to
+	LocalQueueName string `json:"localQueueName"`
+
+	// ClusterQueue indicates the name of the ClusterQueue the workload reserves quota in
+	ClusterQueue string `json:"clusterQueue"`
+
+	// PodSets contains the flavors assigned to each of the workload's pod sets,
+	// and the resources they use
+	PodSets []PodSetUsage `json:"podSets"`
+
+	// Borrowing indicates whether the ClusterQueue borrows quota from its
+	// cohort for any of the flavors and resources used by the workload
+	Borrowing bool `json:"borrowing"`
+
+	// QuotaReservationTime indicates when the workload reserved quota
+	QuotaReservationTime metav1.Time `json:"quotaReservationTime"`
+
+	// AdmissionTime indicates when the workload was admitted. It is unset
+	// while the workload waits for its admission checks.
+	// +optional
+	AdmissionTime *metav1.Time `json:"admissionTime,omitempty"`
+}
+
+// PodSetUsage contains the flavors assigned to the resources of a pod set,
+// and the resources it uses.
+type PodSetUsage struct {
+	// Name indicates the name of the pod set
+	Name string `json:"name"`
+
+	// Count indicates the number of pods the quota is reserved for
+	Count int32 `json:"count"`
+
+	// Flavors indicates the flavor assigned to each of the resources requested by the pod set
+	Flavors map[corev1.ResourceName]string `json:"flavors,omitempty"`
+
+	// ResourceUsage indicates the total resources used by the pods of the pod set
+	ResourceUsage corev1.ResourceList `json:"resourceUsage,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// AdmittedWorkloadsSummary contains a list of workloads reserving quota in
+// the context of the query (within LocalQueue, ClusterQueue or its cohort).
+type AdmittedWorkloadsSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Items []AdmittedWorkload `json:"items"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// PreemptionDryRun contains the result of simulating the admission of a
+// pending workload into its ClusterQueue, without reserving any quota or
+// evicting any workload.
+type PreemptionDryRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ClusterQueue indicates the name of the ClusterQueue the workload is queued in
+	ClusterQueue string `json:"clusterQueue"`
+
+	// Mode indicates the outcome of the flavor assignment, considering all the
+	// pod sets. It is one of Fit, Preempt or NoFit.
+	Mode string `json:"mode"`
+
+	// Admissible indicates whether the workload could be admitted, either
+	// because it fits, or because enough workloads can be preempted.
+	Admissible bool `json:"admissible"`
+
+	// PodSets contains the flavors chosen for each of the workload's pod sets
+	PodSets []PodSetFlavors `json:"podSets"`
+
+	// PreemptionTargets contains the admitted workloads that would be preempted
+	// in order to admit the workload
+	PreemptionTargets []PreemptionTarget `json:"preemptionTargets"`
+
+	// ProtectedWorkloads contains the admitted workloads that can't be preempted
+	// yet, because they didn't run for their minimum runtime
+	ProtectedWorkloads []ProtectedWorkload `json:"protectedWorkloads,omitempty"`
+
+	// Message explains why the flavors couldn't be assigned to the pod sets, if any
+	Message string `json:"message,omitempty"`
+}
+
+// PodSetFlavors contains the flavors chosen for the resources of a pod set.
+type PodSetFlavors struct {
+	// Name indicates the name of the pod set
+	Name string `json:"name"`
+
+	// Count indicates the number of pods considered for the pod set
+	Count int32 `json:"count"`
+
+	// Flavors indicates the flavor chosen for each of the resources requested by the pod set
+	Flavors map[corev1.ResourceName]string `json:"flavors,omitempty"`
+
+	// Mode indicates the outcome of the flavor assignment for the pod set.
+	// It is one of Fit, Preempt or NoFit.
+	Mode string `json:"mode"`
+
+	// Message explains why flavors couldn't be assigned to the pod set, if any
+	Message string `json:"message,omitempty"`
+}
+
+// PreemptionTarget is a user-facing representation of an admitted workload
+// which would be preempted.
+type PreemptionTarget struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ClusterQueue indicates the name of the ClusterQueue the workload is admitted in
+	ClusterQueue string `json:"clusterQueue"`
+
+	// Priority indicates the workload's priority
+	Priority int32 `json:"priority"`
+
+	// Reason indicates why the workload would be preempted. It is one of
+	// InClusterQueue, InCohortReclamation, InCohortFairSharing or
+	// InCohortReclaimWhileBorrowing.
+	Reason string `json:"reason"`
+}
+
+// ProtectedWorkload is a user-facing representation of an admitted workload
+// which can't be preempted until it runs for its minimum runtime.
+type ProtectedWorkload struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ClusterQueue indicates the name of the ClusterQueue the workload is admitted in
+	ClusterQueue string `json:"clusterQueue"`
+
+	// Priority indicates the workload's priority
+	Priority int32 `json:"priority"`
+
+	// ProtectedUntil indicates when the workload's protection from preemption ends
+	ProtectedUntil metav1.Time `json:"protectedUntil"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// SchedulingDecisions contains the latest scheduling decisions recorded for a
+// workload or a ClusterQueue, oldest first.
+type SchedulingDecisions struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Items []SchedulingDecision `json:"items"`
+}
+
+// SchedulingDecision is the record of the evaluation of a pending workload in
+// a scheduling cycle.
+type SchedulingDecision struct {
+	// Time indicates when the scheduling cycle started
+	Time metav1.Time `json:"time"`
+
+	// SchedulingCycle indicates the number of the scheduling cycle since the
+	// scheduler started
+	SchedulingCycle int64 `json:"schedulingCycle"`
+
+	// Workload indicates the workload evaluated
+	Workload WorkloadReference `json:"workload"`
+
+	// ClusterQueue indicates the name of the ClusterQueue the workload is queued in
+	ClusterQueue string `json:"clusterQueue"`
+
+	// Priority indicates the workload's priority, raised by the priority aging
+	// of the ClusterQueue, if any
+	Priority int32 `json:"priority"`
+
+	// Mode indicates the outcome of the flavor assignment, considering all the
+	// pod sets. It is one of Fit, Preempt or NoFit.
+	Mode string `json:"mode"`
+
+	// Borrowing indicates whether the flavor assignment borrows quota from the cohort
+	Borrowing bool `json:"borrowing"`
+
+	// PodSets contains the flavors evaluated and chosen for each of the workload's pod sets
+	PodSets []PodSetDecision `json:"podSets,omitempty"`
+
+	// PreemptionTargets contains the admitted workloads to preempt in order
+	// to admit the workload
+	PreemptionTargets []PreemptionTarget `json:"preemptionTargets,omitempty"`
+
+	// Outcome indicates the result of the scheduling cycle for the workload.
+	// It is one of:
+	// - Admitted: the workload reserved quota.
+	// - Preempting: the preemption of the targets was issued, the workload
+	//   waits for them to be evicted.
+	// - Skipped: the workload fitted, but no longer fits after admitting other
+	//   workloads in the cycle, or it shares preemption targets with them.
+	// - Inadmissible: the workload doesn't fit.
+	Outcome string `json:"outcome"`
+
+	// Message explains the outcome, if any
+	Message string `json:"message,omitempty"`
+}
+
+// WorkloadReference identifies a workload.
+type WorkloadReference struct {
+	// Namespace indicates the namespace of the workload
+	Namespace string `json:"namespace"`
+
+	// Name indicates the name of the workload
+	Name string `json:"name"`
+}
+
+// PodSetDecision contains the flavors evaluated and chosen for the resources
+// of a pod set.
+type PodSetDecision struct {
+	// Name indicates the name of the pod set
+	Name string `json:"name"`
+
+	// ResourceGroups contains, for each of the resource groups requested by
+	// the pod set, the flavors evaluated and the flavor chosen
+	ResourceGroups []ResourceGroupDecision `json:"resourceGroups,omitempty"`
+}
+
+// ResourceGroupDecision contains the flavors evaluated and the flavor chosen
+// for the resources of a resource group requested by a pod set.
+type ResourceGroupDecision struct {
+	// Resources indicates the resources of the group requested by the pod set
+	Resources []corev1.ResourceName `json:"resources"`
+
+	// Attempts contains the flavors evaluated, in order
+	Attempts []FlavorAttempt `json:"attempts,omitempty"`
+
+	// Flavor indicates the flavor chosen, if any
+	Flavor string `json:"flavor,omitempty"`
+
+	// Reason indicates why the flavor was chosen, following the flavor
+	// fungibility of the ClusterQueue. It is one of:
+	// - Fit: the flavor fits without borrowing.
+	// - Borrow: the flavor fits by borrowing, and whenCanBorrow is Borrow.
+	// - Preempt: the flavor requires preemption, and whenCanPreempt is Preempt.
+	// - BestMode: no flavor satisfies the flavor fungibility, the flavor with
+	//   the best mode was chosen among all of them.
+	// - Score: the flavors were scored by cost or by plugins.
+	Reason string `json:"reason,omitempty"`
+}
+
+// FlavorAttempt is the evaluation of a flavor for the resources of a
+// resource group.
+type FlavorAttempt struct {
+	// Flavor indicates the name of the flavor
+	Flavor string `json:"flavor"`
+
+	// Mode indicates whether the resources fit in the flavor. It is one of
+	// Fit, Preempt or NoFit.
+	Mode string `json:"mode"`
+
+	// Borrowing indicates whether the resources need to borrow quota from the cohort
+	Borrowing bool `json:"borrowing,omitempty"`
+
+	// Message explains why the flavor can't be used, or doesn't fit, if any
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -105,9 +521,35 @@ type PendingWorkloadOptions struct {
 	Limit int64 `json:"limit,omitempty"`
 }
 
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +k8s:conversion-gen:explicit-from=net/url.Values
+// +k8s:defaulter-gen=true
+
+// AdmittedWorkloadOptions are query params used in the admitted workloads
+// visibility queries
+type AdmittedWorkloadOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Offset indicates position of the first admitted workload that should be fetched, starting from 0. 0 by default
+	Offset int64 `json:"offset"`
+
+	// Limit indicates max number of admitted workloads that should be fetched. 1000 by default
+	Limit int64 `json:"limit,omitempty"`
+
+	// Cohort indicates whether to fetch the workloads reserving quota in all
+	// the ClusterQueues of the cohort of the ClusterQueue, rather than only
+	// in the ClusterQueue. It is ignored for LocalQueues. false by default
+	Cohort bool `json:"cohort,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&PendingWorkloadsSummary{},
 		&PendingWorkloadOptions{},
+		&AdmittedWorkloadsSummary{},
+		&AdmittedWorkloadOptions{},
+		&PreemptionDryRun{},
+		&SchedulingDecisions{},
 	)
 }
@@ -34,6 +34,11 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*url.Values)(nil), (*AdmittedWorkloadOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_url_Values_To_v1beta1_AdmittedWorkloadOptions(a.(*url.Values), b.(*AdmittedWorkloadOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*url.Values)(nil), (*PendingWorkloadOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_url_Values_To_v1beta1_PendingWorkloadOptions(a.(*url.Values), b.(*PendingWorkloadOptions), scope)
 	}); err != nil {
@@ -42,6 +47,38 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_url_Values_To_v1beta1_AdmittedWorkloadOptions(in *url.Values, out *AdmittedWorkloadOptions, s conversion.Scope) error {
+	// WARNING: Field TypeMeta does not have json tag, skipping.
+
+	if values, ok := map[string][]string(*in)["offset"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_int64(&values, &out.Offset, s); err != nil {
+			return err
+		}
+	} else {
+		out.Offset = 0
+	}
+	if values, ok := map[string][]string(*in)["limit"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_int64(&values, &out.Limit, s); err != nil {
+			return err
+		}
+	} else {
+		out.Limit = 0
+	}
+	if values, ok := map[string][]string(*in)["cohort"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.Cohort, s); err != nil {
+			return err
+		}
+	} else {
+		out.Cohort = false
+	}
+	return nil
+}
+
+// Convert_url_Values_To_v1beta1_AdmittedWorkloadOptions is an autogenerated conversion function.
+func Convert_url_Values_To_v1beta1_AdmittedWorkloadOptions(in *url.Values, out *AdmittedWorkloadOptions, s conversion.Scope) error {
+	return autoConvert_url_Values_To_v1beta1_AdmittedWorkloadOptions(in, out, s)
+}
+
 func autoConvert_url_Values_To_v1beta1_PendingWorkloadOptions(in *url.Values, out *PendingWorkloadOptions, s conversion.Scope) error {
 	// WARNING: Field TypeMeta does not have json tag, skipping.
 
@@ -21,9 +21,110 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmittedWorkload) DeepCopyInto(out *AdmittedWorkload) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.PodSets != nil {
+		in, out := &in.PodSets, &out.PodSets
+		*out = make([]PodSetUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.QuotaReservationTime.DeepCopyInto(&out.QuotaReservationTime)
+	if in.AdmissionTime != nil {
+		in, out := &in.AdmissionTime, &out.AdmissionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmittedWorkload.
+func (in *AdmittedWorkload) DeepCopy() *AdmittedWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmittedWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmittedWorkloadOptions) DeepCopyInto(out *AdmittedWorkloadOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmittedWorkloadOptions.
+func (in *AdmittedWorkloadOptions) DeepCopy() *AdmittedWorkloadOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmittedWorkloadOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AdmittedWorkloadOptions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmittedWorkloadsSummary) DeepCopyInto(out *AdmittedWorkloadsSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AdmittedWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmittedWorkloadsSummary.
+func (in *AdmittedWorkloadsSummary) DeepCopy() *AdmittedWorkloadsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmittedWorkloadsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AdmittedWorkloadsSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlockingResource) DeepCopyInto(out *BlockingResource) {
+	*out = *in
+	out.MaxShortfall = in.MaxShortfall.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockingResource.
+func (in *BlockingResource) DeepCopy() *BlockingResource {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockingResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueue) DeepCopyInto(out *ClusterQueue) {
 	*out = *in
@@ -82,6 +183,51 @@ func (in *ClusterQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorAttempt) DeepCopyInto(out *FlavorAttempt) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorAttempt.
+func (in *FlavorAttempt) DeepCopy() *FlavorAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorBlocker) DeepCopyInto(out *FlavorBlocker) {
+	*out = *in
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Borrowable != nil {
+		in, out := &in.Borrowable, &out.Borrowable
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorBlocker.
+func (in *FlavorBlocker) DeepCopy() *FlavorBlocker {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorBlocker)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueue) DeepCopyInto(out *LocalQueue) {
 	*out = *in
@@ -144,6 +290,22 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 func (in *PendingWorkload) DeepCopyInto(out *PendingWorkload) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.EffectivePriority != nil {
+		in, out := &in.EffectivePriority, &out.EffectivePriority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EstimatedStartTime != nil {
+		in, out := &in.EstimatedStartTime, &out.EstimatedStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Blockers != nil {
+		in, out := &in.Blockers, &out.Blockers
+		*out = make([]PodSetBlockers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingWorkload.
@@ -192,6 +354,13 @@ func (in *PendingWorkloadsSummary) DeepCopyInto(out *PendingWorkloadsSummary) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TopBlockingResources != nil {
+		in, out := &in.TopBlockingResources, &out.TopBlockingResources
+		*out = make([]BlockingResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingWorkloadsSummary.
@@ -211,3 +380,360 @@ func (in *PendingWorkloadsSummary) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetBlockers) DeepCopyInto(out *PodSetBlockers) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceBlockers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetBlockers.
+func (in *PodSetBlockers) DeepCopy() *PodSetBlockers {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetBlockers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetDecision) DeepCopyInto(out *PodSetDecision) {
+	*out = *in
+	if in.ResourceGroups != nil {
+		in, out := &in.ResourceGroups, &out.ResourceGroups
+		*out = make([]ResourceGroupDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetDecision.
+func (in *PodSetDecision) DeepCopy() *PodSetDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetFlavors) DeepCopyInto(out *PodSetFlavors) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make(map[v1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetFlavors.
+func (in *PodSetFlavors) DeepCopy() *PodSetFlavors {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetFlavors)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetUsage) DeepCopyInto(out *PodSetUsage) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make(map[v1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetUsage.
+func (in *PodSetUsage) DeepCopy() *PodSetUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionDryRun) DeepCopyInto(out *PreemptionDryRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.PodSets != nil {
+		in, out := &in.PodSets, &out.PodSets
+		*out = make([]PodSetFlavors, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreemptionTargets != nil {
+		in, out := &in.PreemptionTargets, &out.PreemptionTargets
+		*out = make([]PreemptionTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProtectedWorkloads != nil {
+		in, out := &in.ProtectedWorkloads, &out.ProtectedWorkloads
+		*out = make([]ProtectedWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionDryRun.
+func (in *PreemptionDryRun) DeepCopy() *PreemptionDryRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionDryRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreemptionDryRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionTarget) DeepCopyInto(out *PreemptionTarget) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionTarget.
+func (in *PreemptionTarget) DeepCopy() *PreemptionTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedWorkload) DeepCopyInto(out *ProtectedWorkload) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.ProtectedUntil.DeepCopyInto(&out.ProtectedUntil)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedWorkload.
+func (in *ProtectedWorkload) DeepCopy() *ProtectedWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectedWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBlockers) DeepCopyInto(out *ResourceBlockers) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]FlavorBlocker, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBlockers.
+func (in *ResourceBlockers) DeepCopy() *ResourceBlockers {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBlockers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceGroupDecision) DeepCopyInto(out *ResourceGroupDecision) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]v1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]FlavorAttempt, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceGroupDecision.
+func (in *ResourceGroupDecision) DeepCopy() *ResourceGroupDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceGroupDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingDecision) DeepCopyInto(out *SchedulingDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Workload = in.Workload
+	if in.PodSets != nil {
+		in, out := &in.PodSets, &out.PodSets
+		*out = make([]PodSetDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreemptionTargets != nil {
+		in, out := &in.PreemptionTargets, &out.PreemptionTargets
+		*out = make([]PreemptionTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingDecision.
+func (in *SchedulingDecision) DeepCopy() *SchedulingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingDecisions) DeepCopyInto(out *SchedulingDecisions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SchedulingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingDecisions.
+func (in *SchedulingDecisions) DeepCopy() *SchedulingDecisions {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingDecisions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchedulingDecisions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Workload) DeepCopyInto(out *Workload) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.PreemptionDryRun.DeepCopyInto(&out.PreemptionDryRun)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workload.
+func (in *Workload) DeepCopy() *Workload {
+	if in == nil {
+		return nil
+	}
+	out := new(Workload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Workload) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadList) DeepCopyInto(out *WorkloadList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Workload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadList.
+func (in *WorkloadList) DeepCopy() *WorkloadList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
+func (in *WorkloadReference) DeepCopy() *WorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
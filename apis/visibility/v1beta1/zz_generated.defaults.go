@@ -28,10 +28,15 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&AdmittedWorkloadOptions{}, func(obj interface{}) { SetObjectDefaults_AdmittedWorkloadOptions(obj.(*AdmittedWorkloadOptions)) })
 	scheme.AddTypeDefaultingFunc(&PendingWorkloadOptions{}, func(obj interface{}) { SetObjectDefaults_PendingWorkloadOptions(obj.(*PendingWorkloadOptions)) })
 	return nil
 }
 
+func SetObjectDefaults_AdmittedWorkloadOptions(in *AdmittedWorkloadOptions) {
+	SetDefaults_AdmittedWorkloadOptions(in)
+}
+
 func SetObjectDefaults_PendingWorkloadOptions(in *PendingWorkloadOptions) {
 	SetDefaults_PendingWorkloadOptions(in)
 }
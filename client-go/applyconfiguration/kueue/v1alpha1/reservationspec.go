@@ -0,0 +1,93 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// ReservationSpecApplyConfiguration represents a declarative configuration of the ReservationSpec type for use
+// with apply.
+type ReservationSpecApplyConfiguration struct {
+	ClusterQueue  *v1beta1.ClusterQueueReference     `json:"clusterQueue,omitempty"`
+	Cohort        *v1beta1.CohortReference           `json:"cohort,omitempty"`
+	Flavors       []ReservedFlavorApplyConfiguration `json:"flavors,omitempty"`
+	StartTime     *v1.Time                           `json:"startTime,omitempty"`
+	Duration      *v1.Duration                       `json:"duration,omitempty"`
+	DrainDuration *v1.Duration                       `json:"drainDuration,omitempty"`
+}
+
+// ReservationSpecApplyConfiguration constructs a declarative configuration of the ReservationSpec type for use with
+// apply.
+func ReservationSpec() *ReservationSpecApplyConfiguration {
+	return &ReservationSpecApplyConfiguration{}
+}
+
+// WithClusterQueue sets the ClusterQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterQueue field is set to the value of the last call.
+func (b *ReservationSpecApplyConfiguration) WithClusterQueue(value v1beta1.ClusterQueueReference) *ReservationSpecApplyConfiguration {
+	b.ClusterQueue = &value
+	return b
+}
+
+// WithCohort sets the Cohort field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cohort field is set to the value of the last call.
+func (b *ReservationSpecApplyConfiguration) WithCohort(value v1beta1.CohortReference) *ReservationSpecApplyConfiguration {
+	b.Cohort = &value
+	return b
+}
+
+// WithFlavors adds the given value to the Flavors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Flavors field.
+func (b *ReservationSpecApplyConfiguration) WithFlavors(values ...*ReservedFlavorApplyConfiguration) *ReservationSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFlavors")
+		}
+		b.Flavors = append(b.Flavors, *values[i])
+	}
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *ReservationSpecApplyConfiguration) WithStartTime(value v1.Time) *ReservationSpecApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *ReservationSpecApplyConfiguration) WithDuration(value v1.Duration) *ReservationSpecApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithDrainDuration sets the DrainDuration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrainDuration field is set to the value of the last call.
+func (b *ReservationSpecApplyConfiguration) WithDrainDuration(value v1.Duration) *ReservationSpecApplyConfiguration {
+	b.DrainDuration = &value
+	return b
+}
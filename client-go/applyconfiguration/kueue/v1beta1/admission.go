@@ -18,6 +18,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
@@ -26,6 +27,7 @@ import (
 type AdmissionApplyConfiguration struct {
 	ClusterQueue      *kueuev1beta1.ClusterQueueReference  `json:"clusterQueue,omitempty"`
 	PodSetAssignments []PodSetAssignmentApplyConfiguration `json:"podSetAssignments,omitempty"`
+	Cost              *resource.Quantity                   `json:"cost,omitempty"`
 }
 
 // AdmissionApplyConfiguration constructs a declarative configuration of the Admission type for use with
@@ -54,3 +56,11 @@ func (b *AdmissionApplyConfiguration) WithPodSetAssignments(values ...*PodSetAss
 	}
 	return b
 }
+
+// WithCost sets the Cost field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cost field is set to the value of the last call.
+func (b *AdmissionApplyConfiguration) WithCost(value resource.Quantity) *AdmissionApplyConfiguration {
+	b.Cost = &value
+	return b
+}
@@ -24,9 +24,10 @@ import (
 // ClusterQueuePreemptionApplyConfiguration represents a declarative configuration of the ClusterQueuePreemption type for use
 // with apply.
 type ClusterQueuePreemptionApplyConfiguration struct {
-	ReclaimWithinCohort *kueuev1beta1.PreemptionPolicy        `json:"reclaimWithinCohort,omitempty"`
-	BorrowWithinCohort  *BorrowWithinCohortApplyConfiguration `json:"borrowWithinCohort,omitempty"`
-	WithinClusterQueue  *kueuev1beta1.PreemptionPolicy        `json:"withinClusterQueue,omitempty"`
+	ReclaimWithinCohort   *kueuev1beta1.PreemptionPolicy        `json:"reclaimWithinCohort,omitempty"`
+	BorrowWithinCohort    *BorrowWithinCohortApplyConfiguration `json:"borrowWithinCohort,omitempty"`
+	WithinClusterQueue    *kueuev1beta1.PreemptionPolicy        `json:"withinClusterQueue,omitempty"`
+	MinimumRuntimeSeconds *int32                                `json:"minimumRuntimeSeconds,omitempty"`
 }
 
 // ClusterQueuePreemptionApplyConfiguration constructs a declarative configuration of the ClusterQueuePreemption type for use with
@@ -58,3 +59,11 @@ func (b *ClusterQueuePreemptionApplyConfiguration) WithWithinClusterQueue(value
 	b.WithinClusterQueue = &value
 	return b
 }
+
+// WithMinimumRuntimeSeconds sets the MinimumRuntimeSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinimumRuntimeSeconds field is set to the value of the last call.
+func (b *ClusterQueuePreemptionApplyConfiguration) WithMinimumRuntimeSeconds(value int32) *ClusterQueuePreemptionApplyConfiguration {
+	b.MinimumRuntimeSeconds = &value
+	return b
+}
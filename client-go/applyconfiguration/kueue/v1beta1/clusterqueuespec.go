@@ -30,11 +30,15 @@ type ClusterQueueSpecApplyConfiguration struct {
 	QueueingStrategy        *kueuev1beta1.QueueingStrategy             `json:"queueingStrategy,omitempty"`
 	NamespaceSelector       *v1.LabelSelectorApplyConfiguration        `json:"namespaceSelector,omitempty"`
 	FlavorFungibility       *FlavorFungibilityApplyConfiguration       `json:"flavorFungibility,omitempty"`
+	FlavorSelectionPolicy   *kueuev1beta1.FlavorSelectionPolicy        `json:"flavorSelectionPolicy,omitempty"`
+	PriorityAging           *PriorityAgingApplyConfiguration           `json:"priorityAging,omitempty"`
+	MaxAdmittedWorkloads    *int32                                     `json:"maxAdmittedWorkloads,omitempty"`
 	Preemption              *ClusterQueuePreemptionApplyConfiguration  `json:"preemption,omitempty"`
 	AdmissionChecks         []string                                   `json:"admissionChecks,omitempty"`
 	AdmissionChecksStrategy *AdmissionChecksStrategyApplyConfiguration `json:"admissionChecksStrategy,omitempty"`
 	StopPolicy              *kueuev1beta1.StopPolicy                   `json:"stopPolicy,omitempty"`
 	FairSharing             *FairSharingApplyConfiguration             `json:"fairSharing,omitempty"`
+	AdmissionScope          *AdmissionScopeApplyConfiguration          `json:"admissionScope,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs a declarative configuration of the ClusterQueueSpec type for use with
@@ -88,6 +92,30 @@ func (b *ClusterQueueSpecApplyConfiguration) WithFlavorFungibility(value *Flavor
 	return b
 }
 
+// WithFlavorSelectionPolicy sets the FlavorSelectionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlavorSelectionPolicy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithFlavorSelectionPolicy(value kueuev1beta1.FlavorSelectionPolicy) *ClusterQueueSpecApplyConfiguration {
+	b.FlavorSelectionPolicy = &value
+	return b
+}
+
+// WithPriorityAging sets the PriorityAging field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityAging field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithPriorityAging(value *PriorityAgingApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.PriorityAging = value
+	return b
+}
+
+// WithMaxAdmittedWorkloads sets the MaxAdmittedWorkloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAdmittedWorkloads field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithMaxAdmittedWorkloads(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.MaxAdmittedWorkloads = &value
+	return b
+}
+
 // WithPreemption sets the Preemption field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Preemption field is set to the value of the last call.
@@ -129,3 +157,11 @@ func (b *ClusterQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingA
 	b.FairSharing = value
 	return b
 }
+
+// WithAdmissionScope sets the AdmissionScope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdmissionScope field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithAdmissionScope(value *AdmissionScopeApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.AdmissionScope = value
+	return b
+}
@@ -24,14 +24,15 @@ import (
 // ClusterQueueStatusApplyConfiguration represents a declarative configuration of the ClusterQueueStatus type for use
 // with apply.
 type ClusterQueueStatusApplyConfiguration struct {
-	FlavorsReservation     []FlavorUsageApplyConfiguration                       `json:"flavorsReservation,omitempty"`
-	FlavorsUsage           []FlavorUsageApplyConfiguration                       `json:"flavorsUsage,omitempty"`
-	PendingWorkloads       *int32                                                `json:"pendingWorkloads,omitempty"`
-	ReservingWorkloads     *int32                                                `json:"reservingWorkloads,omitempty"`
-	AdmittedWorkloads      *int32                                                `json:"admittedWorkloads,omitempty"`
-	Conditions             []v1.ConditionApplyConfiguration                      `json:"conditions,omitempty"`
-	PendingWorkloadsStatus *ClusterQueuePendingWorkloadsStatusApplyConfiguration `json:"pendingWorkloadsStatus,omitempty"`
-	FairSharing            *FairSharingStatusApplyConfiguration                  `json:"fairSharing,omitempty"`
+	FlavorsReservation        []FlavorUsageApplyConfiguration                       `json:"flavorsReservation,omitempty"`
+	FlavorsUsage              []FlavorUsageApplyConfiguration                       `json:"flavorsUsage,omitempty"`
+	PendingWorkloads          *int32                                                `json:"pendingWorkloads,omitempty"`
+	ReservingWorkloads        *int32                                                `json:"reservingWorkloads,omitempty"`
+	AdmittedWorkloads         *int32                                                `json:"admittedWorkloads,omitempty"`
+	Conditions                []v1.ConditionApplyConfiguration                      `json:"conditions,omitempty"`
+	PendingWorkloadsStatus    *ClusterQueuePendingWorkloadsStatusApplyConfiguration `json:"pendingWorkloadsStatus,omitempty"`
+	FairSharing               *FairSharingStatusApplyConfiguration                  `json:"fairSharing,omitempty"`
+	MaxAdmittedWorkloadsUsage *int32                                                `json:"maxAdmittedWorkloadsUsage,omitempty"`
 }
 
 // ClusterQueueStatusApplyConfiguration constructs a declarative configuration of the ClusterQueueStatus type for use with
@@ -118,3 +119,11 @@ func (b *ClusterQueueStatusApplyConfiguration) WithFairSharing(value *FairSharin
 	b.FairSharing = value
 	return b
 }
+
+// WithMaxAdmittedWorkloadsUsage sets the MaxAdmittedWorkloadsUsage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAdmittedWorkloadsUsage field is set to the value of the last call.
+func (b *ClusterQueueStatusApplyConfiguration) WithMaxAdmittedWorkloadsUsage(value int32) *ClusterQueueStatusApplyConfiguration {
+	b.MaxAdmittedWorkloadsUsage = &value
+	return b
+}
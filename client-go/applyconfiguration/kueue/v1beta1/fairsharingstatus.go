@@ -20,7 +20,8 @@ package v1beta1
 // FairSharingStatusApplyConfiguration represents a declarative configuration of the FairSharingStatus type for use
 // with apply.
 type FairSharingStatusApplyConfiguration struct {
-	WeightedShare *int64 `json:"weightedShare,omitempty"`
+	WeightedShare     *int64                               `json:"weightedShare,omitempty"`
+	ConsumedResources *ConsumedResourcesApplyConfiguration `json:"consumedResources,omitempty"`
 }
 
 // FairSharingStatusApplyConfiguration constructs a declarative configuration of the FairSharingStatus type for use with
@@ -36,3 +37,11 @@ func (b *FairSharingStatusApplyConfiguration) WithWeightedShare(value int64) *Fa
 	b.WeightedShare = &value
 	return b
 }
+
+// WithConsumedResources sets the ConsumedResources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConsumedResources field is set to the value of the last call.
+func (b *FairSharingStatusApplyConfiguration) WithConsumedResources(value *ConsumedResourcesApplyConfiguration) *FairSharingStatusApplyConfiguration {
+	b.ConsumedResources = value
+	return b
+}
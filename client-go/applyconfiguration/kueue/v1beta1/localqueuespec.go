@@ -24,8 +24,11 @@ import (
 // LocalQueueSpecApplyConfiguration represents a declarative configuration of the LocalQueueSpec type for use
 // with apply.
 type LocalQueueSpecApplyConfiguration struct {
-	ClusterQueue *kueuev1beta1.ClusterQueueReference `json:"clusterQueue,omitempty"`
-	StopPolicy   *kueuev1beta1.StopPolicy            `json:"stopPolicy,omitempty"`
+	ClusterQueue         *kueuev1beta1.ClusterQueueReference        `json:"clusterQueue,omitempty"`
+	StopPolicy           *kueuev1beta1.StopPolicy                   `json:"stopPolicy,omitempty"`
+	Quotas               []LocalQueueFlavorQuotasApplyConfiguration `json:"quotas,omitempty"`
+	FairSharing          *FairSharingApplyConfiguration             `json:"fairSharing,omitempty"`
+	MaxAdmittedWorkloads *int32                                     `json:"maxAdmittedWorkloads,omitempty"`
 }
 
 // LocalQueueSpecApplyConfiguration constructs a declarative configuration of the LocalQueueSpec type for use with
@@ -49,3 +52,32 @@ func (b *LocalQueueSpecApplyConfiguration) WithStopPolicy(value kueuev1beta1.Sto
 	b.StopPolicy = &value
 	return b
 }
+
+// WithQuotas adds the given value to the Quotas field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Quotas field.
+func (b *LocalQueueSpecApplyConfiguration) WithQuotas(values ...*LocalQueueFlavorQuotasApplyConfiguration) *LocalQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithQuotas")
+		}
+		b.Quotas = append(b.Quotas, *values[i])
+	}
+	return b
+}
+
+// WithFairSharing sets the FairSharing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FairSharing field is set to the value of the last call.
+func (b *LocalQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingApplyConfiguration) *LocalQueueSpecApplyConfiguration {
+	b.FairSharing = value
+	return b
+}
+
+// WithMaxAdmittedWorkloads sets the MaxAdmittedWorkloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAdmittedWorkloads field is set to the value of the last call.
+func (b *LocalQueueSpecApplyConfiguration) WithMaxAdmittedWorkloads(value int32) *LocalQueueSpecApplyConfiguration {
+	b.MaxAdmittedWorkloads = &value
+	return b
+}
@@ -24,13 +24,15 @@ import (
 // LocalQueueStatusApplyConfiguration represents a declarative configuration of the LocalQueueStatus type for use
 // with apply.
 type LocalQueueStatusApplyConfiguration struct {
-	PendingWorkloads   *int32                                     `json:"pendingWorkloads,omitempty"`
-	ReservingWorkloads *int32                                     `json:"reservingWorkloads,omitempty"`
-	AdmittedWorkloads  *int32                                     `json:"admittedWorkloads,omitempty"`
-	Conditions         []v1.ConditionApplyConfiguration           `json:"conditions,omitempty"`
-	FlavorsReservation []LocalQueueFlavorUsageApplyConfiguration  `json:"flavorsReservation,omitempty"`
-	FlavorUsage        []LocalQueueFlavorUsageApplyConfiguration  `json:"flavorUsage,omitempty"`
-	Flavors            []LocalQueueFlavorStatusApplyConfiguration `json:"flavors,omitempty"`
+	PendingWorkloads          *int32                                     `json:"pendingWorkloads,omitempty"`
+	ReservingWorkloads        *int32                                     `json:"reservingWorkloads,omitempty"`
+	AdmittedWorkloads         *int32                                     `json:"admittedWorkloads,omitempty"`
+	Conditions                []v1.ConditionApplyConfiguration           `json:"conditions,omitempty"`
+	FlavorsReservation        []LocalQueueFlavorUsageApplyConfiguration  `json:"flavorsReservation,omitempty"`
+	FlavorUsage               []LocalQueueFlavorUsageApplyConfiguration  `json:"flavorUsage,omitempty"`
+	Flavors                   []LocalQueueFlavorStatusApplyConfiguration `json:"flavors,omitempty"`
+	FairSharing               *FairSharingStatusApplyConfiguration       `json:"fairSharing,omitempty"`
+	MaxAdmittedWorkloadsUsage *int32                                     `json:"maxAdmittedWorkloadsUsage,omitempty"`
 }
 
 // LocalQueueStatusApplyConfiguration constructs a declarative configuration of the LocalQueueStatus type for use with
@@ -114,3 +116,19 @@ func (b *LocalQueueStatusApplyConfiguration) WithFlavors(values ...*LocalQueueFl
 	}
 	return b
 }
+
+// WithFairSharing sets the FairSharing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FairSharing field is set to the value of the last call.
+func (b *LocalQueueStatusApplyConfiguration) WithFairSharing(value *FairSharingStatusApplyConfiguration) *LocalQueueStatusApplyConfiguration {
+	b.FairSharing = value
+	return b
+}
+
+// WithMaxAdmittedWorkloadsUsage sets the MaxAdmittedWorkloadsUsage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAdmittedWorkloadsUsage field is set to the value of the last call.
+func (b *LocalQueueStatusApplyConfiguration) WithMaxAdmittedWorkloadsUsage(value int32) *LocalQueueStatusApplyConfiguration {
+	b.MaxAdmittedWorkloadsUsage = &value
+	return b
+}
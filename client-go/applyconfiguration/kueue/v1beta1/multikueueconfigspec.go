@@ -20,7 +20,8 @@ package v1beta1
 // MultiKueueConfigSpecApplyConfiguration represents a declarative configuration of the MultiKueueConfigSpec type for use
 // with apply.
 type MultiKueueConfigSpecApplyConfiguration struct {
-	Clusters []string `json:"clusters,omitempty"`
+	Clusters   []string                                `json:"clusters,omitempty"`
+	Dispatcher *MultiKueueDispatcherApplyConfiguration `json:"dispatcher,omitempty"`
 }
 
 // MultiKueueConfigSpecApplyConfiguration constructs a declarative configuration of the MultiKueueConfigSpec type for use with
@@ -38,3 +39,11 @@ func (b *MultiKueueConfigSpecApplyConfiguration) WithClusters(values ...string)
 	}
 	return b
 }
+
+// WithDispatcher sets the Dispatcher field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Dispatcher field is set to the value of the last call.
+func (b *MultiKueueConfigSpecApplyConfiguration) WithDispatcher(value *MultiKueueDispatcherApplyConfiguration) *MultiKueueConfigSpecApplyConfiguration {
+	b.Dispatcher = value
+	return b
+}
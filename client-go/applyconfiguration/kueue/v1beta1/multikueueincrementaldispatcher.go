@@ -0,0 +1,51 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MultiKueueIncrementalDispatcherApplyConfiguration represents a declarative configuration of the MultiKueueIncrementalDispatcher type for use
+// with apply.
+type MultiKueueIncrementalDispatcherApplyConfiguration struct {
+	BatchSize    *int32       `json:"batchSize,omitempty"`
+	RoundTimeout *v1.Duration `json:"roundTimeout,omitempty"`
+}
+
+// MultiKueueIncrementalDispatcherApplyConfiguration constructs a declarative configuration of the MultiKueueIncrementalDispatcher type for use with
+// apply.
+func MultiKueueIncrementalDispatcher() *MultiKueueIncrementalDispatcherApplyConfiguration {
+	return &MultiKueueIncrementalDispatcherApplyConfiguration{}
+}
+
+// WithBatchSize sets the BatchSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BatchSize field is set to the value of the last call.
+func (b *MultiKueueIncrementalDispatcherApplyConfiguration) WithBatchSize(value int32) *MultiKueueIncrementalDispatcherApplyConfiguration {
+	b.BatchSize = &value
+	return b
+}
+
+// WithRoundTimeout sets the RoundTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RoundTimeout field is set to the value of the last call.
+func (b *MultiKueueIncrementalDispatcherApplyConfiguration) WithRoundTimeout(value v1.Duration) *MultiKueueIncrementalDispatcherApplyConfiguration {
+	b.RoundTimeout = &value
+	return b
+}
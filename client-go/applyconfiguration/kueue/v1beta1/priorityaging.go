@@ -0,0 +1,69 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PriorityAgingApplyConfiguration represents a declarative configuration of the PriorityAging type for use
+// with apply.
+type PriorityAgingApplyConfiguration struct {
+	Interval        *v1.Duration `json:"interval,omitempty"`
+	Increment       *int32       `json:"increment,omitempty"`
+	MaxIncrement    *int32       `json:"maxIncrement,omitempty"`
+	UseInPreemption *bool        `json:"useInPreemption,omitempty"`
+}
+
+// PriorityAgingApplyConfiguration constructs a declarative configuration of the PriorityAging type for use with
+// apply.
+func PriorityAging() *PriorityAgingApplyConfiguration {
+	return &PriorityAgingApplyConfiguration{}
+}
+
+// WithInterval sets the Interval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Interval field is set to the value of the last call.
+func (b *PriorityAgingApplyConfiguration) WithInterval(value v1.Duration) *PriorityAgingApplyConfiguration {
+	b.Interval = &value
+	return b
+}
+
+// WithIncrement sets the Increment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Increment field is set to the value of the last call.
+func (b *PriorityAgingApplyConfiguration) WithIncrement(value int32) *PriorityAgingApplyConfiguration {
+	b.Increment = &value
+	return b
+}
+
+// WithMaxIncrement sets the MaxIncrement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxIncrement field is set to the value of the last call.
+func (b *PriorityAgingApplyConfiguration) WithMaxIncrement(value int32) *PriorityAgingApplyConfiguration {
+	b.MaxIncrement = &value
+	return b
+}
+
+// WithUseInPreemption sets the UseInPreemption field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UseInPreemption field is set to the value of the last call.
+func (b *PriorityAgingApplyConfiguration) WithUseInPreemption(value bool) *PriorityAgingApplyConfiguration {
+	b.UseInPreemption = &value
+	return b
+}
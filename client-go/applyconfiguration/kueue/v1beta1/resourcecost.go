@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceCostApplyConfiguration represents a declarative configuration of the ResourceCost type for use
+// with apply.
+type ResourceCostApplyConfiguration struct {
+	Name  *v1.ResourceName   `json:"name,omitempty"`
+	Price *resource.Quantity `json:"price,omitempty"`
+	Unit  *resource.Quantity `json:"unit,omitempty"`
+}
+
+// ResourceCostApplyConfiguration constructs a declarative configuration of the ResourceCost type for use with
+// apply.
+func ResourceCost() *ResourceCostApplyConfiguration {
+	return &ResourceCostApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ResourceCostApplyConfiguration) WithName(value v1.ResourceName) *ResourceCostApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithPrice sets the Price field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Price field is set to the value of the last call.
+func (b *ResourceCostApplyConfiguration) WithPrice(value resource.Quantity) *ResourceCostApplyConfiguration {
+	b.Price = &value
+	return b
+}
+
+// WithUnit sets the Unit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unit field is set to the value of the last call.
+func (b *ResourceCostApplyConfiguration) WithUnit(value resource.Quantity) *ResourceCostApplyConfiguration {
+	b.Unit = &value
+	return b
+}
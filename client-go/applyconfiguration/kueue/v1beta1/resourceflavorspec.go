@@ -29,6 +29,7 @@ type ResourceFlavorSpecApplyConfiguration struct {
 	NodeTaints   []v1.TaintApplyConfiguration      `json:"nodeTaints,omitempty"`
 	Tolerations  []v1.TolerationApplyConfiguration `json:"tolerations,omitempty"`
 	TopologyName *kueuev1beta1.TopologyReference   `json:"topologyName,omitempty"`
+	Costs        []ResourceCostApplyConfiguration  `json:"costs,omitempty"`
 }
 
 // ResourceFlavorSpecApplyConfiguration constructs a declarative configuration of the ResourceFlavorSpec type for use with
@@ -84,3 +85,16 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTopologyName(value kueuev1bet
 	b.TopologyName = &value
 	return b
 }
+
+// WithCosts adds the given value to the Costs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Costs field.
+func (b *ResourceFlavorSpecApplyConfiguration) WithCosts(values ...*ResourceCostApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCosts")
+		}
+		b.Costs = append(b.Costs, *values[i])
+	}
+	return b
+}
@@ -25,10 +25,11 @@ import (
 // ResourceQuotaApplyConfiguration represents a declarative configuration of the ResourceQuota type for use
 // with apply.
 type ResourceQuotaApplyConfiguration struct {
-	Name           *v1.ResourceName   `json:"name,omitempty"`
-	NominalQuota   *resource.Quantity `json:"nominalQuota,omitempty"`
-	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
-	LendingLimit   *resource.Quantity `json:"lendingLimit,omitempty"`
+	Name           *v1.ResourceName                  `json:"name,omitempty"`
+	NominalQuota   *resource.Quantity                `json:"nominalQuota,omitempty"`
+	BorrowingLimit *resource.Quantity                `json:"borrowingLimit,omitempty"`
+	LendingLimit   *resource.Quantity                `json:"lendingLimit,omitempty"`
+	Schedules      []QuotaScheduleApplyConfiguration `json:"schedules,omitempty"`
 }
 
 // ResourceQuotaApplyConfiguration constructs a declarative configuration of the ResourceQuota type for use with
@@ -68,3 +69,16 @@ func (b *ResourceQuotaApplyConfiguration) WithLendingLimit(value resource.Quanti
 	b.LendingLimit = &value
 	return b
 }
+
+// WithSchedules adds the given value to the Schedules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Schedules field.
+func (b *ResourceQuotaApplyConfiguration) WithSchedules(values ...*QuotaScheduleApplyConfiguration) *ResourceQuotaApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSchedules")
+		}
+		b.Schedules = append(b.Schedules, *values[i])
+	}
+	return b
+}
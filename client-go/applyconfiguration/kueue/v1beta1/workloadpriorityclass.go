@@ -30,6 +30,7 @@ type WorkloadPriorityClassApplyConfiguration struct {
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
 	Value                            *int32  `json:"value,omitempty"`
 	Description                      *string `json:"description,omitempty"`
+	MinimumRuntimeSeconds            *int32  `json:"minimumRuntimeSeconds,omitempty"`
 }
 
 // WorkloadPriorityClass constructs a declarative configuration of the WorkloadPriorityClass type for use with
@@ -216,6 +217,14 @@ func (b *WorkloadPriorityClassApplyConfiguration) WithDescription(value string)
 	return b
 }
 
+// WithMinimumRuntimeSeconds sets the MinimumRuntimeSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinimumRuntimeSeconds field is set to the value of the last call.
+func (b *WorkloadPriorityClassApplyConfiguration) WithMinimumRuntimeSeconds(value int32) *WorkloadPriorityClassApplyConfiguration {
+	b.MinimumRuntimeSeconds = &value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *WorkloadPriorityClassApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
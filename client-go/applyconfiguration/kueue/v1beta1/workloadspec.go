@@ -27,6 +27,7 @@ type WorkloadSpecApplyConfiguration struct {
 	PriorityClassSource         *string                    `json:"priorityClassSource,omitempty"`
 	Active                      *bool                      `json:"active,omitempty"`
 	MaximumExecutionTimeSeconds *int32                     `json:"maximumExecutionTimeSeconds,omitempty"`
+	MinimumRuntimeSeconds       *int32                     `json:"minimumRuntimeSeconds,omitempty"`
 }
 
 // WorkloadSpecApplyConfiguration constructs a declarative configuration of the WorkloadSpec type for use with
@@ -95,3 +96,11 @@ func (b *WorkloadSpecApplyConfiguration) WithMaximumExecutionTimeSeconds(value i
 	b.MaximumExecutionTimeSeconds = &value
 	return b
 }
+
+// WithMinimumRuntimeSeconds sets the MinimumRuntimeSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinimumRuntimeSeconds field is set to the value of the last call.
+func (b *WorkloadSpecApplyConfiguration) WithMinimumRuntimeSeconds(value int32) *WorkloadSpecApplyConfiguration {
+	b.MinimumRuntimeSeconds = &value
+	return b
+}
@@ -35,6 +35,14 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=kueue.x-k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("Reservation"):
+		return &kueuev1alpha1.ReservationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReservationSpec"):
+		return &kueuev1alpha1.ReservationSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReservationStatus"):
+		return &kueuev1alpha1.ReservationStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ReservedFlavor"):
+		return &kueuev1alpha1.ReservedFlavorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Topology"):
 		return &kueuev1alpha1.TopologyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TopologyLevel"):
@@ -59,6 +67,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.AdmissionCheckStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckStrategyRule"):
 		return &kueuev1beta1.AdmissionCheckStrategyRuleApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionScope"):
+		return &kueuev1beta1.AdmissionScopeApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("BorrowWithinCohort"):
 		return &kueuev1beta1.BorrowWithinCohortApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
@@ -73,6 +83,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ClusterQueueSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueStatus"):
 		return &kueuev1beta1.ClusterQueueStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ConsumedResources"):
+		return &kueuev1beta1.ConsumedResourcesApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FairSharing"):
 		return &kueuev1beta1.FairSharingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FairSharingStatus"):
@@ -87,10 +99,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.KubeConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueue"):
 		return &kueuev1beta1.LocalQueueApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorQuotas"):
+		return &kueuev1beta1.LocalQueueFlavorQuotasApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorStatus"):
 		return &kueuev1beta1.LocalQueueFlavorStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorUsage"):
 		return &kueuev1beta1.LocalQueueFlavorUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueResourceQuota"):
+		return &kueuev1beta1.LocalQueueResourceQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueResourceUsage"):
 		return &kueuev1beta1.LocalQueueResourceUsageApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueSpec"):
@@ -107,6 +123,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.MultiKueueConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueConfigSpec"):
 		return &kueuev1beta1.MultiKueueConfigSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueDispatcher"):
+		return &kueuev1beta1.MultiKueueDispatcherApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueIncrementalDispatcher"):
+		return &kueuev1beta1.MultiKueueIncrementalDispatcherApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSet"):
 		return &kueuev1beta1.PodSetApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetAssignment"):
@@ -117,16 +137,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.PodSetTopologyRequestApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetUpdate"):
 		return &kueuev1beta1.PodSetUpdateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PriorityAging"):
+		return &kueuev1beta1.PriorityAgingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfig"):
 		return &kueuev1beta1.ProvisioningRequestConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfigSpec"):
 		return &kueuev1beta1.ProvisioningRequestConfigSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestRetryStrategy"):
 		return &kueuev1beta1.ProvisioningRequestRetryStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("QuotaSchedule"):
+		return &kueuev1beta1.QuotaScheduleApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ReclaimablePod"):
 		return &kueuev1beta1.ReclaimablePodApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("RequeueState"):
 		return &kueuev1beta1.RequeueStateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceCost"):
+		return &kueuev1beta1.ResourceCostApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavor"):
 		return &kueuev1beta1.ResourceFlavorApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavorSpec"):
@@ -151,14 +177,32 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.WorkloadStatusApplyConfiguration{}
 
 		// Group=visibility.kueue.x-k8s.io, Version=v1beta1
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("BlockingResource"):
+		return &applyconfigurationvisibilityv1beta1.BlockingResourceApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
 		return &applyconfigurationvisibilityv1beta1.ClusterQueueApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("FlavorBlocker"):
+		return &applyconfigurationvisibilityv1beta1.FlavorBlockerApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("LocalQueue"):
 		return &applyconfigurationvisibilityv1beta1.LocalQueueApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("PendingWorkload"):
 		return &applyconfigurationvisibilityv1beta1.PendingWorkloadApplyConfiguration{}
 	case visibilityv1beta1.SchemeGroupVersion.WithKind("PendingWorkloadsSummary"):
 		return &applyconfigurationvisibilityv1beta1.PendingWorkloadsSummaryApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("PodSetBlockers"):
+		return &applyconfigurationvisibilityv1beta1.PodSetBlockersApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("PodSetFlavors"):
+		return &applyconfigurationvisibilityv1beta1.PodSetFlavorsApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("PreemptionDryRun"):
+		return &applyconfigurationvisibilityv1beta1.PreemptionDryRunApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("PreemptionTarget"):
+		return &applyconfigurationvisibilityv1beta1.PreemptionTargetApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("ProtectedWorkload"):
+		return &applyconfigurationvisibilityv1beta1.ProtectedWorkloadApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("ResourceBlockers"):
+		return &applyconfigurationvisibilityv1beta1.ResourceBlockersApplyConfiguration{}
+	case visibilityv1beta1.SchemeGroupVersion.WithKind("Workload"):
+		return &applyconfigurationvisibilityv1beta1.WorkloadApplyConfiguration{}
 
 	}
 	return nil
@@ -0,0 +1,70 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// BlockingResourceApplyConfiguration represents a declarative configuration of the BlockingResource type for use
+// with apply.
+type BlockingResourceApplyConfiguration struct {
+	Flavor       *string            `json:"flavor,omitempty"`
+	Resource     *v1.ResourceName   `json:"resource,omitempty"`
+	Workloads    *int32             `json:"workloads,omitempty"`
+	MaxShortfall *resource.Quantity `json:"maxShortfall,omitempty"`
+}
+
+// BlockingResourceApplyConfiguration constructs a declarative configuration of the BlockingResource type for use with
+// apply.
+func BlockingResource() *BlockingResourceApplyConfiguration {
+	return &BlockingResourceApplyConfiguration{}
+}
+
+// WithFlavor sets the Flavor field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Flavor field is set to the value of the last call.
+func (b *BlockingResourceApplyConfiguration) WithFlavor(value string) *BlockingResourceApplyConfiguration {
+	b.Flavor = &value
+	return b
+}
+
+// WithResource sets the Resource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resource field is set to the value of the last call.
+func (b *BlockingResourceApplyConfiguration) WithResource(value v1.ResourceName) *BlockingResourceApplyConfiguration {
+	b.Resource = &value
+	return b
+}
+
+// WithWorkloads sets the Workloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Workloads field is set to the value of the last call.
+func (b *BlockingResourceApplyConfiguration) WithWorkloads(value int32) *BlockingResourceApplyConfiguration {
+	b.Workloads = &value
+	return b
+}
+
+// WithMaxShortfall sets the MaxShortfall field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxShortfall field is set to the value of the last call.
+func (b *BlockingResourceApplyConfiguration) WithMaxShortfall(value resource.Quantity) *BlockingResourceApplyConfiguration {
+	b.MaxShortfall = &value
+	return b
+}
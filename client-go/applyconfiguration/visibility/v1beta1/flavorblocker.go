@@ -0,0 +1,87 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// FlavorBlockerApplyConfiguration represents a declarative configuration of the FlavorBlocker type for use
+// with apply.
+type FlavorBlockerApplyConfiguration struct {
+	Name       *string            `json:"name,omitempty"`
+	Requested  *resource.Quantity `json:"requested,omitempty"`
+	Available  *resource.Quantity `json:"available,omitempty"`
+	Borrowable *resource.Quantity `json:"borrowable,omitempty"`
+	Reason     *string            `json:"reason,omitempty"`
+	Message    *string            `json:"message,omitempty"`
+}
+
+// FlavorBlockerApplyConfiguration constructs a declarative configuration of the FlavorBlocker type for use with
+// apply.
+func FlavorBlocker() *FlavorBlockerApplyConfiguration {
+	return &FlavorBlockerApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *FlavorBlockerApplyConfiguration) WithName(value string) *FlavorBlockerApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithRequested sets the Requested field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Requested field is set to the value of the last call.
+func (b *FlavorBlockerApplyConfiguration) WithRequested(value resource.Quantity) *FlavorBlockerApplyConfiguration {
+	b.Requested = &value
+	return b
+}
+
+// WithAvailable sets the Available field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Available field is set to the value of the last call.
+func (b *FlavorBlockerApplyConfiguration) WithAvailable(value resource.Quantity) *FlavorBlockerApplyConfiguration {
+	b.Available = &value
+	return b
+}
+
+// WithBorrowable sets the Borrowable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Borrowable field is set to the value of the last call.
+func (b *FlavorBlockerApplyConfiguration) WithBorrowable(value resource.Quantity) *FlavorBlockerApplyConfiguration {
+	b.Borrowable = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *FlavorBlockerApplyConfiguration) WithReason(value string) *FlavorBlockerApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *FlavorBlockerApplyConfiguration) WithMessage(value string) *FlavorBlockerApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -27,10 +27,15 @@ import (
 // with apply.
 type PendingWorkloadApplyConfiguration struct {
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Priority                         *int32  `json:"priority,omitempty"`
-	LocalQueueName                   *string `json:"localQueueName,omitempty"`
-	PositionInClusterQueue           *int32  `json:"positionInClusterQueue,omitempty"`
-	PositionInLocalQueue             *int32  `json:"positionInLocalQueue,omitempty"`
+	Priority                         *int32                             `json:"priority,omitempty"`
+	EffectivePriority                *int32                             `json:"effectivePriority,omitempty"`
+	LocalQueueName                   *string                            `json:"localQueueName,omitempty"`
+	PositionInClusterQueue           *int32                             `json:"positionInClusterQueue,omitempty"`
+	PositionInLocalQueue             *int32                             `json:"positionInLocalQueue,omitempty"`
+	EstimatedStartTime               *metav1.Time                       `json:"estimatedStartTime,omitempty"`
+	EstimateConfidence               *string                            `json:"estimateConfidence,omitempty"`
+	InadmissibleReason               *string                            `json:"inadmissibleReason,omitempty"`
+	Blockers                         []PodSetBlockersApplyConfiguration `json:"blockers,omitempty"`
 }
 
 // PendingWorkloadApplyConfiguration constructs a declarative configuration of the PendingWorkload type for use with
@@ -189,6 +194,14 @@ func (b *PendingWorkloadApplyConfiguration) WithPriority(value int32) *PendingWo
 	return b
 }
 
+// WithEffectivePriority sets the EffectivePriority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EffectivePriority field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithEffectivePriority(value int32) *PendingWorkloadApplyConfiguration {
+	b.EffectivePriority = &value
+	return b
+}
+
 // WithLocalQueueName sets the LocalQueueName field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LocalQueueName field is set to the value of the last call.
@@ -213,6 +226,43 @@ func (b *PendingWorkloadApplyConfiguration) WithPositionInLocalQueue(value int32
 	return b
 }
 
+// WithEstimatedStartTime sets the EstimatedStartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EstimatedStartTime field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithEstimatedStartTime(value metav1.Time) *PendingWorkloadApplyConfiguration {
+	b.EstimatedStartTime = &value
+	return b
+}
+
+// WithEstimateConfidence sets the EstimateConfidence field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EstimateConfidence field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithEstimateConfidence(value string) *PendingWorkloadApplyConfiguration {
+	b.EstimateConfidence = &value
+	return b
+}
+
+// WithInadmissibleReason sets the InadmissibleReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InadmissibleReason field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithInadmissibleReason(value string) *PendingWorkloadApplyConfiguration {
+	b.InadmissibleReason = &value
+	return b
+}
+
+// WithBlockers adds the given value to the Blockers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Blockers field.
+func (b *PendingWorkloadApplyConfiguration) WithBlockers(values ...*PodSetBlockersApplyConfiguration) *PendingWorkloadApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithBlockers")
+		}
+		b.Blockers = append(b.Blockers, *values[i])
+	}
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *PendingWorkloadApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
@@ -28,7 +28,8 @@ import (
 type PendingWorkloadsSummaryApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Items                            []PendingWorkloadApplyConfiguration `json:"items,omitempty"`
+	Items                            []PendingWorkloadApplyConfiguration  `json:"items,omitempty"`
+	TopBlockingResources             []BlockingResourceApplyConfiguration `json:"topBlockingResources,omitempty"`
 }
 
 // PendingWorkloadsSummaryApplyConfiguration constructs a declarative configuration of the PendingWorkloadsSummary type for use with
@@ -211,6 +212,19 @@ func (b *PendingWorkloadsSummaryApplyConfiguration) WithItems(values ...*Pending
 	return b
 }
 
+// WithTopBlockingResources adds the given value to the TopBlockingResources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopBlockingResources field.
+func (b *PendingWorkloadsSummaryApplyConfiguration) WithTopBlockingResources(values ...*BlockingResourceApplyConfiguration) *PendingWorkloadsSummaryApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTopBlockingResources")
+		}
+		b.TopBlockingResources = append(b.TopBlockingResources, *values[i])
+	}
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *PendingWorkloadsSummaryApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
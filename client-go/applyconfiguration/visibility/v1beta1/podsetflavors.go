@@ -0,0 +1,84 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodSetFlavorsApplyConfiguration represents a declarative configuration of the PodSetFlavors type for use
+// with apply.
+type PodSetFlavorsApplyConfiguration struct {
+	Name    *string                    `json:"name,omitempty"`
+	Count   *int32                     `json:"count,omitempty"`
+	Flavors map[v1.ResourceName]string `json:"flavors,omitempty"`
+	Mode    *string                    `json:"mode,omitempty"`
+	Message *string                    `json:"message,omitempty"`
+}
+
+// PodSetFlavorsApplyConfiguration constructs a declarative configuration of the PodSetFlavors type for use with
+// apply.
+func PodSetFlavors() *PodSetFlavorsApplyConfiguration {
+	return &PodSetFlavorsApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PodSetFlavorsApplyConfiguration) WithName(value string) *PodSetFlavorsApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *PodSetFlavorsApplyConfiguration) WithCount(value int32) *PodSetFlavorsApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithFlavors puts the entries into the Flavors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Flavors field,
+// overwriting an existing map entries in Flavors field with the same key.
+func (b *PodSetFlavorsApplyConfiguration) WithFlavors(entries map[v1.ResourceName]string) *PodSetFlavorsApplyConfiguration {
+	if b.Flavors == nil && len(entries) > 0 {
+		b.Flavors = make(map[v1.ResourceName]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Flavors[k] = v
+	}
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *PodSetFlavorsApplyConfiguration) WithMode(value string) *PodSetFlavorsApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *PodSetFlavorsApplyConfiguration) WithMessage(value string) *PodSetFlavorsApplyConfiguration {
+	b.Message = &value
+	return b
+}
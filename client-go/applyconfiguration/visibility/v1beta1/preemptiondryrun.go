@@ -0,0 +1,282 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// PreemptionDryRunApplyConfiguration represents a declarative configuration of the PreemptionDryRun type for use
+// with apply.
+type PreemptionDryRunApplyConfiguration struct {
+	v1.TypeMetaApplyConfiguration    `json:",inline"`
+	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
+	ClusterQueue                     *string                               `json:"clusterQueue,omitempty"`
+	Mode                             *string                               `json:"mode,omitempty"`
+	Admissible                       *bool                                 `json:"admissible,omitempty"`
+	PodSets                          []PodSetFlavorsApplyConfiguration     `json:"podSets,omitempty"`
+	PreemptionTargets                []PreemptionTargetApplyConfiguration  `json:"preemptionTargets,omitempty"`
+	ProtectedWorkloads               []ProtectedWorkloadApplyConfiguration `json:"protectedWorkloads,omitempty"`
+	Message                          *string                               `json:"message,omitempty"`
+}
+
+// PreemptionDryRunApplyConfiguration constructs a declarative configuration of the PreemptionDryRun type for use with
+// apply.
+func PreemptionDryRun() *PreemptionDryRunApplyConfiguration {
+	b := &PreemptionDryRunApplyConfiguration{}
+	b.WithKind("PreemptionDryRun")
+	b.WithAPIVersion("visibility.kueue.x-k8s.io/v1beta1")
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithKind(value string) *PreemptionDryRunApplyConfiguration {
+	b.TypeMetaApplyConfiguration.Kind = &value
+	return b
+}
+
+// WithAPIVersion sets the APIVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIVersion field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithAPIVersion(value string) *PreemptionDryRunApplyConfiguration {
+	b.TypeMetaApplyConfiguration.APIVersion = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithName(value string) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.Name = &value
+	return b
+}
+
+// WithGenerateName sets the GenerateName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GenerateName field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithGenerateName(value string) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.GenerateName = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithNamespace(value string) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.Namespace = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithUID(value types.UID) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.UID = &value
+	return b
+}
+
+// WithResourceVersion sets the ResourceVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceVersion field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithResourceVersion(value string) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.ResourceVersion = &value
+	return b
+}
+
+// WithGeneration sets the Generation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Generation field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithGeneration(value int64) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.Generation = &value
+	return b
+}
+
+// WithCreationTimestamp sets the CreationTimestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CreationTimestamp field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithCreationTimestamp(value metav1.Time) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.CreationTimestamp = &value
+	return b
+}
+
+// WithDeletionTimestamp sets the DeletionTimestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionTimestamp field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithDeletionTimestamp(value metav1.Time) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.DeletionTimestamp = &value
+	return b
+}
+
+// WithDeletionGracePeriodSeconds sets the DeletionGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionGracePeriodSeconds field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithDeletionGracePeriodSeconds(value int64) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	b.ObjectMetaApplyConfiguration.DeletionGracePeriodSeconds = &value
+	return b
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *PreemptionDryRunApplyConfiguration) WithLabels(entries map[string]string) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	if b.ObjectMetaApplyConfiguration.Labels == nil && len(entries) > 0 {
+		b.ObjectMetaApplyConfiguration.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.ObjectMetaApplyConfiguration.Labels[k] = v
+	}
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *PreemptionDryRunApplyConfiguration) WithAnnotations(entries map[string]string) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	if b.ObjectMetaApplyConfiguration.Annotations == nil && len(entries) > 0 {
+		b.ObjectMetaApplyConfiguration.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.ObjectMetaApplyConfiguration.Annotations[k] = v
+	}
+	return b
+}
+
+// WithOwnerReferences adds the given value to the OwnerReferences field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OwnerReferences field.
+func (b *PreemptionDryRunApplyConfiguration) WithOwnerReferences(values ...*v1.OwnerReferenceApplyConfiguration) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOwnerReferences")
+		}
+		b.ObjectMetaApplyConfiguration.OwnerReferences = append(b.ObjectMetaApplyConfiguration.OwnerReferences, *values[i])
+	}
+	return b
+}
+
+// WithFinalizers adds the given value to the Finalizers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Finalizers field.
+func (b *PreemptionDryRunApplyConfiguration) WithFinalizers(values ...string) *PreemptionDryRunApplyConfiguration {
+	b.ensureObjectMetaApplyConfigurationExists()
+	for i := range values {
+		b.ObjectMetaApplyConfiguration.Finalizers = append(b.ObjectMetaApplyConfiguration.Finalizers, values[i])
+	}
+	return b
+}
+
+func (b *PreemptionDryRunApplyConfiguration) ensureObjectMetaApplyConfigurationExists() {
+	if b.ObjectMetaApplyConfiguration == nil {
+		b.ObjectMetaApplyConfiguration = &v1.ObjectMetaApplyConfiguration{}
+	}
+}
+
+// WithClusterQueue sets the ClusterQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterQueue field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithClusterQueue(value string) *PreemptionDryRunApplyConfiguration {
+	b.ClusterQueue = &value
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithMode(value string) *PreemptionDryRunApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithAdmissible sets the Admissible field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Admissible field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithAdmissible(value bool) *PreemptionDryRunApplyConfiguration {
+	b.Admissible = &value
+	return b
+}
+
+// WithPodSets adds the given value to the PodSets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PodSets field.
+func (b *PreemptionDryRunApplyConfiguration) WithPodSets(values ...*PodSetFlavorsApplyConfiguration) *PreemptionDryRunApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPodSets")
+		}
+		b.PodSets = append(b.PodSets, *values[i])
+	}
+	return b
+}
+
+// WithPreemptionTargets adds the given value to the PreemptionTargets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PreemptionTargets field.
+func (b *PreemptionDryRunApplyConfiguration) WithPreemptionTargets(values ...*PreemptionTargetApplyConfiguration) *PreemptionDryRunApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPreemptionTargets")
+		}
+		b.PreemptionTargets = append(b.PreemptionTargets, *values[i])
+	}
+	return b
+}
+
+// WithProtectedWorkloads adds the given value to the ProtectedWorkloads field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ProtectedWorkloads field.
+func (b *PreemptionDryRunApplyConfiguration) WithProtectedWorkloads(values ...*ProtectedWorkloadApplyConfiguration) *PreemptionDryRunApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithProtectedWorkloads")
+		}
+		b.ProtectedWorkloads = append(b.ProtectedWorkloads, *values[i])
+	}
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *PreemptionDryRunApplyConfiguration) WithMessage(value string) *PreemptionDryRunApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// GetName retrieves the value of the Name field in the declarative configuration.
+func (b *PreemptionDryRunApplyConfiguration) GetName() *string {
+	b.ensureObjectMetaApplyConfigurationExists()
+	return b.ObjectMetaApplyConfiguration.Name
+}
@@ -0,0 +1,56 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ResourceBlockersApplyConfiguration represents a declarative configuration of the ResourceBlockers type for use
+// with apply.
+type ResourceBlockersApplyConfiguration struct {
+	Name    *v1.ResourceName                  `json:"name,omitempty"`
+	Flavors []FlavorBlockerApplyConfiguration `json:"flavors,omitempty"`
+}
+
+// ResourceBlockersApplyConfiguration constructs a declarative configuration of the ResourceBlockers type for use with
+// apply.
+func ResourceBlockers() *ResourceBlockersApplyConfiguration {
+	return &ResourceBlockersApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ResourceBlockersApplyConfiguration) WithName(value v1.ResourceName) *ResourceBlockersApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithFlavors adds the given value to the Flavors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Flavors field.
+func (b *ResourceBlockersApplyConfiguration) WithFlavors(values ...*FlavorBlockerApplyConfiguration) *ResourceBlockersApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFlavors")
+		}
+		b.Flavors = append(b.Flavors, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	gentype "k8s.io/client-go/gentype"
+	v1alpha1 "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueuev1alpha1 "sigs.k8s.io/kueue/client-go/applyconfiguration/kueue/v1alpha1"
+	typedkueuev1alpha1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1alpha1"
+)
+
+// fakeReservations implements ReservationInterface
+type fakeReservations struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.Reservation, *v1alpha1.ReservationList, *kueuev1alpha1.ReservationApplyConfiguration]
+	Fake *FakeKueueV1alpha1
+}
+
+func newFakeReservations(fake *FakeKueueV1alpha1) typedkueuev1alpha1.ReservationInterface {
+	return &fakeReservations{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.Reservation, *v1alpha1.ReservationList, *kueuev1alpha1.ReservationApplyConfiguration](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("reservations"),
+			v1alpha1.SchemeGroupVersion.WithKind("Reservation"),
+			func() *v1alpha1.Reservation { return &v1alpha1.Reservation{} },
+			func() *v1alpha1.ReservationList { return &v1alpha1.ReservationList{} },
+			func(dst, src *v1alpha1.ReservationList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ReservationList) []*v1alpha1.Reservation {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ReservationList, items []*v1alpha1.Reservation) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
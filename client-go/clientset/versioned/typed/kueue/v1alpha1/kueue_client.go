@@ -27,6 +27,7 @@ import (
 
 type KueueV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ReservationsGetter
 	TopologiesGetter
 }
 
@@ -35,6 +36,10 @@ type KueueV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KueueV1alpha1Client) Reservations() ReservationInterface {
+	return newReservations(c)
+}
+
 func (c *KueueV1alpha1Client) Topologies() TopologyInterface {
 	return newTopologies(c)
 }
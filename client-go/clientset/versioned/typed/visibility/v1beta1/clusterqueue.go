@@ -47,6 +47,8 @@ type ClusterQueueInterface interface {
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *visibilityv1beta1.ClusterQueue, err error)
 	Apply(ctx context.Context, clusterQueue *applyconfigurationvisibilityv1beta1.ClusterQueueApplyConfiguration, opts v1.ApplyOptions) (result *visibilityv1beta1.ClusterQueue, err error)
 	GetPendingWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*visibilityv1beta1.PendingWorkloadsSummary, error)
+	GetAdmittedWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*visibilityv1beta1.AdmittedWorkloadsSummary, error)
+	GetSchedulingDecisions(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*visibilityv1beta1.SchedulingDecisions, error)
 
 	ClusterQueueExpansion
 }
@@ -82,3 +84,29 @@ func (c *clusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clusterQ
 		Into(result)
 	return
 }
+
+// GetAdmittedWorkloadsSummary takes name of the clusterQueue, and returns the corresponding visibilityv1beta1.AdmittedWorkloadsSummary object, and an error if there is any.
+func (c *clusterQueues) GetAdmittedWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *visibilityv1beta1.AdmittedWorkloadsSummary, err error) {
+	result = &visibilityv1beta1.AdmittedWorkloadsSummary{}
+	err = c.GetClient().Get().
+		Resource("clusterqueues").
+		Name(clusterQueueName).
+		SubResource("admittedworkloads").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// GetSchedulingDecisions takes name of the clusterQueue, and returns the corresponding visibilityv1beta1.SchedulingDecisions object, and an error if there is any.
+func (c *clusterQueues) GetSchedulingDecisions(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *visibilityv1beta1.SchedulingDecisions, err error) {
+	result = &visibilityv1beta1.SchedulingDecisions{}
+	err = c.GetClient().Get().
+		Resource("clusterqueues").
+		Name(clusterQueueName).
+		SubResource("schedulingdecisions").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -65,3 +65,25 @@ func (c *fakeClusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clus
 	}
 	return obj.(*v1beta1.PendingWorkloadsSummary), err
 }
+
+// GetAdmittedWorkloadsSummary takes name of the clusterQueue, and returns the corresponding admittedWorkloadsSummary object, and an error if there is any.
+func (c *fakeClusterQueues) GetAdmittedWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *v1beta1.AdmittedWorkloadsSummary, err error) {
+	emptyResult := &v1beta1.AdmittedWorkloadsSummary{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetSubresourceActionWithOptions(c.Resource(), "admittedworkloads", clusterQueueName, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.AdmittedWorkloadsSummary), err
+}
+
+// GetSchedulingDecisions takes name of the clusterQueue, and returns the corresponding schedulingDecisions object, and an error if there is any.
+func (c *fakeClusterQueues) GetSchedulingDecisions(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *v1beta1.SchedulingDecisions, err error) {
+	emptyResult := &v1beta1.SchedulingDecisions{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetSubresourceActionWithOptions(c.Resource(), "schedulingdecisions", clusterQueueName, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.SchedulingDecisions), err
+}
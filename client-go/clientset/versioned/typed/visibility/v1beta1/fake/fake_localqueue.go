@@ -64,3 +64,15 @@ func (c *fakeLocalQueues) GetPendingWorkloadsSummary(ctx context.Context, localQ
 	}
 	return obj.(*v1beta1.PendingWorkloadsSummary), err
 }
+
+// GetAdmittedWorkloadsSummary takes name of the localQueue, and returns the corresponding admittedWorkloadsSummary object, and an error if there is any.
+func (c *fakeLocalQueues) GetAdmittedWorkloadsSummary(ctx context.Context, localQueueName string, options v1.GetOptions) (result *v1beta1.AdmittedWorkloadsSummary, err error) {
+	emptyResult := &v1beta1.AdmittedWorkloadsSummary{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceActionWithOptions(c.Resource(), c.Namespace(), "admittedworkloads", localQueueName, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.AdmittedWorkloadsSummary), err
+}
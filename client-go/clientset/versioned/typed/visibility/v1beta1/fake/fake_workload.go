@@ -0,0 +1,78 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	context "context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gentype "k8s.io/client-go/gentype"
+	testing "k8s.io/client-go/testing"
+	v1beta1 "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	visibilityv1beta1 "sigs.k8s.io/kueue/client-go/applyconfiguration/visibility/v1beta1"
+	typedvisibilityv1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/visibility/v1beta1"
+)
+
+// fakeWorkloads implements WorkloadInterface
+type fakeWorkloads struct {
+	*gentype.FakeClientWithListAndApply[*v1beta1.Workload, *v1beta1.WorkloadList, *visibilityv1beta1.WorkloadApplyConfiguration]
+	Fake *FakeVisibilityV1beta1
+}
+
+func newFakeWorkloads(fake *FakeVisibilityV1beta1, namespace string) typedvisibilityv1beta1.WorkloadInterface {
+	return &fakeWorkloads{
+		gentype.NewFakeClientWithListAndApply[*v1beta1.Workload, *v1beta1.WorkloadList, *visibilityv1beta1.WorkloadApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1beta1.SchemeGroupVersion.WithResource("workloads"),
+			v1beta1.SchemeGroupVersion.WithKind("Workload"),
+			func() *v1beta1.Workload { return &v1beta1.Workload{} },
+			func() *v1beta1.WorkloadList { return &v1beta1.WorkloadList{} },
+			func(dst, src *v1beta1.WorkloadList) { dst.ListMeta = src.ListMeta },
+			func(list *v1beta1.WorkloadList) []*v1beta1.Workload { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1beta1.WorkloadList, items []*v1beta1.Workload) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
+
+// GetPreemptionDryRun takes name of the workload, and returns the corresponding preemptionDryRun object, and an error if there is any.
+func (c *fakeWorkloads) GetPreemptionDryRun(ctx context.Context, workloadName string, options v1.GetOptions) (result *v1beta1.PreemptionDryRun, err error) {
+	emptyResult := &v1beta1.PreemptionDryRun{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceActionWithOptions(c.Resource(), c.Namespace(), "preemptiondryrun", workloadName, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.PreemptionDryRun), err
+}
+
+// GetSchedulingDecisions takes name of the workload, and returns the corresponding schedulingDecisions object, and an error if there is any.
+func (c *fakeWorkloads) GetSchedulingDecisions(ctx context.Context, workloadName string, options v1.GetOptions) (result *v1beta1.SchedulingDecisions, err error) {
+	emptyResult := &v1beta1.SchedulingDecisions{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceActionWithOptions(c.Resource(), c.Namespace(), "schedulingdecisions", workloadName, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.SchedulingDecisions), err
+}
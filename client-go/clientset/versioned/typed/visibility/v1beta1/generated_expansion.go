@@ -20,3 +20,5 @@ package v1beta1
 type ClusterQueueExpansion interface{}
 
 type LocalQueueExpansion interface{}
+
+type WorkloadExpansion interface{}
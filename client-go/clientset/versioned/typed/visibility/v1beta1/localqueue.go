@@ -47,6 +47,7 @@ type LocalQueueInterface interface {
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *visibilityv1beta1.LocalQueue, err error)
 	Apply(ctx context.Context, localQueue *applyconfigurationvisibilityv1beta1.LocalQueueApplyConfiguration, opts v1.ApplyOptions) (result *visibilityv1beta1.LocalQueue, err error)
 	GetPendingWorkloadsSummary(ctx context.Context, localQueueName string, options v1.GetOptions) (*visibilityv1beta1.PendingWorkloadsSummary, error)
+	GetAdmittedWorkloadsSummary(ctx context.Context, localQueueName string, options v1.GetOptions) (*visibilityv1beta1.AdmittedWorkloadsSummary, error)
 
 	LocalQueueExpansion
 }
@@ -83,3 +84,17 @@ func (c *localQueues) GetPendingWorkloadsSummary(ctx context.Context, localQueue
 		Into(result)
 	return
 }
+
+// GetAdmittedWorkloadsSummary takes name of the localQueue, and returns the corresponding visibilityv1beta1.AdmittedWorkloadsSummary object, and an error if there is any.
+func (c *localQueues) GetAdmittedWorkloadsSummary(ctx context.Context, localQueueName string, options v1.GetOptions) (result *visibilityv1beta1.AdmittedWorkloadsSummary, err error) {
+	result = &visibilityv1beta1.AdmittedWorkloadsSummary{}
+	err = c.GetClient().Get().
+		Namespace(c.GetNamespace()).
+		Resource("localqueues").
+		Name(localQueueName).
+		SubResource("admittedworkloads").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -98,3 +98,13 @@ func PodsGVR() schema.GroupVersionResource {
 	}
 	return podsGVR
 }
+
+// VisibilityLocalQueuesGVR defines the GroupVersionResource for LocalQueues
+// in the visibility API
+func VisibilityLocalQueuesGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "visibility.kueue.x-k8s.io",
+		Version:  "v1beta1",
+		Resource: "localqueues",
+	}
+}
@@ -93,6 +93,7 @@ func fetchWorkloadDetails(dynamicClient dynamic.Interface, namespace, workloadNa
 		} else {
 			workload.Object["clusterQueueName"] = "Unknown"
 		}
+		workload.Object["pendingWorkload"] = fetchPendingWorkload(dynamicClient, namespace, localQueueName, workloadName)
 	} else {
 		workload.Object["clusterQueueName"] = "Unknown"
 	}
@@ -100,6 +101,27 @@ func fetchWorkloadDetails(dynamicClient dynamic.Interface, namespace, workloadNa
 	return workload, nil
 }
 
+// fetchPendingWorkload returns the position and the estimated start time of
+// the workload from the visibility API, or nil if the workload is not pending
+// or the visibility API is not available.
+func fetchPendingWorkload(dynamicClient dynamic.Interface, namespace, localQueueName, workloadName string) interface{} {
+	summary, err := dynamicClient.Resource(VisibilityLocalQueuesGVR()).Namespace(namespace).Get(context.TODO(), localQueueName, metav1.GetOptions{}, "pendingworkloads")
+	if err != nil {
+		return nil
+	}
+	items, _ := summary.Object["items"].([]interface{})
+	for _, item := range items {
+		pendingWorkload, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if metadata, ok := pendingWorkload["metadata"].(map[string]interface{}); ok && metadata["name"] == workloadName {
+			return pendingWorkload
+		}
+	}
+	return nil
+}
+
 func WorkloadEventsWebSocketHandler(dynamicClient dynamic.Interface) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		namespace := c.Param("namespace")
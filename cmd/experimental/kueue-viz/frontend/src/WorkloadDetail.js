@@ -54,6 +54,17 @@ const WorkloadDetail = () => {
           <Typography variant="body1"><strong>Status:</strong> {workload.status?.state || 'Unknown'}</Typography>
           <Typography variant="body1"><strong>Priority:</strong> {workload.spec?.priority || 'N/A'}</Typography>
           <Typography variant="body1"><strong>Priority Class Name:</strong> {workload.spec?.priorityClassName || 'N/A'}</Typography>
+          {workload.pendingWorkload && (
+            <>
+              <Typography variant="body1"><strong>Position in Queue:</strong> {workload.pendingWorkload.positionInLocalQueue}</Typography>
+              <Typography variant="body1">
+                <strong>Estimated Start:</strong>{" "}
+                {workload.pendingWorkload.estimatedStartTime
+                  ? `${new Date(workload.pendingWorkload.estimatedStartTime).toLocaleString()} (${workload.pendingWorkload.estimateConfidence} confidence)`
+                  : 'N/A'}
+              </Typography>
+            </>
+          )}
         </Grid>
         <Grid item xs={12} sm={6}>
           <Typography variant="body1"><strong>Owner Reference:</strong></Typography>
@@ -58,15 +58,18 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/tas"
 	tasindexer "sigs.k8s.io/kueue/pkg/controller/tas/indexer"
 	"sigs.k8s.io/kueue/pkg/debugger"
+	"sigs.k8s.io/kueue/pkg/decisionlog"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	"sigs.k8s.io/kueue/pkg/util/cert"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
 	"sigs.k8s.io/kueue/pkg/util/useragent"
 	"sigs.k8s.io/kueue/pkg/version"
 	"sigs.k8s.io/kueue/pkg/visibility"
+	visibilityapi "sigs.k8s.io/kueue/pkg/visibility/api/v1beta1"
 	"sigs.k8s.io/kueue/pkg/webhooks"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -194,8 +197,12 @@ func main() {
 	}
 	if cfg.FairSharing != nil {
 		cacheOptions = append(cacheOptions, cache.WithFairSharing(cfg.FairSharing.Enable))
+		if cfg.FairSharing.UsageHalfLifeTime != nil {
+			cacheOptions = append(cacheOptions, cache.WithUsageHalfLifeTime(cfg.FairSharing.UsageHalfLifeTime.Duration))
+		}
 	}
 	cCache := cache.New(mgr.GetClient(), cacheOptions...)
+	queueOptions = append(queueOptions, queue.WithLocalQueueShares(cCache))
 	queues := queue.NewManager(mgr.GetClient(), cCache, queueOptions...)
 
 	ctx := ctrl.SetupSignalHandler()
@@ -216,12 +223,13 @@ func main() {
 	go queues.CleanUpOnContext(ctx)
 	go cCache.CleanUpOnContext(ctx)
 
+	decisionSinks, decisions := setupDecisionLog(mgr, &cfg)
+	sched := setupScheduler(mgr, cCache, queues, &cfg, decisionSinks)
+
 	if features.Enabled(features.VisibilityOnDemand) {
-		go visibility.CreateAndStartVisibilityServer(ctx, queues)
+		go visibility.CreateAndStartVisibilityServer(ctx, queues, cCache, sched, decisions)
 	}
 
-	setupScheduler(mgr, cCache, queues, &cfg)
-
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "Could not run manager")
@@ -303,6 +311,11 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 			multikueue.WithGCInterval(cfg.MultiKueue.GCInterval.Duration),
 			multikueue.WithOrigin(ptr.Deref(cfg.MultiKueue.Origin, configapi.DefaultMultiKueueOrigin)),
 			multikueue.WithWorkerLostTimeout(cfg.MultiKueue.WorkerLostTimeout.Duration),
+			multikueue.WithFailoverPolicy(
+				cfg.MultiKueue.FailoverPolicy.UnreachableTimeout.Duration,
+				int(*cfg.MultiKueue.FailoverPolicy.CordonThreshold),
+				cfg.MultiKueue.FailoverPolicy.CordonWindow.Duration,
+			),
 			multikueue.WithAdapters(adapters),
 		); err != nil {
 			setupLog.Error(err, "Could not setup MultiKueue controller")
@@ -325,6 +338,7 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 	opts := []jobframework.Option{
 		jobframework.WithManageJobsWithoutQueueName(cfg.ManageJobsWithoutQueueName),
 		jobframework.WithWaitForPodsReady(cfg.WaitForPodsReady),
+		jobframework.WithGracefulPreemption(cfg.GracefulPreemption),
 		jobframework.WithKubeServerVersion(serverVersionFetcher),
 		jobframework.WithEnabledFrameworks(cfg.Integrations.Frameworks),
 		jobframework.WithEnabledExternalFrameworks(cfg.Integrations.ExternalFrameworks),
@@ -380,7 +394,52 @@ func setupProbeEndpoints(mgr ctrl.Manager, certsReady <-chan struct{}) {
 	}
 }
 
-func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration) {
+// setupDecisionLog creates the sinks recording the scheduling decisions, and
+// returns them along with the in-memory decisions to serve in the visibility
+// API, if any.
+func setupDecisionLog(mgr ctrl.Manager, cfg *configapi.Configuration) ([]decisionlog.Sink, visibilityapi.SchedulingDecisionsLister) {
+	if !features.Enabled(features.SchedulingDecisionLog) || cfg.Scheduler == nil || cfg.Scheduler.DecisionLog == nil {
+		return nil, nil
+	}
+	dl := cfg.Scheduler.DecisionLog
+	var sinks []decisionlog.Sink
+	if dl.File != nil {
+		fileSink, err := decisionlog.NewFileSink(*dl.File)
+		if err != nil {
+			setupLog.Error(err, "Unable to open the scheduling decision log file")
+			os.Exit(1)
+		}
+		if err := mgr.Add(fileSink); err != nil {
+			setupLog.Error(err, "Unable to add the scheduling decision log file to manager")
+			os.Exit(1)
+		}
+		sinks = append(sinks, fileSink)
+	}
+	if dl.HTTPEndpoint != nil {
+		httpSink := decisionlog.NewHTTPSink(*dl.HTTPEndpoint)
+		if err := mgr.Add(httpSink); err != nil {
+			setupLog.Error(err, "Unable to add the scheduling decision log endpoint to manager")
+			os.Exit(1)
+		}
+		sinks = append(sinks, httpSink)
+	}
+	var decisions visibilityapi.SchedulingDecisionsLister
+	if capacity := ptr.Deref(dl.MemoryCapacity, 0); capacity > 0 {
+		ring := decisionlog.NewRing(int(capacity))
+		sinks = append(sinks, ring)
+		decisions = ring
+	}
+	return sinks, decisions
+}
+
+func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration, decisionSinks []decisionlog.Sink) *scheduler.Scheduler {
+	// Out-of-tree plugins are registered with framework.Register by the
+	// packages imported in the build, before the configuration is loaded.
+	fw, err := framework.New(framework.NewInTreeRegistry(), cfg.Scheduler, mgr.GetClient())
+	if err != nil {
+		setupLog.Error(err, "Unable to set up the scheduler plugins")
+		os.Exit(1)
+	}
 	sched := scheduler.New(
 		queues,
 		cCache,
@@ -388,11 +447,14 @@ func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager
 		mgr.GetEventRecorderFor(constants.AdmissionName),
 		scheduler.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(cfg)),
 		scheduler.WithFairSharing(cfg.FairSharing),
+		scheduler.WithFramework(fw),
+		scheduler.WithDecisionSinks(decisionSinks...),
 	)
 	if err := mgr.Add(sched); err != nil {
 		setupLog.Error(err, "Unable to add scheduler to manager")
 		os.Exit(1)
 	}
+	return sched
 }
 
 func setupServerVersionFetcher(mgr ctrl.Manager, kubeConfig *rest.Config) *kubeversion.ServerVersionFetcher {
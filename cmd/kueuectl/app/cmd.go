@@ -26,9 +26,11 @@ import (
 
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/create"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/explain"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/list"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/passthrough"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/resume"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/simulate"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/stop"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/version"
@@ -79,6 +81,8 @@ func NewKueuectlCmd(o KueuectlOptions) *cobra.Command {
 	cmd.AddCommand(resume.NewResumeCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(stop.NewStopCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(list.NewListCmd(clientGetter, o.IOStreams, o.Clock))
+	cmd.AddCommand(explain.NewExplainCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(simulate.NewSimulateCmd(o.IOStreams))
 	cmd.AddCommand(passthrough.NewCommands(clientGetter, o.IOStreams)...)
 	cmd.AddCommand(version.NewVersionCmd(clientGetter, o.IOStreams))
 
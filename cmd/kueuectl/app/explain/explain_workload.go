@@ -0,0 +1,213 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/ptr"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
+	visibilityv1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/visibility/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	wlLong = templates.LongDesc(`
+		Simulates the admission of the pending Workload into its ClusterQueue,
+		and shows the flavors that would be assigned to each pod set, and the
+		admitted Workloads that would be preempted to make room for it.
+		No quota is reserved and no Workload is evicted.
+	`)
+	wlExample = templates.Examples(`
+		# Explain the pending workload
+		kueuectl explain workload my-workload
+
+		# Explain the pending workload in YAML format
+		kueuectl explain workload my-workload -o yaml
+	`)
+)
+
+type WorkloadOptions struct {
+	PrintFlags *genericclioptions.PrintFlags
+
+	WorkloadName string
+	Namespace    string
+
+	Client visibilityv1beta1.VisibilityV1beta1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		PrintFlags: genericclioptions.NewPrintFlags("").WithTypeSetter(scheme.Scheme),
+		IOStreams:  streams,
+	}
+}
+
+func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWorkloadOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "workload NAME [--namespace NAMESPACE] [--output FORMAT]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"wl"},
+		Short:                 "Explain the admission of the given pending Workload",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, ptr.To(true)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.WorkloadName = args[0]
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.VisibilityV1beta1()
+
+	return nil
+}
+
+// Run explains the admission of the workload
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	dryRun, err := o.Client.Workloads(o.Namespace).GetPreemptionDryRun(ctx, o.WorkloadName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if o.PrintFlags.OutputFlagSpecified() {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		return printer.PrintObj(dryRun, o.Out)
+	}
+
+	return printPreemptionDryRun(dryRun, o.Out)
+}
+
+func printPreemptionDryRun(dryRun *visibility.PreemptionDryRun, out io.Writer) error {
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintf(w, "Name:\t%s\n", dryRun.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", dryRun.Namespace)
+	fmt.Fprintf(w, "ClusterQueue:\t%s\n", dryRun.ClusterQueue)
+	fmt.Fprintf(w, "Mode:\t%s\n", dryRun.Mode)
+	fmt.Fprintf(w, "Admissible:\t%t\n", dryRun.Admissible)
+	if len(dryRun.Message) > 0 {
+		fmt.Fprintf(w, "Message:\t%s\n", dryRun.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if len(dryRun.PodSets) == 0 {
+		fmt.Fprintln(out, "PodSets: <none>")
+	} else {
+		fmt.Fprintln(out, "PodSets:")
+		w = printers.GetNewTabWriter(out)
+		fmt.Fprint(w, "  NAME\tCOUNT\tMODE\tFLAVORS\tMESSAGE\n")
+		for _, ps := range dryRun.PodSets {
+			fmt.Fprintf(w, "  %s\t%d\t%s\t%s\t%s\n", ps.Name, ps.Count, ps.Mode, formatFlavors(ps.Flavors), ps.Message)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(out)
+	if len(dryRun.PreemptionTargets) == 0 {
+		fmt.Fprintln(out, "Preemption Targets: <none>")
+	} else {
+		fmt.Fprintln(out, "Preemption Targets:")
+		w = printers.GetNewTabWriter(out)
+		fmt.Fprint(w, "  NAMESPACE\tNAME\tCLUSTERQUEUE\tPRIORITY\tREASON\n")
+		for _, target := range dryRun.PreemptionTargets {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%s\n", target.Namespace, target.Name, target.ClusterQueue, target.Priority, target.Reason)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(dryRun.ProtectedWorkloads) == 0 {
+		return nil
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Protected Workloads:")
+	w = printers.GetNewTabWriter(out)
+	fmt.Fprint(w, "  NAMESPACE\tNAME\tCLUSTERQUEUE\tPRIORITY\tPROTECTED UNTIL\n")
+	for _, pw := range dryRun.ProtectedWorkloads {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%s\n", pw.Namespace, pw.Name, pw.ClusterQueue, pw.Priority, pw.ProtectedUntil.UTC().Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+func formatFlavors(flavors map[corev1.ResourceName]string) string {
+	if len(flavors) == 0 {
+		return "<none>"
+	}
+	resources := make([]string, 0, len(flavors))
+	for res, flv := range flavors {
+		resources = append(resources, fmt.Sprintf("%s=%s", res, flv))
+	}
+	slices.Sort(resources)
+	return strings.Join(resources, ",")
+}
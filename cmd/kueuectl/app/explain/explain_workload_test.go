@@ -0,0 +1,220 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+)
+
+func TestWorkloadCmd(t *testing.T) {
+	testCases := map[string]struct {
+		ns         string
+		args       []string
+		dryRun     *visibility.PreemptionDryRun
+		wantOut    string
+		wantOutErr string
+		wantErr    string
+	}{
+		"no arguments": {
+			args:    []string{},
+			wantErr: "accepts 1 arg(s), received 0",
+		},
+		"workload not pending": {
+			args:    []string{"wl1"},
+			wantErr: `workload.visibility.kueue.x-k8s.io "wl1" not found`,
+		},
+		"workload fits": {
+			args: []string{"wl1"},
+			dryRun: &visibility.PreemptionDryRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "wl1",
+					Namespace: metav1.NamespaceDefault,
+				},
+				ClusterQueue: "cq1",
+				Mode:         "Fit",
+				Admissible:   true,
+				PodSets: []visibility.PodSetFlavors{{
+					Name:  "main",
+					Count: 2,
+					Flavors: map[corev1.ResourceName]string{
+						corev1.ResourceMemory: "default",
+						corev1.ResourceCPU:    "default",
+					},
+					Mode: "Fit",
+				}},
+			},
+			wantOut: "Name:           wl1\n" +
+				"Namespace:      default\n" +
+				"ClusterQueue:   cq1\n" +
+				"Mode:           Fit\n" +
+				"Admissible:     true\n" +
+				"\n" +
+				"PodSets:\n" +
+				"  NAME   COUNT   MODE   FLAVORS                      MESSAGE\n" +
+				"  main   2       Fit    cpu=default,memory=default   \n" +
+				"\n" +
+				"Preemption Targets: <none>\n",
+		},
+		"workload requires preemption in another namespace": {
+			ns:   "ns1",
+			args: []string{"wl1"},
+			dryRun: &visibility.PreemptionDryRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "wl1",
+					Namespace: "ns1",
+				},
+				ClusterQueue: "cq1",
+				Mode:         "Preempt",
+				Admissible:   true,
+				PodSets: []visibility.PodSetFlavors{{
+					Name:    "main",
+					Count:   1,
+					Flavors: map[corev1.ResourceName]string{corev1.ResourceCPU: "default"},
+					Mode:    "Preempt",
+					Message: "insufficient unused quota for cpu in flavor default, 1 more needed",
+				}},
+				PreemptionTargets: []visibility.PreemptionTarget{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl2",
+						Namespace: "ns1",
+					},
+					ClusterQueue: "cq1",
+					Priority:     50,
+					Reason:       "InClusterQueue",
+				}},
+				Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+			},
+			wantOut: `Name:           wl1
+Namespace:      ns1
+ClusterQueue:   cq1
+Mode:           Preempt
+Admissible:     true
+Message:        couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed
+
+PodSets:
+  NAME   COUNT   MODE      FLAVORS       MESSAGE
+  main   1       Preempt   cpu=default   insufficient unused quota for cpu in flavor default, 1 more needed
+
+Preemption Targets:
+  NAMESPACE   NAME   CLUSTERQUEUE   PRIORITY   REASON
+  ns1         wl2    cq1            50         InClusterQueue
+`,
+		},
+		"should print the workloads protected by their minimum runtime": {
+			ns:   "ns1",
+			args: []string{"wl1"},
+			dryRun: &visibility.PreemptionDryRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "wl1",
+					Namespace: "ns1",
+				},
+				ClusterQueue: "cq1",
+				Mode:         "Preempt",
+				ProtectedWorkloads: []visibility.ProtectedWorkload{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl2",
+						Namespace: "ns1",
+					},
+					ClusterQueue:   "cq1",
+					Priority:       50,
+					ProtectedUntil: metav1.NewTime(time.Date(2025, time.January, 1, 10, 30, 0, 0, time.UTC)),
+				}},
+			},
+			wantOut: `Name:           wl1
+Namespace:      ns1
+ClusterQueue:   cq1
+Mode:           Preempt
+Admissible:     false
+
+PodSets: <none>
+
+Preemption Targets: <none>
+
+Protected Workloads:
+  NAMESPACE   NAME   CLUSTERQUEUE   PRIORITY   PROTECTED UNTIL
+  ns1         wl2    cq1            50         2025-01-01T10:30:00Z
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ns := metav1.NamespaceDefault
+			if tc.ns != "" {
+				ns = tc.ns
+			}
+
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+			clientset := fake.NewSimpleClientset()
+			// The preemption dry run is a virtual subresource, so it can't be
+			// added to the object tracker.
+			clientset.PrependReactor("get", "workloads", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+				getAction := action.(kubetesting.GetAction)
+				if getAction.GetSubresource() != "preemptiondryrun" {
+					return false, nil, nil
+				}
+				if tc.dryRun == nil || tc.dryRun.Namespace != getAction.GetNamespace() || tc.dryRun.Name != getAction.GetName() {
+					return true, nil, errors.NewNotFound(visibility.Resource("workload"), getAction.GetName())
+				}
+				return true, tc.dryRun, nil
+			})
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithNamespace(ns).
+				WithKueueClientset(clientset)
+
+			cmd := NewWorkloadCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantErr != "" {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -76,6 +76,7 @@ func (p *listWorkloadPrinter) PrintObj(obj runtime.Object, out io.Writer) error
 			{Name: "ClusterQueue", Type: "string"},
 			{Name: "Status", Type: "string"},
 			{Name: "Position in Queue", Type: "string"},
+			{Name: "Estimated Start", Type: "string"},
 			{Name: "Exec Time", Type: "string"},
 			{Name: "Age", Type: "string"},
 		},
@@ -135,9 +136,10 @@ func (p *listWorkloadPrinter) printWorkload(wl *v1beta1.Workload) metav1.TableRo
 		clusterQueueName = string(lq.Spec.ClusterQueue)
 	}
 
-	var positionInQueue string
+	var positionInQueue, estimatedStart string
 	if pendingWorkload, ok := p.resources.pendingWorkloads[workload.Key(wl)]; ok {
 		positionInQueue = fmt.Sprintf("%d", pendingWorkload.PositionInLocalQueue)
+		estimatedStart = p.estimatedStart(pendingWorkload)
 	}
 
 	var execTime string
@@ -161,6 +163,7 @@ func (p *listWorkloadPrinter) printWorkload(wl *v1beta1.Workload) metav1.TableRo
 		clusterQueueName,
 		strings.ToUpper(workload.Status(wl)),
 		positionInQueue,
+		estimatedStart,
 		execTime,
 		duration.HumanDuration(p.clock.Since(wl.CreationTimestamp.Time)),
 	}
@@ -168,6 +171,22 @@ func (p *listWorkloadPrinter) printWorkload(wl *v1beta1.Workload) metav1.TableRo
 	return row
 }
 
+// estimatedStart returns how long the workload is expected to wait before
+// starting, with the confidence of the estimate.
+func (p *listWorkloadPrinter) estimatedStart(pendingWorkload *visibility.PendingWorkload) string {
+	if pendingWorkload.EstimatedStartTime == nil {
+		return ""
+	}
+	wait := "now"
+	if until := pendingWorkload.EstimatedStartTime.Sub(p.clock.Now()); until > 0 {
+		wait = duration.HumanDuration(until)
+	}
+	if len(pendingWorkload.EstimateConfidence) == 0 {
+		return wait
+	}
+	return fmt.Sprintf("%s (%s)", wait, pendingWorkload.EstimateConfidence)
+}
+
 func (p *listWorkloadPrinter) crdTypes(wl *v1beta1.Workload) []string {
 	crdTypes := sets.New[string]()
 
@@ -42,6 +42,7 @@ import (
 	restfake "k8s.io/client-go/rest/fake"
 	kubetesting "k8s.io/client-go/testing"
 	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
@@ -85,8 +86,8 @@ func TestWorkloadCmd(t *testing.T) {
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with localqueue filter": {
@@ -107,8 +108,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with localqueue filter (short flag)": {
@@ -129,8 +130,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with clusterqueue filter": {
@@ -151,8 +152,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with clusterqueue filter (short flag)": {
@@ -173,8 +174,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with all status flag": {
@@ -254,12 +255,12 @@ wl1               j1         lq1          cq1            PENDING
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                    60m
-wl2               j2         lq2          cq2            ADMITTED                       60m         120m
-wl3               j3         lq3          cq3            PENDING                                    120m
-wl4               j4         lq4          cq4            FINISHED                       60m         3h
-wl5               j5         lq5          cq5            ADMITTED                       120m        3h
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                      60m
+wl2               j2         lq2          cq2            ADMITTED                                         60m         120m
+wl3               j3         lq3          cq3            PENDING                                                      120m
+wl4               j4         lq4          cq4            FINISHED                                         60m         3h
+wl5               j5         lq5          cq5            ADMITTED                                         120m        3h
 `,
 		},
 		"should print workload list with only admitted and finished status flags": {
@@ -306,9 +307,9 @@ wl5               j5         lq5          cq5            ADMITTED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl2               j2         lq2          cq2            ADMITTED                       60m         120m
-wl3               j3         lq3          cq3            FINISHED                       60m         3h
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl2               j2         lq2          cq2            ADMITTED                                         60m         120m
+wl3               j3         lq3          cq3            FINISHED                                         60m         3h
 `,
 		},
 		"should print workload list with only pending filter": {
@@ -335,8 +336,8 @@ wl3               j3         lq3          cq3            FINISHED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with only quotareserved filter": {
@@ -369,8 +370,8 @@ wl1               j1         lq1          cq1            PENDING
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS          POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            QUOTARESERVED                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS          POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            QUOTARESERVED                                                     60m
 `,
 		},
 		"should print workload list with only admitted filter": {
@@ -405,8 +406,8 @@ wl1               j1         lq1          cq1            QUOTARESERVED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            ADMITTED                       60m         60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            ADMITTED                                         60m         60m
 `,
 		},
 		"should print workload list with only finished status filter": {
@@ -451,8 +452,8 @@ wl1               j1         lq1          cq1            ADMITTED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            FINISHED                       60m         60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            FINISHED                                         60m         60m
 `,
 		},
 		"should print workload list with label selector filter": {
@@ -475,8 +476,8 @@ wl1               j1         lq1          cq1            FINISHED
 					Label("key", "value2").
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with label selector filter (short flag)": {
@@ -499,8 +500,8 @@ wl1               j1         lq1          cq1            PENDING
 					Label("key", "value2").
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING                                                     60m
 `,
 		},
 		"should print workload list with Job types": {
@@ -558,10 +559,10 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-3 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE                  JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    job                       j1         lq1          cq1            PENDING                                   60m
-wl2    rayjob.ray.io             j2         lq2          cq2            PENDING                                   120m
-wl3    pytorchjob.kubeflow....   j3         lq3          cq3            PENDING                                   3h
+			wantOut: `NAME   JOB TYPE                  JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1    job                       j1         lq1          cq1            PENDING                                                     60m
+wl2    rayjob.ray.io             j2         lq2          cq2            PENDING                                                     120m
+wl3    pytorchjob.kubeflow....   j3         lq3          cq3            PENDING                                                     3h
 `,
 		},
 		"should print workload list with resource filter": {
@@ -617,8 +618,8 @@ wl3    pytorchjob.kubeflow....   j3         lq3          cq3            PENDING
 					},
 				},
 			},
-			wantOut: `NAME   JOB TYPE    JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    job.batch   job-test   lq1          cq1            PENDING                                   120m
+			wantOut: `NAME   JOB TYPE    JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1    job.batch   job-test   lq1          cq1            PENDING                                                     120m
 `,
 		},
 		"should print workload list with resource filter and composable jobs": {
@@ -679,8 +680,8 @@ wl1    job.batch   job-test   lq1          cq1            PENDING
 					UID("pod-test-uid-1").
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME     LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl2    pod        pod-test-1   lq2          cq2            PENDING                                   3h
+			wantOut: `NAME   JOB TYPE   JOB NAME     LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl2    pod        pod-test-1   lq2          cq2            PENDING                                                     3h
 `,
 		},
 		"should print workload list with custom resource filter": {
@@ -747,8 +748,8 @@ wl2    pod        pod-test-1   lq2          cq2            PENDING
 					},
 				},
 			},
-			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING                                   120m
+			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING                                                     120m
 `,
 		},
 		"should print workload list with full resource filter": {
@@ -815,8 +816,8 @@ wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING
 					},
 				},
 			},
-			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING                                   120m
+			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING                                                     120m
 `,
 		},
 		"should print workload list with position in queue": {
@@ -858,9 +859,63 @@ wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING   12                              60m
-wl2               j2         lq2          cq2            PENDING   22                              120m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   12                                                60m
+wl2               j2         lq2          cq2            PENDING   22                                                120m
+`,
+		},
+		"should print workload list with estimated start time": {
+			pendingWorkloads: []visibility.PendingWorkload{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl1",
+						Namespace: metav1.NamespaceDefault,
+					},
+					LocalQueueName:       "lq1",
+					PositionInLocalQueue: 0,
+					EstimatedStartTime:   ptr.To(metav1.NewTime(testStartTime.Add(-time.Minute))),
+					EstimateConfidence:   "High",
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl2",
+						Namespace: metav1.NamespaceDefault,
+					},
+					LocalQueueName:       "lq1",
+					PositionInLocalQueue: 1,
+					EstimatedStartTime:   ptr.To(metav1.NewTime(testStartTime.Add(90 * time.Minute))),
+					EstimateConfidence:   "Medium",
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl3",
+						Namespace: metav1.NamespaceDefault,
+					},
+					LocalQueueName:       "lq1",
+					PositionInLocalQueue: 2,
+				},
+			},
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					Queue("lq1").
+					Admission(utiltesting.MakeAdmission("cq1").Obj()).
+					Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					Queue("lq1").
+					Admission(utiltesting.MakeAdmission("cq1").Obj()).
+					Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl3", metav1.NamespaceDefault).
+					Queue("lq1").
+					Admission(utiltesting.MakeAdmission("cq1").Obj()).
+					Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+					Obj(),
+			},
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1                          lq1          cq1            PENDING   0                   now (High)                    60m
+wl2                          lq1          cq1            PENDING   1                   90m (Medium)                  60m
+wl3                          lq1          cq1            PENDING   2                                                 60m
 `,
 		},
 		"should print not found error": {
@@ -0,0 +1,249 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/simulator"
+)
+
+const (
+	outputYAML = "yaml"
+	outputJSON = "json"
+)
+
+var (
+	simulateLong = templates.LongDesc(`
+		Replays a trace of workload submissions against a set of Kueue objects,
+		offline, with the Kueue scheduler and a virtual clock, and reports the
+		wait times and preemptions of the workloads, and the utilization of the
+		quotas of the ClusterQueues.
+
+		The objects can be ResourceFlavors, Cohorts, ClusterQueues, LocalQueues,
+		WorkloadPriorityClasses and Namespaces, in YAML or JSON, like the output
+		of "kubectl get -o yaml". The trace lists the workloads with their
+		LocalQueue, priority, submission time and duration since the start of
+		the trace, and the requests of their pod sets.
+	`)
+	simulateExample = templates.Examples(`
+		# Replay the trace against the objects
+		kueuectl simulate --objects queues.yaml --trace trace.yaml
+
+		# Replay the trace with fair sharing, in JSON format
+		kueuectl simulate --objects queues.yaml --trace trace.yaml --fair-sharing -o json
+	`)
+)
+
+type SimulateOptions struct {
+	ObjectsFiles []string
+	TraceFile    string
+	FeatureGates string
+	FairSharing  bool
+	Output       string
+
+	Objects []client.Object
+	Trace   *simulator.Trace
+
+	genericiooptions.IOStreams
+}
+
+func NewSimulateOptions(streams genericiooptions.IOStreams) *SimulateOptions {
+	return &SimulateOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewSimulateCmd(streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewSimulateOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "simulate --objects FILE --trace FILE [--fair-sharing] [--feature-gates GATES] [--output FORMAT]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Simulate the scheduling of a trace of workloads",
+		Long:                  simulateLong,
+		Example:               simulateExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete()
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&o.ObjectsFiles, "objects", nil, "Files with the Kueue objects to simulate. Can be repeated.")
+	cmd.Flags().StringVar(&o.TraceFile, "trace", "", "File with the trace of the workload submissions.")
+	cmd.Flags().StringVar(&o.FeatureGates, "feature-gates", "", "Comma-separated list of key=value pairs of the Kueue feature gates to set.")
+	cmd.Flags().BoolVar(&o.FairSharing, "fair-sharing", false, "Enable fair sharing.")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "Output format. One of: (json, yaml).")
+	cobra.CheckErr(cmd.MarkFlagRequired("objects"))
+	cobra.CheckErr(cmd.MarkFlagRequired("trace"))
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *SimulateOptions) Complete() error {
+	if o.Output != "" && o.Output != outputYAML && o.Output != outputJSON {
+		return fmt.Errorf("unable to match a printer suitable for the output format %q, allowed formats are: %s,%s", o.Output, outputJSON, outputYAML)
+	}
+
+	if o.FeatureGates != "" {
+		if err := utilfeature.DefaultMutableFeatureGate.Set(o.FeatureGates); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range o.ObjectsFiles {
+		objs, err := loadFile(file, simulator.LoadObjects)
+		if err != nil {
+			return err
+		}
+		o.Objects = append(o.Objects, objs...)
+	}
+
+	var err error
+	o.Trace, err = loadFile(o.TraceFile, simulator.LoadTrace)
+	return err
+}
+
+func loadFile[T any](name string, load func(io.Reader) (T, error)) (T, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+	loaded, err := load(f)
+	if err != nil {
+		return loaded, fmt.Errorf("loading %s: %w", name, err)
+	}
+	return loaded, nil
+}
+
+// Run simulates the trace
+func (o *SimulateOptions) Run(ctx context.Context) error {
+	ctx = ctrl.LoggerInto(ctx, logr.Discard())
+	var opts []simulator.Option
+	if o.FairSharing {
+		opts = append(opts, simulator.WithFairSharing(&config.FairSharing{Enable: true}))
+	}
+	result, err := simulator.Run(ctx, o.Objects, o.Trace, opts...)
+	if err != nil {
+		return err
+	}
+
+	switch o.Output {
+	case outputYAML:
+		out, err := yaml.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, err = o.Out.Write(out)
+		return err
+	case outputJSON:
+		out, err := json.MarshalIndent(result, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(o.Out, string(out))
+		return err
+	}
+	return printResult(result, o.Out)
+}
+
+func printResult(result *simulator.Result, out io.Writer) error {
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintf(w, "Duration:\t%s\n", result.Summary.Duration.Duration)
+	fmt.Fprintf(w, "Workloads:\t%d\n", result.Summary.Workloads)
+	fmt.Fprintf(w, "Finished:\t%d\n", result.Summary.Finished)
+	fmt.Fprintf(w, "Pending:\t%d\n", result.Summary.Pending)
+	fmt.Fprintf(w, "Preemptions:\t%d\n", result.Summary.Preemptions)
+	fmt.Fprintf(w, "Average Wait Time:\t%s\n", result.Summary.AverageWaitTime.Duration)
+	fmt.Fprintf(w, "Max Wait Time:\t%s\n", result.Summary.MaxWaitTime.Duration)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	if len(result.Workloads) == 0 {
+		fmt.Fprintln(out, "Workloads: <none>")
+	} else {
+		fmt.Fprintln(out, "Workloads:")
+		w = printers.GetNewTabWriter(out)
+		fmt.Fprint(w, "  NAMESPACE\tNAME\tCLUSTERQUEUE\tSUBMITTED\tADMITTED\tFINISHED\tWAIT TIME\tPREEMPTIONS\n")
+		for _, wl := range result.Workloads {
+			clusterQueue := string(wl.ClusterQueue)
+			if clusterQueue == "" {
+				clusterQueue = "<none>"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n", wl.Namespace, wl.Name, clusterQueue,
+				wl.Submitted.Duration, formatTime(wl.Admitted), formatTime(wl.Finished), wl.WaitTime.Duration, wl.Preemptions)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(out)
+	if len(result.ClusterQueues) == 0 {
+		fmt.Fprintln(out, "ClusterQueues: <none>")
+		return nil
+	}
+	fmt.Fprintln(out, "ClusterQueues:")
+	w = printers.GetNewTabWriter(out)
+	fmt.Fprint(w, "  NAME\tADMITTED\tPREEMPTIONS\tFLAVOR\tRESOURCE\tNOMINAL QUOTA\tAVERAGE USAGE\tUTILIZATION\n")
+	for _, cq := range result.ClusterQueues {
+		if len(cq.Resources) == 0 {
+			fmt.Fprintf(w, "  %s\t%d\t%d\t<none>\t<none>\t\t\t\n", cq.Name, cq.AdmittedWorkloads, cq.Preemptions)
+		}
+		for _, r := range cq.Resources {
+			fmt.Fprintf(w, "  %s\t%d\t%d\t%s\t%s\t%s\t%s\t%.1f%%\n", cq.Name, cq.AdmittedWorkloads, cq.Preemptions,
+				r.Flavor, r.Resource, r.NominalQuota.String(), r.AverageUsage.String(), r.Utilization*100)
+		}
+	}
+	return w.Flush()
+}
+
+func formatTime(d *metav1.Duration) string {
+	if d == nil {
+		return "<none>"
+	}
+	return d.Duration.String()
+}
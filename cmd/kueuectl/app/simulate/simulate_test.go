@@ -0,0 +1,208 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+const (
+	testObjects = `apiVersion: kueue.x-k8s.io/v1beta1
+kind: ResourceFlavor
+metadata:
+  name: default
+---
+apiVersion: kueue.x-k8s.io/v1beta1
+kind: ClusterQueue
+metadata:
+  name: cq
+spec:
+  namespaceSelector: {}
+  preemption:
+    withinClusterQueue: LowerPriority
+  resourceGroups:
+  - coveredResources: [cpu]
+    flavors:
+    - name: default
+      resources:
+      - name: cpu
+        nominalQuota: 4
+---
+apiVersion: kueue.x-k8s.io/v1beta1
+kind: LocalQueue
+metadata:
+  name: main
+  namespace: team
+spec:
+  clusterQueue: cq
+`
+	testTrace = `workloads:
+- name: low
+  namespace: team
+  queue: main
+  duration: 1h
+  podSets:
+  - count: 2
+    requests:
+      cpu: 2
+- name: high
+  namespace: team
+  queue: main
+  priority: 100
+  submitTime: 10m
+  duration: 10m
+  podSets:
+  - count: 1
+    requests:
+      cpu: 3
+`
+)
+
+func TestSimulateCmd(t *testing.T) {
+	testCases := map[string]struct {
+		noFiles    bool
+		args       []string
+		trace      string
+		wantOut    string
+		wantOutErr string
+		wantErr    string
+	}{
+		"missing flags": {
+			noFiles: true,
+			wantErr: `required flag(s) "objects", "trace" not set`,
+		},
+		"invalid output format": {
+			args:    []string{"-o", "wide"},
+			wantErr: `unable to match a printer suitable for the output format "wide", allowed formats are: json,yaml`,
+		},
+		"invalid trace": {
+			trace:   `workloads: [{name: a, queue: main}]`,
+			wantErr: "workloads[0]: at least one podSet is required",
+		},
+		"prints the results": {
+			wantOut: `Duration:            1h20m0s
+Workloads:           2
+Finished:            2
+Pending:             0
+Preemptions:         1
+Average Wait Time:   5m0s
+Max Wait Time:       10m0s
+
+Workloads:
+  NAMESPACE   NAME   CLUSTERQUEUE   SUBMITTED   ADMITTED   FINISHED   WAIT TIME   PREEMPTIONS
+  team        low    cq             0s          20m0s      1h20m0s    10m0s       1
+  team        high   cq             10m0s       10m0s      20m0s      0s          0
+
+ClusterQueues:
+  NAME   ADMITTED   PREEMPTIONS   FLAVOR    RESOURCE   NOMINAL QUOTA   AVERAGE USAGE   UTILIZATION
+  cq     3          1             default   cpu        4               3875m           96.9%
+`,
+		},
+		"prints the results in YAML format": {
+			args: []string{"-o", "yaml"},
+			trace: `workloads:
+- name: a
+  namespace: team
+  queue: main
+  duration: 1m
+  podSets:
+  - count: 1
+    requests:
+      cpu: 4
+`,
+			wantOut: `clusterQueues:
+- admittedWorkloads: 1
+  name: cq
+  preemptions: 0
+  resources:
+  - averageUsage: "4"
+    flavor: default
+    nominalQuota: "4"
+    resource: cpu
+    utilization: 1
+summary:
+  averageWaitTime: 0s
+  duration: 1m0s
+  finished: 1
+  maxWaitTime: 0s
+  pending: 0
+  preemptions: 0
+  workloads: 1
+workloads:
+- admitted: 0s
+  clusterQueue: cq
+  finished: 1m0s
+  name: a
+  namespace: team
+  preemptions: 0
+  submitted: 0s
+  waitTime: 0s
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			objectsFile := filepath.Join(dir, "objects.yaml")
+			traceFile := filepath.Join(dir, "trace.yaml")
+			trace := testTrace
+			if tc.trace != "" {
+				trace = tc.trace
+			}
+			if err := os.WriteFile(objectsFile, []byte(testObjects), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(traceFile, []byte(trace), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			args := tc.args
+			if !tc.noFiles {
+				args = append([]string{"--objects", objectsFile, "--trace", traceFile}, args...)
+			}
+
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+			cmd := NewSimulateCmd(streams)
+			cmd.SetOut(out)
+			cmd.SetErr(outErr)
+			cmd.SetArgs(args)
+
+			gotErr := ""
+			if err := cmd.Execute(); err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if tc.wantErr != "" {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -47,6 +49,8 @@ import (
 
 var (
 	ErrCqNotFound          = errors.New("cluster queue not found")
+	ErrCohortNotFound      = errors.New("cohort not found")
+	ErrCohortHasCycle      = errors.New("cohort has a cycle")
 	errQNotFound           = errors.New("queue not found")
 	errWorkloadNotAdmitted = errors.New("workload not admitted by a ClusterQueue")
 )
@@ -61,6 +65,8 @@ type options struct {
 	workloadInfoOptions []workload.InfoOption
 	podsReadyTracking   bool
 	fairSharingEnabled  bool
+	usageHalfLifeTime   time.Duration
+	clock               clock.Clock
 }
 
 // Option configures the reconciler.
@@ -94,7 +100,24 @@ func WithFairSharing(enabled bool) Option {
 	}
 }
 
-var defaultOptions = options{}
+// WithUsageHalfLifeTime makes the shares account for the resources consumed
+// in the past, decaying by half after the given time.
+func WithUsageHalfLifeTime(d time.Duration) Option {
+	return func(o *options) {
+		o.usageHalfLifeTime = d
+	}
+}
+
+// WithClock sets the clock used to resolve the quota schedules.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+var defaultOptions = options{
+	clock: clock.RealClock{},
+}
 
 // Cache keeps track of the Workloads that got admitted through ClusterQueues.
 type Cache struct {
@@ -108,10 +131,18 @@ type Cache struct {
 	admissionChecks     map[string]AdmissionCheck
 	workloadInfoOptions []workload.InfoOption
 	fairSharingEnabled  bool
+	usageHalfLifeTime   time.Duration
+	clock               clock.Clock
 
 	hm hierarchy.Manager[*clusterQueue, *cohort]
 
 	tasCache TASCache
+
+	reservations map[string]*reservation
+
+	// runtimes are the average runtimes of the finished workloads, by
+	// LocalQueue (namespace/name).
+	runtimes map[string]time.Duration
 }
 
 func New(client client.Client, opts ...Option) *Cache {
@@ -127,8 +158,12 @@ func New(client client.Client, opts ...Option) *Cache {
 		podsReadyTracking:   options.podsReadyTracking,
 		workloadInfoOptions: options.workloadInfoOptions,
 		fairSharingEnabled:  options.fairSharingEnabled,
+		usageHalfLifeTime:   options.usageHalfLifeTime,
+		clock:               options.clock,
 		hm:                  hierarchy.NewManager[*clusterQueue, *cohort](newCohort),
 		tasCache:            NewTASCache(client),
+		reservations:        make(map[string]*reservation),
+		runtimes:            make(map[string]time.Duration),
 	}
 	c.podsReadyCond.L = &c.RWMutex
 	return c
@@ -148,7 +183,7 @@ func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*clusterQueue, error) {
 	}
 	c.hm.AddClusterQueue(cqImpl)
 	c.hm.UpdateClusterQueueEdge(kueue.ClusterQueueReference(cq.Name), cq.Spec.Cohort)
-	if err := cqImpl.updateClusterQueue(c.hm.CycleChecker, cq, c.resourceFlavors, c.admissionChecks, nil); err != nil {
+	if err := cqImpl.updateClusterQueue(c.hm.CycleChecker, cq, c.resourceFlavors, c.admissionChecks, nil, c.clock.Now()); err != nil {
 		return nil, err
 	}
 
@@ -396,11 +431,15 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 	for _, q := range queues.Items {
 		qKey := queueKey(&q)
 		qImpl := &queue{
-			key:                qKey,
-			reservingWorkloads: 0,
-			admittedWorkloads:  0,
-			totalReserved:      make(resources.FlavorResourceQuantities),
-			admittedUsage:      make(resources.FlavorResourceQuantities),
+			key:                  qKey,
+			reservingWorkloads:   0,
+			admittedWorkloads:    0,
+			totalReserved:        make(resources.FlavorResourceQuantities),
+			admittedUsage:        make(resources.FlavorResourceQuantities),
+			quotas:               localQueueQuotas(&q),
+			fairSharing:          q.Spec.FairSharing,
+			maxAdmittedWorkloads: maxAdmittedWorkloads(q.Spec.MaxAdmittedWorkloads),
+			consumed:             consumedResourcesFrom(q.Status.FairSharing),
 		}
 		qImpl.resetFlavorsAndResources(cqImpl.resourceNode.Usage, cqImpl.AdmittedUsage)
 		cqImpl.localQueues[qKey] = qImpl
@@ -428,7 +467,7 @@ func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 	}
 	oldParent := cqImpl.Parent()
 	c.hm.UpdateClusterQueueEdge(kueue.ClusterQueueReference(cq.Name), cq.Spec.Cohort)
-	if err := cqImpl.updateClusterQueue(c.hm.CycleChecker, cq, c.resourceFlavors, c.admissionChecks, oldParent); err != nil {
+	if err := cqImpl.updateClusterQueue(c.hm.CycleChecker, cq, c.resourceFlavors, c.admissionChecks, oldParent, c.clock.Now()); err != nil {
 		return err
 	}
 	for _, qImpl := range cqImpl.localQueues {
@@ -465,7 +504,7 @@ func (c *Cache) AddOrUpdateCohort(apiCohort *kueuealpha.Cohort) error {
 	cohort := c.hm.Cohort(cohortName)
 	oldParent := cohort.Parent()
 	c.hm.UpdateCohortEdge(cohortName, apiCohort.Spec.Parent)
-	return cohort.updateCohort(c.hm.CycleChecker, apiCohort, oldParent)
+	return cohort.updateCohort(c.hm.CycleChecker, apiCohort, oldParent, c.clock.Now())
 }
 
 func (c *Cache) DeleteCohort(cohortName kueue.CohortReference) {
@@ -481,6 +520,49 @@ func (c *Cache) DeleteCohort(cohortName kueue.CohortReference) {
 	}
 }
 
+// ResolveQuotaSchedules re-evaluates the quota schedules of the ClusterQueues
+// and Cohorts at the current time. It returns the ClusterQueues whose quotas,
+// or the quotas of any node in their Cohort tree, changed.
+func (c *Cache) ResolveQuotaSchedules() sets.Set[kueue.ClusterQueueReference] {
+	if !features.Enabled(features.QuotaSchedules) {
+		return nil
+	}
+	c.Lock()
+	defer c.Unlock()
+
+	now := c.clock.Now()
+	changedCQs := sets.New[kueue.ClusterQueueReference]()
+	changedRoots := sets.New[kueue.CohortReference]()
+	for _, cq := range c.hm.ClusterQueues() {
+		if !cq.resolveQuotas(now) {
+			continue
+		}
+		if !cq.HasParent() {
+			updateClusterQueueResourceNode(cq)
+			changedCQs.Insert(cq.Name)
+		} else if !c.hm.CycleChecker.HasCycle(cq.Parent()) {
+			changedRoots.Insert(cq.Parent().getRootUnsafe().Name)
+		}
+	}
+	for _, cohort := range c.hm.Cohorts() {
+		if cohort.resolveQuotas(now) && !c.hm.CycleChecker.HasCycle(cohort) {
+			changedRoots.Insert(cohort.getRootUnsafe().Name)
+		}
+	}
+	if changedRoots.Len() == 0 {
+		return changedCQs
+	}
+	for root := range changedRoots {
+		updateCohortResourceNode(c.hm.Cohort(root))
+	}
+	for _, cq := range c.hm.ClusterQueues() {
+		if cq.HasParent() && !c.hm.CycleChecker.HasCycle(cq.Parent()) && changedRoots.Has(cq.Parent().getRootUnsafe().Name) {
+			changedCQs.Insert(cq.Name)
+		}
+	}
+	return changedCQs
+}
+
 func (c *Cache) AddLocalQueue(q *kueue.LocalQueue) error {
 	c.Lock()
 	defer c.Unlock()
@@ -494,6 +576,7 @@ func (c *Cache) AddLocalQueue(q *kueue.LocalQueue) error {
 func (c *Cache) DeleteLocalQueue(q *kueue.LocalQueue) {
 	c.Lock()
 	defer c.Unlock()
+	delete(c.runtimes, queueKey(q))
 	cq := c.hm.ClusterQueue(q.Spec.ClusterQueue)
 	if cq == nil {
 		return
@@ -502,11 +585,19 @@ func (c *Cache) DeleteLocalQueue(q *kueue.LocalQueue) {
 }
 
 func (c *Cache) UpdateLocalQueue(oldQ, newQ *kueue.LocalQueue) error {
+	c.Lock()
+	defer c.Unlock()
 	if oldQ.Spec.ClusterQueue == newQ.Spec.ClusterQueue {
+		if cq := c.hm.ClusterQueue(newQ.Spec.ClusterQueue); cq != nil {
+			if qImpl, ok := cq.localQueues[queueKey(newQ)]; ok {
+				qImpl.quotas = localQueueQuotas(newQ)
+				qImpl.fairSharing = newQ.Spec.FairSharing
+				qImpl.maxAdmittedWorkloads = maxAdmittedWorkloads(newQ.Spec.MaxAdmittedWorkloads)
+				qImpl.consumed = consumedResourcesFrom(newQ.Status.FairSharing)
+			}
+		}
 		return nil
 	}
-	c.Lock()
-	defer c.Unlock()
 	cq := c.hm.ClusterQueue(oldQ.Spec.ClusterQueue)
 	if cq != nil {
 		cq.deleteLocalQueue(oldQ)
@@ -608,7 +699,30 @@ func (c *Cache) IsAssumedOrAdmittedWorkload(w workload.Info) bool {
 func (c *Cache) AssumeWorkload(w *kueue.Workload) error {
 	c.Lock()
 	defer c.Unlock()
+	return c.assumeWorkload(w)
+}
 
+// AssumeWorkloads assumes all the workloads under a single lock. If any of
+// them can't be assumed, the ones assumed before it are forgotten, so that
+// either all the workloads are assumed or none of them is.
+func (c *Cache) AssumeWorkloads(wls []*kueue.Workload) error {
+	c.Lock()
+	defer c.Unlock()
+	for i, w := range wls {
+		if err := c.assumeWorkload(w); err != nil {
+			for _, assumed := range wls[:i] {
+				c.cleanupAssumedState(assumed)
+				if cq := c.hm.ClusterQueue(assumed.Status.Admission.ClusterQueue); cq != nil {
+					cq.deleteWorkload(assumed)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cache) assumeWorkload(w *kueue.Workload) error {
 	if !workload.HasQuotaReservation(w) {
 		return errWorkloadNotAdmitted
 	}
@@ -723,6 +837,155 @@ func getUsage(frq resources.FlavorResourceQuantities, cq *clusterQueue) []kueue.
 	return usage
 }
 
+// AdmittedWorkload is a workload reserving quota in a ClusterQueue.
+type AdmittedWorkload struct {
+	Info         *workload.Info
+	ClusterQueue kueue.ClusterQueueReference
+	// Borrowing indicates whether the ClusterQueue borrows quota from its
+	// Cohort for any of the flavors and resources used by the workload.
+	Borrowing bool
+}
+
+// AdmittedWorkloads returns the workloads reserving quota in the ClusterQueue
+// or, if inCohort is true, in all the ClusterQueues of the Cohort tree the
+// ClusterQueue belongs to. The workloads aren't in any particular order.
+func (c *Cache) AdmittedWorkloads(name kueue.ClusterQueueReference, inCohort bool) ([]AdmittedWorkload, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueue(name)
+	if cq == nil {
+		return nil, ErrCqNotFound
+	}
+	cqs := []*clusterQueue{cq}
+	if inCohort && cq.HasParent() && !hierarchy.HasCycle[kueue.CohortReference](cq.Parent()) {
+		cqs = clusterQueuesInTree(cq.Parent().getRootUnsafe(), nil)
+	}
+	var result []AdmittedWorkload
+	for _, cq := range cqs {
+		for _, wi := range cq.Workloads {
+			result = append(result, AdmittedWorkload{
+				Info:         wi,
+				ClusterQueue: cq.Name,
+				Borrowing:    cq.borrowingFor(wi),
+			})
+		}
+	}
+	return result, nil
+}
+
+func clusterQueuesInTree(cohort *cohort, cqs []*clusterQueue) []*clusterQueue {
+	cqs = append(cqs, cohort.ChildCQs()...)
+	for _, child := range cohort.ChildCohorts() {
+		cqs = clusterQueuesInTree(child, cqs)
+	}
+	return cqs
+}
+
+type CohortUsageStats struct {
+	ReservedResources []kueuealpha.CohortFlavorUsage
+	WeightedShare     int64
+}
+
+// CohortUsage reports the subtree quota and the reserved resources of the Cohort.
+func (c *Cache) CohortUsage(name kueue.CohortReference) (*CohortUsageStats, error) {
+	// The CycleChecker memoizes its results, so it requires the write lock.
+	c.Lock()
+	defer c.Unlock()
+
+	cohort := c.hm.Cohort(name)
+	if cohort == nil {
+		return nil, ErrCohortNotFound
+	}
+	if c.hm.CycleChecker.HasCycle(cohort) {
+		return nil, ErrCohortHasCycle
+	}
+
+	stats := &CohortUsageStats{
+		ReservedResources: getCohortUsage(cohort),
+	}
+
+	if c.fairSharingEnabled {
+		weightedShare, _ := dominantResourceShare(cohort, nil)
+		stats.WeightedShare = int64(weightedShare)
+	}
+
+	return stats, nil
+}
+
+func getCohortUsage(cohort *cohort) []kueuealpha.CohortFlavorUsage {
+	byFlavor := make(map[kueue.ResourceFlavorReference][]kueuealpha.CohortResourceUsage)
+	for fr, subtreeQuota := range cohort.resourceNode.SubtreeQuota {
+		used := cohort.resourceNode.Usage[fr]
+		rUsage := kueuealpha.CohortResourceUsage{
+			Name:         fr.Resource,
+			SubtreeQuota: resources.ResourceQuantity(fr.Resource, subtreeQuota),
+			Total:        resources.ResourceQuantity(fr.Resource, used),
+		}
+		// Only a Cohort with a parent can borrow.
+		if cohort.HasParent() {
+			if borrowed := used - subtreeQuota; borrowed > 0 {
+				rUsage.Borrowed = resources.ResourceQuantity(fr.Resource, borrowed)
+			}
+		}
+		byFlavor[fr.Flavor] = append(byFlavor[fr.Flavor], rUsage)
+	}
+	usage := make([]kueuealpha.CohortFlavorUsage, 0, len(byFlavor))
+	for fName, rUsages := range byFlavor {
+		// The usages should be in a stable order to avoid endless creation of update events.
+		sort.Slice(rUsages, func(i, j int) bool {
+			return rUsages[i].Name < rUsages[j].Name
+		})
+		usage = append(usage, kueuealpha.CohortFlavorUsage{
+			Name:      fName,
+			Resources: rUsages,
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Name < usage[j].Name
+	})
+	return usage
+}
+
+// CohortAncestors returns the names of the Cohort and of its ancestors, up to
+// the root of the Cohort tree, which are backed by an API object.
+func (c *Cache) CohortAncestors(name kueue.CohortReference) []kueue.CohortReference {
+	c.Lock()
+	defer c.Unlock()
+	return c.explicitCohortsInPath(c.hm.Cohort(name))
+}
+
+// ClusterQueueAncestors returns the names of the Cohorts, up to the root of
+// the Cohort tree, which contain the ClusterQueue and are backed by an API object.
+func (c *Cache) ClusterQueueAncestors(name kueue.ClusterQueueReference) []kueue.CohortReference {
+	c.Lock()
+	defer c.Unlock()
+	cq := c.hm.ClusterQueue(name)
+	if cq == nil {
+		return nil
+	}
+	return c.explicitCohortsInPath(cq.Parent())
+}
+
+func (c *Cache) explicitCohortsInPath(cohort *cohort) []kueue.CohortReference {
+	if cohort == nil {
+		return nil
+	}
+	if c.hm.CycleChecker.HasCycle(cohort) {
+		if cohort.IsExplicit() {
+			return []kueue.CohortReference{cohort.Name}
+		}
+		return nil
+	}
+	var names []kueue.CohortReference
+	for ; cohort != nil; cohort = cohort.Parent() {
+		if cohort.IsExplicit() {
+			names = append(names, cohort.Name)
+		}
+	}
+	return names
+}
+
 type LocalQueueUsageStats struct {
 	ReservedResources  []kueue.LocalQueueFlavorUsage
 	ReservingWorkloads int
@@ -731,6 +994,31 @@ type LocalQueueUsageStats struct {
 	Flavors            []kueue.LocalQueueFlavorStatus
 }
 
+// LocalQueueShares returns the shares of the LocalQueues of the ClusterQueue,
+// by (namespace/name), if the ClusterQueue uses admission fair sharing.
+// Otherwise, it returns nil.
+func (c *Cache) LocalQueueShares(name kueue.ClusterQueueReference) map[string]int {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.hm.ClusterQueue(name)
+	if cq == nil || !cq.AdmissionFairSharing {
+		return nil
+	}
+	now := c.clock.Now()
+	shares := make(map[string]int, len(cq.localQueues))
+	for key, q := range cq.localQueues {
+		lq := LocalQueueSnapshot{
+			Usage:      q.totalReserved,
+			FairWeight: parseFairWeight(q.fairSharing),
+		}
+		if c.usageHalfLifeTime > 0 {
+			lq.AverageUsage = q.consumed.averageUsage(now, c.usageHalfLifeTime)
+		}
+		shares[key] = lq.share(cq.resourceNode.Quotas)
+	}
+	return shares
+}
+
 func (c *Cache) LocalQueueUsage(qObj *kueue.LocalQueue) (*LocalQueueUsageStats, error) {
 	c.RLock()
 	defer c.RUnlock()
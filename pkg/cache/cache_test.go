@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -30,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +43,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 )
 
 func TestCacheClusterQueueOperations(t *testing.T) {
@@ -111,6 +114,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "a",
 					AllocatableResourceGeneration: 2,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -120,6 +124,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "b",
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -129,6 +134,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "c",
 					AllocatableResourceGeneration: 3,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -138,6 +144,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "d",
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -147,6 +154,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "e",
 					AllocatableResourceGeneration: 2,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
@@ -158,6 +166,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					NamespaceSelector:             labels.Nothing(),
 					Status:                        active,
 					Preemption:                    defaultPreemption,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility: kueue.FlavorFungibility{
 						WhenCanBorrow:  kueue.TryNextFlavor,
 						WhenCanPreempt: kueue.TryNextFlavor,
@@ -188,6 +197,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Everything(),
 					Status:                        active,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Preemption: kueue.ClusterQueuePreemption{
 						ReclaimWithinCohort: kueue.PreemptionPolicyLowerPriority,
@@ -212,6 +222,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Everything(),
 					Status:                        active,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Preemption:                    defaultPreemption,
 					FairWeight:                    resource.MustParse("2"),
@@ -236,6 +247,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 				"a": {
 					Name:                          "a",
 					AllocatableResourceGeneration: 2,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					NamespaceSelector:             labels.Nothing(),
 					Status:                        active,
@@ -245,6 +257,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 				"b": {
 					Name:                          "b",
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					NamespaceSelector:             labels.Nothing(),
 					Status:                        active,
@@ -255,6 +268,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "c",
 					AllocatableResourceGeneration: 3,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -264,6 +278,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "d",
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -273,6 +288,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "e",
 					AllocatableResourceGeneration: 2,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
@@ -284,6 +300,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					NamespaceSelector:             labels.Nothing(),
 					Status:                        active,
 					Preemption:                    defaultPreemption,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility: kueue.FlavorFungibility{
 						WhenCanBorrow:  kueue.TryNextFlavor,
 						WhenCanPreempt: kueue.TryNextFlavor,
@@ -338,6 +355,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "a",
 					AllocatableResourceGeneration: 4,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -347,6 +365,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "b",
 					AllocatableResourceGeneration: 3,
 					NamespaceSelector:             labels.Everything(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -356,6 +375,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "c",
 					AllocatableResourceGeneration: 5,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -365,6 +385,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "d",
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -374,6 +395,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "e",
 					AllocatableResourceGeneration: 4,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -385,6 +407,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					NamespaceSelector:             labels.Nothing(),
 					Status:                        active,
 					Preemption:                    defaultPreemption,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility: kueue.FlavorFungibility{
 						WhenCanBorrow:  kueue.TryNextFlavor,
 						WhenCanPreempt: kueue.TryNextFlavor,
@@ -444,6 +467,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "a",
 					AllocatableResourceGeneration: 2,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					resourceNode: ResourceNode{
 						Usage: resources.FlavorResourceQuantities{
@@ -511,6 +535,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 				"a": {
 					Name:                          "a",
 					AllocatableResourceGeneration: 4,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -520,6 +545,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 				"b": {
 					Name:                          "b",
 					AllocatableResourceGeneration: 3,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -529,6 +555,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 				"c": {
 					Name:                          "c",
 					AllocatableResourceGeneration: 4,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -561,6 +588,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "b",
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -570,6 +598,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "c",
 					AllocatableResourceGeneration: 3,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -579,6 +608,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "e",
 					AllocatableResourceGeneration: 2,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
@@ -590,6 +620,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					NamespaceSelector:             labels.Nothing(),
 					Status:                        active,
 					Preemption:                    defaultPreemption,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility: kueue.FlavorFungibility{
 						WhenCanBorrow:  kueue.TryNextFlavor,
 						WhenCanPreempt: kueue.TryNextFlavor,
@@ -617,6 +648,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "a",
 					AllocatableResourceGeneration: 2,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -626,6 +658,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "b",
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -635,6 +668,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "c",
 					AllocatableResourceGeneration: 3,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -644,6 +678,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "d",
 					AllocatableResourceGeneration: 1,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -653,6 +688,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "e",
 					AllocatableResourceGeneration: 2,
 					NamespaceSelector:             labels.Nothing(),
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        active,
 					Preemption:                    defaultPreemption,
@@ -664,6 +700,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					NamespaceSelector:             labels.Nothing(),
 					Status:                        active,
 					Preemption:                    defaultPreemption,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility: kueue.FlavorFungibility{
 						WhenCanBorrow:  kueue.TryNextFlavor,
 						WhenCanPreempt: kueue.TryNextFlavor,
@@ -706,6 +743,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Name:                          "foo",
 					NamespaceSelector:             labels.Everything(),
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
@@ -732,6 +770,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
 						"check1": sets.New[kueue.ResourceFlavorReference](),
@@ -764,6 +803,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        active,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
 						"check1": sets.New[kueue.ResourceFlavorReference](),
@@ -797,6 +837,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
 						"check1": sets.New[kueue.ResourceFlavorReference](),
@@ -830,6 +871,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
 						"check1": sets.New[kueue.ResourceFlavorReference](),
@@ -881,6 +923,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        active,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					AdmittedUsage: resources.FlavorResourceQuantities{
 						{Flavor: "f1", Resource: corev1.ResourceCPU}: 1000,
@@ -981,6 +1024,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					FairWeight:                    oneQuantity,
 				},
@@ -1045,6 +1089,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        pending,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					FairWeight:                    oneQuantity,
 				},
@@ -1088,6 +1133,7 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Status:                        active,
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 2,
+					QueueingStrategy:              kueue.BestEffortFIFO,
 					FlavorFungibility:             defaultFlavorFungibility,
 					FairWeight:                    oneQuantity,
 				},
@@ -1177,6 +1223,18 @@ func TestCacheWorkloadOperations(t *testing.T) {
 			},
 		},
 	}
+	scaledUpAssignments := []kueue.PodSetAssignment{
+		psAssignments[0],
+		{
+			Name: "workers",
+			Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+				corev1.ResourceCPU: "spot",
+			},
+			ResourceUsage: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("30m"),
+			},
+		},
+	}
 	cl := utiltesting.NewFakeClient(
 		utiltesting.MakeWorkload("a", "").PodSets(podSets...).ReserveQuota(&kueue.Admission{
 			ClusterQueue:      "one",
@@ -1666,6 +1724,96 @@ func TestCacheWorkloadOperations(t *testing.T) {
 				"/e": "two",
 			},
 		},
+		{
+			name: "assume workload slice replacing an admitted workload",
+			operation: func(cache *Cache) error {
+				w := utiltesting.MakeWorkload("a-slice", "").
+					Annotation(workloadslicing.ReplacementForAnnotationKey, "/a").
+					PodSets(podSets...).
+					ReserveQuota(&kueue.Admission{
+						ClusterQueue:      "one",
+						PodSetAssignments: scaledUpAssignments,
+					}).Obj()
+				return cache.AssumeWorkload(w)
+			},
+			wantResults: map[kueue.ClusterQueueReference]result{
+				"one": {
+					Workloads: sets.New("/a-slice", "/b"),
+					UsedResources: resources.FlavorResourceQuantities{
+						{Flavor: "on-demand", Resource: corev1.ResourceCPU}: 10,
+						{Flavor: "spot", Resource: corev1.ResourceCPU}:      30,
+					},
+				},
+				"two": {
+					Workloads: sets.New("/c"),
+				},
+			},
+			wantAssumedWorkloads: map[string]kueue.ClusterQueueReference{
+				"/a-slice": "one",
+			},
+		},
+		{
+			name: "forget workload slice restores the replaced workload",
+			operation: func(cache *Cache) error {
+				w := utiltesting.MakeWorkload("a-slice", "").
+					Annotation(workloadslicing.ReplacementForAnnotationKey, "/a").
+					PodSets(podSets...).
+					ReserveQuota(&kueue.Admission{
+						ClusterQueue:      "one",
+						PodSetAssignments: scaledUpAssignments,
+					}).Obj()
+				if err := cache.AssumeWorkload(w); err != nil {
+					return err
+				}
+				return cache.ForgetWorkload(w)
+			},
+			wantResults: map[kueue.ClusterQueueReference]result{
+				"one": {
+					Workloads: sets.New("/a", "/b"),
+					UsedResources: resources.FlavorResourceQuantities{
+						{Flavor: "on-demand", Resource: corev1.ResourceCPU}: 10,
+						{Flavor: "spot", Resource: corev1.ResourceCPU}:      15,
+					},
+				},
+				"two": {
+					Workloads: sets.New("/c"),
+				},
+			},
+		},
+		{
+			name: "delete replaced workload while replaced by a workload slice",
+			operation: func(cache *Cache) error {
+				w := utiltesting.MakeWorkload("a-slice", "").
+					Annotation(workloadslicing.ReplacementForAnnotationKey, "/a").
+					PodSets(podSets...).
+					ReserveQuota(&kueue.Admission{
+						ClusterQueue:      "one",
+						PodSetAssignments: scaledUpAssignments,
+					}).Obj()
+				if err := cache.AssumeWorkload(w); err != nil {
+					return err
+				}
+				if err := cache.DeleteWorkload(utiltesting.MakeWorkload("a", "").PodSets(podSets...).ReserveQuota(&kueue.Admission{
+					ClusterQueue:      "one",
+					PodSetAssignments: psAssignments,
+				}).Obj()); err != nil {
+					return err
+				}
+				return cache.ForgetWorkload(w)
+			},
+			wantResults: map[kueue.ClusterQueueReference]result{
+				"one": {
+					Workloads: sets.New("/b"),
+					UsedResources: resources.FlavorResourceQuantities{
+						{Flavor: "on-demand", Resource: corev1.ResourceCPU}: 0,
+						{Flavor: "spot", Resource: corev1.ResourceCPU}:      0,
+					},
+				},
+				"two": {
+					Workloads: sets.New("/c"),
+				},
+			},
+		},
 	}
 	for _, step := range steps {
 		t.Run(step.name, func(t *testing.T) {
@@ -2734,7 +2882,7 @@ func TestCacheQueueOperations(t *testing.T) {
 					cacheQueues[qKey] = cacheQ
 				}
 			}
-			if diff := cmp.Diff(tc.wantLocalQueues, cacheQueues, cmp.AllowUnexported(queue{}), cmpopts.EquateEmpty()); diff != "" {
+			if diff := cmp.Diff(tc.wantLocalQueues, cacheQueues, cmp.AllowUnexported(queue{}, consumedResources{}), cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf("Unexpected localQueues (-want,+got):\n%s", diff)
 			}
 		})
@@ -3826,3 +3974,70 @@ func TestSnapshotError(t *testing.T) {
 		t.Fatalf("Unexpected error (-want/+got)\n%s", diff)
 	}
 }
+
+func TestResolveQuotaSchedules(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.QuotaSchedules, true)
+	ctx := context.Background()
+	// Monday at noon.
+	fakeClock := testingclock.NewFakeClock(time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC))
+	cache := New(utiltesting.NewFakeClient(), WithClock(fakeClock))
+
+	research := utiltesting.MakeClusterQueue("research").
+		Cohort("cohort").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("a100").
+				ResourceQuotaWrapper("nvidia.com/gpu").
+				NominalQuota("2").
+				Schedule("overnight", "20:00", "06:00", "8").
+				Append().Obj(),
+		).Obj()
+	product := utiltesting.MakeClusterQueue("product").
+		Cohort("cohort").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("a100").
+				ResourceQuotaWrapper("nvidia.com/gpu").
+				NominalQuota("8").
+				Schedule("overnight", "20:00", "06:00", "2").
+				Append().Obj(),
+		).Obj()
+	standalone := utiltesting.MakeClusterQueue("standalone").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("a100").Resource("nvidia.com/gpu", "4").Obj()).
+		Obj()
+	for _, cq := range []*kueue.ClusterQueue{research, product, standalone} {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed to add ClusterQueue %q: %v", cq.Name, err)
+		}
+	}
+
+	fr := resources.FlavorResource{Flavor: "a100", Resource: "nvidia.com/gpu"}
+	checkNominal := func(want map[kueue.ClusterQueueReference]int64) {
+		t.Helper()
+		got := make(map[kueue.ClusterQueueReference]int64, len(want))
+		for name := range want {
+			got[name] = cache.hm.ClusterQueue(name).resourceNode.Quotas[fr].Nominal
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Unexpected nominal quotas (-want,+got):\n%s", diff)
+		}
+		if diff := cmp.Diff(int64(10), cache.hm.Cohort("cohort").resourceNode.SubtreeQuota[fr]); diff != "" {
+			t.Errorf("Unexpected cohort subtree quota (-want,+got):\n%s", diff)
+		}
+	}
+	checkNominal(map[kueue.ClusterQueueReference]int64{"research": 2, "product": 8, "standalone": 4})
+
+	if got := cache.ResolveQuotaSchedules(); got.Len() != 0 {
+		t.Errorf("Unexpected changed ClusterQueues before the window: %v", sets.List(got))
+	}
+
+	fakeClock.SetTime(time.Date(2025, time.March, 3, 21, 0, 0, 0, time.UTC))
+	if diff := cmp.Diff(sets.New[kueue.ClusterQueueReference]("research", "product"), cache.ResolveQuotaSchedules()); diff != "" {
+		t.Errorf("Unexpected changed ClusterQueues when the window opens (-want,+got):\n%s", diff)
+	}
+	checkNominal(map[kueue.ClusterQueueReference]int64{"research": 8, "product": 2, "standalone": 4})
+
+	fakeClock.SetTime(time.Date(2025, time.March, 4, 6, 0, 0, 0, time.UTC))
+	if diff := cmp.Diff(sets.New[kueue.ClusterQueueReference]("research", "product"), cache.ResolveQuotaSchedules()); diff != "" {
+		t.Errorf("Unexpected changed ClusterQueues when the window closes (-want,+got):\n%s", diff)
+	}
+	checkNominal(map[kueue.ClusterQueueReference]int64{"research": 2, "product": 8, "standalone": 4})
+}
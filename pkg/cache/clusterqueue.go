@@ -22,6 +22,7 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -39,6 +40,7 @@ import (
 	utilac "sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 )
 
 var (
@@ -56,6 +58,22 @@ type clusterQueue struct {
 	Preemption        kueue.ClusterQueuePreemption
 	FairWeight        resource.Quantity
 	FlavorFungibility kueue.FlavorFungibility
+	QueueingStrategy  kueue.QueueingStrategy
+	// FlavorSelectionPolicy indicates how the flavors of the resource groups
+	// are chosen.
+	FlavorSelectionPolicy kueue.FlavorSelectionPolicy
+	// AdmissionFairSharing indicates whether the workloads are ordered by
+	// the share of their LocalQueues.
+	AdmissionFairSharing bool
+	// PriorityAging raises the priority of the pending workloads as they
+	// wait, or nil if the ClusterQueue has no priority aging.
+	PriorityAging *kueue.PriorityAging
+	// MaxAdmittedWorkloads is the maximum number of workloads reserving
+	// quota, or nil if the number isn't limited.
+	MaxAdmittedWorkloads *int32
+	// consumed holds the resources consumed in the past, as persisted in
+	// the status.
+	consumed consumedResources
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
@@ -81,8 +99,14 @@ type clusterQueue struct {
 	admittedWorkloadsCount                          int
 	isStopped                                       bool
 	workloadInfoOptions                             []workload.InfoOption
-
-	resourceNode ResourceNode
+	// replacedSlices holds the workload slices replaced by a workload slice
+	// reserving quota in the ClusterQueue, by key. Their usage is accounted
+	// by the replacing slice, so the replaced slices are kept out of the
+	// Workloads, to be restored if the replacing slice is removed first.
+	replacedSlices map[string]*workload.Info
+
+	resourceNode    ResourceNode
+	scheduledQuotas scheduledQuotas
 	hierarchy.ClusterQueue[*cohort]
 
 	tasCache *TASCache
@@ -108,6 +132,18 @@ type queue struct {
 	admittedWorkloads  int
 	totalReserved      resources.FlavorResourceQuantities
 	admittedUsage      resources.FlavorResourceQuantities
+	// quotas is the maximum usage, per flavor and resource, declared by
+	// the LocalQueue. It's nil if the LocalQueue doesn't declare quotas.
+	quotas resources.FlavorResourceQuantities
+	// fairSharing holds the weight of the LocalQueue when the ClusterQueue
+	// uses admission fair sharing.
+	fairSharing *kueue.FairSharing
+	// maxAdmittedWorkloads is the maximum number of workloads of the
+	// LocalQueue reserving quota, or nil if the number isn't limited.
+	maxAdmittedWorkloads *int32
+	// consumed holds the resources consumed in the past, as persisted in
+	// the status of the LocalQueue.
+	consumed consumedResources
 }
 
 func (c *clusterQueue) Active() bool {
@@ -121,8 +157,8 @@ var defaultPreemption = kueue.ClusterQueuePreemption{
 
 var defaultFlavorFungibility = kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.TryNextFlavor}
 
-func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, in *kueue.ClusterQueue, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, admissionChecks map[string]AdmissionCheck, oldParent *cohort) error {
-	if c.updateQuotasAndResourceGroups(in.Spec.ResourceGroups) || oldParent != c.Parent() {
+func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, in *kueue.ClusterQueue, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, admissionChecks map[string]AdmissionCheck, oldParent *cohort, now time.Time) error {
+	if c.updateQuotasAndResourceGroups(in.Spec.ResourceGroups, now) || oldParent != c.Parent() {
 		if oldParent != nil && oldParent != c.Parent() {
 			// ignore error when old Cohort has cycle.
 			_ = updateCohortTreeResources(oldParent, cycleChecker)
@@ -170,7 +206,13 @@ func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, i
 		c.FlavorFungibility = defaultFlavorFungibility
 	}
 
+	c.QueueingStrategy = in.Spec.QueueingStrategy
+	c.FlavorSelectionPolicy = in.Spec.FlavorSelectionPolicy
 	c.FairWeight = parseFairWeight(in.Spec.FairSharing)
+	c.AdmissionFairSharing = admissionFairSharing(in)
+	c.PriorityAging = priorityAging(in)
+	c.MaxAdmittedWorkloads = maxAdmittedWorkloads(in.Spec.MaxAdmittedWorkloads)
+	c.consumed = consumedResourcesFrom(in.Status.FairSharing)
 
 	return nil
 }
@@ -189,13 +231,15 @@ func createdResourceGroups(kueueRgs []kueue.ResourceGroup) []ResourceGroup {
 	return rgs
 }
 
-// updateQuotasAndResourceGroups updates Quotas and ResourceGroups.
+// updateQuotasAndResourceGroups updates Quotas and ResourceGroups, resolving
+// the quota schedules at the provided time.
 // It returns true if any changes were made.
-func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup) bool {
+func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup, now time.Time) bool {
 	oldRG := c.ResourceGroups
 	oldQuotas := c.resourceNode.Quotas
 	c.ResourceGroups = createdResourceGroups(in)
-	c.resourceNode.Quotas = createResourceQuotas(in)
+	c.scheduledQuotas = createScheduledQuotas(in)
+	c.resourceNode.Quotas = c.scheduledQuotas.resolve(now)
 
 	// Start at 1, for backwards compatibility.
 	return c.AllocatableResourceGeneration == 0 ||
@@ -203,6 +247,20 @@ func (c *clusterQueue) updateQuotasAndResourceGroups(in []kueue.ResourceGroup) b
 		!equality.Semantic.DeepEqual(oldQuotas, c.resourceNode.Quotas)
 }
 
+// resolveQuotas updates the Quotas to the values effective at the provided
+// time. It returns true if they changed.
+func (c *clusterQueue) resolveQuotas(now time.Time) bool {
+	if len(c.scheduledQuotas.schedules) == 0 {
+		return false
+	}
+	quotas := c.scheduledQuotas.resolve(now)
+	if equality.Semantic.DeepEqual(quotas, c.resourceNode.Quotas) {
+		return false
+	}
+	c.resourceNode.Quotas = quotas
+	return true
+}
+
 func (c *clusterQueue) updateQueueStatus() {
 	status := active
 	if c.isStopped ||
@@ -457,6 +515,24 @@ func (c *clusterQueue) addWorkload(w *kueue.Workload) error {
 		return errors.New("workload already exists in ClusterQueue")
 	}
 	wi := workload.NewInfo(w, c.workloadInfoOptions...)
+	if _, replaced := c.replacedSlices[k]; replaced {
+		c.replacedSlices[k] = wi
+		return nil
+	}
+	if replacedKey, isSlice := workloadslicing.ReplacementFor(w); isSlice {
+		replaced := c.Workloads[replacedKey]
+		if replaced != nil {
+			if previousKey, found := workloadslicing.ReplacementFor(replaced.Obj); found {
+				// The slice replaced by the replaced slice is no longer restored.
+				delete(c.replacedSlices, previousKey)
+			}
+			c.deleteWorkload(replaced.Obj)
+		}
+		if c.replacedSlices == nil {
+			c.replacedSlices = make(map[string]*workload.Info)
+		}
+		c.replacedSlices[replacedKey] = replaced
+	}
 	c.Workloads[k] = wi
 	c.updateWorkloadUsage(wi, 1)
 	if c.podsReadyTracking && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
@@ -468,6 +544,10 @@ func (c *clusterQueue) addWorkload(w *kueue.Workload) error {
 
 func (c *clusterQueue) deleteWorkload(w *kueue.Workload) {
 	k := workload.Key(w)
+	if _, replaced := c.replacedSlices[k]; replaced {
+		// The replaced slice is gone, it's no longer restored.
+		c.replacedSlices[k] = nil
+	}
 	wi, exist := c.Workloads[k]
 	if !exist {
 		return
@@ -482,6 +562,17 @@ func (c *clusterQueue) deleteWorkload(w *kueue.Workload) {
 
 	delete(c.Workloads, k)
 	c.reportActiveWorkloads()
+
+	if replacedKey, isSlice := workloadslicing.ReplacementFor(w); isSlice {
+		if replaced, found := c.replacedSlices[replacedKey]; found {
+			delete(c.replacedSlices, replacedKey)
+			if replaced != nil {
+				// The replacing slice no longer accounts for the usage of the
+				// replaced slice.
+				_ = c.addWorkload(replaced.Obj)
+			}
+		}
+	}
 }
 
 func (c *clusterQueue) reportActiveWorkloads() {
@@ -538,6 +629,20 @@ func (c *clusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 	}
 }
 
+// borrowingFor returns whether the ClusterQueue uses more than its nominal
+// quota for any of the flavors and resources used by the workload.
+func (c *clusterQueue) borrowingFor(wi *workload.Info) bool {
+	if !c.HasParent() {
+		return false
+	}
+	for fr := range wi.FlavorResourceUsage() {
+		if c.resourceNode.Usage[fr] > c.resourceNode.Quotas[fr].Nominal {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *clusterQueue) tasFlavorCache(flvName kueue.ResourceFlavorReference) *TASFlavorCache {
 	if !features.Enabled(features.TopologyAwareScheduling) {
 		return nil
@@ -562,9 +667,13 @@ func (c *clusterQueue) addLocalQueue(q *kueue.LocalQueue) error {
 	// We need to count the workloads, because they could have been added before
 	// receiving the queue add event.
 	qImpl := &queue{
-		key:                qKey,
-		reservingWorkloads: 0,
-		totalReserved:      make(resources.FlavorResourceQuantities),
+		key:                  qKey,
+		reservingWorkloads:   0,
+		totalReserved:        make(resources.FlavorResourceQuantities),
+		quotas:               localQueueQuotas(q),
+		fairSharing:          q.Spec.FairSharing,
+		maxAdmittedWorkloads: maxAdmittedWorkloads(q.Spec.MaxAdmittedWorkloads),
+		consumed:             consumedResourcesFrom(q.Status.FairSharing),
 	}
 	qImpl.resetFlavorsAndResources(c.resourceNode.Usage, c.AdmittedUsage)
 	for _, wl := range c.Workloads {
@@ -618,6 +727,45 @@ func resetUsage(lqUsage resources.FlavorResourceQuantities, cqUsage resources.Fl
 	return usedFlavorResources
 }
 
+// localQueueQuotas returns the maximum usage per flavor and resource declared
+// by the LocalQueue, or nil if it doesn't declare any.
+func localQueueQuotas(q *kueue.LocalQueue) resources.FlavorResourceQuantities {
+	if !features.Enabled(features.LocalQueueQuotas) || len(q.Spec.Quotas) == 0 {
+		return nil
+	}
+	quotas := make(resources.FlavorResourceQuantities)
+	for _, fq := range q.Spec.Quotas {
+		for _, rq := range fq.Resources {
+			quotas[resources.FlavorResource{Flavor: fq.Name, Resource: rq.Name}] = resources.ResourceValue(rq.Name, rq.Max)
+		}
+	}
+	return quotas
+}
+
+// admissionFairSharing returns whether the ClusterQueue orders its workloads
+// by the share of their LocalQueues.
+func admissionFairSharing(cq *kueue.ClusterQueue) bool {
+	return features.Enabled(features.AdmissionFairSharing) &&
+		cq.Spec.AdmissionScope != nil &&
+		cq.Spec.AdmissionScope.AdmissionMode == kueue.UsageBasedAdmissionFairSharing
+}
+
+// maxAdmittedWorkloads returns the maximum number of workloads reserving
+// quota declared by a queue, or nil if it's not limited.
+func maxAdmittedWorkloads(limit *int32) *int32 {
+	if !features.Enabled(features.MaxAdmittedWorkloads) {
+		return nil
+	}
+	return limit
+}
+
+func priorityAging(cq *kueue.ClusterQueue) *kueue.PriorityAging {
+	if !features.Enabled(features.PriorityAging) {
+		return nil
+	}
+	return cq.Spec.PriorityAging
+}
+
 func workloadBelongsToLocalQueue(wl *kueue.Workload, q *kueue.LocalQueue) bool {
 	return wl.Namespace == q.Namespace && wl.Spec.QueueName == q.Name
 }
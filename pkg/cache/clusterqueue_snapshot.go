@@ -42,6 +42,25 @@ type ClusterQueueSnapshot struct {
 	Preemption        kueue.ClusterQueuePreemption
 	FairWeight        resource.Quantity
 	FlavorFungibility kueue.FlavorFungibility
+	QueueingStrategy  kueue.QueueingStrategy
+	// FlavorSelectionPolicy indicates how the flavors of the resource groups
+	// are chosen.
+	FlavorSelectionPolicy kueue.FlavorSelectionPolicy
+	// AdmissionFairSharing indicates whether the workloads are ordered by
+	// the share of their LocalQueues.
+	AdmissionFairSharing bool
+	// PriorityAging raises the priority of the pending workloads as they
+	// wait, or nil if the ClusterQueue has no priority aging.
+	PriorityAging *kueue.PriorityAging
+	// MaxAdmittedWorkloads is the maximum number of workloads reserving
+	// quota, or nil if the number isn't limited.
+	MaxAdmittedWorkloads *int32
+	// ReservingWorkloads is the number of workloads reserving quota,
+	// including the workloads assumed during the scheduling cycle.
+	ReservingWorkloads int
+	// AverageUsage is the usage per resource averaged over the past, when
+	// fair sharing accounts for the historical usage. Otherwise, it's nil.
+	AverageUsage map[corev1.ResourceName]int64
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
@@ -55,6 +74,137 @@ type ClusterQueueSnapshot struct {
 	hierarchy.ClusterQueue[*CohortSnapshot]
 
 	TASFlavors map[kueue.ResourceFlavorReference]*TASFlavorSnapshot
+
+	// LocalQueues holds the quotas and the usage of the LocalQueues which
+	// declare quotas or a maximum number of admitted workloads, or of all the
+	// LocalQueues if the ClusterQueue uses admission fair sharing, by
+	// (namespace/name).
+	LocalQueues map[string]*LocalQueueSnapshot
+
+	// reservations holds the capacity held back by the Reservations whose
+	// capacity can be used by the workloads of the ClusterQueue, by name.
+	reservations map[string]*reservationSnapshot
+}
+
+// LocalQueueSnapshot holds the quotas of a LocalQueue and the resources
+// reserved by its workloads.
+type LocalQueueSnapshot struct {
+	Quotas     resources.FlavorResourceQuantities
+	Usage      resources.FlavorResourceQuantities
+	FairWeight resource.Quantity
+	// AverageUsage is the usage per resource averaged over the past, when
+	// fair sharing accounts for the historical usage. Otherwise, it's nil.
+	AverageUsage map[corev1.ResourceName]int64
+	// MaxAdmittedWorkloads is the maximum number of workloads reserving
+	// quota, or nil if the number isn't limited.
+	MaxAdmittedWorkloads *int32
+	// ReservingWorkloads is the number of workloads reserving quota,
+	// including the workloads assumed during the scheduling cycle.
+	ReservingWorkloads int
+}
+
+// Available returns the quota that remains available for the LocalQueue in
+// the flavor resource, and whether the LocalQueue declares a quota for it.
+func (q *LocalQueueSnapshot) Available(fr resources.FlavorResource) (int64, bool) {
+	maxUsage, ok := q.Quotas[fr]
+	if !ok {
+		return 0, false
+	}
+	return max(0, maxUsage-q.Usage[fr]), true
+}
+
+// OverQuota returns true if the usage of the LocalQueue exceeds any of its
+// quotas, which can happen after the quotas are lowered.
+func (q *LocalQueueSnapshot) OverQuota() bool {
+	for fr, maxUsage := range q.Quotas {
+		if q.Usage[fr] > maxUsage {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalQueueFor returns the snapshot of the LocalQueue of the workload, or
+// nil if the LocalQueue doesn't declare quotas nor a maximum number of
+// admitted workloads.
+func (c *ClusterQueueSnapshot) LocalQueueFor(wl *kueue.Workload) *LocalQueueSnapshot {
+	return c.LocalQueues[workload.QueueKey(wl)]
+}
+
+// LocalQueueShare returns the share of the LocalQueue of the workload, when
+// the ClusterQueue uses admission fair sharing. Otherwise, it returns 0.
+func (c *ClusterQueueSnapshot) LocalQueueShare(wl *kueue.Workload) int {
+	lq := c.LocalQueueFor(wl)
+	if !c.AdmissionFairSharing || lq == nil {
+		return 0
+	}
+	return lq.share(c.ResourceNode.Quotas)
+}
+
+// share returns the share of the LocalQueue, given the quotas of its
+// ClusterQueue: based on its average usage in the past if the shares account
+// for the historical usage, or on its current usage otherwise.
+// The queues and the scheduler use it for the same shares.
+func (q *LocalQueueSnapshot) share(quotas map[resources.FlavorResource]ResourceQuota) int {
+	usage := q.AverageUsage
+	if usage == nil {
+		usage = usageByResource(q.Usage)
+	}
+	return localQueueShare(usage, quotas, &q.FairWeight)
+}
+
+// FitsInLocalQueue returns true if the usage fits in the quotas of the
+// LocalQueue of the workload.
+func (c *ClusterQueueSnapshot) FitsInLocalQueue(wl *kueue.Workload, usage resources.FlavorResourceQuantities) bool {
+	lq := c.LocalQueueFor(wl)
+	if lq == nil {
+		return true
+	}
+	for fr, q := range usage {
+		if available, limited := lq.Available(fr); limited && available < q {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxAdmittedWorkloadsReached returns whether count more workloads of the
+// LocalQueue of the workload would exceed the maximum number of admitted
+// workloads of the ClusterQueue, and of the LocalQueue.
+func (c *ClusterQueueSnapshot) MaxAdmittedWorkloadsReached(wl *kueue.Workload, count int) (bool, bool) {
+	cqReached := c.MaxAdmittedWorkloads != nil && c.ReservingWorkloads+count > int(*c.MaxAdmittedWorkloads)
+	lq := c.LocalQueueFor(wl)
+	lqReached := lq != nil && lq.MaxAdmittedWorkloads != nil && lq.ReservingWorkloads+count > int(*lq.MaxAdmittedWorkloads)
+	return cqReached, lqReached
+}
+
+// AddReservingWorkload counts the workload, assumed during the scheduling
+// cycle, towards the maximum numbers of admitted workloads.
+func (c *ClusterQueueSnapshot) AddReservingWorkload(wl *kueue.Workload) {
+	c.updateReservingWorkloads(wl, add)
+}
+
+func (c *ClusterQueueSnapshot) updateReservingWorkloads(wl *kueue.Workload, op usageOp) {
+	m := 1
+	if op == subtract {
+		m = -1
+	}
+	c.ReservingWorkloads += m
+	if lq := c.LocalQueueFor(wl); lq != nil {
+		lq.ReservingWorkloads += m
+	}
+}
+
+func (c *ClusterQueueSnapshot) updateLocalQueueUsage(wl *workload.Info, op usageOp) {
+	lq := c.LocalQueueFor(wl.Obj)
+	if lq == nil {
+		return
+	}
+	m := int64(1)
+	if op == subtract {
+		m = -1
+	}
+	updateFlavorUsage(wl.FlavorResourceUsage(), lq.Usage, m)
 }
 
 // RGByResource returns the ResourceGroup which contains capacity
@@ -79,10 +229,22 @@ func (c *ClusterQueueSnapshot) SimulateUsageRemoval(workloads []*workload.Info)
 	for _, u := range usage {
 		c.RemoveUsage(u)
 	}
+	for _, w := range workloads {
+		c.updateLocalQueueUsage(w, subtract)
+		if w.ClusterQueue == c.Name {
+			c.updateReservingWorkloads(w.Obj, subtract)
+		}
+	}
 	return func() {
 		for _, u := range usage {
 			c.AddUsage(u)
 		}
+		for _, w := range workloads {
+			c.updateLocalQueueUsage(w, add)
+			if w.ClusterQueue == c.Name {
+				c.updateReservingWorkloads(w.Obj, add)
+			}
+		}
 	}
 }
 
@@ -182,6 +344,12 @@ func (c *ClusterQueueSnapshot) DominantResourceShare() int {
 	return share
 }
 
+// HistoricalShare returns the share of the ClusterQueue according to its
+// average usage in the past.
+func (c *ClusterQueueSnapshot) HistoricalShare() int {
+	return historicalShare(c, c.AverageUsage)
+}
+
 func (c *ClusterQueueSnapshot) DominantResourceShareWith(wlReq resources.FlavorResourceQuantities) int {
 	share, _ := dominantResourceShare(c, wlReq)
 	return share
@@ -17,6 +17,9 @@ limitations under the License.
 package cache
 
 import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
@@ -29,7 +32,8 @@ type cohort struct {
 	Name kueue.CohortReference
 	hierarchy.Cohort[*clusterQueue, *cohort]
 
-	resourceNode ResourceNode
+	resourceNode    ResourceNode
+	scheduledQuotas scheduledQuotas
 
 	FairWeight resource.Quantity
 }
@@ -42,10 +46,11 @@ func newCohort(name kueue.CohortReference) *cohort {
 	}
 }
 
-func (c *cohort) updateCohort(cycleChecker hierarchy.CycleChecker, apiCohort *kueuealpha.Cohort, oldParent *cohort) error {
+func (c *cohort) updateCohort(cycleChecker hierarchy.CycleChecker, apiCohort *kueuealpha.Cohort, oldParent *cohort, now time.Time) error {
 	c.FairWeight = parseFairWeight(apiCohort.Spec.FairSharing)
 
-	c.resourceNode.Quotas = createResourceQuotas(apiCohort.Spec.ResourceGroups)
+	c.scheduledQuotas = createScheduledQuotas(apiCohort.Spec.ResourceGroups)
+	c.resourceNode.Quotas = c.scheduledQuotas.resolve(now)
 	if oldParent != nil && oldParent != c.Parent() {
 		// ignore error when old Cohort has cycle.
 		_ = updateCohortTreeResources(oldParent, cycleChecker)
@@ -53,6 +58,20 @@ func (c *cohort) updateCohort(cycleChecker hierarchy.CycleChecker, apiCohort *ku
 	return updateCohortTreeResources(c, cycleChecker)
 }
 
+// resolveQuotas updates the Quotas to the values effective at the provided
+// time. It returns true if they changed.
+func (c *cohort) resolveQuotas(now time.Time) bool {
+	if len(c.scheduledQuotas.schedules) == 0 {
+		return false
+	}
+	quotas := c.scheduledQuotas.resolve(now)
+	if equality.Semantic.DeepEqual(quotas, c.resourceNode.Quotas) {
+		return false
+	}
+	c.resourceNode.Quotas = quotas
+	return true
+}
+
 func (c *cohort) GetName() kueue.CohortReference {
 	return c.Name
 }
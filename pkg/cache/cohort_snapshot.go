@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -73,6 +74,18 @@ func (c *CohortSnapshot) DominantResourceShare() int {
 	return share
 }
 
+// HistoricalShare returns the share of the Cohort according to the average
+// usage in the past of the ClusterQueues in its subtree.
+func (c *CohortSnapshot) HistoricalShare() int {
+	averageUsage := make(map[corev1.ResourceName]int64)
+	for _, cq := range c.SubtreeClusterQueues() {
+		for rName, u := range cq.AverageUsage {
+			averageUsage[rName] += u
+		}
+	}
+	return historicalShare(c, averageUsage)
+}
+
 // The methods below implement hierarchicalResourceNode interface.
 
 func (c *CohortSnapshot) getResourceNode() ResourceNode {
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"math"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -99,6 +100,94 @@ func calculateLendable(node hierarchicalResourceNode) map[corev1.ResourceName]in
 	return lendable
 }
 
+// historicalShare returns a value representing the maximum of the ratios
+// of the average usage in the past above the nominal quota to the lendable
+// resources in the cohort, among all the resources provided by the node, and
+// divided by the weight.
+// If zero, it means that the node didn't use more than its nominal quota.
+// Also for a weight of zero, this will return 9223372036854775807.
+func historicalShare(node dominantResourceShareNode, averageUsage map[corev1.ResourceName]int64) int {
+	if !node.HasParent() {
+		return 0
+	}
+	if node.fairWeight().IsZero() {
+		return math.MaxInt
+	}
+	quota := make(map[corev1.ResourceName]int64, len(averageUsage))
+	for fr, q := range node.getResourceNode().SubtreeQuota {
+		quota[fr.Resource] += q
+	}
+	lendable := calculateLendable(node.parentHRN())
+	var share int64
+	for rName, u := range averageUsage {
+		if lr := lendable[rName]; lr > 0 && u > quota[rName] {
+			share = max(share, (u-quota[rName])*1000/lr)
+		}
+	}
+	return int(share * 1000 / node.fairWeight().MilliValue())
+}
+
+// consumedResources holds the resource-seconds consumed in the past by a
+// ClusterQueue or a LocalQueue, as persisted in its status.
+type consumedResources struct {
+	resources  corev1.ResourceList
+	lastUpdate time.Time
+}
+
+func consumedResourcesFrom(fs *kueue.FairSharingStatus) consumedResources {
+	if fs == nil || fs.ConsumedResources == nil {
+		return consumedResources{}
+	}
+	return consumedResources{
+		resources:  fs.ConsumedResources.Resources,
+		lastUpdate: fs.ConsumedResources.LastUpdate.Time,
+	}
+}
+
+// averageUsage returns the usage per resource, averaged over the past with
+// exponentially decaying weights, given the half-life time.
+// A constant usage u results in consumed resource-seconds of u*halfLife/ln2.
+func (c *consumedResources) averageUsage(now time.Time, halfLife time.Duration) map[corev1.ResourceName]int64 {
+	usage := make(map[corev1.ResourceName]int64, len(c.resources))
+	elapsed := max(0, now.Sub(c.lastUpdate).Seconds())
+	factor := math.Exp2(-elapsed/halfLife.Seconds()) * math.Ln2 / halfLife.Seconds()
+	for rName, q := range c.resources {
+		avg := resource.NewMilliQuantity(int64(math.Round(q.AsApproximateFloat64()*factor*1000)), resource.DecimalSI)
+		usage[rName] = resources.ResourceValue(rName, *avg)
+	}
+	return usage
+}
+
+// usageByResource aggregates the usage of all the flavors, per resource.
+func usageByResource(usage resources.FlavorResourceQuantities) map[corev1.ResourceName]int64 {
+	byResource := make(map[corev1.ResourceName]int64, len(usage))
+	for fr, u := range usage {
+		byResource[fr.Resource] += u
+	}
+	return byResource
+}
+
+// localQueueShare returns a value representing the maximum of the ratios of
+// the usage of a LocalQueue to the nominal quota of its ClusterQueue, among
+// all the resources, and divided by the weight of the LocalQueue.
+// For a weight of zero, this will return 9223372036854775807.
+func localQueueShare(usage map[corev1.ResourceName]int64, quotas map[resources.FlavorResource]ResourceQuota, weight *resource.Quantity) int {
+	if weight.IsZero() {
+		return math.MaxInt
+	}
+	nominal := make(map[corev1.ResourceName]int64, len(quotas))
+	for fr, q := range quotas {
+		nominal[fr.Resource] += q.Nominal
+	}
+	var share int64
+	for rName, u := range usage {
+		if n := nominal[rName]; n > 0 {
+			share = max(share, u*1000/n)
+		}
+	}
+	return int(share * 1000 / weight.MilliValue())
+}
+
 // parseFairWeight parses FairSharing.Weight if it exists,
 // or otherwise returns the default value of 1.
 func parseFairWeight(fs *kueue.FairSharing) resource.Quantity {
@@ -20,11 +20,14 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	testingclock "k8s.io/utils/clock/testing"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -693,3 +696,105 @@ func TestDominantResourceShare(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoricalShare(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	halfLife := time.Hour
+	// consumed returns the resource-seconds consumed by a constant usage
+	// of the given CPUs, as of the given last update.
+	consumed := func(cpus float64, lastUpdate time.Time) *kueue.FairSharingStatus {
+		return &kueue.FairSharingStatus{
+			ConsumedResources: &kueue.ConsumedResources{
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU: *resource.NewMilliQuantity(int64(math.Round(cpus*halfLife.Seconds()/math.Ln2*1000)), resource.DecimalSI),
+				},
+				LastUpdate: metav1.NewTime(lastUpdate),
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		status     *kueue.FairSharingStatus
+		wantUsage  map[corev1.ResourceName]int64
+		wantShares map[kueue.ClusterQueueReference]int
+	}{
+		"no consumed resources": {
+			wantUsage: map[corev1.ResourceName]int64{},
+			wantShares: map[kueue.ClusterQueueReference]int{
+				"cq":         0,
+				"lending-cq": 0,
+			},
+		},
+		"average usage below nominal quota": {
+			status: consumed(2, now),
+			wantUsage: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU: 2_000,
+			},
+			wantShares: map[kueue.ClusterQueueReference]int{
+				"cq":         0,
+				"lending-cq": 0,
+			},
+		},
+		"average usage above nominal quota": {
+			status: consumed(6, now),
+			wantUsage: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU: 6_000,
+			},
+			wantShares: map[kueue.ClusterQueueReference]int{
+				"cq":         400,
+				"lending-cq": 0,
+			},
+		},
+		"average usage decays since the last update": {
+			status: consumed(6, now.Add(-halfLife)),
+			wantUsage: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU: 3_000,
+			},
+			wantShares: map[kueue.ClusterQueueReference]int{
+				"cq":         100,
+				"lending-cq": 0,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cache := New(utiltesting.NewFakeClient(),
+				WithFairSharing(true),
+				WithUsageHalfLifeTime(halfLife),
+				WithClock(testingclock.NewFakeClock(now)),
+			)
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			cq := utiltesting.MakeClusterQueue("cq").
+				Cohort("test-cohort").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+				Obj()
+			cq.Status.FairSharing = tc.status
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed adding ClusterQueue: %v", err)
+			}
+			lendingCQ := utiltesting.MakeClusterQueue("lending-cq").
+				Cohort("test-cohort").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8").Obj()).
+				Obj()
+			if err := cache.AddClusterQueue(ctx, lendingCQ); err != nil {
+				t.Fatalf("Failed adding ClusterQueue: %v", err)
+			}
+
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantUsage, snapshot.ClusterQueue("cq").AverageUsage); diff != "" {
+				t.Errorf("Unexpected average usage (-want,+got):\n%s", diff)
+			}
+			gotShares := make(map[kueue.ClusterQueueReference]int)
+			for name, cq := range snapshot.ClusterQueues() {
+				gotShares[name] = cq.HistoricalShare()
+			}
+			if diff := cmp.Diff(tc.wantShares, gotShares); diff != "" {
+				t.Errorf("Unexpected historical shares (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
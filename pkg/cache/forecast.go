@@ -0,0 +1,200 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/hierarchy"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// runtimeHistoryWeight is the weight of the latest runtime in the moving
+// average of the runtimes of the workloads of a LocalQueue.
+const runtimeHistoryWeight = 0.2
+
+// RuntimeSource indicates how the runtime of a workload is estimated.
+type RuntimeSource int
+
+const (
+	// RuntimeUnknown means the runtime of the workload can't be estimated.
+	RuntimeUnknown RuntimeSource = iota
+	// RuntimeFromHistory means the runtime is the average runtime of the
+	// workloads of the LocalQueue which finished since Kueue started.
+	RuntimeFromHistory
+	// RuntimeFromMaxExecutionTime means the runtime is the maximum execution
+	// time of the workload.
+	RuntimeFromMaxExecutionTime
+)
+
+// Forecast is the quota available to the pending workloads of a
+// ClusterQueue, now and as the workloads reserving quota finish.
+type Forecast struct {
+	// Time is when the forecast was made.
+	Time             time.Time
+	QueueingStrategy kueue.QueueingStrategy
+	// ResourceGroups are the resource groups of the ClusterQueue, to check
+	// in which flavors the workloads fit.
+	ResourceGroups []ResourceGroup
+	// Available is the quota available now, per flavor and resource. It
+	// includes the quota the ClusterQueue can borrow from its Cohort.
+	Available resources.FlavorResourceQuantities
+	// Borrowable is the part of the available quota which is borrowed
+	// from the Cohort.
+	Borrowable resources.FlavorResourceQuantities
+	// Releases are the quota released by the workloads reserving quota,
+	// when they are expected to finish, in chronological order. The
+	// workloads whose runtime can't be estimated aren't included.
+	Releases []QuotaRelease
+
+	// runtimes are the average runtimes by LocalQueue (namespace/name).
+	runtimes map[string]time.Duration
+}
+
+// QuotaRelease is the quota released by a workload when it finishes.
+type QuotaRelease struct {
+	Time   time.Time
+	Usage  resources.FlavorResourceQuantities
+	Source RuntimeSource
+}
+
+// ObserveFinishedWorkload accounts the runtime of a workload, which finished
+// after being admitted, in the average runtime of its LocalQueue.
+func (c *Cache) ObserveFinishedWorkload(w *kueue.Workload) {
+	admittedCond := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadAdmitted)
+	finishedCond := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadFinished)
+	if admittedCond == nil || admittedCond.Status != metav1.ConditionTrue ||
+		finishedCond == nil || finishedCond.Status != metav1.ConditionTrue {
+		return
+	}
+	runtime := finishedCond.LastTransitionTime.Sub(admittedCond.LastTransitionTime.Time)
+	if runtime < 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	key := workloadQueueKey(w)
+	if average, found := c.runtimes[key]; found {
+		runtime = average + time.Duration(runtimeHistoryWeight*float64(runtime-average))
+	}
+	c.runtimes[key] = runtime
+}
+
+// Forecast returns the quota forecast of the ClusterQueue.
+func (c *Cache) Forecast(name kueue.ClusterQueueReference) (*Forecast, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueue(name)
+	if cq == nil {
+		return nil, ErrCqNotFound
+	}
+	now := c.clock.Now()
+	canBorrow := cq.HasParent() && !hierarchy.HasCycle[kueue.CohortReference](cq.Parent())
+	f := &Forecast{
+		Time:             now,
+		QueueingStrategy: cq.QueueingStrategy,
+		ResourceGroups:   make([]ResourceGroup, 0, len(cq.ResourceGroups)),
+		Available:        make(resources.FlavorResourceQuantities),
+		Borrowable:       make(resources.FlavorResourceQuantities),
+		runtimes:         maps.Clone(c.runtimes),
+	}
+	for _, rg := range cq.ResourceGroups {
+		f.ResourceGroups = append(f.ResourceGroups, ResourceGroup{
+			CoveredResources: rg.CoveredResources.Clone(),
+			Flavors:          slices.Clone(rg.Flavors),
+		})
+		for _, fName := range rg.Flavors {
+			for rName := range rg.CoveredResources {
+				fr := resources.FlavorResource{Flavor: fName, Resource: rName}
+				nominalAvailable := max(0, cq.resourceNode.Quotas[fr].Nominal-cq.resourceNode.Usage[fr])
+				if !canBorrow {
+					f.Available[fr] = nominalAvailable
+					continue
+				}
+				available := max(0, available(cq, fr))
+				f.Available[fr] = available
+				f.Borrowable[fr] = max(0, available-nominalAvailable)
+			}
+		}
+	}
+	for _, wi := range cq.Workloads {
+		if end, source := f.expectedEnd(wi.Obj, now); source != RuntimeUnknown {
+			f.Releases = append(f.Releases, QuotaRelease{
+				Time:   end,
+				Usage:  maps.Clone(wi.FlavorResourceUsage()),
+				Source: source,
+			})
+		}
+	}
+	slices.SortStableFunc(f.Releases, func(a, b QuotaRelease) int {
+		return a.Time.Compare(b.Time)
+	})
+	return f, nil
+}
+
+// Runtime returns the expected runtime of the workload: its remaining
+// maximum execution time, if any, or the average runtime of the workloads
+// of its LocalQueue.
+func (f *Forecast) Runtime(w *kueue.Workload) (time.Duration, RuntimeSource) {
+	if w.Spec.MaximumExecutionTimeSeconds != nil {
+		remaining := *w.Spec.MaximumExecutionTimeSeconds - ptr.Deref(w.Status.AccumulatedPastExexcutionTimeSeconds, 0)
+		return time.Duration(max(0, remaining)) * time.Second, RuntimeFromMaxExecutionTime
+	}
+	if average, found := f.runtimes[workloadQueueKey(w)]; found {
+		return average, RuntimeFromHistory
+	}
+	return 0, RuntimeUnknown
+}
+
+// expectedEnd returns when the workload reserving quota is expected to
+// finish, not earlier than now.
+func (f *Forecast) expectedEnd(w *kueue.Workload, now time.Time) (time.Time, RuntimeSource) {
+	if deadline, found := workload.MaxExecutionDeadline(w); found {
+		return later(deadline, now), RuntimeFromMaxExecutionTime
+	}
+	runtime, source := f.Runtime(w)
+	if source == RuntimeUnknown {
+		return time.Time{}, RuntimeUnknown
+	}
+	start := now
+	if admittedCond := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadAdmitted); admittedCond != nil && admittedCond.Status == metav1.ConditionTrue {
+		start = admittedCond.LastTransitionTime.Time
+	}
+	return later(start.Add(runtime), now), source
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func workloadQueueKey(w *kueue.Workload) string {
+	return fmt.Sprintf("%s/%s", w.Namespace, w.Spec.QueueName)
+}
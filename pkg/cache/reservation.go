@@ -0,0 +1,237 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"maps"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// reservation holds back quota of a ClusterQueue or a Cohort for the
+// workloads naming it, from drainStart until end. The workloads naming the
+// reservation can use the held capacity from start.
+type reservation struct {
+	name         string
+	clusterQueue kueue.ClusterQueueReference
+	cohort       kueue.CohortReference
+	quantities   resources.FlavorResourceQuantities
+	drainStart   time.Time
+	start        time.Time
+	end          time.Time
+}
+
+// ReservationWindow returns the time at which the Reservation starts holding
+// back capacity, along with the start and the end of its time window.
+func ReservationWindow(r *kueuealpha.Reservation) (time.Time, time.Time, time.Time) {
+	start := r.Spec.StartTime.Time
+	drainStart := start
+	if r.Spec.DrainDuration != nil {
+		drainStart = start.Add(-r.Spec.DrainDuration.Duration)
+	}
+	return drainStart, start, start.Add(r.Spec.Duration.Duration)
+}
+
+func newReservation(r *kueuealpha.Reservation) *reservation {
+	res := &reservation{
+		name:         r.Name,
+		clusterQueue: r.Spec.ClusterQueue,
+		cohort:       r.Spec.Cohort,
+		quantities:   make(resources.FlavorResourceQuantities),
+	}
+	res.drainStart, res.start, res.end = ReservationWindow(r)
+	for _, f := range r.Spec.Flavors {
+		for name, q := range f.Resources {
+			res.quantities[resources.FlavorResource{Flavor: f.Name, Resource: name}] = resources.ResourceValue(name, q)
+		}
+	}
+	return res
+}
+
+// holdsCapacity returns whether the capacity is held back at the given time.
+func (r *reservation) holdsCapacity(now time.Time) bool {
+	return !now.Before(r.drainStart) && now.Before(r.end)
+}
+
+// inWindow returns whether the workloads naming the reservation can use the
+// held capacity at the given time.
+func (r *reservation) inWindow(now time.Time) bool {
+	return !now.Before(r.start) && now.Before(r.end)
+}
+
+// AddOrUpdateReservation adds or updates the Reservation in the cache. It
+// returns the ClusterQueues whose available quota could change, before and
+// after the update.
+func (c *Cache) AddOrUpdateReservation(r *kueuealpha.Reservation) sets.Set[kueue.ClusterQueueReference] {
+	c.Lock()
+	defer c.Unlock()
+	cqs := sets.New[kueue.ClusterQueueReference]()
+	if old, found := c.reservations[r.Name]; found {
+		cqs = c.reservationClusterQueues(old)
+	}
+	res := newReservation(r)
+	c.reservations[r.Name] = res
+	return cqs.Union(c.reservationClusterQueues(res))
+}
+
+// DeleteReservation removes the Reservation from the cache. It returns the
+// ClusterQueues whose available quota could change.
+func (c *Cache) DeleteReservation(name string) sets.Set[kueue.ClusterQueueReference] {
+	c.Lock()
+	defer c.Unlock()
+	old, found := c.reservations[name]
+	if !found {
+		return nil
+	}
+	delete(c.reservations, name)
+	return c.reservationClusterQueues(old)
+}
+
+// reservationClusterQueues returns the ClusterQueues whose workloads can use
+// the capacity of the reservation.
+func (c *Cache) reservationClusterQueues(r *reservation) sets.Set[kueue.ClusterQueueReference] {
+	if r.clusterQueue != "" {
+		return sets.New(r.clusterQueue)
+	}
+	cqs := sets.New[kueue.ClusterQueueReference]()
+	root := c.hm.Cohort(r.cohort)
+	if root == nil || c.hm.CycleChecker.HasCycle(root) {
+		return cqs
+	}
+	var collect func(*cohort)
+	collect = func(co *cohort) {
+		for _, cq := range co.ChildCQs() {
+			cqs.Insert(cq.Name)
+		}
+		for _, child := range co.ChildCohorts() {
+			collect(child)
+		}
+	}
+	collect(root)
+	return cqs
+}
+
+// reservationSnapshot is the capacity held back by a reservation in a
+// Snapshot. It's shared by the ClusterQueues whose workloads can use it.
+type reservationSnapshot struct {
+	// node is the ClusterQueue or Cohort whose quota is reserved.
+	node hierarchicalResourceNode
+	// held is the reserved capacity which isn't in use by the workloads
+	// naming the reservation, held back in the node separately from its
+	// usage.
+	held resources.FlavorResourceQuantities
+	// inWindow indicates whether the workloads naming the reservation can
+	// use the held capacity.
+	inWindow bool
+	released bool
+}
+
+// snapshotReservations accounts, in the snapshot, the capacity held back by
+// the reservations at the given time.
+func (c *Cache) snapshotReservations(snap *Snapshot, now time.Time) {
+	for _, r := range c.reservations {
+		if !r.holdsCapacity(now) {
+			continue
+		}
+		var node hierarchicalResourceNode
+		var cqs []*ClusterQueueSnapshot
+		if r.clusterQueue != "" {
+			cq := snap.ClusterQueue(r.clusterQueue)
+			if cq == nil {
+				continue
+			}
+			node, cqs = cq, []*ClusterQueueSnapshot{cq}
+		} else {
+			cohort := snap.Cohort(r.cohort)
+			if cohort == nil {
+				continue
+			}
+			node, cqs = cohort, cohort.SubtreeClusterQueues()
+		}
+		rs := &reservationSnapshot{
+			node:     node,
+			held:     maps.Clone(r.quantities),
+			inWindow: r.inWindow(now),
+		}
+		for _, cq := range cqs {
+			for _, wl := range cq.Workloads {
+				if wl.Obj.Labels[kueuealpha.ReservationLabel] != r.name {
+					continue
+				}
+				for fr, v := range wl.Usage().Quota {
+					if _, found := rs.held[fr]; found {
+						rs.held[fr] = max(0, rs.held[fr]-v)
+					}
+				}
+			}
+			if cq.reservations == nil {
+				cq.reservations = make(map[string]*reservationSnapshot)
+			}
+			cq.reservations[r.name] = rs
+		}
+		for fr, v := range rs.held {
+			addHeld(node, fr, v)
+		}
+	}
+}
+
+// ReleaseReservation makes the capacity held back by the Reservation named
+// by the workload available to it, if the time window of the Reservation
+// started. It returns the function which holds the capacity back again.
+func (c *ClusterQueueSnapshot) ReleaseReservation(wl *kueue.Workload) func() {
+	r := c.reservations[wl.Labels[kueuealpha.ReservationLabel]]
+	if r == nil || !r.inWindow || r.released {
+		return func() {}
+	}
+	r.released = true
+	for fr, v := range r.held {
+		removeHeld(r.node, fr, v)
+	}
+	return func() {
+		for fr, v := range r.held {
+			addHeld(r.node, fr, v)
+		}
+		r.released = false
+	}
+}
+
+// ConsumeReservation reduces the capacity held back by the Reservation named
+// by the workload by the quota that the workload uses, once the workload is
+// assumed in the snapshot, so that the capacity isn't counted both as held
+// and as used.
+func (c *ClusterQueueSnapshot) ConsumeReservation(wl *kueue.Workload, quota resources.FlavorResourceQuantities) {
+	r := c.reservations[wl.Labels[kueuealpha.ReservationLabel]]
+	if r == nil {
+		return
+	}
+	for fr, v := range quota {
+		held, found := r.held[fr]
+		if !found {
+			continue
+		}
+		consumed := min(held, v)
+		r.held[fr] = held - consumed
+		if !r.released {
+			removeHeld(r.node, fr, consumed)
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package cache
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
@@ -73,3 +75,159 @@ func createResourceQuotas(kueueRgs []kueue.ResourceGroup) map[resources.FlavorRe
 	}
 	return quotas
 }
+
+// quotaSchedule is the parsed representation of a kueue.QuotaSchedule.
+type quotaSchedule struct {
+	days     sets.Set[time.Weekday]
+	start    time.Duration
+	end      time.Duration
+	location *time.Location
+
+	nominal        *int64
+	borrowingLimit *int64
+	lendingLimit   *int64
+}
+
+var weekdays = map[kueue.DayOfWeek]time.Weekday{
+	kueue.Sunday:    time.Sunday,
+	kueue.Monday:    time.Monday,
+	kueue.Tuesday:   time.Tuesday,
+	kueue.Wednesday: time.Wednesday,
+	kueue.Thursday:  time.Thursday,
+	kueue.Friday:    time.Friday,
+	kueue.Saturday:  time.Saturday,
+}
+
+// parseTimeOfDay returns the offset from midnight of a "HH:MM" time.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func newQuotaSchedule(name corev1.ResourceName, in *kueue.QuotaSchedule) (*quotaSchedule, error) {
+	start, err := parseTimeOfDay(in.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeOfDay(in.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	location := time.UTC
+	if in.TimeZone != nil {
+		if location, err = time.LoadLocation(*in.TimeZone); err != nil {
+			return nil, err
+		}
+	}
+	qs := &quotaSchedule{
+		days:     sets.New[time.Weekday](),
+		start:    start,
+		end:      end,
+		location: location,
+	}
+	for _, d := range in.DaysOfWeek {
+		qs.days.Insert(weekdays[d])
+	}
+	if in.NominalQuota != nil {
+		qs.nominal = ptr.To(resources.ResourceValue(name, *in.NominalQuota))
+	}
+	if in.BorrowingLimit != nil {
+		qs.borrowingLimit = ptr.To(resources.ResourceValue(name, *in.BorrowingLimit))
+	}
+	if in.LendingLimit != nil {
+		qs.lendingLimit = ptr.To(resources.ResourceValue(name, *in.LendingLimit))
+	}
+	return qs, nil
+}
+
+// activeAt returns true if the window of the schedule contains the provided
+// time. A window which ends the following day is considered to belong to the
+// day on which it starts.
+func (s *quotaSchedule) activeAt(now time.Time) bool {
+	now = now.In(s.location)
+	length := s.end - s.start
+	if length <= 0 {
+		length += 24 * time.Hour
+	}
+	for _, dayOffset := range []int{0, -1} {
+		y, m, d := now.AddDate(0, 0, dayOffset).Date()
+		windowStart := time.Date(y, m, d, 0, 0, 0, 0, s.location).Add(s.start)
+		if s.days.Len() > 0 && !s.days.Has(windowStart.Weekday()) {
+			continue
+		}
+		if !now.Before(windowStart) && now.Before(windowStart.Add(length)) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the quota with the values overridden by the schedule.
+func (s *quotaSchedule) apply(quota ResourceQuota) ResourceQuota {
+	if s.nominal != nil {
+		quota.Nominal = *s.nominal
+	}
+	if s.borrowingLimit != nil {
+		quota.BorrowingLimit = s.borrowingLimit
+	}
+	if features.Enabled(features.LendingLimit) && s.lendingLimit != nil {
+		quota.LendingLimit = s.lendingLimit
+	}
+	return quota
+}
+
+// scheduledQuotas holds the ResourceQuotas of a node, as specified in the
+// API, together with the schedules overriding them.
+type scheduledQuotas struct {
+	quotas    map[resources.FlavorResource]ResourceQuota
+	schedules map[resources.FlavorResource][]*quotaSchedule
+}
+
+func createScheduledQuotas(kueueRgs []kueue.ResourceGroup) scheduledQuotas {
+	sq := scheduledQuotas{
+		quotas: createResourceQuotas(kueueRgs),
+	}
+	if !features.Enabled(features.QuotaSchedules) {
+		return sq
+	}
+	for _, kueueRg := range kueueRgs {
+		for _, kueueFlavor := range kueueRg.Flavors {
+			for _, kueueQuota := range kueueFlavor.Resources {
+				fr := resources.FlavorResource{Flavor: kueueFlavor.Name, Resource: kueueQuota.Name}
+				for i := range kueueQuota.Schedules {
+					// Invalid schedules are rejected by the webhook, skip them defensively.
+					qs, err := newQuotaSchedule(kueueQuota.Name, &kueueQuota.Schedules[i])
+					if err != nil {
+						continue
+					}
+					if sq.schedules == nil {
+						sq.schedules = make(map[resources.FlavorResource][]*quotaSchedule)
+					}
+					sq.schedules[fr] = append(sq.schedules[fr], qs)
+				}
+			}
+		}
+	}
+	return sq
+}
+
+// resolve returns the ResourceQuotas effective at the provided time.
+func (sq *scheduledQuotas) resolve(now time.Time) map[resources.FlavorResource]ResourceQuota {
+	if len(sq.schedules) == 0 {
+		return sq.quotas
+	}
+	quotas := make(map[resources.FlavorResource]ResourceQuota, len(sq.quotas))
+	for fr, quota := range sq.quotas {
+		for _, qs := range sq.schedules[fr] {
+			if qs.activeAt(now) {
+				quota = qs.apply(quota)
+				break
+			}
+		}
+		quotas[fr] = quota
+	}
+	return quotas
+}
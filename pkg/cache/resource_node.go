@@ -39,6 +39,12 @@ type ResourceNode struct {
 	// usage. For Cohorts, this is the sum of childrens'
 	// usages past childrens' guaranteedQuotas.
 	Usage resources.FlavorResourceQuantities
+	// Held is the capacity held back by Reservations, which isn't
+	// available to the node but doesn't count as its usage. For
+	// Cohorts, this is the capacity held back by the children
+	// past their guaranteedQuotas and their usages. It's only tracked
+	// in the snapshots.
+	Held resources.FlavorResourceQuantities
 }
 
 func NewResourceNode() ResourceNode {
@@ -49,13 +55,18 @@ func NewResourceNode() ResourceNode {
 	}
 }
 
-// Clone clones the mutable field Usage, while returning copies to
+// Clone clones the mutable fields Usage and Held, while returning copies to
 // Quota and SubtreeQuota (these are replaced with new maps upon update).
 func (r ResourceNode) Clone() ResourceNode {
+	held := maps.Clone(r.Held)
+	if held == nil {
+		held = make(resources.FlavorResourceQuantities)
+	}
 	return ResourceNode{
 		Quotas:       r.Quotas,
 		SubtreeQuota: r.SubtreeQuota,
 		Usage:        maps.Clone(r.Usage),
+		Held:         held,
 	}
 }
 
@@ -68,6 +79,19 @@ func (r ResourceNode) guaranteedQuota(fr resources.FlavorResource) int64 {
 	return 0
 }
 
+// consumed is the capacity of the node which is either used or held back.
+func (r ResourceNode) consumed(fr resources.FlavorResource) int64 {
+	return r.Usage[fr] + r.Held[fr]
+}
+
+// storedInParent returns the usage and the held capacity which the node
+// stores in its Cohort, past its guaranteedQuota. The usage takes the
+// guaranteedQuota first.
+func (r ResourceNode) storedInParent(fr resources.FlavorResource) (int64, int64) {
+	usage := max(0, r.Usage[fr]-r.guaranteedQuota(fr))
+	return usage, max(0, r.consumed(fr)-r.guaranteedQuota(fr)) - usage
+}
+
 // hierarchicalResourceNode abstracts over ClusterQueues and Cohorts,
 // by providing access to the contained ResourceNode, with the ability
 // to navigate to the parent node.
@@ -84,20 +108,22 @@ type hierarchicalResourceNode interface {
 // queries the parent's capacity, limiting this amount by the borrowing
 // limit - and by how much capacity the node is storing/using in its parent.
 //
+// The capacity held back by Reservations isn't available.
+//
 // This function may return a negative number in the case of
 // overadmission - e.g. capacity was removed or the node moved to
 // another Cohort.
 func available(node hierarchicalResourceNode, fr resources.FlavorResource) int64 {
 	r := node.getResourceNode()
 	if !node.HasParent() {
-		return r.SubtreeQuota[fr] - r.Usage[fr]
+		return r.SubtreeQuota[fr] - r.consumed(fr)
 	}
-	localAvailable := max(0, r.guaranteedQuota(fr)-r.Usage[fr])
+	localAvailable := max(0, r.guaranteedQuota(fr)-r.consumed(fr))
 	parentAvailable := available(node.parentHRN(), fr)
 
 	if borrowingLimit := r.Quotas[fr].BorrowingLimit; borrowingLimit != nil {
 		storedInParent := r.SubtreeQuota[fr] - r.guaranteedQuota(fr)
-		usedInParent := max(0, r.Usage[fr]-r.guaranteedQuota(fr))
+		usedInParent := max(0, r.consumed(fr)-r.guaranteedQuota(fr))
 		withMaxFromParent := storedInParent - usedInParent + *borrowingLimit
 		parentAvailable = min(withMaxFromParent, parentAvailable)
 	}
@@ -122,26 +148,47 @@ func potentialAvailable(node hierarchicalResourceNode, fr resources.FlavorResour
 // addUsage adds usage to the current node, and bubbles up usage to
 // its Cohort when usage exceeds guaranteedQuota.
 func addUsage(node hierarchicalResourceNode, fr resources.FlavorResource, val int64) {
-	r := node.getResourceNode()
-	localAvailable := max(0, r.guaranteedQuota(fr)-r.Usage[fr])
-	r.Usage[fr] += val
-	if node.HasParent() && val > localAvailable {
-		deltaParentUsage := val - localAvailable
-		addUsage(node.parentHRN(), fr, deltaParentUsage)
-	}
+	updateConsumption(node, fr, val, 0)
 }
 
 // removeUsage removes usage from the current node, and removes usage
 // past guaranteedQuota that it was storing in its Cohort.
 func removeUsage(node hierarchicalResourceNode, fr resources.FlavorResource, val int64) {
+	updateConsumption(node, fr, -val, 0)
+}
+
+// addHeld holds back capacity of the current node, and bubbles up the
+// held capacity to its Cohort when it exceeds guaranteedQuota.
+func addHeld(node hierarchicalResourceNode, fr resources.FlavorResource, val int64) {
+	updateConsumption(node, fr, 0, val)
+}
+
+// removeHeld releases held capacity of the current node, and the held
+// capacity past guaranteedQuota that it was storing in its Cohort.
+func removeHeld(node hierarchicalResourceNode, fr resources.FlavorResource, val int64) {
+	updateConsumption(node, fr, 0, -val)
+}
+
+// updateConsumption updates the usage and the held capacity of the current
+// node, and bubbles up the changes of what it stores in its Cohort.
+func updateConsumption(node hierarchicalResourceNode, fr resources.FlavorResource, deltaUsage, deltaHeld int64) {
 	r := node.getResourceNode()
-	usageStoredInParent := r.Usage[fr] - r.guaranteedQuota(fr)
-	r.Usage[fr] -= val
-	if usageStoredInParent <= 0 || !node.HasParent() {
+	oldUsage, oldHeld := r.storedInParent(fr)
+	// Only touch the maps which change, not to record empty usage of
+	// the held flavor resources.
+	if deltaUsage != 0 || deltaHeld == 0 {
+		r.Usage[fr] += deltaUsage
+	}
+	if deltaHeld != 0 {
+		r.Held[fr] += deltaHeld
+	}
+	if !node.HasParent() {
 		return
 	}
-	deltaParentUsage := min(val, usageStoredInParent)
-	removeUsage(node.parentHRN(), fr, deltaParentUsage)
+	newUsage, newHeld := r.storedInParent(fr)
+	if newUsage != oldUsage || newHeld != oldHeld {
+		updateConsumption(node.parentHRN(), fr, newUsage-oldUsage, newHeld-oldHeld)
+	}
 }
 
 func updateClusterQueueResourceNode(cq *clusterQueue) {
@@ -19,8 +19,11 @@ package cache
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -453,3 +456,57 @@ func TestAvailable(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotaScheduleActiveAt(t *testing.T) {
+	cases := map[string]struct {
+		schedule kueue.QuotaSchedule
+		now      time.Time
+		want     bool
+	}{
+		"within daily window": {
+			schedule: kueue.QuotaSchedule{StartTime: "09:00", EndTime: "17:00"},
+			now:      time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC),
+			want:     true,
+		},
+		"end of window is exclusive": {
+			schedule: kueue.QuotaSchedule{StartTime: "09:00", EndTime: "17:00"},
+			now:      time.Date(2025, time.March, 3, 17, 0, 0, 0, time.UTC),
+		},
+		"window spanning midnight, before midnight": {
+			schedule: kueue.QuotaSchedule{StartTime: "20:00", EndTime: "06:00"},
+			now:      time.Date(2025, time.March, 3, 23, 0, 0, 0, time.UTC),
+			want:     true,
+		},
+		"window spanning midnight, after midnight": {
+			schedule: kueue.QuotaSchedule{StartTime: "20:00", EndTime: "06:00"},
+			now:      time.Date(2025, time.March, 4, 5, 59, 0, 0, time.UTC),
+			want:     true,
+		},
+		"window spanning midnight belongs to the start day": {
+			// Saturday 02:00 belongs to the window started on Friday.
+			schedule: kueue.QuotaSchedule{StartTime: "20:00", EndTime: "06:00", DaysOfWeek: []kueue.DayOfWeek{kueue.Friday}},
+			now:      time.Date(2025, time.March, 8, 2, 0, 0, 0, time.UTC),
+			want:     true,
+		},
+		"day not listed": {
+			schedule: kueue.QuotaSchedule{StartTime: "09:00", EndTime: "17:00", DaysOfWeek: []kueue.DayOfWeek{kueue.Saturday, kueue.Sunday}},
+			now:      time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC),
+		},
+		"time zone": {
+			schedule: kueue.QuotaSchedule{StartTime: "09:00", EndTime: "17:00", TimeZone: ptr.To("America/New_York")},
+			// 08:00 in UTC is 03:00 in New York.
+			now: time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			qs, err := newQuotaSchedule(corev1.ResourceCPU, &tc.schedule)
+			if err != nil {
+				t.Fatalf("Failed to parse the schedule: %v", err)
+			}
+			if got := qs.activeAt(tc.now); got != tc.want {
+				t.Errorf("Unexpected activeAt, got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
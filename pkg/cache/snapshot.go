@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -45,6 +46,8 @@ func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 	cq := s.ClusterQueue(wl.ClusterQueue)
 	delete(cq.Workloads, workload.Key(wl.Obj))
 	cq.RemoveUsage(wl.Usage())
+	cq.updateLocalQueueUsage(wl, subtract)
+	cq.updateReservingWorkloads(wl.Obj, subtract)
 }
 
 // AddWorkload adds a workload from its corresponding ClusterQueue and
@@ -53,6 +56,52 @@ func (s *Snapshot) AddWorkload(wl *workload.Info) {
 	cq := s.ClusterQueue(wl.ClusterQueue)
 	cq.Workloads[workload.Key(wl.Obj)] = wl
 	cq.AddUsage(wl.Usage())
+	cq.updateLocalQueueUsage(wl, add)
+	cq.updateReservingWorkloads(wl.Obj, add)
+}
+
+// Partition splits the snapshot into snapshots of the independent cohort
+// trees, which can be scheduled concurrently. The trees of the ClusterQueues
+// sharing a TAS flavor are kept in the same snapshot. The snapshots share the
+// ClusterQueues, Cohorts and ResourceFlavors with s.
+func (s *Snapshot) Partition() []*Snapshot {
+	managers := s.Manager.Partition()
+	group := make([]int, len(managers))
+	for i := range group {
+		group[i] = i
+	}
+	find := func(i int) int {
+		for group[i] != i {
+			i = group[i]
+		}
+		return i
+	}
+	tasOwners := make(map[*TASFlavorSnapshot]int)
+	for i := range managers {
+		for _, cq := range managers[i].ClusterQueues() {
+			for _, tasSnapshot := range cq.TASFlavors {
+				if j, found := tasOwners[tasSnapshot]; found {
+					group[find(i)] = find(j)
+				} else {
+					tasOwners[tasSnapshot] = i
+				}
+			}
+		}
+	}
+	snapshots := make(map[int]*Snapshot, len(managers))
+	for i := range managers {
+		root := find(i)
+		if snap, found := snapshots[root]; found {
+			snap.Merge(managers[i])
+			continue
+		}
+		snapshots[root] = &Snapshot{
+			Manager:                  managers[i],
+			ResourceFlavors:          s.ResourceFlavors,
+			InactiveClusterQueueSets: s.InactiveClusterQueueSets,
+		}
+	}
+	return slices.Collect(maps.Values(snapshots))
 }
 
 func (s *Snapshot) Log(log logr.Logger) {
@@ -67,6 +116,7 @@ func (s *Snapshot) Log(log logr.Logger) {
 			"cohort", cohortName,
 			"resourceGroups", cq.ResourceGroups,
 			"usage", cq.ResourceNode.Usage,
+			"held", cq.ResourceNode.Held,
 			"workloads", slices.Collect(maps.Keys(cq.Workloads)),
 		)
 	}
@@ -75,6 +125,7 @@ func (s *Snapshot) Log(log logr.Logger) {
 			"cohort", name,
 			"resources", cohort.ResourceNode.SubtreeQuota,
 			"usage", cohort.ResourceNode.Usage,
+			"held", cohort.ResourceNode.Held,
 		)
 	}
 
@@ -121,6 +172,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 			snap.UpdateCohortEdge(cohort.Name, cohort.Parent().Name)
 		}
 	}
+	now := c.clock.Now()
 	tasSnapshots := make(map[kueue.ResourceFlavorReference]*TASFlavorSnapshot)
 	if features.Enabled(features.TopologyAwareScheduling) {
 		for key, cache := range c.tasCache.Clone() {
@@ -138,6 +190,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 			continue
 		}
 		cqSnapshot := snapshotClusterQueue(cq)
+		c.snapshotAverageUsage(cq, cqSnapshot, now)
 		snap.AddClusterQueue(cqSnapshot)
 		if cq.HasParent() {
 			snap.UpdateClusterQueueEdge(cq.Name, cq.Parent().Name)
@@ -150,6 +203,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 			}
 		}
 	}
+	c.snapshotReservations(&snap, now)
 	for name, rf := range c.resourceFlavors {
 		// Shallow copy is enough
 		snap.ResourceFlavors[name] = rf
@@ -157,6 +211,18 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 	return &snap, nil
 }
 
+// snapshotAverageUsage sets the average usage in the past of the ClusterQueue
+// and its LocalQueues, when the shares account for the historical usage.
+func (c *Cache) snapshotAverageUsage(cq *clusterQueue, cc *ClusterQueueSnapshot, now time.Time) {
+	if c.usageHalfLifeTime <= 0 {
+		return
+	}
+	cc.AverageUsage = cq.consumed.averageUsage(now, c.usageHalfLifeTime)
+	for key, lq := range cc.LocalQueues {
+		lq.AverageUsage = cq.localQueues[key].consumed.averageUsage(now, c.usageHalfLifeTime)
+	}
+}
+
 // snapshotClusterQueue creates a copy of ClusterQueue that includes
 // references to immutable objects and deep copies of changing ones.
 func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
@@ -164,7 +230,13 @@ func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 		Name:                          c.Name,
 		ResourceGroups:                make([]ResourceGroup, len(c.ResourceGroups)),
 		FlavorFungibility:             c.FlavorFungibility,
+		QueueingStrategy:              c.QueueingStrategy,
+		FlavorSelectionPolicy:         c.FlavorSelectionPolicy,
 		FairWeight:                    c.FairWeight,
+		AdmissionFairSharing:          c.AdmissionFairSharing,
+		PriorityAging:                 c.PriorityAging,
+		MaxAdmittedWorkloads:          c.MaxAdmittedWorkloads,
+		ReservingWorkloads:            len(c.Workloads),
 		AllocatableResourceGeneration: c.AllocatableResourceGeneration,
 		Workloads:                     maps.Clone(c.Workloads),
 		Preemption:                    c.Preemption,
@@ -173,10 +245,22 @@ func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 		AdmissionChecks:               utilmaps.DeepCopySets[kueue.ResourceFlavorReference](c.AdmissionChecks),
 		ResourceNode:                  c.resourceNode.Clone(),
 		TASFlavors:                    make(map[kueue.ResourceFlavorReference]*TASFlavorSnapshot),
+		LocalQueues:                   make(map[string]*LocalQueueSnapshot),
 	}
 	for i, rg := range c.ResourceGroups {
 		cc.ResourceGroups[i] = rg.Clone()
 	}
+	for key, lq := range c.localQueues {
+		if lq.quotas != nil || lq.maxAdmittedWorkloads != nil || c.AdmissionFairSharing {
+			cc.LocalQueues[key] = &LocalQueueSnapshot{
+				Quotas:               maps.Clone(lq.quotas),
+				Usage:                maps.Clone(lq.totalReserved),
+				FairWeight:           parseFairWeight(lq.fairSharing),
+				MaxAdmittedWorkloads: lq.maxAdmittedWorkloads,
+				ReservingWorkloads:   lq.reservingWorkloads,
+			}
+		}
+	}
 	return cc
 }
 
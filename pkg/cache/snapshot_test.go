@@ -18,7 +18,10 @@ package cache
 
 import (
 	"context"
+	"math"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -1719,3 +1722,427 @@ func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotLocalQueueQuotas(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.LocalQueueQuotas, true)
+	ctx := context.Background()
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Queue("lq").
+		Request(corev1.ResourceCPU, "2").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+		Obj()
+	cl := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: []kueue.Workload{*wl}}).Build()
+	cqCache := New(cl)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	lq := utiltesting.MakeLocalQueue("lq", "ns").
+		ClusterQueue("cq").
+		Quota("default", corev1.ResourceCPU, "4").
+		Obj()
+	if err := cqCache.AddLocalQueue(lq); err != nil {
+		t.Fatalf("Couldn't add LocalQueue to cache: %v", err)
+	}
+	if err := cqCache.AddLocalQueue(utiltesting.MakeLocalQueue("other", "ns").ClusterQueue("cq").Obj()); err != nil {
+		t.Fatalf("Couldn't add LocalQueue to cache: %v", err)
+	}
+	fr := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	cqSnapshot := snapshot.ClusterQueue("cq")
+	wantLocalQueues := map[string]*LocalQueueSnapshot{
+		"ns/lq": {
+			Quotas:             resources.FlavorResourceQuantities{fr: 4_000},
+			Usage:              resources.FlavorResourceQuantities{fr: 2_000},
+			FairWeight:         resource.MustParse("1"),
+			ReservingWorkloads: 1,
+		},
+	}
+	if diff := cmp.Diff(wantLocalQueues, cqSnapshot.LocalQueues); diff != "" {
+		t.Errorf("Unexpected LocalQueues in snapshot (-want,+got):\n%s", diff)
+	}
+	if !cqSnapshot.FitsInLocalQueue(wl, resources.FlavorResourceQuantities{fr: 2_000}) {
+		t.Error("Expected 2 CPUs to fit in the LocalQueue")
+	}
+	if cqSnapshot.FitsInLocalQueue(wl, resources.FlavorResourceQuantities{fr: 3_000}) {
+		t.Error("Expected 3 CPUs to not fit in the LocalQueue")
+	}
+
+	wlInfo := cqSnapshot.Workloads[workload.Key(wl)]
+	snapshot.RemoveWorkload(wlInfo)
+	if got := cqSnapshot.LocalQueues["ns/lq"].Usage[fr]; got != 0 {
+		t.Errorf("Unexpected LocalQueue usage after removing the workload: %d", got)
+	}
+	snapshot.AddWorkload(wlInfo)
+	if got := cqSnapshot.LocalQueues["ns/lq"].Usage[fr]; got != 2_000 {
+		t.Errorf("Unexpected LocalQueue usage after adding the workload: %d", got)
+	}
+
+	updatedLq := lq.DeepCopy()
+	updatedLq.Spec.Quotas[0].Resources[0].Max = resource.MustParse("1")
+	if err := cqCache.UpdateLocalQueue(lq, updatedLq); err != nil {
+		t.Fatalf("Couldn't update LocalQueue: %v", err)
+	}
+	snapshot, err = cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	if !snapshot.ClusterQueue("cq").LocalQueues["ns/lq"].OverQuota() {
+		t.Error("Expected the LocalQueue to be over its quota after lowering it")
+	}
+}
+
+func TestSnapshotMaxAdmittedWorkloads(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.MaxAdmittedWorkloads, true)
+	ctx := context.Background()
+	admission := utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()
+	wls := []kueue.Workload{
+		*utiltesting.MakeWorkload("a", "ns").Queue("lq").Request(corev1.ResourceCPU, "1").ReserveQuota(admission).Obj(),
+		*utiltesting.MakeWorkload("b", "ns").Queue("other").Request(corev1.ResourceCPU, "1").ReserveQuota(admission).Obj(),
+	}
+	cl := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: wls}).Build()
+	cqCache := New(cl)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		MaxAdmittedWorkloads(3).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	for _, lq := range []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").MaxAdmittedWorkloads(1).Obj(),
+		utiltesting.MakeLocalQueue("other", "ns").ClusterQueue("cq").Obj(),
+	} {
+		if err := cqCache.AddLocalQueue(lq); err != nil {
+			t.Fatalf("Couldn't add LocalQueue to cache: %v", err)
+		}
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	cqSnapshot := snapshot.ClusterQueue("cq")
+	pending := utiltesting.MakeWorkload("pending", "ns").Queue("lq").Obj()
+	otherPending := utiltesting.MakeWorkload("other-pending", "ns").Queue("other").Obj()
+
+	type reached struct{ cq, lq bool }
+	check := func(desc string, wl *kueue.Workload, count int, want reached) {
+		t.Helper()
+		var got reached
+		got.cq, got.lq = cqSnapshot.MaxAdmittedWorkloadsReached(wl, count)
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(reached{})); diff != "" {
+			t.Errorf("%s: unexpected limits reached (-want,+got):\n%s", desc, diff)
+		}
+	}
+	check("LocalQueue at its limit", pending, 1, reached{lq: true})
+	check("other LocalQueue", otherPending, 1, reached{})
+	check("two more workloads", otherPending, 2, reached{cq: true})
+
+	cqSnapshot.AddReservingWorkload(otherPending)
+	check("after assuming a workload", otherPending, 1, reached{cq: true})
+
+	revert := cqSnapshot.SimulateUsageRemoval([]*workload.Info{cqSnapshot.Workloads["ns/a"]})
+	check("after removing a workload of the LocalQueue", pending, 1, reached{})
+	revert()
+	check("after restoring the workload", pending, 1, reached{cq: true, lq: true})
+}
+
+func TestSnapshotPartition(t *testing.T) {
+	cases := map[string]struct {
+		clusterQueues []*kueue.ClusterQueue
+		// sharedTASFlavor lists the ClusterQueues sharing a TAS flavor.
+		sharedTASFlavor []kueue.ClusterQueueReference
+		wantPartitions  [][]kueue.ClusterQueueReference
+	}{
+		"independent cohorts": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("a1").Cohort("a").Obj(),
+				utiltesting.MakeClusterQueue("a2").Cohort("a").Obj(),
+				utiltesting.MakeClusterQueue("b1").Cohort("b").Obj(),
+				utiltesting.MakeClusterQueue("standalone").Obj(),
+			},
+			wantPartitions: [][]kueue.ClusterQueueReference{
+				{"a1", "a2"},
+				{"b1"},
+				{"standalone"},
+			},
+		},
+		"cohorts sharing a TAS flavor": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("a1").Cohort("a").Obj(),
+				utiltesting.MakeClusterQueue("b1").Cohort("b").Obj(),
+				utiltesting.MakeClusterQueue("c1").Cohort("c").Obj(),
+				utiltesting.MakeClusterQueue("standalone").Obj(),
+			},
+			sharedTASFlavor: []kueue.ClusterQueueReference{"a1", "standalone"},
+			wantPartitions: [][]kueue.ClusterQueueReference{
+				{"a1", "standalone"},
+				{"b1"},
+				{"c1"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cqCache := New(utiltesting.NewFakeClient())
+			for _, cq := range tc.clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+				}
+			}
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			tasSnapshot := &TASFlavorSnapshot{}
+			for _, name := range tc.sharedTASFlavor {
+				snapshot.ClusterQueue(name).TASFlavors["tas"] = tasSnapshot
+			}
+			var gotPartitions [][]kueue.ClusterQueueReference
+			for _, p := range snapshot.Partition() {
+				names := p.ClusterQueuesNames()
+				slices.Sort(names)
+				gotPartitions = append(gotPartitions, names)
+				for _, name := range names {
+					if p.ClusterQueue(name) != snapshot.ClusterQueue(name) {
+						t.Errorf("Expected the partition to share the ClusterQueue %s with the snapshot", name)
+					}
+				}
+			}
+			opts := cmpopts.SortSlices(func(a, b []kueue.ClusterQueueReference) bool { return a[0] < b[0] })
+			if diff := cmp.Diff(tc.wantPartitions, gotPartitions, opts); diff != "" {
+				t.Errorf("Unexpected partitions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLocalQueueShares(t *testing.T) {
+	ctx := context.Background()
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("a1", "ns").
+			Queue("a").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj(),
+		*utiltesting.MakeWorkload("b1", "ns").
+			Queue("b").
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+	}
+	localQueues := []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("a", "ns").ClusterQueue("cq").Obj(),
+		utiltesting.MakeLocalQueue("b", "ns").ClusterQueue("cq").FairWeight(resource.MustParse("2")).Obj(),
+		utiltesting.MakeLocalQueue("c", "ns").ClusterQueue("cq").FairWeight(resource.MustParse("0")).Obj(),
+		utiltesting.MakeLocalQueue("d", "ns").ClusterQueue("cq").Obj(),
+	}
+	cases := map[string]struct {
+		enableAdmissionFairSharing bool
+		admissionMode              kueue.AdmissionMode
+		wantShares                 map[string]int
+	}{
+		"feature disabled": {
+			admissionMode: kueue.UsageBasedAdmissionFairSharing,
+		},
+		"no admission scope": {
+			enableAdmissionFairSharing: true,
+		},
+		"no admission fair sharing": {
+			enableAdmissionFairSharing: true,
+			admissionMode:              kueue.NoAdmissionFairSharing,
+		},
+		"usage based admission fair sharing": {
+			enableAdmissionFairSharing: true,
+			admissionMode:              kueue.UsageBasedAdmissionFairSharing,
+			wantShares: map[string]int{
+				"ns/a": 200,
+				"ns/b": 200,
+				"ns/c": math.MaxInt,
+				"ns/d": 0,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdmissionFairSharing, tc.enableAdmissionFairSharing)
+			cl := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: workloads}).Build()
+			cqCache := New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			cqWrapper := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj())
+			if tc.admissionMode != "" {
+				cqWrapper.AdmissionMode(tc.admissionMode)
+			}
+			cq := cqWrapper.Obj()
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+			}
+			for _, lq := range localQueues {
+				if err := cqCache.AddLocalQueue(lq); err != nil {
+					t.Fatalf("Couldn't add LocalQueue to cache: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.wantShares, cqCache.LocalQueueShares("cq")); diff != "" {
+				t.Errorf("Unexpected shares (-want,+got):\n%s", diff)
+			}
+
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			cqSnapshot := snapshot.ClusterQueue("cq")
+			for _, wl := range workloads {
+				if got, want := cqSnapshot.LocalQueueShare(&wl), tc.wantShares[workload.QueueKey(&wl)]; got != want {
+					t.Errorf("Unexpected share in snapshot for %s: %d, want %d", workload.QueueKey(&wl), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshotReservations(t *testing.T) {
+	now := time.Now()
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("c1").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("c2").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj(),
+	}
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("reserved", "").
+			Label(kueuealpha.ReservationLabel, "reservation").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+			Obj(),
+		*utiltesting.MakeWorkload("other", "").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("c2").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+			Obj(),
+	}
+	cases := map[string]struct {
+		reservation *kueuealpha.Reservation
+		// wantAvailable is the available quota of c1 and c2, in milliCPU.
+		wantAvailable         map[kueue.ClusterQueueReference]int64
+		wantAvailableReleased map[kueue.ClusterQueueReference]int64
+		// wantHeld is the capacity held back in c1, c2 and the cohort, in milliCPU.
+		wantHeld map[string]int64
+		// wantHeldConsumed is the capacity held back once the reserved
+		// workload is assumed to use 2 more CPUs, in milliCPU.
+		wantHeldConsumed map[string]int64
+	}{
+		"scheduled": {
+			reservation: utiltesting.MakeReservation("reservation", now.Add(time.Hour), time.Hour).
+				Cohort("cohort").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				Obj(),
+			wantAvailable:         map[kueue.ClusterQueueReference]int64{"c1": 10_000, "c2": 10_000},
+			wantAvailableReleased: map[kueue.ClusterQueueReference]int64{"c1": 10_000, "c2": 10_000},
+			wantHeld:              map[string]int64{"c1": 0, "c2": 0, "cohort": 0},
+			wantHeldConsumed:      map[string]int64{"c1": 0, "c2": 0, "cohort": 0},
+		},
+		"draining": {
+			reservation: utiltesting.MakeReservation("reservation", now.Add(time.Minute), time.Hour).
+				Cohort("cohort").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				DrainDuration(10 * time.Minute).
+				Obj(),
+			wantAvailable:         map[kueue.ClusterQueueReference]int64{"c1": 7_000, "c2": 7_000},
+			wantAvailableReleased: map[kueue.ClusterQueueReference]int64{"c1": 7_000, "c2": 7_000},
+			wantHeld:              map[string]int64{"c1": 0, "c2": 0, "cohort": 3_000},
+			wantHeldConsumed:      map[string]int64{"c1": 0, "c2": 0, "cohort": 1_000},
+		},
+		"in the time window": {
+			reservation: utiltesting.MakeReservation("reservation", now.Add(-time.Minute), time.Hour).
+				Cohort("cohort").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				Obj(),
+			wantAvailable:         map[kueue.ClusterQueueReference]int64{"c1": 7_000, "c2": 7_000},
+			wantAvailableReleased: map[kueue.ClusterQueueReference]int64{"c1": 10_000, "c2": 10_000},
+			wantHeld:              map[string]int64{"c1": 0, "c2": 0, "cohort": 3_000},
+			wantHeldConsumed:      map[string]int64{"c1": 0, "c2": 0, "cohort": 1_000},
+		},
+		"in the time window of a ClusterQueue": {
+			reservation: utiltesting.MakeReservation("reservation", now.Add(-time.Minute), time.Hour).
+				ClusterQueue("c2").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				Obj(),
+			wantAvailable:         map[kueue.ClusterQueueReference]int64{"c1": 6_000, "c2": 6_000},
+			wantAvailableReleased: map[kueue.ClusterQueueReference]int64{"c1": 6_000, "c2": 6_000},
+			wantHeld:              map[string]int64{"c1": 0, "c2": 4_000, "cohort": 4_000},
+			wantHeldConsumed:      map[string]int64{"c1": 0, "c2": 4_000, "cohort": 4_000},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cl := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: workloads}).Build()
+			cqCache := New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+				}
+			}
+			cqCache.AddOrUpdateReservation(tc.reservation)
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			available := func() map[kueue.ClusterQueueReference]int64 {
+				return map[kueue.ClusterQueueReference]int64{
+					"c1": snapshot.ClusterQueue("c1").Available(cpu),
+					"c2": snapshot.ClusterQueue("c2").Available(cpu),
+				}
+			}
+			if diff := cmp.Diff(tc.wantAvailable, available()); diff != "" {
+				t.Errorf("Unexpected available quota (-want,+got):\n%s", diff)
+			}
+			held := func() map[string]int64 {
+				return map[string]int64{
+					"c1":     snapshot.ClusterQueue("c1").ResourceNode.Held[cpu],
+					"c2":     snapshot.ClusterQueue("c2").ResourceNode.Held[cpu],
+					"cohort": snapshot.Cohort("cohort").ResourceNode.Held[cpu],
+				}
+			}
+			if diff := cmp.Diff(tc.wantHeld, held()); diff != "" {
+				t.Errorf("Unexpected held capacity (-want,+got):\n%s", diff)
+			}
+			// The held capacity isn't accounted as usage.
+			gotUsage := map[string]int64{
+				"c1":     snapshot.ClusterQueue("c1").ResourceNode.Usage[cpu],
+				"c2":     snapshot.ClusterQueue("c2").ResourceNode.Usage[cpu],
+				"cohort": snapshot.Cohort("cohort").ResourceNode.Usage[cpu],
+			}
+			if diff := cmp.Diff(map[string]int64{"c1": 1_000, "c2": 1_000, "cohort": 2_000}, gotUsage); diff != "" {
+				t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+			}
+			restore := snapshot.ClusterQueue("c1").ReleaseReservation(&workloads[0])
+			if diff := cmp.Diff(tc.wantAvailableReleased, available()); diff != "" {
+				t.Errorf("Unexpected available quota after the release (-want,+got):\n%s", diff)
+			}
+			restore()
+			if diff := cmp.Diff(tc.wantAvailable, available()); diff != "" {
+				t.Errorf("Unexpected available quota after the restore (-want,+got):\n%s", diff)
+			}
+			snapshot.ClusterQueue("c1").ConsumeReservation(&workloads[0], resources.FlavorResourceQuantities{cpu: 2_000})
+			if diff := cmp.Diff(tc.wantHeldConsumed, held()); diff != "" {
+				t.Errorf("Unexpected held capacity after consuming the reservation (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
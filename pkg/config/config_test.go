@@ -393,10 +393,17 @@ webhook:
 		},
 	}
 
+	defaultFailoverPolicy := &configapi.MultiKueueFailoverPolicy{
+		UnreachableTimeout: &metav1.Duration{Duration: configapi.DefaultMultiKueueUnreachableTimeout},
+		CordonThreshold:    ptr.To(configapi.DefaultMultiKueueCordonThreshold),
+		CordonWindow:       &metav1.Duration{Duration: configapi.DefaultMultiKueueCordonWindow},
+	}
+
 	defaultMultiKueue := &configapi.MultiKueue{
 		GCInterval:        &metav1.Duration{Duration: configapi.DefaultMultiKueueGCInterval},
 		Origin:            ptr.To(configapi.DefaultMultiKueueOrigin),
 		WorkerLostTimeout: &metav1.Duration{Duration: configapi.DefaultMultiKueueWorkerLostTimeout},
+		FailoverPolicy:    defaultFailoverPolicy,
 	}
 
 	testcases := []struct {
@@ -872,6 +879,7 @@ webhook:
 					GCInterval:        &metav1.Duration{Duration: 90 * time.Second},
 					Origin:            ptr.To("multikueue-manager1"),
 					WorkerLostTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+					FailoverPolicy:    defaultFailoverPolicy,
 				},
 				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
 			},
@@ -1039,6 +1047,11 @@ func TestEncode(t *testing.T) {
 					"gcInterval":        "1m0s",
 					"origin":            "multikueue",
 					"workerLostTimeout": "15m0s",
+					"failoverPolicy": map[string]any{
+						"unreachableTimeout": "5m0s",
+						"cordonThreshold":    int64(3),
+						"cordonWindow":       "10m0s",
+					},
 				},
 			},
 		},
@@ -19,6 +19,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"strings"
 	"unsafe"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	podworkload "sigs.k8s.io/kueue/pkg/controller/jobs/pod"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 )
 
 const (
@@ -58,6 +60,11 @@ var (
 	requeuingStrategyPath             = waitForPodsReadyPath.Child("requeuingStrategy")
 	multiKueuePath                    = field.NewPath("multiKueue")
 	fsPreemptionStrategiesPath        = field.NewPath("fairSharing", "preemptionStrategies")
+	fsUsageHalfLifeTimePath           = field.NewPath("fairSharing", "usageHalfLifeTime")
+	fsUsageSamplingIntervalPath       = field.NewPath("fairSharing", "usageSamplingInterval")
+	gracefulPreemptionTimeoutPath     = field.NewPath("gracefulPreemption", "timeout")
+	schedulerPluginsPath              = field.NewPath("scheduler", "plugins")
+	schedulerDecisionLogPath          = field.NewPath("scheduler", "decisionLog")
 	internalCertManagementPath        = field.NewPath("internalCertManagement")
 	queueVisibilityPath               = field.NewPath("queueVisibility")
 	resourceTransformationPath        = field.NewPath("resources", "transformations")
@@ -70,6 +77,9 @@ func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorLis
 	allErrs = append(allErrs, validateIntegrations(c, scheme)...)
 	allErrs = append(allErrs, validateMultiKueue(c)...)
 	allErrs = append(allErrs, validateFairSharing(c)...)
+	allErrs = append(allErrs, validateGracefulPreemption(c)...)
+	allErrs = append(allErrs, validateSchedulerPlugins(c)...)
+	allErrs = append(allErrs, validateSchedulerDecisionLog(c)...)
 	allErrs = append(allErrs, validateInternalCertManagement(c)...)
 	allErrs = append(allErrs, validateResourceTransformations(c)...)
 	allErrs = append(allErrs, validateManagedJobsNamespaceSelector(c)...)
@@ -105,6 +115,21 @@ func validateMultiKueue(c *configapi.Configuration) field.ErrorList {
 			allErrs = append(allErrs, field.Invalid(multiKueuePath.Child("workerLostTimeout"),
 				c.MultiKueue.WorkerLostTimeout.Duration, apimachineryvalidation.IsNegativeErrorMsg))
 		}
+		if fp := c.MultiKueue.FailoverPolicy; fp != nil {
+			failoverPath := multiKueuePath.Child("failoverPolicy")
+			if fp.UnreachableTimeout != nil && fp.UnreachableTimeout.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(failoverPath.Child("unreachableTimeout"),
+					fp.UnreachableTimeout.Duration, apimachineryvalidation.IsNegativeErrorMsg))
+			}
+			if fp.CordonThreshold != nil && *fp.CordonThreshold < 0 {
+				allErrs = append(allErrs, field.Invalid(failoverPath.Child("cordonThreshold"),
+					*fp.CordonThreshold, apimachineryvalidation.IsNegativeErrorMsg))
+			}
+			if fp.CordonWindow != nil && fp.CordonWindow.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(failoverPath.Child("cordonWindow"),
+					fp.CordonWindow.Duration, apimachineryvalidation.IsNegativeErrorMsg))
+			}
+		}
 		if c.MultiKueue.Origin != nil {
 			if errs := apimachineryutilvalidation.IsValidLabelValue(*c.MultiKueue.Origin); len(errs) != 0 {
 				allErrs = append(allErrs, field.Invalid(multiKueuePath.Child("origin"), *c.MultiKueue.Origin, strings.Join(errs, ",")))
@@ -298,6 +323,78 @@ func validateFairSharing(c *configapi.Configuration) field.ErrorList {
 			allErrs = append(allErrs, field.NotSupported(fsPreemptionStrategiesPath, fs.PreemptionStrategies, validStrategySetsStr))
 		}
 	}
+	if fs.UsageHalfLifeTime != nil && fs.UsageHalfLifeTime.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fsUsageHalfLifeTimePath, fs.UsageHalfLifeTime.Duration, "must be greater than 0"))
+	}
+	if fs.UsageSamplingInterval != nil && fs.UsageSamplingInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fsUsageSamplingIntervalPath, fs.UsageSamplingInterval.Duration, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateGracefulPreemption(c *configapi.Configuration) field.ErrorList {
+	gp := c.GracefulPreemption
+	if gp == nil || gp.Timeout == nil || gp.Timeout.Duration > 0 {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(gracefulPreemptionTimeoutPath, gp.Timeout.Duration, "must be greater than 0")}
+}
+
+func validateSchedulerPlugins(c *configapi.Configuration) field.ErrorList {
+	if c.Scheduler == nil || c.Scheduler.Plugins == nil {
+		return nil
+	}
+	plugins := c.Scheduler.Plugins
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateSchedulerPluginList(plugins.FlavorFilter, schedulerPluginsPath.Child("flavorFilter"))...)
+	allErrs = append(allErrs, validateSchedulerPluginList(plugins.FlavorScore, schedulerPluginsPath.Child("flavorScore"))...)
+	allErrs = append(allErrs, validateSchedulerPluginList(plugins.QueueSort, schedulerPluginsPath.Child("queueSort"))...)
+	allErrs = append(allErrs, validateSchedulerPluginList(plugins.PostFilter, schedulerPluginsPath.Child("postFilter"))...)
+	if len(plugins.QueueSort) > 1 {
+		allErrs = append(allErrs, field.TooMany(schedulerPluginsPath.Child("queueSort"), len(plugins.QueueSort), 1))
+	}
+	for i, p := range plugins.FlavorScore {
+		if p.Weight != nil && *p.Weight <= 0 {
+			allErrs = append(allErrs, field.Invalid(schedulerPluginsPath.Child("flavorScore").Index(i).Child("weight"), *p.Weight, "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
+func validateSchedulerDecisionLog(c *configapi.Configuration) field.ErrorList {
+	if c.Scheduler == nil || c.Scheduler.DecisionLog == nil {
+		return nil
+	}
+	dl := c.Scheduler.DecisionLog
+	var allErrs field.ErrorList
+	if dl.File != nil && *dl.File == "" {
+		allErrs = append(allErrs, field.Invalid(schedulerDecisionLogPath.Child("file"), *dl.File, "must not be empty"))
+	}
+	if dl.HTTPEndpoint != nil {
+		if u, err := url.Parse(*dl.HTTPEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(schedulerDecisionLogPath.Child("httpEndpoint"), *dl.HTTPEndpoint, "must be an absolute http or https URL"))
+		}
+	}
+	if dl.MemoryCapacity != nil && *dl.MemoryCapacity < 0 {
+		allErrs = append(allErrs, field.Invalid(schedulerDecisionLogPath.Child("memoryCapacity"), *dl.MemoryCapacity, apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	return allErrs
+}
+
+func validateSchedulerPluginList(plugins []configapi.SchedulerPlugin, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := sets.New[string]()
+	for i, p := range plugins {
+		switch {
+		case p.Name == "":
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("name"), ""))
+		case seen.Has(p.Name):
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("name"), p.Name))
+		case !framework.IsRegistered(p.Name):
+			allErrs = append(allErrs, field.NotSupported(path.Index(i).Child("name"), p.Name, framework.RegisteredNames()))
+		}
+		seen.Insert(p.Name)
+	}
 	return allErrs
 }
 
@@ -32,8 +32,17 @@ import (
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 )
 
+func init() {
+	for _, name := range []string{"a", "b"} {
+		if err := framework.Register(name, nil); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func TestValidate(t *testing.T) {
 	testScheme := runtime.NewScheme()
 	if err := configapi.AddToScheme(testScheme); err != nil {
@@ -572,6 +581,22 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"negative multiKueue.failoverPolicy.cordonThreshold": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				MultiKueue: &configapi.MultiKueue{
+					FailoverPolicy: &configapi.MultiKueueFailoverPolicy{
+						CordonThreshold: ptr.To[int32](-1),
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "multiKueue.failoverPolicy.cordonThreshold",
+				},
+			},
+		},
 		"invalid .multiKueue.origin label value": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -624,6 +649,148 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"invalid fair sharing usage half-life time and sampling interval": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				FairSharing: &configapi.FairSharing{
+					Enable:                true,
+					UsageHalfLifeTime:     &metav1.Duration{},
+					UsageSamplingInterval: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "fairSharing.usageHalfLifeTime",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "fairSharing.usageSamplingInterval",
+				},
+			},
+		},
+		"valid fair sharing usage half-life time and sampling interval": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				FairSharing: &configapi.FairSharing{
+					Enable:                true,
+					UsageHalfLifeTime:     &metav1.Duration{Duration: 24 * time.Hour},
+					UsageSamplingInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+		"invalid graceful preemption timeout": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				GracefulPreemption: &configapi.GracefulPreemption{
+					Timeout: &metav1.Duration{},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "gracefulPreemption.timeout",
+				},
+			},
+		},
+		"valid scheduler plugins": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					Plugins: &configapi.SchedulerPlugins{
+						FlavorFilter: []configapi.SchedulerPlugin{{Name: "a"}, {Name: "b"}},
+						FlavorScore:  []configapi.SchedulerPlugin{{Name: "a", Weight: ptr.To[int32](2)}},
+						QueueSort:    []configapi.SchedulerPlugin{{Name: "a"}},
+						PostFilter:   []configapi.SchedulerPlugin{{Name: "a"}},
+					},
+				},
+			},
+		},
+		"invalid scheduler plugins": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					Plugins: &configapi.SchedulerPlugins{
+						FlavorFilter: []configapi.SchedulerPlugin{{Name: "a"}, {Name: "a"}},
+						FlavorScore:  []configapi.SchedulerPlugin{{Name: "a", Weight: ptr.To[int32](0)}},
+						QueueSort:    []configapi.SchedulerPlugin{{Name: "a"}, {Name: "b"}},
+						PostFilter:   []configapi.SchedulerPlugin{{}},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "scheduler.plugins.flavorFilter[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "scheduler.plugins.postFilter[0].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeTooMany,
+					Field: "scheduler.plugins.queueSort",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.plugins.flavorScore[0].weight",
+				},
+			},
+		},
+		"unregistered scheduler plugin": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					Plugins: &configapi.SchedulerPlugins{
+						FlavorFilter: []configapi.SchedulerPlugin{{Name: "a"}, {Name: "unknown"}},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "scheduler.plugins.flavorFilter[1].name",
+				},
+			},
+		},
+		"valid scheduler decisionLog": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					DecisionLog: &configapi.SchedulerDecisionLog{
+						File:           ptr.To("/var/log/kueue/decisions.jsonl"),
+						HTTPEndpoint:   ptr.To("https://audit.example.com/kueue"),
+						MemoryCapacity: ptr.To[int32](0),
+					},
+				},
+			},
+		},
+		"invalid scheduler decisionLog": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Scheduler: &configapi.Scheduler{
+					DecisionLog: &configapi.SchedulerDecisionLog{
+						File:           ptr.To(""),
+						HTTPEndpoint:   ptr.To("audit.example.com/kueue"),
+						MemoryCapacity: ptr.To[int32](-1),
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.decisionLog.file",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.decisionLog.httpEndpoint",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "scheduler.decisionLog.memoryCapacity",
+				},
+			},
+		},
 		"invalid .internalCertManagement.webhookSecretName": {
 			cfg: &configapi.Configuration{
 				Integrations: defaultIntegrations,
@@ -39,7 +39,8 @@ const (
 	WorkloadPriorityClassSource = "kueue.x-k8s.io/workloadpriorityclass"
 	PodPriorityClassSource      = "scheduling.k8s.io/priorityclass"
 
-	DefaultPendingWorkloadsLimit = 1000
+	DefaultPendingWorkloadsLimit  = 1000
+	DefaultAdmittedWorkloadsLimit = 1000
 
 	// ManagedByKueueLabelKey label that signalize that an object is managed by Kueue
 	ManagedByKueueLabelKey   = "kueue.x-k8s.io/managed"
@@ -25,6 +25,7 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -57,6 +58,9 @@ func newMultiKueueStoreHelper(c client.Client) (*multiKueueStoreHelper, error) {
 type ACReconciler struct {
 	client client.Client
 	helper *multiKueueStoreHelper
+	// dispatchers are the names of the dispatchers which can be used by
+	// the MultiKueueConfigs.
+	dispatchers sets.Set[kueue.MultiKueueDispatcherName]
 }
 
 var _ reconcile.Reconciler = (*ACReconciler)(nil)
@@ -82,6 +86,10 @@ func (a *ACReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 		newCondition.Status = metav1.ConditionFalse
 		newCondition.Reason = "BadConfig"
 		newCondition.Message = fmt.Sprintf("Cannot load the AdmissionChecks parameters: %s", err.Error())
+	} else if d := cfg.Spec.Dispatcher; features.Enabled(features.MultiKueueDispatcher) && d != nil && !a.dispatchers.Has(d.Name) {
+		newCondition.Status = metav1.ConditionFalse
+		newCondition.Reason = "BadConfig"
+		newCondition.Message = fmt.Sprintf("Unknown dispatcher %q", d.Name)
 	} else {
 		var missingClusters []string
 		var inactiveClusters []string
@@ -168,10 +176,11 @@ func (a *ACReconciler) Reconcile(ctx context.Context, req reconcile.Request) (re
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=admissionchecks,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=multikueueconfigs,verbs=get;list;watch
 
-func newACReconciler(c client.Client, helper *multiKueueStoreHelper) *ACReconciler {
+func newACReconciler(c client.Client, helper *multiKueueStoreHelper, dispatchers sets.Set[kueue.MultiKueueDispatcherName]) *ACReconciler {
 	return &ACReconciler{
-		client: c,
-		helper: helper,
+		client:      c,
+		helper:      helper,
+		dispatchers: dispatchers,
 	}
 }
 
@@ -295,7 +295,7 @@ func TestReconcile(t *testing.T) {
 			c := builder.Build()
 
 			helper, _ := newMultiKueueStoreHelper(c)
-			reconciler := newACReconciler(c, helper)
+			reconciler := newACReconciler(c, helper, knownDispatchers(nil))
 
 			_, gotErr := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.reconcileFor}})
 			if diff := cmp.Diff(tc.wantError, gotErr); diff != "" {
@@ -21,6 +21,7 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 )
@@ -37,6 +38,8 @@ type SetupOptions struct {
 	workerLostTimeout time.Duration
 	eventsBatchPeriod time.Duration
 	adapters          map[string]jobframework.MultiKueueAdapter
+	clusterSelectors  map[kueue.MultiKueueDispatcherName]ClusterSelector
+	failoverPolicy    failoverPolicy
 }
 
 type SetupOption func(o *SetupOptions)
@@ -80,6 +83,29 @@ func WithAdapters(adapters map[string]jobframework.MultiKueueAdapter) SetupOptio
 	}
 }
 
+// WithClusterSelector - registers a custom cluster selector, which can be
+// used by the MultiKueueConfigs as dispatcher under the given name.
+// The name must be a domain-prefixed path, such as acme.io/selector.
+func WithClusterSelector(name kueue.MultiKueueDispatcherName, selector ClusterSelector) SetupOption {
+	return func(o *SetupOptions) {
+		o.clusterSelectors[name] = selector
+	}
+}
+
+// WithFailoverPolicy - sets the time after which the workloads that got quota
+// reservation in an unreachable worker cluster are dispatched to the other
+// worker clusters, and the number of connection failures within the cordon
+// window after which a worker cluster stops receiving new workloads.
+func WithFailoverPolicy(unreachableTimeout time.Duration, cordonThreshold int, cordonWindow time.Duration) SetupOption {
+	return func(o *SetupOptions) {
+		o.failoverPolicy = failoverPolicy{
+			unreachableTimeout: unreachableTimeout,
+			cordonThreshold:    cordonThreshold,
+			cordonWindow:       cordonWindow,
+		}
+	}
+}
+
 func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) error {
 	options := &SetupOptions{
 		gcInterval:        defaultGCInterval,
@@ -87,6 +113,12 @@ func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) e
 		workerLostTimeout: defaultWorkerLostTimeout,
 		eventsBatchPeriod: constants.UpdatesBatchPeriod,
 		adapters:          make(map[string]jobframework.MultiKueueAdapter),
+		clusterSelectors:  make(map[kueue.MultiKueueDispatcherName]ClusterSelector),
+		failoverPolicy: failoverPolicy{
+			unreachableTimeout: defaultUnreachableTimeout,
+			cordonThreshold:    defaultCordonThreshold,
+			cordonWindow:       defaultCordonWindow,
+		},
 	}
 
 	for _, o := range opts {
@@ -104,18 +136,19 @@ func SetupControllers(mgr ctrl.Manager, namespace string, opts ...SetupOption) e
 		return err
 	}
 
-	cRec := newClustersReconciler(mgr.GetClient(), namespace, options.gcInterval, options.origin, fsWatcher, options.adapters)
+	cRec := newClustersReconciler(mgr.GetClient(), namespace, options.gcInterval, options.origin, fsWatcher, options.adapters, options.failoverPolicy)
 	err = cRec.setupWithManager(mgr)
 	if err != nil {
 		return err
 	}
 
-	acRec := newACReconciler(mgr.GetClient(), helper)
+	acRec := newACReconciler(mgr.GetClient(), helper, knownDispatchers(options.clusterSelectors))
 	err = acRec.setupWithManager(mgr)
 	if err != nil {
 		return err
 	}
 
-	wlRec := newWlReconciler(mgr.GetClient(), helper, cRec, options.origin, options.workerLostTimeout, options.eventsBatchPeriod, options.adapters)
+	wlRec := newWlReconciler(mgr.GetClient(), helper, cRec, options.origin, options.workerLostTimeout, options.eventsBatchPeriod, options.adapters,
+		withClusterSelectors(options.clusterSelectors), withFailoverPolicy(options.failoverPolicy))
 	return wlRec.setupWithManager(mgr)
 }
@@ -0,0 +1,284 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	defaultIncrementalBatchSize    = 3
+	defaultIncrementalRoundTimeout = 5 * time.Minute
+
+	// freeCapacityTTL is the time during which the free capacity read from a
+	// worker cluster is reused, instead of reading it for each workload.
+	freeCapacityTTL = 30 * time.Second
+)
+
+var errUnknownDispatcher = errors.New("unknown dispatcher")
+
+// WorkerCluster is a worker cluster to which a workload can be dispatched.
+type WorkerCluster struct {
+	// Name is the name of the MultiKueueCluster.
+	Name string
+	// Client is a client for the worker cluster.
+	Client client.Client
+}
+
+// Nomination is the outcome of a ClusterSelector.
+type Nomination struct {
+	// Clusters are the names of the clusters where the workload should be
+	// created.
+	Clusters []string
+	// RequeueAfter, if not zero, is the time after which the nomination
+	// should be evaluated again, if the workload didn't reserve quota in
+	// any of the nominated clusters.
+	RequeueAfter time.Duration
+}
+
+// ClusterSelector nominates the worker clusters to which a workload is
+// dispatched.
+type ClusterSelector interface {
+	// Nominate returns the clusters, among the candidates, where the workload
+	// should be created. The candidates are the active clusters, in the order
+	// in which they are listed in the MultiKueueConfig.
+	// It's called until the workload reserves quota in one of the worker
+	// clusters. The remote workloads created in clusters which are no longer
+	// nominated are kept.
+	Nominate(ctx context.Context, local *kueue.Workload, dispatcher *kueue.MultiKueueDispatcher, candidates []WorkerCluster) (*Nomination, error)
+}
+
+func builtinSelectors(clk clock.Clock) map[kueue.MultiKueueDispatcherName]ClusterSelector {
+	return map[kueue.MultiKueueDispatcherName]ClusterSelector{
+		kueue.MultiKueueDispatcherAllAtOnce:    &allAtOnceSelector{},
+		kueue.MultiKueueDispatcherIncremental:  &incrementalSelector{clock: clk},
+		kueue.MultiKueueDispatcherFreeCapacity: &incrementalSelector{clock: clk, freeCapacity: newFreeCapacityCache(clk)},
+	}
+}
+
+// knownDispatchers returns the names of the built-in dispatchers, along with
+// the names of the custom cluster selectors.
+func knownDispatchers(custom map[kueue.MultiKueueDispatcherName]ClusterSelector) sets.Set[kueue.MultiKueueDispatcherName] {
+	names := sets.New(
+		kueue.MultiKueueDispatcherAllAtOnce,
+		kueue.MultiKueueDispatcherIncremental,
+		kueue.MultiKueueDispatcherFreeCapacity,
+	)
+	for name := range custom {
+		names.Insert(name)
+	}
+	return names
+}
+
+// allAtOnceSelector nominates all the candidates.
+type allAtOnceSelector struct{}
+
+func (*allAtOnceSelector) Nominate(_ context.Context, _ *kueue.Workload, _ *kueue.MultiKueueDispatcher, candidates []WorkerCluster) (*Nomination, error) {
+	return &Nomination{Clusters: clusterNames(candidates)}, nil
+}
+
+// incrementalSelector nominates the candidates in batches, adding a batch
+// each time the round timeout elapses since the workload reserved quota in
+// the manager cluster.
+type incrementalSelector struct {
+	clock clock.Clock
+	// freeCapacity, if not nil, orders the candidates by their free capacity,
+	// instead of the order in the MultiKueueConfig.
+	freeCapacity *freeCapacityCache
+}
+
+func (s *incrementalSelector) Nominate(ctx context.Context, local *kueue.Workload, dispatcher *kueue.MultiKueueDispatcher, candidates []WorkerCluster) (*Nomination, error) {
+	if s.freeCapacity != nil {
+		candidates = s.freeCapacity.order(ctx, local, candidates)
+	}
+	batchSize, roundTimeout := incrementalSettings(dispatcher)
+	var elapsed time.Duration
+	if c := apimeta.FindStatusCondition(local.Status.Conditions, kueue.WorkloadQuotaReserved); c != nil {
+		elapsed = max(0, s.clock.Since(c.LastTransitionTime.Time))
+	}
+	rounds := int(elapsed/roundTimeout) + 1
+	count := min(len(candidates), rounds*batchSize)
+	nomination := &Nomination{Clusters: clusterNames(candidates[:count])}
+	if count < len(candidates) {
+		nomination.RequeueAfter = time.Duration(rounds)*roundTimeout - elapsed
+	}
+	return nomination, nil
+}
+
+func incrementalSettings(dispatcher *kueue.MultiKueueDispatcher) (int, time.Duration) {
+	batchSize := defaultIncrementalBatchSize
+	roundTimeout := defaultIncrementalRoundTimeout
+	if dispatcher != nil && dispatcher.Incremental != nil {
+		if dispatcher.Incremental.BatchSize != nil {
+			batchSize = int(*dispatcher.Incremental.BatchSize)
+		}
+		if dispatcher.Incremental.RoundTimeout != nil && dispatcher.Incremental.RoundTimeout.Duration > 0 {
+			roundTimeout = dispatcher.Incremental.RoundTimeout.Duration
+		}
+	}
+	return max(1, batchSize), roundTimeout
+}
+
+type freeCapacityKey struct {
+	cluster string
+	// localQueue is the key (namespace/name) of the LocalQueue of the
+	// workload, which points to the ClusterQueue in the worker cluster.
+	localQueue string
+}
+
+type freeCapacityEntry struct {
+	free    resources.Requests
+	fetched time.Time
+}
+
+// freeCapacityCache holds the free capacity of the ClusterQueues in the
+// worker clusters, by LocalQueue, read at most once per freeCapacityTTL.
+type freeCapacityCache struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[freeCapacityKey]freeCapacityEntry
+}
+
+func newFreeCapacityCache(clk clock.Clock) *freeCapacityCache {
+	return &freeCapacityCache{
+		clock:   clk,
+		entries: make(map[freeCapacityKey]freeCapacityEntry),
+	}
+}
+
+// order returns the candidates sorted by the free capacity of the
+// ClusterQueue that the workload would use in each of them, relative to the
+// requests of the workload. The candidates for which the free capacity can't
+// be determined are placed last.
+func (c *freeCapacityCache) order(ctx context.Context, local *kueue.Workload, candidates []WorkerCluster) []WorkerCluster {
+	log := ctrl.LoggerFrom(ctx)
+	requests := totalRequests(local)
+	scores := make(map[string]float64, len(candidates))
+	for _, wc := range candidates {
+		free, err := c.get(ctx, wc, local)
+		if err != nil {
+			log.V(3).Info("Unable to get the free capacity", "workerCluster", wc.Name, "error", err.Error())
+			scores[wc.Name] = math.Inf(-1)
+			continue
+		}
+		scores[wc.Name] = freeCapacityScore(free, requests)
+	}
+	sorted := slices.Clone(candidates)
+	slices.SortStableFunc(sorted, func(a, b WorkerCluster) int {
+		return cmp.Compare(scores[b.Name], scores[a.Name])
+	})
+	return sorted
+}
+
+// get returns the free capacity of the ClusterQueue that the workload would
+// use in the worker cluster, reading it from the worker cluster only if it
+// wasn't read in the last freeCapacityTTL.
+func (c *freeCapacityCache) get(ctx context.Context, wc WorkerCluster, local *kueue.Workload) (resources.Requests, error) {
+	key := freeCapacityKey{cluster: wc.Name, localQueue: workload.QueueKey(local)}
+	now := c.clock.Now()
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	c.mu.Unlock()
+	if found && now.Sub(entry.fetched) < freeCapacityTTL {
+		return entry.free, nil
+	}
+	free, err := freeCapacity(ctx, wc.Client, local)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if now.Sub(e.fetched) >= freeCapacityTTL {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = freeCapacityEntry{free: free, fetched: now}
+	return free, nil
+}
+
+// freeCapacity returns the unreserved nominal quota of the ClusterQueue
+// that the workload would use in the worker cluster.
+func freeCapacity(ctx context.Context, c client.Client, local *kueue.Workload) (resources.Requests, error) {
+	lq := &kueue.LocalQueue{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: local.Namespace, Name: local.Spec.QueueName}, lq); err != nil {
+		return nil, err
+	}
+	cq := &kueue.ClusterQueue{}
+	if err := c.Get(ctx, client.ObjectKey{Name: string(lq.Spec.ClusterQueue)}, cq); err != nil {
+		return nil, err
+	}
+	free := make(resources.Requests)
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for _, rq := range fq.Resources {
+				free[rq.Name] += resources.ResourceValue(rq.Name, rq.NominalQuota)
+			}
+		}
+	}
+	for _, fu := range cq.Status.FlavorsReservation {
+		for _, ru := range fu.Resources {
+			free[ru.Name] -= resources.ResourceValue(ru.Name, ru.Total)
+		}
+	}
+	return free, nil
+}
+
+// freeCapacityScore returns the minimum, among the resources requested by the
+// workload, of the ratio of the free capacity to the requests.
+func freeCapacityScore(free, requests resources.Requests) float64 {
+	score := math.Inf(1)
+	for rName, req := range requests {
+		if req > 0 {
+			score = min(score, float64(free[rName])/float64(req))
+		}
+	}
+	return score
+}
+
+func totalRequests(wl *kueue.Workload) resources.Requests {
+	total := make(resources.Requests)
+	for _, ps := range workload.NewInfo(wl).TotalRequests {
+		total.Add(ps.Requests)
+	}
+	return total
+}
+
+func clusterNames(clusters []WorkerCluster) []string {
+	names := make([]string, len(clusters))
+	for i, c := range clusters {
+		names[i] = c.Name
+	}
+	return names
+}
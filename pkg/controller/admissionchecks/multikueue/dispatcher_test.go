@@ -0,0 +1,215 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestNominate(t *testing.T) {
+	now := time.Now()
+
+	// worker builds a worker cluster with a ClusterQueue providing the
+	// nominal quota of CPUs, of which the reserved CPUs are in use.
+	worker := func(name string, nominal, reserved string) WorkerCluster {
+		builder, _ := getClientBuilder()
+		cq := utiltesting.MakeClusterQueue("cq").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, nominal).Obj()).
+			Obj()
+		cq.Status.FlavorsReservation = []kueue.FlavorUsage{{
+			Name: "default",
+			Resources: []kueue.ResourceUsage{{
+				Name:  corev1.ResourceCPU,
+				Total: resource.MustParse(reserved),
+			}},
+		}}
+		lq := utiltesting.MakeLocalQueue("lq", TestNamespace).ClusterQueue("cq").Obj()
+		return WorkerCluster{
+			Name:   name,
+			Client: builder.WithObjects(cq, lq).Build(),
+		}
+	}
+	unreachable := func(name string) WorkerCluster {
+		builder, _ := getClientBuilder()
+		return WorkerCluster{Name: name, Client: builder.Build()}
+	}
+	wlReservedAt := func(t time.Time) *kueue.Workload {
+		return utiltesting.MakeWorkload("wl", TestNamespace).
+			Queue("lq").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "default", "2").Obj(), t).
+			Obj()
+	}
+
+	cases := map[string]struct {
+		dispatcher     kueue.MultiKueueDispatcher
+		workload       *kueue.Workload
+		candidates     []WorkerCluster
+		wantNomination *Nomination
+	}{
+		"all at once": {
+			dispatcher: kueue.MultiKueueDispatcher{Name: kueue.MultiKueueDispatcherAllAtOnce},
+			workload:   wlReservedAt(now),
+			candidates: []WorkerCluster{unreachable("w1"), unreachable("w2"), unreachable("w3"), unreachable("w4")},
+			wantNomination: &Nomination{
+				Clusters: []string{"w1", "w2", "w3", "w4"},
+			},
+		},
+		"incremental, first round with the default batch size": {
+			dispatcher: kueue.MultiKueueDispatcher{Name: kueue.MultiKueueDispatcherIncremental},
+			workload:   wlReservedAt(now.Add(-time.Minute)),
+			candidates: []WorkerCluster{unreachable("w1"), unreachable("w2"), unreachable("w3"), unreachable("w4")},
+			wantNomination: &Nomination{
+				Clusters:     []string{"w1", "w2", "w3"},
+				RequeueAfter: 4 * time.Minute,
+			},
+		},
+		"incremental, second round": {
+			dispatcher: kueue.MultiKueueDispatcher{
+				Name: kueue.MultiKueueDispatcherIncremental,
+				Incremental: &kueue.MultiKueueIncrementalDispatcher{
+					BatchSize:    ptr.To[int32](1),
+					RoundTimeout: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			workload:   wlReservedAt(now.Add(-90 * time.Second)),
+			candidates: []WorkerCluster{unreachable("w1"), unreachable("w2"), unreachable("w3"), unreachable("w4")},
+			wantNomination: &Nomination{
+				Clusters:     []string{"w1", "w2"},
+				RequeueAfter: 30 * time.Second,
+			},
+		},
+		"incremental, all the clusters nominated": {
+			dispatcher: kueue.MultiKueueDispatcher{
+				Name: kueue.MultiKueueDispatcherIncremental,
+				Incremental: &kueue.MultiKueueIncrementalDispatcher{
+					BatchSize: ptr.To[int32](2),
+				},
+			},
+			workload:   wlReservedAt(now.Add(-6 * time.Minute)),
+			candidates: []WorkerCluster{unreachable("w1"), unreachable("w2"), unreachable("w3")},
+			wantNomination: &Nomination{
+				Clusters: []string{"w1", "w2", "w3"},
+			},
+		},
+		"free capacity, ordered by the free quota relative to the requests": {
+			dispatcher: kueue.MultiKueueDispatcher{
+				Name: kueue.MultiKueueDispatcherFreeCapacity,
+				Incremental: &kueue.MultiKueueIncrementalDispatcher{
+					BatchSize: ptr.To[int32](2),
+				},
+			},
+			workload: wlReservedAt(now),
+			candidates: []WorkerCluster{
+				unreachable("w1"),
+				worker("w2", "10", "9"),
+				worker("w3", "10", "2"),
+				worker("w4", "20", "8"),
+			},
+			wantNomination: &Nomination{
+				Clusters:     []string{"w4", "w3"},
+				RequeueAfter: 5 * time.Minute,
+			},
+		},
+		"free capacity, the clusters without information are last": {
+			dispatcher: kueue.MultiKueueDispatcher{Name: kueue.MultiKueueDispatcherFreeCapacity},
+			workload:   wlReservedAt(now),
+			candidates: []WorkerCluster{
+				unreachable("w1"),
+				worker("w2", "10", "9"),
+				unreachable("w3"),
+				worker("w4", "20", "8"),
+			},
+			wantNomination: &Nomination{
+				Clusters:     []string{"w4", "w2", "w1"},
+				RequeueAfter: 5 * time.Minute,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, ctx := getClientBuilder()
+			selectors := builtinSelectors(testingclock.NewFakeClock(now))
+			got, err := selectors[tc.dispatcher.Name].Nominate(ctx, tc.workload, &tc.dispatcher, tc.candidates)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantNomination, got); diff != "" {
+				t.Errorf("Unexpected nomination (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFreeCapacityCache(t *testing.T) {
+	now := time.Now()
+	fakeClock := testingclock.NewFakeClock(now)
+	builder, ctx := getClientBuilder()
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", TestNamespace).ClusterQueue("cq").Obj()
+	gets := 0
+	worker := WorkerCluster{
+		Name: "worker",
+		Client: builder.WithObjects(cq, lq).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				gets++
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build(),
+	}
+	wl := utiltesting.MakeWorkload("wl", TestNamespace).Queue("lq").Request(corev1.ResourceCPU, "2").Obj()
+	want := resources.Requests{corev1.ResourceCPU: 10_000}
+
+	cache := newFreeCapacityCache(fakeClock)
+	for range 2 {
+		got, err := cache.get(ctx, worker, wl)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Unexpected free capacity (-want,+got):\n%s", diff)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("Unexpected number of reads from the worker cluster %d, want 2", gets)
+	}
+
+	fakeClock.Step(freeCapacityTTL)
+	if _, err := cache.get(ctx, worker, wl); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gets != 4 {
+		t.Errorf("Unexpected number of reads from the worker cluster once expired %d, want 4", gets)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multikueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	defaultUnreachableTimeout = 5 * time.Minute
+	defaultCordonThreshold    = 3
+	defaultCordonWindow       = 10 * time.Minute
+
+	cordonedReason    = "RepeatedConnectionFailures"
+	notCordonedReason = "Healthy"
+)
+
+// failoverPolicy configures the failover of the workloads away from the
+// unreachable worker clusters, and the cordoning of the worker clusters
+// whose connection fails repeatedly.
+type failoverPolicy struct {
+	// unreachableTimeout is the time after which the workloads that got quota
+	// reservation in an unreachable worker cluster are dispatched to the
+	// other worker clusters.
+	unreachableTimeout time.Duration
+	// cordonThreshold is the number of connection failures within the
+	// cordonWindow after which a worker cluster is cordoned.
+	// If 0, the worker clusters are never cordoned.
+	cordonThreshold int
+	cordonWindow    time.Duration
+}
+
+// connFailures keeps track of the recent connection failures of a worker
+// cluster.
+type connFailures struct {
+	lock  sync.Mutex
+	times []time.Time
+}
+
+func (f *connFailures) record(now time.Time) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.times = append(f.times, now)
+}
+
+// cordonedFor returns the time for which the worker cluster remains cordoned,
+// or 0 if it isn't cordoned. A worker cluster is cordoned while the number of
+// connection failures within the cordon window reaches the threshold.
+func (f *connFailures) cordonedFor(now time.Time, policy failoverPolicy) time.Duration {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	cutoff := now.Add(-policy.cordonWindow)
+	f.times = slices.DeleteFunc(f.times, func(t time.Time) bool { return !t.After(cutoff) })
+	if policy.cordonThreshold <= 0 || len(f.times) < policy.cordonThreshold {
+		return 0
+	}
+	return f.times[len(f.times)-policy.cordonThreshold].Add(policy.cordonWindow).Sub(now)
+}
+
+// abandonedClusters returns the worker clusters the workload failed over
+// from, whose remote objects are yet to be deleted.
+func abandonedClusters(wl *kueue.Workload) []string {
+	value := wl.Annotations[kueue.MultiKueueAbandonedClustersAnnotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// setAbandonedClusters records in the workload the worker clusters it failed
+// over from, whose remote objects are yet to be deleted.
+func (w *wlReconciler) setAbandonedClusters(ctx context.Context, wl *kueue.Workload, clusters []string) error {
+	patch := client.MergeFrom(wl.DeepCopy())
+	if len(clusters) == 0 {
+		delete(wl.Annotations, kueue.MultiKueueAbandonedClustersAnnotation)
+	} else {
+		if wl.Annotations == nil {
+			wl.Annotations = make(map[string]string, 1)
+		}
+		wl.Annotations[kueue.MultiKueueAbandonedClustersAnnotation] = strings.Join(clusters, ",")
+	}
+	return w.client.Patch(ctx, wl, patch)
+}
+
+// cordonedFor returns the time for which the worker cluster remains
+// cordoned, or 0 if it isn't cordoned.
+func (c *clustersReconciler) cordonedFor(clusterName string) time.Duration {
+	rc, found := c.controllerFor(clusterName)
+	if !found {
+		return 0
+	}
+	return rc.connFailures.cordonedFor(c.clock.Now(), c.failoverPolicy)
+}
+
+func (c *clustersReconciler) cordonedCondition(cluster *kueue.MultiKueueCluster) metav1.Condition {
+	if d := c.cordonedFor(cluster.Name); d > 0 {
+		return metav1.Condition{
+			Type:               kueue.MultiKueueClusterCordoned,
+			Status:             metav1.ConditionTrue,
+			Reason:             cordonedReason,
+			Message:            fmt.Sprintf("The connection failed at least %d times in %s", c.failoverPolicy.cordonThreshold, c.failoverPolicy.cordonWindow),
+			ObservedGeneration: cluster.Generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               kueue.MultiKueueClusterCordoned,
+		Status:             metav1.ConditionFalse,
+		Reason:             notCordonedReason,
+		Message:            "The cluster receives new workloads",
+		ObservedGeneration: cluster.Generation,
+	}
+}
+
+// unreachablePlacement returns the name of the worker cluster in which the
+// workload of the group got quota reservation, if the cluster is unreachable,
+// along with the time after which the workload should be dispatched to the
+// other worker clusters.
+func (w *wlReconciler) unreachablePlacement(ctx context.Context, group *wlGroup) (string, time.Duration, error) {
+	placement := ptr.Deref(group.local.Status.ClusterName, "")
+	if placement == "" {
+		return "", 0, nil
+	}
+	if _, connected := group.remoteClients[placement]; connected {
+		return "", 0, nil
+	}
+	cluster := &kueue.MultiKueueCluster{}
+	if err := w.client.Get(ctx, types.NamespacedName{Name: placement}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return placement, 0, nil
+		}
+		return "", 0, err
+	}
+	active := apimeta.FindStatusCondition(cluster.Status.Conditions, kueue.MultiKueueClusterActive)
+	if active == nil || active.Status == metav1.ConditionTrue {
+		// The connection is being re-established.
+		return "", 0, nil
+	}
+	return placement, w.failoverPolicy.unreachableTimeout - w.clock.Since(active.LastTransitionTime.Time), nil
+}
+
+// failOver makes the workload of the group pending in the MultiKueue
+// admission check, for it to be dispatched to the other worker clusters.
+// The workload keeps its quota reservation in the manager cluster.
+// The placement is recorded as abandoned in the workload, for its remote
+// objects to be deleted once the unreachable cluster is reachable again, and
+// is no longer recorded as the cluster in which the workload has reservation,
+// so that it's never preferred over the new placement, even after a restart.
+func (w *wlReconciler) failOver(ctx context.Context, group *wlGroup, acs *kueue.AdmissionCheckState, placement string) error {
+	if abandoned := abandonedClusters(group.local); !slices.Contains(abandoned, placement) {
+		if err := w.setAbandonedClusters(ctx, group.local, append(abandoned, placement)); err != nil {
+			return err
+		}
+	}
+	acs.State = kueue.CheckStatePending
+	acs.Message = fmt.Sprintf("The worker cluster %q is unreachable, dispatching the workload to the other clusters", placement)
+	acs.LastTransitionTime = metav1.NewTime(w.clock.Now())
+	wlPatch := workload.BaseSSAWorkload(group.local)
+	workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, *acs, w.clock)
+	return w.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName), client.ForceOwnership)
+}
+
+// removeAbandoned deletes the remote objects of the workload of the group in
+// the reachable worker clusters from which the workload failed over, and
+// forgets these clusters, along with the ones no longer in the
+// MultiKueueConfig. The clusters whose remote objects can't be deleted are
+// kept, for the deletion to be retried.
+func (w *wlReconciler) removeAbandoned(ctx context.Context, group *wlGroup) error {
+	abandoned := abandonedClusters(group.local)
+	if len(abandoned) == 0 {
+		return nil
+	}
+	var errs []error
+	remaining := make([]string, 0, len(abandoned))
+	for _, rem := range abandoned {
+		if _, connected := group.remoteClients[rem]; connected {
+			if err := group.RemoveRemoteObjects(ctx, rem); client.IgnoreNotFound(err) != nil {
+				errs = append(errs, fmt.Errorf("worker cluster %q: %w", rem, err))
+				remaining = append(remaining, rem)
+				continue
+			}
+			ctrl.LoggerFrom(ctx).V(2).Info("Deleted the remote objects of the abandoned placement", "workerCluster", rem)
+		} else if slices.Contains(group.clusters, rem) {
+			remaining = append(remaining, rem)
+		}
+	}
+	if len(remaining) < len(abandoned) {
+		errs = append(errs, w.setAbandonedClusters(ctx, group.local, remaining))
+	}
+	return errors.Join(errs...)
+}
+
+// reservationMessage returns the message of the admission check when the
+// workload of the group got reservation in the given worker cluster.
+func reservationMessage(group *wlGroup, reservingRemote string) string {
+	if abandoned := abandonedClusters(group.local); len(abandoned) > 0 && abandoned[len(abandoned)-1] != reservingRemote {
+		return fmt.Sprintf("The workload got reservation on %q, after failing over from %q", reservingRemote, abandoned[len(abandoned)-1])
+	}
+	return fmt.Sprintf("The workload got reservation on %q", reservingRemote)
+}
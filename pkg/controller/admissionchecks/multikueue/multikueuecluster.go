@@ -40,6 +40,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,6 +53,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
 )
 
 const (
@@ -86,6 +88,10 @@ type remoteClient struct {
 
 	connecting         atomic.Bool
 	failedConnAttempts uint
+	// connFailures are the recent connection failures, used to cordon the
+	// cluster if they happen repeatedly.
+	connFailures connFailures
+	clock        clock.Clock
 
 	// For unit testing only. There is now need of creating fully functional remote clients in the unit tests
 	// and creating valid kubeconfig content is not trivial.
@@ -101,6 +107,7 @@ func newRemoteClient(localClient client.Client, wlUpdateCh, watchEndedCh chan<-
 		localClient:  localClient,
 		origin:       origin,
 		adapters:     adapters,
+		clock:        realClock,
 	}
 	rc.connecting.Store(true)
 	return rc
@@ -151,6 +158,7 @@ func (rc *remoteClient) setConfig(watchCtx context.Context, kubeconfig []byte) (
 	}
 	remoteClient, err := builder(kubeconfig, client.Options{Scheme: rc.localClient.Scheme()})
 	if err != nil {
+		rc.connFailures.record(rc.clock.Now())
 		return nil, err
 	}
 
@@ -160,6 +168,7 @@ func (rc *remoteClient) setConfig(watchCtx context.Context, kubeconfig []byte) (
 	err = rc.startWatcher(watchCtx, kueue.GroupVersion.WithKind("Workload").GroupKind().String(), &workloadKueueWatcher{})
 	if err != nil {
 		rc.failedConnAttempts++
+		rc.connFailures.record(rc.clock.Now())
 		return ptr.To(retryAfter(rc.failedConnAttempts)), err
 	}
 
@@ -175,6 +184,7 @@ func (rc *remoteClient) setConfig(watchCtx context.Context, kubeconfig []byte) (
 			ctrl.LoggerFrom(watchCtx).Error(err, "Unable to start the watcher", "kind", kind)
 			// however let's not accept this for now.
 			rc.failedConnAttempts++
+			rc.connFailures.record(rc.clock.Now())
 			return ptr.To(retryAfter(rc.failedConnAttempts)), err
 		}
 	}
@@ -217,6 +227,7 @@ func (rc *remoteClient) startWatcher(ctx context.Context, kind string, w jobfram
 			oldConnecting := rc.connecting.Swap(true)
 			// reconnect if this is the first watch failing.
 			if !oldConnecting {
+				rc.connFailures.record(rc.clock.Now())
 				log.V(2).Info("Queue reconcile for reconnect", "cluster", rc.clusterName)
 				rc.queueWatchEndedEvent(ctx)
 			}
@@ -250,8 +261,8 @@ func (rc *remoteClient) queueWatchEndedEvent(ctx context.Context) {
 }
 
 // runGC - lists all the remote workloads having the same multikueue-origin and remove those who
-// no longer have a local correspondent (missing or awaiting deletion). If the remote workload
-// is owned by a job, also delete the job.
+// no longer have a local correspondent (missing or awaiting deletion) or that failed over to
+// another cluster. If the remote workload is owned by a job, also delete the job.
 func (rc *remoteClient) runGC(ctx context.Context) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -268,15 +279,17 @@ func (rc *remoteClient) runGC(ctx context.Context) {
 	}
 
 	for _, remoteWl := range lst.Items {
+		key := client.ObjectKeyFromObject(&remoteWl)
 		localWl := &kueue.Workload{}
 		wlLog := log.WithValues("remoteWl", klog.KObj(&remoteWl))
-		err := rc.localClient.Get(ctx, client.ObjectKeyFromObject(&remoteWl), localWl)
+		err := rc.localClient.Get(ctx, key, localWl)
 		if client.IgnoreNotFound(err) != nil {
 			wlLog.Error(err, "Reading local workload")
 			continue
 		}
 
-		if err == nil && localWl.DeletionTimestamp.IsZero() {
+		abandoned := err == nil && slices.Contains(abandonedClusters(localWl), rc.clusterName)
+		if err == nil && localWl.DeletionTimestamp.IsZero() && !abandoned {
 			// The local workload exists and isn't being deleted, so the remote workload is still relevant.
 			continue
 		}
@@ -295,7 +308,7 @@ func (rc *remoteClient) runGC(ctx context.Context) {
 				}
 			}
 		}
-		wlLog.V(5).Info("MultiKueueGC deleting remote workload")
+		wlLog.V(5).Info("MultiKueueGC deleting remote workload", "abandoned", abandoned)
 		if err := rc.client.Delete(ctx, &remoteWl); client.IgnoreNotFound(err) != nil {
 			wlLog.Error(err, "Deleting remote workload")
 		}
@@ -336,6 +349,9 @@ type clustersReconciler struct {
 	fsWatcher *KubeConfigFSWatcher
 
 	adapters map[string]jobframework.MultiKueueAdapter
+
+	failoverPolicy failoverPolicy
+	clock          clock.Clock
 }
 
 var _ manager.Runnable = (*clustersReconciler)(nil)
@@ -366,6 +382,7 @@ func (c *clustersReconciler) setRemoteClientConfig(ctx context.Context, clusterN
 		if c.builderOverride != nil {
 			client.builderOverride = c.builderOverride
 		}
+		client.clock = c.clock
 		c.remoteClients[clusterName] = client
 	}
 
@@ -422,7 +439,12 @@ func (c *clustersReconciler) Reconcile(ctx context.Context, req reconcile.Reques
 			return reconcile.Result{RequeueAfter: ptr.Deref(retryAfter, 0)}, nil
 		}
 	}
-	return reconcile.Result{}, c.updateStatus(ctx, cluster, true, "Active", "Connected")
+	var result reconcile.Result
+	if features.Enabled(features.MultiKueueFailover) {
+		// Reconcile again when the cluster is no longer cordoned.
+		result.RequeueAfter = c.cordonedFor(cluster.Name)
+	}
+	return result, c.updateStatus(ctx, cluster, true, "Active", "Connected")
 }
 
 func (c *clustersReconciler) getKubeConfig(ctx context.Context, ref *kueue.KubeConfig) ([]byte, bool, error) {
@@ -469,13 +491,26 @@ func (c *clustersReconciler) updateStatus(ctx context.Context, cluster *kueue.Mu
 		newCondition.Status = metav1.ConditionTrue
 	}
 
-	// if the condition is up-to-date
+	needsUpdate := false
 	oldCondition := apimeta.FindStatusCondition(cluster.Status.Conditions, kueue.MultiKueueClusterActive)
-	if cmpConditionState(oldCondition, &newCondition) {
-		return nil
+	if !cmpConditionState(oldCondition, &newCondition) {
+		apimeta.SetStatusCondition(&cluster.Status.Conditions, newCondition)
+		needsUpdate = true
+	}
+
+	if features.Enabled(features.MultiKueueFailover) {
+		cordonedCondition := c.cordonedCondition(cluster)
+		oldCordonedCondition := apimeta.FindStatusCondition(cluster.Status.Conditions, kueue.MultiKueueClusterCordoned)
+		if !cmpConditionState(oldCordonedCondition, &cordonedCondition) {
+			apimeta.SetStatusCondition(&cluster.Status.Conditions, cordonedCondition)
+			needsUpdate = true
+		}
 	}
 
-	apimeta.SetStatusCondition(&cluster.Status.Conditions, newCondition)
+	// if the conditions are up-to-date
+	if !needsUpdate {
+		return nil
+	}
 	return c.localClient.Status().Update(ctx, cluster)
 }
 
@@ -510,7 +545,7 @@ func (c *clustersReconciler) getRemoteClients() []*remoteClient {
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=multikueueclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=multikueueclusters/status,verbs=get;update;patch
 
-func newClustersReconciler(c client.Client, namespace string, gcInterval time.Duration, origin string, fsWatcher *KubeConfigFSWatcher, adapters map[string]jobframework.MultiKueueAdapter) *clustersReconciler {
+func newClustersReconciler(c client.Client, namespace string, gcInterval time.Duration, origin string, fsWatcher *KubeConfigFSWatcher, adapters map[string]jobframework.MultiKueueAdapter, failover failoverPolicy) *clustersReconciler {
 	return &clustersReconciler{
 		localClient:     c,
 		configNamespace: namespace,
@@ -521,6 +556,8 @@ func newClustersReconciler(c client.Client, namespace string, gcInterval time.Du
 		watchEndedCh:    make(chan event.GenericEvent, eventChBufferSize),
 		fsWatcher:       fsWatcher,
 		adapters:        adapters,
+		failoverPolicy:  failover,
+		clock:           realClock,
 	}
 }
 
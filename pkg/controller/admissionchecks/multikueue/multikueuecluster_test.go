@@ -30,12 +30,14 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/watch"
+	testingclock "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
@@ -71,6 +73,7 @@ func newTestClient(config string, watchCancel func()) *remoteClient {
 		kubeconfig:  []byte(config),
 		localClient: localClient,
 		watchCancel: watchCancel,
+		clock:       realClock,
 
 		builderOverride: fakeClientBuilder,
 	}
@@ -98,12 +101,21 @@ func makeTestSecret(name string, kubeconfig string) corev1.Secret {
 func TestUpdateConfig(t *testing.T) {
 	cancelCalledCount := 0
 	cancelCalled := func() { cancelCalledCount++ }
+	now := time.Now()
+	withConnFailures := func(rc *remoteClient, count int) *remoteClient {
+		for range count {
+			rc.connFailures.record(now.Add(-time.Minute))
+		}
+		return rc
+	}
 
 	cases := map[string]struct {
 		reconcileFor  string
 		remoteClients map[string]*remoteClient
 		clusters      []kueue.MultiKueueCluster
 		secrets       []corev1.Secret
+		// failover enables the MultiKueueFailover feature gate.
+		failover bool
 
 		wantRemoteClients map[string]*remoteClient
 		wantClusters      []kueue.MultiKueueCluster
@@ -367,10 +379,70 @@ func TestUpdateConfig(t *testing.T) {
 			},
 			wantCancelCalled: 1,
 		},
+		"cluster is cordoned after repeated connection failures": {
+			reconcileFor: "worker1",
+			failover:     true,
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Active(metav1.ConditionFalse, "ClientConnectionFailed", "client cannot watch", 1).
+					Generation(1).
+					Obj(),
+			},
+			secrets: []corev1.Secret{
+				makeTestSecret("worker1", "worker1 kubeconfig"),
+			},
+			remoteClients: map[string]*remoteClient{
+				"worker1": withConnFailures(setReconnectState(newTestClient("worker1 kubeconfig", cancelCalled), 2), defaultCordonThreshold),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": newTestClient("worker1 kubeconfig", nil),
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					Cordoned(metav1.ConditionTrue, "RepeatedConnectionFailures", "The connection failed at least 3 times in 10m0s", 1).
+					Generation(1).
+					Obj(),
+			},
+			wantRequeueAfter: defaultCordonWindow - time.Minute,
+			wantCancelCalled: 1,
+		},
+		"cluster is uncordoned when the connection failures within the window are below the threshold": {
+			reconcileFor: "worker1",
+			failover:     true,
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					Cordoned(metav1.ConditionTrue, "RepeatedConnectionFailures", "The connection failed at least 3 times in 10m0s", 1).
+					Generation(1).
+					Obj(),
+			},
+			secrets: []corev1.Secret{
+				makeTestSecret("worker1", "worker1 kubeconfig"),
+			},
+			remoteClients: map[string]*remoteClient{
+				"worker1": withConnFailures(newTestClient("worker1 kubeconfig", cancelCalled), defaultCordonThreshold-1),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": newTestClient("worker1 kubeconfig", nil),
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					Cordoned(metav1.ConditionFalse, "Healthy", "The cluster receives new workloads", 1).
+					Generation(1).
+					Obj(),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.MultiKueueFailover, tc.failover)
 			builder, ctx := getClientBuilder()
 			builder = builder.WithLists(&kueue.MultiKueueClusterList{Items: tc.clusters})
 			builder = builder.WithLists(&corev1.SecretList{Items: tc.secrets})
@@ -378,7 +450,12 @@ func TestUpdateConfig(t *testing.T) {
 			c := builder.Build()
 
 			adapters, _ := jobframework.GetMultiKueueAdapters(sets.New("batch/job"))
-			reconciler := newClustersReconciler(c, TestNamespace, 0, defaultOrigin, nil, adapters)
+			reconciler := newClustersReconciler(c, TestNamespace, 0, defaultOrigin, nil, adapters, failoverPolicy{
+				unreachableTimeout: defaultUnreachableTimeout,
+				cordonThreshold:    defaultCordonThreshold,
+				cordonWindow:       defaultCordonWindow,
+			})
+			reconciler.clock = testingclock.NewFakeClock(now)
 
 			reconciler.rootContext = ctx
 
@@ -501,6 +578,26 @@ func TestRemoteClientGC(t *testing.T) {
 					Obj(),
 			},
 		},
+		"abandoned worker workloads and their owner jobs are deleted": {
+			managersWorkloads: []kueue.Workload{
+				*baseWlBuilder.Clone().
+					Annotation(kueue.MultiKueueAbandonedClustersAnnotation, "worker2,worker1").
+					Obj(),
+			},
+			workersWorkloads: []kueue.Workload{
+				*baseWlBuilder.Clone().
+					Label(kueue.MultiKueueOriginLabel, defaultOrigin).
+					Obj(),
+			},
+			managersJobs: []batchv1.Job{
+				*baseJobBuilder.Clone().
+					Obj(),
+			},
+			workersJobs: []batchv1.Job{
+				*baseJobBuilder.Clone().
+					Obj(),
+			},
+		},
 		"unrelated workers and jobs are not deleted": {
 			workersWorkloads: []kueue.Workload{
 				*baseWlBuilder.Clone().
@@ -539,7 +636,7 @@ func TestRemoteClientGC(t *testing.T) {
 			worker1Client := worker1Builder.Build()
 
 			adapters, _ := jobframework.GetMultiKueueAdapters(sets.New("batch/job"))
-			w1remoteClient := newRemoteClient(managerClient, nil, nil, defaultOrigin, "", adapters)
+			w1remoteClient := newRemoteClient(managerClient, nil, nil, defaultOrigin, "worker1", adapters)
 			w1remoteClient.client = worker1Client
 			w1remoteClient.connecting.Store(false)
 
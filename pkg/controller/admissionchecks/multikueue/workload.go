@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -40,6 +42,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
@@ -60,6 +63,8 @@ type wlReconciler struct {
 	deletedWlCache    *utilmaps.SyncMap[string, *kueue.Workload]
 	eventsBatchPeriod time.Duration
 	adapters          map[string]jobframework.MultiKueueAdapter
+	selectors         map[kueue.MultiKueueDispatcherName]ClusterSelector
+	failoverPolicy    failoverPolicy
 	clock             clock.Clock
 }
 
@@ -69,19 +74,27 @@ type wlGroup struct {
 	local         *kueue.Workload
 	remotes       map[string]*kueue.Workload
 	remoteClients map[string]*remoteClient
+	// clusters are the names of the clusters in the MultiKueueConfig.
+	clusters      []string
+	dispatcher    *kueue.MultiKueueDispatcher
 	acName        string
 	jobAdapter    jobframework.MultiKueueAdapter
 	controllerKey types.NamespacedName
 }
 
 type options struct {
-	clock clock.Clock
+	clock          clock.Clock
+	selectors      map[kueue.MultiKueueDispatcherName]ClusterSelector
+	failoverPolicy failoverPolicy
 }
 
 type Option func(*options)
 
 var defaultOptions = options{
 	clock: realClock,
+	failoverPolicy: failoverPolicy{
+		unreachableTimeout: defaultUnreachableTimeout,
+	},
 }
 
 func WithClock(_ testing.TB, c clock.Clock) Option {
@@ -90,6 +103,18 @@ func WithClock(_ testing.TB, c clock.Clock) Option {
 	}
 }
 
+func withClusterSelectors(selectors map[kueue.MultiKueueDispatcherName]ClusterSelector) Option {
+	return func(o *options) {
+		o.selectors = selectors
+	}
+}
+
+func withFailoverPolicy(policy failoverPolicy) Option {
+	return func(o *options) {
+		o.failoverPolicy = policy
+	}
+}
+
 // IsFinished returns true if the local workload is finished.
 func (g *wlGroup) IsFinished() bool {
 	return apimeta.IsStatusConditionTrue(g.local.Status.Conditions, kueue.WorkloadFinished)
@@ -97,12 +122,20 @@ func (g *wlGroup) IsFinished() bool {
 
 // FirstReserving returns true if there is a workload reserving quota,
 // the string identifies the remote cluster.
+// The cluster in which the local workload is recorded to have reservation
+// is preferred, and the clusters the workload failed over from are ignored.
 func (g *wlGroup) FirstReserving() (bool, string) {
+	if placement := ptr.Deref(g.local.Status.ClusterName, ""); placement != "" {
+		if wl := g.remotes[placement]; wl != nil && workload.HasQuotaReservation(wl) {
+			return true, placement
+		}
+	}
+	abandoned := abandonedClusters(g.local)
 	found := false
 	bestMatch := ""
 	var bestTime time.Time
 	for remote, wl := range g.remotes {
-		if wl == nil {
+		if wl == nil || slices.Contains(abandoned, remote) {
 			continue
 		}
 		c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
@@ -247,10 +280,10 @@ func (w *wlReconciler) updateACS(ctx context.Context, wl *kueue.Workload, acs *k
 	return w.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName), client.ForceOwnership)
 }
 
-func (w *wlReconciler) remoteClientsForAC(ctx context.Context, acName string) (map[string]*remoteClient, error) {
+func (w *wlReconciler) remoteClientsForAC(ctx context.Context, acName string) (*kueue.MultiKueueConfig, map[string]*remoteClient, error) {
 	cfg, err := w.helper.ConfigForAdmissionCheck(ctx, acName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	clients := make(map[string]*remoteClient, len(cfg.Spec.Clusters))
 	for _, clusterName := range cfg.Spec.Clusters {
@@ -262,9 +295,9 @@ func (w *wlReconciler) remoteClientsForAC(ctx context.Context, acName string) (m
 		}
 	}
 	if len(clients) == 0 {
-		return nil, errNoActiveClusters
+		return nil, nil, errNoActiveClusters
 	}
-	return clients, nil
+	return cfg, clients, nil
 }
 
 func (w *wlReconciler) multikueueAC(ctx context.Context, local *kueue.Workload) (*kueue.AdmissionCheckState, error) {
@@ -288,7 +321,7 @@ func (w *wlReconciler) adapter(local *kueue.Workload) (jobframework.MultiKueueAd
 }
 
 func (w *wlReconciler) readGroup(ctx context.Context, local *kueue.Workload, acName string, adapter jobframework.MultiKueueAdapter, controllerName string) (*wlGroup, error) {
-	rClients, err := w.remoteClientsForAC(ctx, acName)
+	cfg, rClients, err := w.remoteClientsForAC(ctx, acName)
 	if err != nil {
 		return nil, fmt.Errorf("admission check %q: %w", acName, err)
 	}
@@ -297,6 +330,8 @@ func (w *wlReconciler) readGroup(ctx context.Context, local *kueue.Workload, acN
 		local:         local,
 		remotes:       make(map[string]*kueue.Workload, len(rClients)),
 		remoteClients: rClients,
+		clusters:      cfg.Spec.Clusters,
+		dispatcher:    cfg.Spec.Dispatcher,
 		acName:        acName,
 		jobAdapter:    adapter,
 		controllerKey: types.NamespacedName{Name: controllerName, Namespace: local.Namespace},
@@ -320,17 +355,42 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 	log := ctrl.LoggerFrom(ctx).WithValues("op", "reconcileGroup")
 	log.V(3).Info("Reconcile Workload Group")
 
+	// 0. delete the remote objects left in the clusters the workload failed over from,
+	// without blocking the reconcile of the group if that fails, and retry later.
+	abandonedErr := w.removeAbandoned(ctx, group)
+	if abandonedErr != nil {
+		log.V(2).Error(abandonedErr, "Deleting abandoned remote objects")
+	}
+	result, err := w.syncGroup(ctx, group)
+	return result, errors.Join(err, abandonedErr)
+}
+
+// syncGroup syncs the local workload of the group with its remote workloads.
+// The remote objects in the clusters the workload failed over from are left
+// to removeAbandoned.
+func (w *wlReconciler) syncGroup(ctx context.Context, group *wlGroup) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
 	acs := workload.FindAdmissionCheck(group.local.Status.AdmissionChecks, group.acName)
+	abandoned := abandonedClusters(group.local)
 
 	// 1. delete all remote workloads when finished or the local wl has no reservation
 	if group.IsFinished() || !workload.HasQuotaReservation(group.local) {
 		var errs []error
 		for rem := range group.remotes {
+			if slices.Contains(abandoned, rem) {
+				continue
+			}
 			if err := group.RemoveRemoteObjects(ctx, rem); err != nil {
 				errs = append(errs, err)
 				log.V(2).Error(err, "Deleting remote workload", "workerCluster", rem)
 			}
 		}
+		if len(errs) == 0 && acs != nil && !workload.HasQuotaReservation(group.local) && group.local.Status.ClusterName != nil {
+			// Forget the cluster in which the workload had reservation.
+			wlPatch := workload.BaseSSAWorkload(group.local)
+			workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, *acs, w.clock)
+			return reconcile.Result{}, w.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName), client.ForceOwnership)
+		}
 		return reconcile.Result{}, errors.Join(errs...)
 	}
 
@@ -361,7 +421,7 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 
 	// 2. delete all workloads that are out of sync or are not in the chosen worker
 	for rem, remWl := range group.remotes {
-		if remWl != nil && !equality.Semantic.DeepEqual(group.local.Spec, remWl.Spec) {
+		if remWl != nil && !slices.Contains(abandoned, rem) && !equality.Semantic.DeepEqual(group.local.Spec, remWl.Spec) {
 			if err := client.IgnoreNotFound(group.RemoveRemoteObjects(ctx, rem)); err != nil {
 				log.V(2).Error(err, "Deleting out of sync remote objects", "remote", rem)
 				return reconcile.Result{}, err
@@ -375,7 +435,7 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 	if hasReserving {
 		// remove the non-reserving worker workloads
 		for rem, remWl := range group.remotes {
-			if remWl != nil && rem != reservingRemote {
+			if remWl != nil && rem != reservingRemote && !slices.Contains(abandoned, rem) {
 				if err := client.IgnoreNotFound(group.RemoveRemoteObjects(ctx, rem)); err != nil {
 					log.V(2).Error(err, "Deleting out of sync remote objects", "remote", rem)
 					return reconcile.Result{}, err
@@ -392,17 +452,26 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 		}
 
 		if acs.State != kueue.CheckStateRetry && acs.State != kueue.CheckStateRejected {
+			failover := features.Enabled(features.MultiKueueFailover)
+			oldState := acs.State
 			if group.jobAdapter.KeepAdmissionCheckPending() {
 				acs.State = kueue.CheckStatePending
 			} else {
 				acs.State = kueue.CheckStateReady
 			}
 			// update the message
-			acs.Message = fmt.Sprintf("The workload got reservation on %q", reservingRemote)
+			if !failover {
+				acs.Message = fmt.Sprintf("The workload got reservation on %q", reservingRemote)
+			} else if oldState != acs.State || ptr.Deref(group.local.Status.ClusterName, "") != reservingRemote {
+				acs.Message = reservationMessage(group, reservingRemote)
+			}
 			// update the transition time since is used to detect the lost worker state.
 			acs.LastTransitionTime = metav1.NewTime(w.clock.Now())
 
 			wlPatch := workload.BaseSSAWorkload(group.local)
+			if failover {
+				wlPatch.Status.ClusterName = &reservingRemote
+			}
 			workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, *acs, w.clock)
 			err := w.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueue.MultiKueueControllerName), client.ForceOwnership)
 			if err != nil {
@@ -414,6 +483,21 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 		// If there is no reserving and the AC is ready, the connection with the reserving remote might
 		// be lost, keep the workload admitted for keepReadyTimeout and put it back in the queue after that.
 		remainingWaitTime := w.workerLostTimeout - time.Since(acs.LastTransitionTime.Time)
+		if features.Enabled(features.MultiKueueFailover) {
+			// If the reserving remote is unreachable, dispatch the workload to the other
+			// clusters after the unreachable timeout.
+			placement, failoverAfter, err := w.unreachablePlacement(ctx, group)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if placement != "" {
+				if failoverAfter <= 0 {
+					log.V(2).Info("Reserving remote unreachable, failing over", "workerCluster", placement)
+					return reconcile.Result{}, w.failOver(ctx, group, acs, placement)
+				}
+				remainingWaitTime = min(remainingWaitTime, failoverAfter)
+			}
+		}
 		if remainingWaitTime > 0 {
 			log.V(3).Info("Reserving remote lost, retry", "retryAfter", remainingWaitTime)
 			return reconcile.Result{RequeueAfter: remainingWaitTime}, nil
@@ -427,10 +511,14 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 		}
 	}
 
-	// finally - create missing workloads
+	// finally - create missing workloads in the nominated clusters
+	nomination, err := w.nominate(ctx, group)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	var errs []error
-	for rem, remWl := range group.remotes {
-		if remWl == nil {
+	for _, rem := range nomination.Clusters {
+		if group.remotes[rem] == nil {
 			clone := cloneForCreate(group.local, group.remoteClients[rem].origin)
 			err := group.remoteClients[rem].client.Create(ctx, clone)
 			if err != nil {
@@ -440,7 +528,31 @@ func (w *wlReconciler) reconcileGroup(ctx context.Context, group *wlGroup) (reco
 			}
 		}
 	}
-	return reconcile.Result{}, errors.Join(errs...)
+	return reconcile.Result{RequeueAfter: nomination.RequeueAfter}, errors.Join(errs...)
+}
+
+// nominate returns the clusters where the workload of the group should be
+// created, according to the dispatcher of the MultiKueueConfig.
+func (w *wlReconciler) nominate(ctx context.Context, group *wlGroup) (*Nomination, error) {
+	dispatcher := group.dispatcher
+	if !features.Enabled(features.MultiKueueDispatcher) || dispatcher == nil {
+		dispatcher = &kueue.MultiKueueDispatcher{Name: kueue.MultiKueueDispatcherAllAtOnce}
+	}
+	selector, found := w.selectors[dispatcher.Name]
+	if !found {
+		return nil, fmt.Errorf("%w %q", errUnknownDispatcher, dispatcher.Name)
+	}
+	candidates := make([]WorkerCluster, 0, len(group.remoteClients))
+	for _, name := range group.clusters {
+		if rc, found := group.remoteClients[name]; found {
+			if features.Enabled(features.MultiKueueFailover) && w.clusters.cordonedFor(name) > 0 {
+				// Cordoned clusters don't receive new workloads.
+				continue
+			}
+			candidates = append(candidates, WorkerCluster{Name: name, Client: rc.client})
+		}
+	}
+	return selector.Nominate(ctx, group.local, dispatcher, candidates)
 }
 
 func (w *wlReconciler) Create(_ event.CreateEvent) bool {
@@ -469,6 +581,11 @@ func newWlReconciler(c client.Client, helper *multiKueueStoreHelper, cRec *clust
 		opt(&options)
 	}
 
+	selectors := builtinSelectors(options.clock)
+	for name, selector := range options.selectors {
+		selectors[name] = selector
+	}
+
 	return &wlReconciler{
 		client:            c,
 		helper:            helper,
@@ -478,6 +595,8 @@ func newWlReconciler(c client.Client, helper *multiKueueStoreHelper, cRec *clust
 		deletedWlCache:    utilmaps.NewSyncMap[string, *kueue.Workload](0),
 		eventsBatchPeriod: eventsBatchPeriod,
 		adapters:          adapters,
+		selectors:         selectors,
+		failoverPolicy:    options.failoverPolicy,
 		clock:             options.clock,
 	}
 }
@@ -46,4 +46,18 @@ const (
 
 	// MaxExecTimeSecondsLabel is the label key in the job that holds the maximum execution time.
 	MaxExecTimeSecondsLabel = `kueue.x-k8s.io/max-exec-time-seconds`
+
+	// DependsOnAnnotation is the annotation key in the job, and in its workload,
+	// that holds the comma-separated names of the jobs, in the same namespace,
+	// whose workloads need to finish successfully before the workload is queued.
+	DependsOnAnnotation = "kueue.x-k8s.io/depends-on"
+
+	// WorkloadGroupLabel is the label key in the job, and in its workload, that
+	// holds the name of the workload group the workload belongs to. The
+	// workloads of a group are admitted and evicted together.
+	WorkloadGroupLabel = "kueue.x-k8s.io/workload-group"
+
+	// WorkloadGroupSizeAnnotation is the annotation key in the job, and in its
+	// workload, that holds the number of workloads in the workload group.
+	WorkloadGroupSizeAnnotation = "kueue.x-k8s.io/workload-group-size"
 )
@@ -74,6 +74,7 @@ type ClusterQueueReconciler struct {
 	watchers                             []ClusterQueueUpdateWatcher
 	reportResourceMetrics                bool
 	fairSharingEnabled                   bool
+	fairSharingUsage                     *fairSharingUsage
 	queueVisibilityUpdateInterval        time.Duration
 	queueVisibilityClusterQueuesMaxCount int32
 	clock                                clock.Clock
@@ -83,6 +84,8 @@ type ClusterQueueReconcilerOptions struct {
 	Watchers                             []ClusterQueueUpdateWatcher
 	ReportResourceMetrics                bool
 	FairSharingEnabled                   bool
+	FairSharingUsageHalfLifeTime         time.Duration
+	FairSharingUsageSamplingInterval     time.Duration
 	QueueVisibilityUpdateInterval        time.Duration
 	QueueVisibilityClusterQueuesMaxCount int32
 	clock                                clock.Clock
@@ -109,6 +112,16 @@ func WithFairSharing(enabled bool) ClusterQueueReconcilerOption {
 	}
 }
 
+// WithFairSharingUsage makes the reconciler accumulate the resources consumed
+// by the ClusterQueue in its status, decaying with the given half-life time and
+// sampled at the given interval.
+func WithFairSharingUsage(halfLifeTime, samplingInterval time.Duration) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.FairSharingUsageHalfLifeTime = halfLifeTime
+		o.FairSharingUsageSamplingInterval = samplingInterval
+	}
+}
+
 // WithQueueVisibilityUpdateInterval specifies the time interval for updates to the structure
 // of the top pending workloads in the queues.
 func WithQueueVisibilityUpdateInterval(interval time.Duration) ClusterQueueReconcilerOption {
@@ -139,6 +152,10 @@ func NewClusterQueueReconciler(
 	for _, opt := range opts {
 		opt(&options)
 	}
+	fsUsage := &fairSharingUsage{
+		halfLifeTime:     options.FairSharingUsageHalfLifeTime,
+		samplingInterval: options.FairSharingUsageSamplingInterval,
+	}
 	return &ClusterQueueReconciler{
 		client:                               client,
 		log:                                  ctrl.Log.WithName("cluster-queue-reconciler"),
@@ -153,6 +170,7 @@ func NewClusterQueueReconciler(
 		watchers:                             options.Watchers,
 		reportResourceMetrics:                options.ReportResourceMetrics,
 		fairSharingEnabled:                   options.FairSharingEnabled,
+		fairSharingUsage:                     fsUsage,
 		queueVisibilityUpdateInterval:        options.QueueVisibilityUpdateInterval,
 		queueVisibilityClusterQueuesMaxCount: options.QueueVisibilityClusterQueuesMaxCount,
 		clock:                                options.clock,
@@ -207,6 +225,9 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.updateCqStatusIfChanged(ctx, newCQObj, cqCondition, reason, msg); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	if r.fairSharingEnabled {
+		return ctrl.Result{RequeueAfter: r.fairSharingUsage.requeueAfter()}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -717,6 +738,7 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	cq.Status.FlavorsUsage = stats.AdmittedResources
 	cq.Status.ReservingWorkloads = int32(stats.ReservingWorkloads)
 	cq.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
+	cq.Status.MaxAdmittedWorkloadsUsage = maxAdmittedWorkloadsUsage(cq.Spec.MaxAdmittedWorkloads, cq.Status.ReservingWorkloads)
 	cq.Status.PendingWorkloads = int32(pendingWorkloads)
 	cq.Status.PendingWorkloadsStatus = r.getWorkloadsStatus(cq)
 	meta.SetStatusCondition(&cq.Status.Conditions, metav1.Condition{
@@ -734,6 +756,12 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 			cq.Status.FairSharing = &kueue.FairSharingStatus{}
 		}
 		cq.Status.FairSharing.WeightedShare = stats.WeightedShare
+		if r.fairSharingUsage.enabled() {
+			cq.Status.FairSharing.ConsumedResources = r.fairSharingUsage.accumulate(
+				cq.Status.FairSharing.ConsumedResources, usageByResourceName(stats.ReservedResources), r.clock.Now())
+		} else {
+			cq.Status.FairSharing.ConsumedResources = nil
+		}
 	} else {
 		cq.Status.FairSharing = nil
 	}
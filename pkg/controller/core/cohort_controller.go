@@ -18,46 +18,129 @@ package core
 
 import (
 	"context"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/resource"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 // CohortReconciler is responsible for synchronizing the in-memory
 // representation of Cohorts in cache.Cache and queue.Manager with
 // Cohort Kubernetes objects.
 type CohortReconciler struct {
-	client   client.Client
-	log      logr.Logger
-	cache    *cache.Cache
-	qManager *queue.Manager
+	client                client.Client
+	log                   logr.Logger
+	cache                 *cache.Cache
+	qManager              *queue.Manager
+	wlUpdateCh            chan event.GenericEvent
+	cqUpdateCh            chan event.GenericEvent
+	reportResourceMetrics bool
+	fairSharingEnabled    bool
+
+	// syncedSpecs holds the specs last written to the cache and the
+	// queue manager, so that reconciliations triggered only to refresh
+	// the status don't requeue the inadmissible workloads.
+	syncedSpecsLock sync.Mutex
+	syncedSpecs     map[v1beta1.CohortReference]kueue.CohortSpec
+}
+
+type CohortReconcilerOptions struct {
+	ReportResourceMetrics bool
+	FairSharingEnabled    bool
 }
 
-func NewCohortReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager) CohortReconciler {
-	return CohortReconciler{client, ctrl.Log.WithName("cohort-reconciler"), cache, qManager}
+// CohortReconcilerOption configures the reconciler.
+type CohortReconcilerOption func(*CohortReconcilerOptions)
+
+func WithCohortReportResourceMetrics(report bool) CohortReconcilerOption {
+	return func(o *CohortReconcilerOptions) {
+		o.ReportResourceMetrics = report
+	}
+}
+
+func WithCohortFairSharing(enabled bool) CohortReconcilerOption {
+	return func(o *CohortReconcilerOptions) {
+		o.FairSharingEnabled = enabled
+	}
+}
+
+func NewCohortReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager, opts ...CohortReconcilerOption) *CohortReconciler {
+	var options CohortReconcilerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &CohortReconciler{
+		client:                client,
+		log:                   ctrl.Log.WithName("cohort-reconciler"),
+		cache:                 cache,
+		qManager:              qManager,
+		wlUpdateCh:            make(chan event.GenericEvent, updateChBuffer),
+		cqUpdateCh:            make(chan event.GenericEvent, updateChBuffer),
+		reportResourceMetrics: options.ReportResourceMetrics,
+		fairSharingEnabled:    options.FairSharingEnabled,
+		syncedSpecs:           make(map[v1beta1.CohortReference]kueue.CohortSpec),
+	}
 }
 
 func (r *CohortReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	wHandler := cohortWorkloadHandler{
+		cache: r.cache,
+	}
+	cqHandler := cohortClusterQueueHandler{
+		cache: r.cache,
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.Cohort{}).
 		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		WatchesRawSource(source.Channel(r.wlUpdateCh, &wHandler)).
+		WatchesRawSource(source.Channel(r.cqUpdateCh, &cqHandler)).
 		WithEventFilter(r).
 		Complete(WithLeadingManager(mgr, r, &kueue.Cohort{}, cfg))
 }
 
+// NotifyWorkloadUpdate signals the controller to refresh the status of the
+// Cohorts containing the ClusterQueue in which the workload reserves quota.
+func (r *CohortReconciler) NotifyWorkloadUpdate(oldWl, newWl *v1beta1.Workload) {
+	if oldWl != nil {
+		r.wlUpdateCh <- event.GenericEvent{Object: oldWl}
+	}
+	if newWl != nil {
+		r.wlUpdateCh <- event.GenericEvent{Object: newWl}
+	}
+}
+
+// NotifyClusterQueueUpdate signals the controller to refresh the status of
+// the Cohorts containing the ClusterQueue, before and after the update.
+func (r *CohortReconciler) NotifyClusterQueueUpdate(oldCQ, newCQ *v1beta1.ClusterQueue) {
+	if oldCQ != nil {
+		r.cqUpdateCh <- event.GenericEvent{Object: oldCQ}
+	}
+	if newCQ != nil && (oldCQ == nil || oldCQ.Spec.Cohort != newCQ.Spec.Cohort) {
+		r.cqUpdateCh <- event.GenericEvent{Object: newCQ}
+	}
+}
+
 func (r *CohortReconciler) Create(e event.CreateEvent) bool {
 	return true
 }
@@ -90,6 +173,7 @@ func (r *CohortReconciler) Generic(e event.GenericEvent) bool {
 }
 
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=cohorts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=cohorts/status,verbs=get;update;patch
 
 func (r *CohortReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -101,14 +185,140 @@ func (r *CohortReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			log.V(2).Info("Cohort is being deleted")
 			r.cache.DeleteCohort(v1beta1.CohortReference(req.NamespacedName.Name))
 			r.qManager.DeleteCohort(v1beta1.CohortReference(req.NamespacedName.Name))
+			r.forgetSpec(v1beta1.CohortReference(req.NamespacedName.Name))
+			metrics.ClearCohortMetrics(req.NamespacedName.Name)
 		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	log.V(2).Info("Cohort is being created or updated", "resources", cohort.Spec.ResourceGroups)
-	if err := r.cache.AddOrUpdateCohort(&cohort); err != nil {
-		log.V(2).Error(err, "Error adding or updating cohort in the cache")
+	if r.specChanged(&cohort) {
+		log.V(2).Info("Cohort is being created or updated", "resources", cohort.Spec.ResourceGroups)
+		if err := r.cache.AddOrUpdateCohort(&cohort); err != nil {
+			log.V(2).Error(err, "Error adding or updating cohort in the cache")
+		}
+		r.qManager.AddOrUpdateCohort(ctx, &cohort)
+	}
+	return ctrl.Result{}, r.updateCohortStatusIfChanged(ctx, &cohort)
+}
+
+// specChanged records the spec of the Cohort, returning whether it differs
+// from the spec recorded in the previous reconciliation.
+func (r *CohortReconciler) specChanged(cohort *kueue.Cohort) bool {
+	r.syncedSpecsLock.Lock()
+	defer r.syncedSpecsLock.Unlock()
+	name := v1beta1.CohortReference(cohort.Name)
+	if spec, ok := r.syncedSpecs[name]; ok && equality.Semantic.DeepEqual(spec, cohort.Spec) {
+		return false
+	}
+	r.syncedSpecs[name] = *cohort.Spec.DeepCopy()
+	return true
+}
+
+func (r *CohortReconciler) forgetSpec(name v1beta1.CohortReference) {
+	r.syncedSpecsLock.Lock()
+	defer r.syncedSpecsLock.Unlock()
+	delete(r.syncedSpecs, name)
+}
+
+func (r *CohortReconciler) updateCohortStatusIfChanged(ctx context.Context, cohort *kueue.Cohort) error {
+	log := ctrl.LoggerFrom(ctx)
+	stats, err := r.cache.CohortUsage(v1beta1.CohortReference(cohort.Name))
+	if err != nil {
+		// The status is updated once the Cohort is removed from
+		// the cycle, which triggers a new reconciliation.
+		log.V(2).Info("Skipping Cohort status update", "reason", err.Error())
+		return nil
+	}
+	oldStatus := cohort.Status.DeepCopy()
+	cohort.Status.FlavorsReservation = stats.ReservedResources
+	if r.reportResourceMetrics {
+		recordCohortResourceMetrics(cohort)
+	}
+	if r.fairSharingEnabled {
+		if r.reportResourceMetrics {
+			metrics.ReportCohortWeightedShare(cohort.Name, stats.WeightedShare)
+		}
+		if cohort.Status.FairSharing == nil {
+			cohort.Status.FairSharing = &v1beta1.FairSharingStatus{}
+		}
+		cohort.Status.FairSharing.WeightedShare = stats.WeightedShare
+	} else {
+		cohort.Status.FairSharing = nil
+	}
+	if !equality.Semantic.DeepEqual(cohort.Status, *oldStatus) {
+		return r.client.Status().Update(ctx, cohort)
+	}
+	return nil
+}
+
+func recordCohortResourceMetrics(cohort *kueue.Cohort) {
+	metrics.ClearCohortResourceMetrics(cohort.Name)
+	for fi := range cohort.Status.FlavorsReservation {
+		fu := &cohort.Status.FlavorsReservation[fi]
+		for ri := range fu.Resources {
+			ru := &fu.Resources[ri]
+			metrics.ReportCohortResourceUsage(
+				cohort.Name, string(fu.Name), string(ru.Name),
+				resource.QuantityToFloat(&ru.SubtreeQuota),
+				resource.QuantityToFloat(&ru.Total),
+				resource.QuantityToFloat(&ru.Borrowed),
+			)
+		}
+	}
+}
+
+func cohortRequests(names []v1beta1.CohortReference, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	for _, name := range names {
+		q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{Name: string(name)}}, constants.UpdatesBatchPeriod)
+	}
+}
+
+// cohortWorkloadHandler signals the controller to reconcile the Cohorts
+// containing the ClusterQueue in which the workload in the event reserves quota.
+// Since the events come from a channel Source, only the Generic handler will
+// receive events.
+type cohortWorkloadHandler struct {
+	cache *cache.Cache
+}
+
+func (h *cohortWorkloadHandler) Create(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *cohortWorkloadHandler) Update(context.Context, event.UpdateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *cohortWorkloadHandler) Delete(context.Context, event.DeleteEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *cohortWorkloadHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	w, ok := e.Object.(*v1beta1.Workload)
+	if !ok || !workload.HasQuotaReservation(w) {
+		return
+	}
+	cohortRequests(h.cache.ClusterQueueAncestors(w.Status.Admission.ClusterQueue), q)
+}
+
+// cohortClusterQueueHandler signals the controller to reconcile the Cohorts
+// containing the ClusterQueue in the event.
+// Since the events come from a channel Source, only the Generic handler will
+// receive events.
+type cohortClusterQueueHandler struct {
+	cache *cache.Cache
+}
+
+func (h *cohortClusterQueueHandler) Create(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *cohortClusterQueueHandler) Update(context.Context, event.UpdateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *cohortClusterQueueHandler) Delete(context.Context, event.DeleteEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *cohortClusterQueueHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	cq, ok := e.Object.(*v1beta1.ClusterQueue)
+	if !ok || cq.Spec.Cohort == "" {
+		return
 	}
-	r.qManager.AddOrUpdateCohort(ctx, &cohort)
-	return ctrl.Result{}, nil
+	cohortRequests(h.cache.CohortAncestors(cq.Spec.Cohort), q)
 }
@@ -22,11 +22,14 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -187,7 +190,7 @@ func TestCohortReconcileLifecycle(t *testing.T) {
 	cohort := utiltesting.MakeCohort("cohort").ResourceGroup(
 		utiltesting.MakeFlavorQuotas("red").Resource("cpu", "10").FlavorQuotas,
 	).Obj()
-	cl := utiltesting.NewClientBuilder().WithObjects(cohort).Build()
+	cl := utiltesting.NewClientBuilder().WithObjects(cohort).WithStatusSubresource(cohort).Build()
 	cache := cache.New(cl)
 	qManager := queue.NewManager(cl, cache)
 	reconciler := NewCohortReconciler(cl, cache, qManager)
@@ -361,3 +364,78 @@ func TestCohortReconcilerFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestCohortReconcileStatus(t *testing.T) {
+	ctx := context.Background()
+	root := utiltesting.MakeCohort("root").ResourceGroup(
+		utiltesting.MakeFlavorQuotas("red").Resource("cpu", "4").FlavorQuotas,
+	).Obj()
+	child := utiltesting.MakeCohort("child").Parent("root").Obj()
+	cq := utiltesting.MakeClusterQueue("cq").
+		Cohort("child").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("red").Resource("cpu", "6").Obj()).
+		Obj()
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Request(corev1.ResourceCPU, "8").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "red", "8").Obj()).
+		Obj()
+	cl := utiltesting.NewClientBuilder().WithObjects(root, child).WithStatusSubresource(root, child).Build()
+	cache := cache.New(cl)
+	qManager := queue.NewManager(cl, cache)
+	reconciler := NewCohortReconciler(cl, cache, qManager)
+
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("red").Obj())
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("unexpected error adding ClusterQueue: %v", err)
+	}
+	for _, cohort := range []*kueuealpha.Cohort{root, child} {
+		if _, err := reconciler.Reconcile(
+			ctx,
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cohort)},
+		); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	cache.AddOrUpdateWorkload(wl)
+	for _, cohort := range []*kueuealpha.Cohort{root, child} {
+		if _, err := reconciler.Reconcile(
+			ctx,
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cohort)},
+		); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	wantStatuses := map[string]kueuealpha.CohortStatus{
+		"root": {
+			FlavorsReservation: []kueuealpha.CohortFlavorUsage{{
+				Name: "red",
+				Resources: []kueuealpha.CohortResourceUsage{{
+					Name:         corev1.ResourceCPU,
+					SubtreeQuota: resource.MustParse("10"),
+					Total:        resource.MustParse("8"),
+				}},
+			}},
+		},
+		"child": {
+			FlavorsReservation: []kueuealpha.CohortFlavorUsage{{
+				Name: "red",
+				Resources: []kueuealpha.CohortResourceUsage{{
+					Name:         corev1.ResourceCPU,
+					SubtreeQuota: resource.MustParse("6"),
+					Total:        resource.MustParse("8"),
+					Borrowed:     resource.MustParse("2"),
+				}},
+			}},
+		},
+	}
+	for name, wantStatus := range wantStatuses {
+		var cohort kueuealpha.Cohort
+		if err := cl.Get(ctx, client.ObjectKey{Name: name}, &cohort); err != nil {
+			t.Fatalf("unexpected error getting Cohort %q: %v", name, err)
+		}
+		if diff := cmp.Diff(wantStatus, cohort.Status); diff != "" {
+			t.Errorf("unexpected status of Cohort %q (-want +got):\n%s", name, diff)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
@@ -42,14 +43,34 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 	if err := acRec.SetupWithManager(mgr, cfg); err != nil {
 		return "AdmissionCheck", err
 	}
-	qRec := NewLocalQueueReconciler(mgr.GetClient(), qManager, cc)
+	var fairSharingEnabled bool
+	var usageHalfLifeTime, usageSamplingInterval time.Duration
+	if cfg.FairSharing != nil {
+		fairSharingEnabled = cfg.FairSharing.Enable
+		if cfg.FairSharing.UsageHalfLifeTime != nil {
+			usageHalfLifeTime = cfg.FairSharing.UsageHalfLifeTime.Duration
+		}
+		if cfg.FairSharing.UsageSamplingInterval != nil {
+			usageSamplingInterval = cfg.FairSharing.UsageSamplingInterval.Duration
+		}
+	}
+
+	qRec := NewLocalQueueReconciler(mgr.GetClient(), qManager, cc,
+		WithLocalQueueFairSharingUsage(usageHalfLifeTime, usageSamplingInterval),
+	)
 	if err := qRec.SetupWithManager(mgr, cfg); err != nil {
 		return "LocalQueue", err
 	}
 
-	var fairSharingEnabled bool
-	if cfg.FairSharing != nil {
-		fairSharingEnabled = cfg.FairSharing.Enable
+	cohortRec := NewCohortReconciler(
+		mgr.GetClient(),
+		cc,
+		qManager,
+		WithCohortReportResourceMetrics(cfg.Metrics.EnableClusterQueueResources),
+		WithCohortFairSharing(fairSharingEnabled),
+	)
+	if err := cohortRec.SetupWithManager(mgr, cfg); err != nil {
+		return "Cohort", err
 	}
 
 	cqRec := NewClusterQueueReconciler(
@@ -60,7 +81,8 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		WithReportResourceMetrics(cfg.Metrics.EnableClusterQueueResources),
 		WithQueueVisibilityClusterQueuesMaxCount(queueVisibilityClusterQueuesMaxCount(cfg)),
 		WithFairSharing(fairSharingEnabled),
-		WithWatchers(rfRec, acRec),
+		WithFairSharingUsage(usageHalfLifeTime, usageSamplingInterval),
+		WithWatchers(rfRec, acRec, cohortRec),
 	)
 	if err := mgr.Add(cqRec); err != nil {
 		return "Unable to add ClusterQueue to manager", err
@@ -71,15 +93,17 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		return "ClusterQueue", err
 	}
 
-	cohortRec := NewCohortReconciler(mgr.GetClient(), cc, qManager)
-	if err := cohortRec.SetupWithManager(mgr, cfg); err != nil {
-		return "Cohort", err
+	if features.Enabled(features.AdvanceReservations) {
+		if err := NewReservationReconciler(mgr.GetClient(), cc, qManager).SetupWithManager(mgr, cfg); err != nil {
+			return "Reservation", err
+		}
 	}
 
 	if err := NewWorkloadReconciler(mgr.GetClient(), qManager, cc,
 		mgr.GetEventRecorderFor(constants.WorkloadControllerName),
-		WithWorkloadUpdateWatchers(qRec, cqRec),
+		WithWorkloadUpdateWatchers(qRec, cqRec, cohortRec),
 		WithWaitForPodsReady(waitForPodsReady(cfg.WaitForPodsReady)),
+		WithGracefulPreemptionTimeout(gracefulPreemptionTimeout(cfg.GracefulPreemption)),
 	).SetupWithManager(mgr, cfg); err != nil {
 		return "Workload", err
 	}
@@ -87,6 +111,13 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 	return "", nil
 }
 
+func gracefulPreemptionTimeout(cfg *configapi.GracefulPreemption) time.Duration {
+	if cfg == nil || cfg.Timeout == nil {
+		return configapi.DefaultGracefulPreemptionTimeout
+	}
+	return cfg.Timeout.Duration
+}
+
 func waitForPodsReady(cfg *configapi.WaitForPodsReady) *waitForPodsReadyConfig {
 	if cfg == nil || !cfg.Enable {
 		return nil
@@ -119,3 +150,13 @@ func queueVisibilityClusterQueuesMaxCount(cfg *configapi.Configuration) int32 {
 	}
 	return 0
 }
+
+// maxAdmittedWorkloadsUsage returns the number of workloads counted against
+// the maximum number of admitted workloads of a queue, or nil if the queue
+// doesn't limit the number of admitted workloads.
+func maxAdmittedWorkloadsUsage(limit *int32, reservingWorkloads int32) *int32 {
+	if limit == nil || !features.Enabled(features.MaxAdmittedWorkloads) {
+		return nil
+	}
+	return &reservingWorkloads
+}
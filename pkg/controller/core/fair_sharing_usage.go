@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// maxMilliResourceSeconds is the largest amount of resource-seconds that is
+// stored with milli precision without risking an overflow.
+const maxMilliResourceSeconds = 1e15
+
+// fairSharingUsage configures the accumulation of the resources consumed by
+// the queues, used by fair sharing to account for the historical usage.
+type fairSharingUsage struct {
+	halfLifeTime     time.Duration
+	samplingInterval time.Duration
+}
+
+func (u *fairSharingUsage) enabled() bool {
+	return u != nil && u.halfLifeTime > 0
+}
+
+// requeueAfter returns the time after which the queue should be reconciled
+// again to keep sampling its usage.
+func (u *fairSharingUsage) requeueAfter() time.Duration {
+	if !u.enabled() {
+		return 0
+	}
+	return u.samplingInterval
+}
+
+// accumulate returns the resources consumed up to now, given the resources
+// consumed until the last update and the usage since then. Past consumption
+// decays exponentially with the configured half-life time.
+// If less than the sampling interval elapsed since the last update, the
+// consumed resources are returned unchanged.
+func (u *fairSharingUsage) accumulate(consumed *kueue.ConsumedResources, usage corev1.ResourceList, now time.Time) *kueue.ConsumedResources {
+	if consumed == nil {
+		return &kueue.ConsumedResources{
+			Resources:  corev1.ResourceList{},
+			LastUpdate: metav1.NewTime(now),
+		}
+	}
+	elapsed := now.Sub(consumed.LastUpdate.Time)
+	if elapsed < u.samplingInterval {
+		return consumed
+	}
+	halfLife := u.halfLifeTime.Seconds()
+	decay := math.Exp2(-elapsed.Seconds() / halfLife)
+	// Integral of a constant usage over the elapsed time, weighted by the
+	// same exponential decay.
+	integral := halfLife / math.Ln2 * (1 - decay)
+
+	result := &kueue.ConsumedResources{
+		Resources:  make(corev1.ResourceList, len(consumed.Resources)+len(usage)),
+		LastUpdate: metav1.NewTime(now),
+	}
+	for rName, q := range consumed.Resources {
+		result.Resources[rName] = resourceSeconds(q.AsApproximateFloat64() * decay)
+	}
+	for rName, q := range usage {
+		prev := result.Resources[rName]
+		result.Resources[rName] = resourceSeconds(prev.AsApproximateFloat64() + q.AsApproximateFloat64()*integral)
+	}
+	return result
+}
+
+func resourceSeconds(v float64) resource.Quantity {
+	if v < maxMilliResourceSeconds {
+		return *resource.NewMilliQuantity(int64(v*1000), resource.DecimalSI)
+	}
+	return *resource.NewQuantity(int64(v), resource.DecimalSI)
+}
+
+// usageByResourceName aggregates the usage of all the flavors per resource.
+func usageByResourceName(usage []kueue.FlavorUsage) corev1.ResourceList {
+	result := make(corev1.ResourceList)
+	for _, fu := range usage {
+		for _, ru := range fu.Resources {
+			q := result[ru.Name]
+			q.Add(ru.Total)
+			result[ru.Name] = q
+		}
+	}
+	return result
+}
+
+// localQueueUsageByResourceName aggregates the usage of all the flavors per
+// resource.
+func localQueueUsageByResourceName(usage []kueue.LocalQueueFlavorUsage) corev1.ResourceList {
+	result := make(corev1.ResourceList)
+	for _, fu := range usage {
+		for _, ru := range fu.Resources {
+			q := result[ru.Name]
+			q.Add(ru.Total)
+			result[ru.Name] = q
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestFairSharingUsageAccumulate(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	usage := &fairSharingUsage{
+		halfLifeTime:     time.Hour,
+		samplingInterval: 5 * time.Minute,
+	}
+
+	cases := map[string]struct {
+		consumed *kueue.ConsumedResources
+		usage    corev1.ResourceList
+		want     *kueue.ConsumedResources
+	}{
+		"first sample": {
+			usage: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			},
+			want: &kueue.ConsumedResources{
+				Resources:  corev1.ResourceList{},
+				LastUpdate: metav1.NewTime(now),
+			},
+		},
+		"less than the sampling interval since the last update": {
+			consumed: &kueue.ConsumedResources{
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("100"),
+				},
+				LastUpdate: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			usage: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			},
+			want: &kueue.ConsumedResources{
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("100"),
+				},
+				LastUpdate: metav1.NewTime(now.Add(-time.Minute)),
+			},
+		},
+		"past consumption decays by half after the half-life time": {
+			consumed: &kueue.ConsumedResources{
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100"),
+					corev1.ResourceMemory: resource.MustParse("10Gi"),
+				},
+				LastUpdate: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			want: &kueue.ConsumedResources{
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50"),
+					corev1.ResourceMemory: resource.MustParse("5368709120"),
+				},
+				LastUpdate: metav1.NewTime(now),
+			},
+		},
+		"usage accumulates weighted by the decay": {
+			consumed: &kueue.ConsumedResources{
+				Resources:  corev1.ResourceList{},
+				LastUpdate: metav1.NewTime(now.Add(-time.Hour)),
+			},
+			usage: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			},
+			// 2 * 3600 / ln2 * (1 - 1/2)
+			want: &kueue.ConsumedResources{
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("5193.702"),
+				},
+				LastUpdate: metav1.NewTime(now),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := usage.accumulate(tc.consumed, tc.usage, now)
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b resource.Quantity) bool {
+				return a.Cmp(b) == 0
+			})); diff != "" {
+				t.Errorf("Unexpected consumed resources (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -27,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 )
 
@@ -38,6 +40,8 @@ const (
 	WorkloadQuotaReservedKey   = "status.quotaReserved"
 	WorkloadRuntimeClassKey    = "spec.runtimeClass"
 	OwnerReferenceUID          = "metadata.ownerReferences.uid"
+	WorkloadDependenciesKey    = "metadata.annotations.dependsOn"
+	WorkloadGroupKey           = "metadata.labels.workloadGroup"
 )
 
 func IndexQueueClusterQueue(obj client.Object) []string {
@@ -116,6 +120,33 @@ func IndexOwnerUID(obj client.Object) []string {
 	return slices.Map(obj.GetOwnerReferences(), func(o *metav1.OwnerReference) string { return string(o.UID) })
 }
 
+// IndexWorkloadDependencies returns the names of the jobs, in the namespace
+// of the workload, that the workload depends on.
+func IndexWorkloadDependencies(obj client.Object) []string {
+	value := obj.GetAnnotations()[controllerconsts.DependsOnAnnotation]
+	if _, ok := obj.(*kueue.Workload); !ok || value == "" {
+		return nil
+	}
+	var deps []string
+	seen := sets.New[string]()
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !seen.Has(name) {
+			seen.Insert(name)
+			deps = append(deps, name)
+		}
+	}
+	return deps
+}
+
+func IndexWorkloadGroup(obj client.Object) []string {
+	name := obj.GetLabels()[controllerconsts.WorkloadGroupLabel]
+	if _, ok := obj.(*kueue.Workload); !ok || name == "" {
+		return nil
+	}
+	return []string{name}
+}
+
 // Setup sets the index with the given fields for core apis.
 func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadQueueKey, IndexWorkloadQueue); err != nil {
@@ -139,5 +170,11 @@ func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, OwnerReferenceUID, IndexOwnerUID); err != nil {
 		return fmt.Errorf("setting index on ownerReferences.uid for Workload: %w", err)
 	}
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadDependenciesKey, IndexWorkloadDependencies); err != nil {
+		return fmt.Errorf("setting index on dependencies for Workload: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadGroupKey, IndexWorkloadGroup); err != nil {
+		return fmt.Errorf("setting index on group for Workload: %w", err)
+	}
 	return nil
 }
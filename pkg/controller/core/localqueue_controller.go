@@ -18,6 +18,7 @@ package core
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -25,8 +26,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,24 +62,59 @@ const (
 
 // LocalQueueReconciler reconciles a LocalQueue object
 type LocalQueueReconciler struct {
-	client     client.Client
-	log        logr.Logger
-	queues     *queue.Manager
-	cache      *cache.Cache
-	wlUpdateCh chan event.GenericEvent
+	client           client.Client
+	log              logr.Logger
+	queues           *queue.Manager
+	cache            *cache.Cache
+	wlUpdateCh       chan event.GenericEvent
+	fairSharingUsage *fairSharingUsage
+	clock            clock.Clock
+}
+
+type LocalQueueReconcilerOptions struct {
+	FairSharingUsageHalfLifeTime     time.Duration
+	FairSharingUsageSamplingInterval time.Duration
+	clock                            clock.Clock
+}
+
+// LocalQueueReconcilerOption configures the reconciler.
+type LocalQueueReconcilerOption func(*LocalQueueReconcilerOptions)
+
+// WithLocalQueueFairSharingUsage makes the reconciler accumulate the resources
+// consumed by the LocalQueues of ClusterQueues using admission fair sharing,
+// decaying with the given half-life time and sampled at the given interval.
+func WithLocalQueueFairSharingUsage(halfLifeTime, samplingInterval time.Duration) LocalQueueReconcilerOption {
+	return func(o *LocalQueueReconcilerOptions) {
+		o.FairSharingUsageHalfLifeTime = halfLifeTime
+		o.FairSharingUsageSamplingInterval = samplingInterval
+	}
+}
+
+var defaultLQOptions = LocalQueueReconcilerOptions{
+	clock: realClock,
 }
 
 func NewLocalQueueReconciler(
 	client client.Client,
 	queues *queue.Manager,
 	cache *cache.Cache,
+	opts ...LocalQueueReconcilerOption,
 ) *LocalQueueReconciler {
+	options := defaultLQOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	return &LocalQueueReconciler{
 		log:        ctrl.Log.WithName("localqueue-reconciler"),
 		queues:     queues,
 		cache:      cache,
 		client:     client,
 		wlUpdateCh: make(chan event.GenericEvent, updateChBuffer),
+		fairSharingUsage: &fairSharingUsage{
+			halfLifeTime:     options.FairSharingUsageHalfLifeTime,
+			samplingInterval: options.FairSharingUsageSamplingInterval,
+		},
+		clock: options.clock,
 	}
 }
 
@@ -123,7 +161,10 @@ func (r *LocalQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 	if meta.IsStatusConditionTrue(cq.Status.Conditions, kueue.ClusterQueueActive) {
 		err = r.UpdateStatusIfChanged(ctx, &queueObj, metav1.ConditionTrue, "Ready", "Can submit new workloads to clusterQueue")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		if err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		return ctrl.Result{RequeueAfter: r.fairSharingUsage.requeueAfter()}, nil
 	}
 	err = r.UpdateStatusIfChanged(ctx, &queueObj, metav1.ConditionFalse, clusterQueueIsInactiveReason, clusterQueueIsInactiveMsg)
 	return ctrl.Result{}, client.IgnoreNotFound(err)
@@ -199,6 +240,11 @@ func (r *LocalQueueReconciler) Update(e event.UpdateEvent) bool {
 		if err := r.cache.UpdateLocalQueue(oldLq, newLq); err != nil {
 			log.Error(err, "Failed to update localQueue in the cache")
 		}
+		if features.Enabled(features.LocalQueueQuotas) && !equality.Semantic.DeepEqual(oldLq.Spec.Quotas, newLq.Spec.Quotas) {
+			// The workloads that didn't fit in the previous quotas might fit now.
+			ctx := logr.NewContext(context.Background(), log)
+			r.queues.QueueInadmissibleWorkloads(ctx, sets.New(newLq.Spec.ClusterQueue))
+		}
 		return true
 	}
 
@@ -371,9 +417,11 @@ func (r *LocalQueueReconciler) UpdateStatusIfChanged(
 	queue.Status.PendingWorkloads = pendingWls
 	queue.Status.ReservingWorkloads = int32(stats.ReservingWorkloads)
 	queue.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
+	queue.Status.MaxAdmittedWorkloadsUsage = maxAdmittedWorkloadsUsage(queue.Spec.MaxAdmittedWorkloads, queue.Status.ReservingWorkloads)
 	queue.Status.FlavorsReservation = stats.ReservedResources
 	queue.Status.FlavorUsage = stats.AdmittedResources
 	queue.Status.Flavors = stats.Flavors
+	queue.Status.FairSharing = r.fairSharingStatus(queue, stats)
 	if len(conditionStatus) != 0 && len(reason) != 0 && len(msg) != 0 {
 		meta.SetStatusCondition(&queue.Status.Conditions, metav1.Condition{
 			Type:               kueue.LocalQueueActive,
@@ -394,3 +442,23 @@ func (r *LocalQueueReconciler) UpdateStatusIfChanged(
 	}
 	return nil
 }
+
+// fairSharingStatus returns the fair sharing status of the LocalQueue, if its
+// ClusterQueue uses admission fair sharing.
+func (r *LocalQueueReconciler) fairSharingStatus(lq *kueue.LocalQueue, stats *cache.LocalQueueUsageStats) *kueue.FairSharingStatus {
+	shares := r.cache.LocalQueueShares(lq.Spec.ClusterQueue)
+	if shares == nil {
+		return nil
+	}
+	status := &kueue.FairSharingStatus{
+		WeightedShare: int64(shares[queue.Key(lq)]),
+	}
+	if r.fairSharingUsage.enabled() {
+		var consumed *kueue.ConsumedResources
+		if lq.Status.FairSharing != nil {
+			consumed = lq.Status.FairSharing.ConsumedResources
+		}
+		status.ConsumedResources = r.fairSharingUsage.accumulate(consumed, localQueueUsageByResourceName(stats.ReservedResources), r.clock.Now())
+	}
+	return status
+}
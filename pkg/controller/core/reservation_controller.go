@@ -0,0 +1,126 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+// ReservationReconciler is responsible for synchronizing the Reservations in
+// cache.Cache with the Reservation Kubernetes objects, and for requeueing the
+// inadmissible workloads when the capacity held back by a Reservation changes.
+type ReservationReconciler struct {
+	client   client.Client
+	cache    *cache.Cache
+	qManager *queue.Manager
+	clock    clock.Clock
+}
+
+func NewReservationReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager) *ReservationReconciler {
+	return &ReservationReconciler{
+		client:   client,
+		cache:    cache,
+		qManager: qManager,
+		clock:    realClock,
+	}
+}
+
+func (r *ReservationReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueuealpha.Reservation{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(WithLeadingManager(mgr, r, &kueuealpha.Reservation{}, cfg))
+}
+
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=reservations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=reservations/status,verbs=get;update;patch
+
+func (r *ReservationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconcile Reservation")
+
+	var reservation kueuealpha.Reservation
+	if err := r.client.Get(ctx, req.NamespacedName, &reservation); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.V(2).Info("Reservation is being deleted")
+			r.qManager.QueueInadmissibleWorkloads(ctx, r.cache.DeleteReservation(req.Name))
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cqs := r.cache.AddOrUpdateReservation(&reservation)
+	cond, requeueAfter := reservationCondition(&reservation, r.clock.Now())
+	oldCond := apimeta.FindStatusCondition(reservation.Status.Conditions, kueuealpha.ReservationActive)
+	if oldCond != nil && oldCond.Reason == cond.Reason && oldCond.ObservedGeneration == cond.ObservedGeneration {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	// The capacity held back by the Reservation changed.
+	log.V(2).Info("Reservation changed, requeueing inadmissible workloads", "reason", cond.Reason)
+	r.qManager.QueueInadmissibleWorkloads(ctx, cqs)
+	apimeta.SetStatusCondition(&reservation.Status.Conditions, cond)
+	if err := r.client.Status().Update(ctx, &reservation); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reservationCondition returns the Active condition of the Reservation at the
+// given time, along with the time after which the condition changes, or 0 if
+// it doesn't change anymore.
+func reservationCondition(r *kueuealpha.Reservation, now time.Time) (metav1.Condition, time.Duration) {
+	drainStart, start, end := cache.ReservationWindow(r)
+	cond := metav1.Condition{
+		Type:               kueuealpha.ReservationActive,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: r.Generation,
+	}
+	var next time.Time
+	switch {
+	case now.Before(drainStart):
+		cond.Reason = kueuealpha.ReservationScheduledReason
+		cond.Message = "The time window didn't start"
+		next = drainStart
+	case now.Before(start):
+		cond.Reason = kueuealpha.ReservationDrainingReason
+		cond.Message = "The capacity is held back from the admission of other workloads"
+		next = start
+	case now.Before(end):
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = kueuealpha.ReservationInWindowReason
+		cond.Message = "The capacity can be used by the workloads naming the Reservation"
+		next = end
+	default:
+		cond.Reason = kueuealpha.ReservationExpiredReason
+		cond.Message = "The time window ended"
+		return cond, 0
+	}
+	return cond, next.Sub(now)
+}
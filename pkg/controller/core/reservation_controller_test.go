@@ -0,0 +1,123 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestReservationReconcile(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	start := now.Add(time.Hour)
+	cases := map[string]struct {
+		now              time.Time
+		wantCondition    metav1.Condition
+		wantRequeueAfter time.Duration
+	}{
+		"before the drain": {
+			now: now,
+			wantCondition: metav1.Condition{
+				Type:               kueuealpha.ReservationActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             kueuealpha.ReservationScheduledReason,
+				Message:            "The time window didn't start",
+				ObservedGeneration: 1,
+			},
+			wantRequeueAfter: 50 * time.Minute,
+		},
+		"draining": {
+			now: now.Add(55 * time.Minute),
+			wantCondition: metav1.Condition{
+				Type:               kueuealpha.ReservationActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             kueuealpha.ReservationDrainingReason,
+				Message:            "The capacity is held back from the admission of other workloads",
+				ObservedGeneration: 1,
+			},
+			wantRequeueAfter: 5 * time.Minute,
+		},
+		"in the time window": {
+			now: start.Add(time.Minute),
+			wantCondition: metav1.Condition{
+				Type:               kueuealpha.ReservationActive,
+				Status:             metav1.ConditionTrue,
+				Reason:             kueuealpha.ReservationInWindowReason,
+				Message:            "The capacity can be used by the workloads naming the Reservation",
+				ObservedGeneration: 1,
+			},
+			wantRequeueAfter: 119 * time.Minute,
+		},
+		"expired": {
+			now: start.Add(2 * time.Hour),
+			wantCondition: metav1.Condition{
+				Type:               kueuealpha.ReservationActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             kueuealpha.ReservationExpiredReason,
+				Message:            "The time window ended",
+				ObservedGeneration: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reservation := utiltesting.MakeReservation("reservation", start, 2*time.Hour).
+				ClusterQueue("cq").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				DrainDuration(10 * time.Minute).
+				Generation(1).
+				Obj()
+			cl := utiltesting.NewFakeClient(reservation)
+			ctx := context.Background()
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewReservationReconciler(cl, cqCache, qManager)
+			reconciler.clock = testingclock.NewFakeClock(tc.now)
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(reservation)})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantRequeueAfter, result.RequeueAfter); diff != "" {
+				t.Errorf("Unexpected requeueAfter (-want,+got):\n%s", diff)
+			}
+			var got kueuealpha.Reservation
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(reservation), &got); err != nil {
+				t.Fatalf("Unexpected error getting the Reservation: %v", err)
+			}
+			if diff := cmp.Diff([]metav1.Condition{tc.wantCondition}, got.Status.Conditions,
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected conditions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
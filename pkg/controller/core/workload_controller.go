@@ -43,10 +43,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
@@ -70,8 +72,9 @@ type waitForPodsReadyConfig struct {
 }
 
 type options struct {
-	watchers               []WorkloadUpdateWatcher
-	waitForPodsReadyConfig *waitForPodsReadyConfig
+	watchers                  []WorkloadUpdateWatcher
+	waitForPodsReadyConfig    *waitForPodsReadyConfig
+	gracefulPreemptionTimeout time.Duration
 }
 
 // Option configures the reconciler.
@@ -84,6 +87,15 @@ func WithWaitForPodsReady(value *waitForPodsReadyConfig) Option {
 	}
 }
 
+// WithGracefulPreemptionTimeout indicates the grace period given to the
+// preempted workloads to stop gracefully, when the GracefulPreemption feature
+// is enabled.
+func WithGracefulPreemptionTimeout(value time.Duration) Option {
+	return func(o *options) {
+		o.gracefulPreemptionTimeout = value
+	}
+}
+
 // WithWorkloadUpdateWatchers allows to specify the workload update watchers
 func WithWorkloadUpdateWatchers(value ...WorkloadUpdateWatcher) Option {
 	return func(o *options) {
@@ -91,7 +103,9 @@ func WithWorkloadUpdateWatchers(value ...WorkloadUpdateWatcher) Option {
 	}
 }
 
-var defaultOptions = options{}
+var defaultOptions = options{
+	gracefulPreemptionTimeout: config.DefaultGracefulPreemptionTimeout,
+}
 
 type WorkloadUpdateWatcher interface {
 	NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload)
@@ -107,6 +121,8 @@ type WorkloadReconciler struct {
 	waitForPodsReady *waitForPodsReadyConfig
 	recorder         record.EventRecorder
 	clock            clock.Clock
+
+	gracefulPreemptionTimeout time.Duration
 }
 
 func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache, recorder record.EventRecorder, opts ...Option) *WorkloadReconciler {
@@ -124,6 +140,8 @@ func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *c
 		waitForPodsReady: options.waitForPodsReadyConfig,
 		recorder:         recorder,
 		clock:            realClock,
+
+		gracefulPreemptionTimeout: options.gracefulPreemptionTimeout,
 	}
 }
 
@@ -184,6 +202,10 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if updated {
 			return ctrl.Result{}, workload.ApplyAdmissionStatus(ctx, r.client, &wl, true, r.clock)
 		}
+
+		if updated, err := r.reconcileDependencies(ctx, &wl); updated || err != nil {
+			return ctrl.Result{}, err
+		}
 	} else {
 		var updated, evicted bool
 		reason := kueue.WorkloadDeactivated
@@ -273,6 +295,10 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{}, err
 		}
 
+		if evictionTriggered, err := r.reconcileGroupEviction(ctx, &wl); evictionTriggered || err != nil {
+			return ctrl.Result{}, err
+		}
+
 		if updated, err := r.reconcileOnLocalQueueActiveState(ctx, &wl, lqExists, &lq); updated || err != nil {
 			return ctrl.Result{}, err
 		}
@@ -289,11 +315,17 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+		gracePeriodRecheckAfter, err := r.reconcileGracefulPreemption(ctx, &wl)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 
 		// get the minimun non-zero value
-		recheckAfter := min(podsReadyRecheckAfter, maxExecRecheckAfter)
-		if recheckAfter == 0 {
-			recheckAfter = max(podsReadyRecheckAfter, maxExecRecheckAfter)
+		var recheckAfter time.Duration
+		for _, d := range []time.Duration{podsReadyRecheckAfter, maxExecRecheckAfter, gracePeriodRecheckAfter} {
+			if d > 0 && (recheckAfter == 0 || d < recheckAfter) {
+				recheckAfter = d
+			}
 		}
 		return ctrl.Result{RequeueAfter: recheckAfter}, nil
 	}
@@ -344,14 +376,91 @@ func isDisabledRequeuedByReason(w *kueue.Workload, reason string) bool {
 	return cond != nil && cond.Status == metav1.ConditionFalse && cond.Reason == reason
 }
 
+// reconcileDependencies keeps the pending workload out of the queues until
+// the workloads it depends on finish successfully, and finishes it if one of
+// them fails.
+func (r *WorkloadReconciler) reconcileDependencies(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	if !features.Enabled(features.WorkloadDependencies) || workload.HasQuotaReservation(wl) || !workload.WaitingForDependencies(wl) {
+		return false, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	state, failed, err := workload.DependenciesStatus(ctx, r.client, wl)
+	if err != nil {
+		return false, err
+	}
+	switch state {
+	case workload.DependenciesFailed:
+		log.V(2).Info("Finishing the workload as a dependency failed", "dependency", failed)
+		msg := fmt.Sprintf("The dependency %s failed", failed)
+		if err := workload.UpdateStatus(ctx, r.client, wl, kueue.WorkloadFinished, metav1.ConditionTrue, kueue.WorkloadFinishedReasonDependencyFailed, msg, constants.WorkloadControllerName, r.clock); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		r.recorder.Event(wl, corev1.EventTypeWarning, kueue.WorkloadFinishedReasonDependencyFailed, msg)
+		return true, nil
+	case workload.DependenciesSucceeded:
+		log.V(2).Info("Queueing the workload as its dependencies finished")
+		workload.SetRequeuedCondition(wl, kueue.WorkloadDependenciesFinished, "The dependencies finished successfully", true)
+		return true, workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock)
+	}
+	if isDisabledRequeuedByReason(wl, kueue.WorkloadWaitingForDependencies) {
+		return false, nil
+	}
+	workload.SetRequeuedCondition(wl, kueue.WorkloadWaitingForDependencies, "Waiting for the dependencies to finish successfully", false)
+	return true, workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock)
+}
+
+// reconcileGroupEviction evicts the workload if another member of its workload
+// group is evicted, as the members are admitted together.
+func (r *WorkloadReconciler) reconcileGroupEviction(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	if !features.Enabled(features.WorkloadGroups) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+		return false, nil
+	}
+	name, _ := workload.Group(wl)
+	if name == "" {
+		return false, nil
+	}
+	var members kueue.WorkloadList
+	if err := r.client.List(ctx, &members, client.InNamespace(wl.Namespace), client.MatchingFields{indexer.WorkloadGroupKey: name}); err != nil {
+		return false, err
+	}
+	quotaReserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	for i := range members.Items {
+		member := &members.Items[i]
+		if member.Name == wl.Name || workload.IsFinished(member) {
+			continue
+		}
+		evicted := apimeta.FindStatusCondition(member.Status.Conditions, kueue.WorkloadEvicted)
+		if evicted == nil || evicted.Status != metav1.ConditionTrue {
+			continue
+		}
+		// The eviction of a member that no longer holds a quota reservation is
+		// only relevant if it happened after the workload reserved quota,
+		// otherwise it's the stale eviction of a previous admission of the group.
+		if !workload.HasQuotaReservation(member) && quotaReserved != nil && !evicted.LastTransitionTime.After(quotaReserved.LastTransitionTime.Time) {
+			continue
+		}
+		log := ctrl.LoggerFrom(ctx)
+		log.V(3).Info("Workload is evicted together with its workload group", "member", klog.KObj(member))
+		message := fmt.Sprintf("The workload group member %s was evicted", member.Name)
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByWorkloadGroup, message)
+		workload.ResetChecksOnEviction(wl, r.clock.Now())
+		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		workload.ReportEvictedWorkload(r.recorder, wl, wl.Status.Admission.ClusterQueue, kueue.WorkloadEvictedByWorkloadGroup, message)
+		return true, nil
+	}
+	return false, nil
+}
+
 // reconcileMaxExecutionTime deactivates the workload if its MaximumExecutionTimeSeconds is exceeded or returns a retry after value.
 func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *kueue.Workload) (time.Duration, error) {
-	admittedCondition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
-	if admittedCondition == nil || admittedCondition.Status != metav1.ConditionTrue || wl.Spec.MaximumExecutionTimeSeconds == nil {
+	deadline, found := workload.MaxExecutionDeadline(wl)
+	if !found {
 		return 0, nil
 	}
 
-	remainingTime := time.Duration(*wl.Spec.MaximumExecutionTimeSeconds-ptr.Deref(wl.Status.AccumulatedPastExexcutionTimeSeconds, 0))*time.Second - r.clock.Since(admittedCondition.LastTransitionTime.Time)
+	remainingTime := deadline.Sub(r.clock.Now())
 	if remainingTime > 0 {
 		return remainingTime, nil
 	}
@@ -367,6 +476,29 @@ func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *
 	return 0, nil
 }
 
+// reconcileGracefulPreemption evicts the workload whose preemption is pending
+// once the grace period expires, or returns a retry after value.
+func (r *WorkloadReconciler) reconcileGracefulPreemption(ctx context.Context, wl *kueue.Workload) (time.Duration, error) {
+	pendingCondition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadPreemptionPending)
+	if pendingCondition == nil || pendingCondition.Status != metav1.ConditionTrue {
+		return 0, nil
+	}
+
+	remainingTime := r.gracefulPreemptionTimeout - r.clock.Since(pendingCondition.LastTransitionTime.Time)
+	if remainingTime > 0 {
+		return remainingTime, nil
+	}
+
+	message := fmt.Sprintf("The graceful stop wasn't acknowledged within %s", r.gracefulPreemptionTimeout)
+	workload.CompletePreemption(wl, kueue.WorkloadGracePeriodExpired, message, r.clock.Now())
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Workload is evicted after the graceful preemption period expired")
+	r.recorder.Event(wl, corev1.EventTypeNormal, kueue.WorkloadGracePeriodExpired, message)
+	return 0, nil
+}
+
 // reconcileCheckBasedEviction returns true if Workload has been deactivated or evicted
 func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl *kueue.Workload) (bool, error) {
 	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) || (!workload.HasRetryChecks(wl) && !workload.HasRejectedChecks(wl)) {
@@ -701,6 +833,10 @@ func (r *WorkloadReconciler) Update(e event.UpdateEvent) bool {
 		// The workload could have been in the queues if we missed an event.
 		r.queues.DeleteWorkload(wl)
 
+		if status == workload.StatusFinished && prevStatus == workload.StatusAdmitted && features.Enabled(features.StartTimeEstimation) {
+			r.cache.ObserveFinishedWorkload(wl)
+		}
+
 		// trigger the move of associated inadmissibleWorkloads, if there are any.
 		r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
 			// Delete the workload from cache while holding the queues lock
@@ -802,6 +938,9 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Conf
 		Watches(&nodev1.RuntimeClass{}, ruh).
 		Watches(&kueue.ClusterQueue{}, wqh).
 		Watches(&kueue.LocalQueue{}, wqh).
+		// The related workloads are watched with a raw source, so that the
+		// event filter of the reconciler doesn't process the events twice.
+		WatchesRawSource(source.Kind(mgr.GetCache(), &kueue.Workload{}, &relatedWorkloadsHandler{r: r})).
 		WithEventFilter(r).
 		Complete(WithLeadingManager(mgr, r, &kueue.Workload{}, cfg))
 }
@@ -902,6 +1041,58 @@ func (h *resourceUpdatesHandler) queueReconcileForPending(ctx context.Context, _
 	}
 }
 
+// relatedWorkloadsHandler queues the reconcile of the workloads depending
+// on a workload that finished, and of the other members of the workload group
+// of a workload that was evicted.
+type relatedWorkloadsHandler struct {
+	r *WorkloadReconciler
+}
+
+var _ handler.TypedEventHandler[*kueue.Workload, reconcile.Request] = (*relatedWorkloadsHandler)(nil)
+
+func (h *relatedWorkloadsHandler) Create(context.Context, event.TypedCreateEvent[*kueue.Workload], workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *relatedWorkloadsHandler) Update(ctx context.Context, e event.TypedUpdateEvent[*kueue.Workload], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldWl, wl := e.ObjectOld, e.ObjectNew
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(wl))
+	ctx = ctrl.LoggerInto(ctx, log)
+	if features.Enabled(features.WorkloadDependencies) && !workload.IsFinished(oldWl) && workload.IsFinished(wl) {
+		h.queueRelated(ctx, wl, indexer.WorkloadDependenciesKey, workload.DependencyName(wl), q)
+	}
+	if features.Enabled(features.WorkloadGroups) && !apimeta.IsStatusConditionTrue(oldWl.Status.Conditions, kueue.WorkloadEvicted) &&
+		apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+		if name, _ := workload.Group(wl); name != "" {
+			h.queueRelated(ctx, wl, indexer.WorkloadGroupKey, name, q)
+		}
+	}
+}
+
+func (h *relatedWorkloadsHandler) Delete(context.Context, event.TypedDeleteEvent[*kueue.Workload], workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *relatedWorkloadsHandler) Generic(context.Context, event.TypedGenericEvent[*kueue.Workload], workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// queueRelated queues the reconcile of the other workloads, in the namespace
+// of the workload, with the value in the index.
+func (h *relatedWorkloadsHandler) queueRelated(ctx context.Context, wl *kueue.Workload, indexKey, value string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	log := ctrl.LoggerFrom(ctx)
+	var lst kueue.WorkloadList
+	if err := h.r.client.List(ctx, &lst, client.InNamespace(wl.Namespace), client.MatchingFields{indexKey: value}); err != nil {
+		log.Error(err, "Could not list the workloads related to the workload", "index", indexKey)
+		return
+	}
+	for i := range lst.Items {
+		related := &lst.Items[i]
+		if related.Name == wl.Name {
+			continue
+		}
+		log.V(5).Info("Queue reconcile for related workload", "related", klog.KObj(related), "index", indexKey)
+		q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(related)})
+	}
+}
+
 type workloadQueueHandler struct {
 	r *WorkloadReconciler
 }
@@ -30,14 +30,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
 	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
@@ -403,6 +407,11 @@ func TestReconcile(t *testing.T) {
 		wantEvents     []utiltesting.EventRecord
 		wantResult     reconcile.Result
 		reconcilerOpts []Option
+
+		enableWorkloadDependencies bool
+		enableWorkloadGroups       bool
+		// objects are the additional objects in the cluster.
+		objects []client.Object
 	}{
 		"assign Admission Checks from ClusterQueue.spec.AdmissionCheckStrategy": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
@@ -1696,10 +1705,310 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"preemption pending within the grace period": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadPreemptionPending,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.InClusterQueueReason,
+					Message:            "Preempted to accommodate a higher priority Workload",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-time.Minute)),
+				}).
+				Obj(),
+			reconcilerOpts: []Option{WithGracefulPreemptionTimeout(3 * time.Minute)},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPreemptionPending,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.InClusterQueueReason,
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 2 * time.Minute},
+		},
+		"preemption pending after the grace period": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Condition(metav1.Condition{
+					Type:               kueue.WorkloadPreemptionPending,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.InClusterQueueReason,
+					Message:            "Preempted to accommodate a higher priority Workload",
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-3 * time.Minute)),
+				}).
+				Obj(),
+			reconcilerOpts: []Option{WithGracefulPreemptionTimeout(2 * time.Minute)},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPreemptionPending,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadGracePeriodExpired,
+					Message: "The graceful stop wasn't acknowledged within 2m0s",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPreempted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.InClusterQueueReason,
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    kueue.WorkloadGracePeriodExpired,
+					Message:   "The graceful stop wasn't acknowledged within 2m0s",
+				},
+			},
+		},
+		"workload waiting for its dependencies": {
+			enableWorkloadDependencies: true,
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").
+				Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("prep-wl", "ns").
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "prep", "prep-uid").
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadRequeued,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitingForDependencies,
+					Message: "Waiting for the dependencies to finish successfully",
+				}).
+				Obj(),
+		},
+		"workload queued once its dependencies succeed": {
+			enableWorkloadDependencies: true,
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadRequeued,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitingForDependencies,
+					Message: "Waiting for the dependencies to finish successfully",
+				}).
+				Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("prep-wl", "ns").
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "prep", "prep-uid").
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadFinished,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadFinishedReasonSucceeded,
+					}).
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadRequeued,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDependenciesFinished,
+					Message: "The dependencies finished successfully",
+				}).
+				Obj(),
+		},
+		"workload finished when a dependency fails": {
+			enableWorkloadDependencies: true,
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadRequeued,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitingForDependencies,
+					Message: "Waiting for the dependencies to finish successfully",
+				}).
+				Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("prep-wl", "ns").
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "prep", "prep-uid").
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadFinished,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadFinishedReasonFailed,
+					}).
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadRequeued,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadWaitingForDependencies,
+					Message: "Waiting for the dependencies to finish successfully",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadFinished,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadFinishedReasonDependencyFailed,
+					Message: "The dependency prep failed",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    kueue.WorkloadFinishedReasonDependencyFailed,
+					Message:   "The dependency prep failed",
+				},
+			},
+		},
+		"workload evicted together with its workload group": {
+			enableWorkloadGroups: true,
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("member", "ns").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					AdmittedAt(true, testStartTime.Add(-time.Hour)).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadEvictedByPreemption,
+					}).
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByWorkloadGroup,
+					Message: "The workload group member member was evicted",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToWorkloadGroup",
+					Message:   "The workload group member member was evicted",
+				},
+			},
+		},
+		"re-admitted workload group member not evicted by the stale eviction of a member": {
+			enableWorkloadGroups: true,
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime).
+				AdmittedAt(true, testStartTime).
+				Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("member", "ns").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(testStartTime.Add(-time.Hour)),
+						Reason:             kueue.WorkloadEvictedByPreemption,
+					}).
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime).
+				AdmittedAt(true, testStartTime).
+				Obj(),
+		},
+		"workload group member evicted after a member without quota reservation is evicted": {
+			enableWorkloadGroups: true,
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-time.Hour)).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("member", "ns").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadEvicted,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(testStartTime),
+						Reason:             kueue.WorkloadEvictedByPreemption,
+					}).
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-time.Hour)).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByWorkloadGroup,
+					Message: "The workload group member member was evicted",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToWorkloadGroup",
+					Message:   "The workload group member member was evicted",
+				},
+			},
+		},
+		"workload group member not evicted while the other members run": {
+			enableWorkloadGroups: true,
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("member", "ns").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+					AdmittedAt(true, testStartTime.Add(-time.Hour)).
+					Obj(),
+			},
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Hour)).
+				Obj(),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			objs := []client.Object{tc.workload}
+			features.SetFeatureGateDuringTest(t, features.WorkloadDependencies, tc.enableWorkloadDependencies)
+			features.SetFeatureGateDuringTest(t, features.WorkloadGroups, tc.enableWorkloadGroups)
+			objs := append([]client.Object{tc.workload}, tc.objects...)
 			clientBuilder := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...).WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
 			cl := clientBuilder.Build()
 			recorder := &utiltesting.EventRecorder{}
@@ -1758,3 +2067,72 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestRelatedWorkloadsHandlerUpdate(t *testing.T) {
+	evicted := metav1.Condition{
+		Type:   kueue.WorkloadEvicted,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadEvictedByPreemption,
+	}
+	cases := map[string]struct {
+		oldWl     *kueue.Workload
+		newWl     *kueue.Workload
+		objects   []client.Object
+		wantQueue []reconcile.Request
+	}{
+		"finished workload queues its dependents": {
+			oldWl: utiltesting.MakeWorkload("prep", "ns").Obj(),
+			newWl: utiltesting.MakeWorkload("prep", "ns").Finished().Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("dependent", "ns").Annotation(controllerconsts.DependsOnAnnotation, "other, prep").Obj(),
+				utiltesting.MakeWorkload("independent", "ns").Annotation(controllerconsts.DependsOnAnnotation, "other").Obj(),
+				utiltesting.MakeWorkload("dependent", "other-ns").Annotation(controllerconsts.DependsOnAnnotation, "prep").Obj(),
+			},
+			wantQueue: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "dependent"}},
+			},
+		},
+		"evicted workload queues the other members of its group": {
+			oldWl: utiltesting.MakeWorkload("wl", "ns").Label(controllerconsts.WorkloadGroupLabel, "group").Obj(),
+			newWl: utiltesting.MakeWorkload("wl", "ns").Label(controllerconsts.WorkloadGroupLabel, "group").Condition(evicted).Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("wl", "ns").Label(controllerconsts.WorkloadGroupLabel, "group").Obj(),
+				utiltesting.MakeWorkload("member", "ns").Label(controllerconsts.WorkloadGroupLabel, "group").Obj(),
+				utiltesting.MakeWorkload("other", "ns").Label(controllerconsts.WorkloadGroupLabel, "other").Obj(),
+			},
+			wantQueue: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "member"}},
+			},
+		},
+		"already evicted workload doesn't queue the other members of its group": {
+			oldWl: utiltesting.MakeWorkload("wl", "ns").Label(controllerconsts.WorkloadGroupLabel, "group").Condition(evicted).Obj(),
+			newWl: utiltesting.MakeWorkload("wl", "ns").Label(controllerconsts.WorkloadGroupLabel, "group").Condition(evicted).Obj(),
+			objects: []client.Object{
+				utiltesting.MakeWorkload("member", "ns").Label(controllerconsts.WorkloadGroupLabel, "group").Obj(),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.WorkloadDependencies, true)
+			features.SetFeatureGateDuringTest(t, features.WorkloadGroups, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.objects...).Build()
+			h := &relatedWorkloadsHandler{r: NewWorkloadReconciler(cl, nil, nil, nil)}
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer q.ShutDown()
+
+			h.Update(ctx, event.TypedUpdateEvent[*kueue.Workload]{ObjectOld: tc.oldWl, ObjectNew: tc.newWl}, q)
+
+			var gotQueue []reconcile.Request
+			for q.Len() > 0 {
+				req, _ := q.Get()
+				gotQueue = append(gotQueue, req)
+				q.Done(req)
+			}
+			if diff := cmp.Diff(tc.wantQueue, gotQueue); diff != "" {
+				t.Errorf("Unexpected queued requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
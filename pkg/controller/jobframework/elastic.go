@@ -0,0 +1,262 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/podset"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	"sigs.k8s.io/kueue/pkg/util/equality"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
+)
+
+// isElasticJob returns whether the job can be scaled while admitted.
+func isElasticJob(job GenericJob) bool {
+	if _, implements := job.(JobWithElasticPods); !implements {
+		return false
+	}
+	if _, composable := job.(ComposableJob); composable {
+		return false
+	}
+	if _, usePrebuiltWorkload := PrebuiltWorkloadFor(job); usePrebuiltWorkload {
+		return false
+	}
+	return workloadslicing.Enabled(job.Object())
+}
+
+// ensureWorkloadSlices returns the workload slice of a running elastic job,
+// creating a new slice when the job is scaled up.
+// It returns false if the workloads of the job should be handled as for any
+// other job, for example when the job isn't admitted.
+func (r *JobReconciler) ensureWorkloadSlices(ctx context.Context, job GenericJob, object client.Object) (*kueue.Workload, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	workloads := &kueue.WorkloadList{}
+	if err := r.client.List(ctx, workloads, client.InNamespace(object.GetNamespace()),
+		client.MatchingFields{GetOwnerKey(job.GVK()): object.GetName()}); err != nil {
+		return nil, false, err
+	}
+	var active []*kueue.Workload
+	for i := range workloads.Items {
+		wl := &workloads.Items[i]
+		// Indexes don't work in unit tests, so we explicitly check for the
+		// owner here.
+		if owner := metav1.GetControllerOf(wl); owner == nil || owner.Name != object.GetName() {
+			continue
+		}
+		if workloadslicing.IsReplaced(wl) {
+			continue
+		}
+		active = append(active, wl)
+	}
+	current, replacement, found := classifySlices(active)
+	if !found {
+		return nil, false, nil
+	}
+
+	if !workload.HasQuotaReservation(current) || job.IsSuspended() || workload.IsEvicted(current) {
+		return nil, false, r.deleteWorkloadSlice(ctx, object, replacement)
+	}
+
+	if replacement != nil && workload.IsAdmitted(replacement) {
+		if err := r.finishReplacedSlice(ctx, object, current, replacement); err != nil {
+			return nil, false, err
+		}
+		current, replacement = replacement, nil
+	}
+
+	jobPodSets, err := job.PodSets()
+	if err != nil {
+		return nil, false, err
+	}
+	if !equivalentIgnoringCounts(ctx, r.client, jobPodSets, current) {
+		log.V(2).Info("The elastic job changed beyond its pod counts", "workload", klog.KObj(current))
+		return nil, false, r.deleteWorkloadSlice(ctx, object, replacement)
+	}
+
+	var jobReclaimable []kueue.ReclaimablePod
+	if jobRecl, implements := job.(JobWithReclaimablePods); implements {
+		if jobReclaimable, err = jobRecl.ReclaimablePods(); err != nil {
+			return nil, false, err
+		}
+	}
+	if !workloadslicing.ScaledUp(current, jobPodSets, jobReclaimable) {
+		return current, true, r.deleteWorkloadSlice(ctx, object, replacement)
+	}
+	if replacement != nil {
+		if equality.ComparePodSetSlices(jobPodSets, replacement.Spec.PodSets, true) {
+			return current, true, nil
+		}
+		if err := r.deleteWorkloadSlice(ctx, object, replacement); err != nil {
+			return nil, false, err
+		}
+	}
+	return current, true, r.createWorkloadSlice(ctx, job, object, current)
+}
+
+// classifySlices returns the current workload slice of an elastic job and,
+// if any, the slice replacing it.
+func classifySlices(wls []*kueue.Workload) (*kueue.Workload, *kueue.Workload, bool) {
+	switch len(wls) {
+	case 1:
+		return wls[0], nil, true
+	case 2:
+		for i, wl := range wls {
+			other := wls[1-i]
+			if key, isSlice := workloadslicing.ReplacementFor(wl); isSlice && key == workload.Key(other) {
+				return other, wl, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// equivalentIgnoringCounts returns whether the PodSets of the job match the
+// PodSets of the workload slice, except for their counts.
+func equivalentIgnoringCounts(ctx context.Context, c client.Client, jobPodSets []kueue.PodSet, wl *kueue.Workload) bool {
+	if len(jobPodSets) != len(wl.Spec.PodSets) {
+		return false
+	}
+	podSets := make([]kueue.PodSet, len(jobPodSets))
+	for i := range jobPodSets {
+		podSets[i] = *jobPodSets[i].DeepCopy()
+		podSets[i].Count = wl.Spec.PodSets[i].Count
+	}
+	podSets = clearMinCountsIfFeatureDisabled(podSets)
+	if runningPodSets := expectedRunningPodSets(ctx, c, wl); runningPodSets != nil {
+		return equality.ComparePodSetSlices(podSets, runningPodSets, workload.IsAdmitted(wl))
+	}
+	return equality.ComparePodSetSlices(podSets, wl.Spec.PodSets, workload.IsAdmitted(wl))
+}
+
+// finishReplacedSlice marks the workload slice as finished, once the slice
+// replacing it is admitted.
+func (r *JobReconciler) finishReplacedSlice(ctx context.Context, object client.Object, replaced, replacement *kueue.Workload) error {
+	if err := workload.RemoveFinalizer(ctx, r.client, replaced); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	msg := fmt.Sprintf("The workload slice is replaced by %s", workload.Key(replacement))
+	err := workload.UpdateStatus(ctx, r.client, replaced, kueue.WorkloadFinished, metav1.ConditionTrue, kueue.WorkloadFinishedReasonSliceReplaced, msg, constants.JobControllerName, r.clock)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	r.record.Eventf(object, corev1.EventTypeNormal, ReasonFinishedWorkload,
+		"Workload '%s' is replaced by '%s'", workload.Key(replaced), workload.Key(replacement))
+	return nil
+}
+
+// createWorkloadSlice creates a workload slice for the new pod counts of the
+// job, replacing the current slice.
+func (r *JobReconciler) createWorkloadSlice(ctx context.Context, job GenericJob, object client.Object, current *kueue.Workload) error {
+	wl, err := r.constructWorkload(ctx, job)
+	if err != nil {
+		return err
+	}
+	if err := r.prepareWorkload(ctx, job, wl); err != nil {
+		return err
+	}
+	wl.Name = GetWorkloadSliceNameForOwnerWithGVK(object.GetName(), object.GetUID(), job.GVK(), object.GetGeneration())
+	if wl.Annotations == nil {
+		wl.Annotations = make(map[string]string)
+	}
+	wl.Annotations[workloadslicing.ReplacementForAnnotationKey] = workload.Key(current)
+	if err := r.client.Create(ctx, wl); err != nil {
+		return client.IgnoreAlreadyExists(err)
+	}
+	r.record.Eventf(object, corev1.EventTypeNormal, ReasonCreatedWorkload,
+		"Created Workload slice: %v, replacing %v", workload.Key(wl), workload.Key(current))
+	return nil
+}
+
+func (r *JobReconciler) deleteWorkloadSlice(ctx context.Context, object client.Object, wl *kueue.Workload) error {
+	if wl == nil {
+		return nil
+	}
+	if err := workload.RemoveFinalizer(ctx, r.client, wl); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err := r.client.Delete(ctx, wl); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.record.Eventf(object, corev1.EventTypeNormal, ReasonDeletedWorkload,
+		"Deleted not matching Workload slice: %v", workload.Key(wl))
+	return nil
+}
+
+// gateElasticPods adds the scheduling gate of the elastic jobs to the pods
+// which are started with the given infos.
+func gateElasticPods(infos []podset.PodSetInfo) {
+	for i := range infos {
+		gate := corev1.PodSchedulingGate{Name: workloadslicing.SchedulingGate}
+		if !slices.Contains(infos[i].SchedulingGates, gate) {
+			infos[i].SchedulingGates = append(infos[i].SchedulingGates, gate)
+		}
+	}
+}
+
+// ungateElasticPods removes the scheduling gate from the pods of the elastic
+// job, up to the counts admitted for the workload slice, excluding the
+// reclaimable pods. The oldest pods are ungated first.
+func (r *JobReconciler) ungateElasticPods(ctx context.Context, job GenericJob, wl *kueue.Workload) error {
+	pods, err := job.(JobWithElasticPods).PodsByPodSet(ctx, r.client)
+	if err != nil {
+		return err
+	}
+	for i, psa := range wl.Status.Admission.PodSetAssignments {
+		allowed := ptr.Deref(psa.Count, wl.Spec.PodSets[i].Count)
+		for _, rp := range wl.Status.ReclaimablePods {
+			if rp.Name == psa.Name {
+				allowed -= rp.Count
+			}
+		}
+		var gated []*corev1.Pod
+		podSetPods := pods[psa.Name]
+		for j := range podSetPods {
+			if utilpod.HasGate(&podSetPods[j], workloadslicing.SchedulingGate) {
+				gated = append(gated, &podSetPods[j])
+			} else {
+				allowed--
+			}
+		}
+		slices.SortStableFunc(gated, func(a, b *corev1.Pod) int {
+			return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+		})
+		for _, pod := range gated[:max(0, min(int(allowed), len(gated)))] {
+			err := clientutil.Patch(ctx, r.client, pod, true, func() (bool, error) {
+				return utilpod.Ungate(pod, workloadslicing.SchedulingGate), nil
+			})
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
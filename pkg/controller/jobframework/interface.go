@@ -19,7 +19,9 @@ package jobframework
 import (
 	"context"
 	"strconv"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -29,8 +31,10 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/podset"
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/maps"
@@ -77,6 +81,16 @@ type JobWithReclaimablePods interface {
 	ReclaimablePods() ([]kueue.ReclaimablePod, error)
 }
 
+// JobWithElasticPods interface should be implemented by generic jobs which
+// can be scaled while admitted, when the ElasticJobsViaWorkloadSlices feature
+// is enabled. The pods of such jobs are created with a scheduling gate, which
+// is removed for the pods covered by the admitted workload slice.
+type JobWithElasticPods interface {
+	// PodsByPodSet returns the pods of the job which are not terminated, by
+	// the name of their PodSet.
+	PodsByPodSet(ctx context.Context, c client.Client) (map[kueue.PodSetReference][]corev1.Pod, error)
+}
+
 type StopReason string
 
 const (
@@ -93,6 +107,19 @@ type JobWithCustomStop interface {
 	Stop(ctx context.Context, c client.Client, podSetsInfo []podset.PodSetInfo, stopReason StopReason, eventMsg string) (bool, error)
 }
 
+// JobWithGracefulStop interface should be implemented by generic jobs which
+// can stop gracefully, for example after saving a checkpoint, when their
+// workload is preempted. It's used when the GracefulPreemption feature is
+// enabled. The quota of the workload is released once the job acknowledges
+// the graceful stop, or the grace period expires.
+type JobWithGracefulStop interface {
+	// RequestGracefulStop signals the job to stop gracefully before the deadline.
+	// Returns whether the job was modified and needs to be updated.
+	RequestGracefulStop(deadline time.Time) bool
+	// GracefulStopAcknowledged returns whether the job is ready to be stopped.
+	GracefulStopAcknowledged() bool
+}
+
 // JobWithFinalize interface should be implemented by generic jobs,
 // when custom finalization logic is needed for a job, after it's finished.
 type JobWithFinalize interface {
@@ -206,8 +233,14 @@ func PrebuiltWorkloadFor(job GenericJob) (string, bool) {
 	return name, found
 }
 
+// ReservationNameForObject returns the name of the Reservation whose capacity
+// the workload of the object can use, if any.
+func ReservationNameForObject(object client.Object) string {
+	return object.GetLabels()[kueuealpha.ReservationLabel]
+}
+
 func NewWorkload(name string, obj client.Object, podSets []kueue.PodSet, labelKeysToCopy []string) *kueue.Workload {
-	return &kueue.Workload{
+	wl := &kueue.Workload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   obj.GetNamespace(),
@@ -221,6 +254,23 @@ func NewWorkload(name string, obj client.Object, podSets []kueue.PodSet, labelKe
 			MaximumExecutionTimeSeconds: MaximumExecutionTimeSecondsForObject(obj),
 		},
 	}
+	if reservation := ReservationNameForObject(obj); reservation != "" && features.Enabled(features.AdvanceReservations) {
+		if wl.Labels == nil {
+			wl.Labels = make(map[string]string, 1)
+		}
+		wl.Labels[kueuealpha.ReservationLabel] = reservation
+	}
+	if dependsOn := obj.GetAnnotations()[constants.DependsOnAnnotation]; dependsOn != "" && features.Enabled(features.WorkloadDependencies) {
+		wl.Annotations[constants.DependsOnAnnotation] = dependsOn
+	}
+	if group := obj.GetLabels()[constants.WorkloadGroupLabel]; group != "" && features.Enabled(features.WorkloadGroups) {
+		if wl.Labels == nil {
+			wl.Labels = make(map[string]string, 1)
+		}
+		wl.Labels[constants.WorkloadGroupLabel] = group
+		wl.Annotations[constants.WorkloadGroupSizeAnnotation] = obj.GetAnnotations()[constants.WorkloadGroupSizeAnnotation]
+	}
+	return wl
 }
 
 // MultiKueueAdapter interface needed for MultiKueue job delegation.
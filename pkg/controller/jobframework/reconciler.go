@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -59,6 +60,7 @@ import (
 	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 )
 
 const (
@@ -83,6 +85,7 @@ type JobReconciler struct {
 	waitForPodsReady             bool
 	labelKeysToCopy              []string
 	clock                        clock.Clock
+	gracefulPreemptionTimeout    time.Duration
 }
 
 type Options struct {
@@ -98,6 +101,7 @@ type Options struct {
 	Queues                       *queue.Manager
 	Cache                        *cache.Cache
 	Clock                        clock.Clock
+	GracefulPreemptionTimeout    time.Duration
 }
 
 // Option configures the reconciler.
@@ -135,6 +139,16 @@ func WithWaitForPodsReady(w *configapi.WaitForPodsReady) Option {
 	}
 }
 
+// WithGracefulPreemption indicates the grace period given to the jobs to stop
+// gracefully when their workload is preempted.
+func WithGracefulPreemption(g *configapi.GracefulPreemption) Option {
+	return func(o *Options) {
+		if g != nil && g.Timeout != nil {
+			o.GracefulPreemptionTimeout = g.Timeout.Duration
+		}
+	}
+}
+
 func WithKubeServerVersion(v *kubeversion.ServerVersionFetcher) Option {
 	return func(o *Options) {
 		o.KubeServerVersion = v
@@ -210,7 +224,8 @@ func WithClock(_ testing.TB, c clock.Clock) Option {
 }
 
 var defaultOptions = Options{
-	Clock: clock.RealClock{},
+	Clock:                     clock.RealClock{},
+	GracefulPreemptionTimeout: configapi.DefaultGracefulPreemptionTimeout,
 }
 
 func NewReconciler(
@@ -227,6 +242,7 @@ func NewReconciler(
 		waitForPodsReady:             options.WaitForPodsReady,
 		labelKeysToCopy:              options.LabelKeysToCopy,
 		clock:                        options.Clock,
+		gracefulPreemptionTimeout:    options.GracefulPreemptionTimeout,
 	}
 }
 
@@ -445,6 +461,19 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 			log.Error(err, "Getting reclaimable pods")
 			return ctrl.Result{}, err
 		}
+		if isElasticJob(job) && workload.HasQuotaReservation(wl) {
+			jobPodSets, err := job.PodSets()
+			if err != nil {
+				log.Error(err, "Getting pod sets")
+				return ctrl.Result{}, err
+			}
+			if workloadslicing.ScaledUp(wl, jobPodSets, reclPods) {
+				// The pods are accounted by the workload slice replacing this one.
+				reclPods = wl.Status.ReclaimablePods
+			} else {
+				reclPods = workloadslicing.ReclaimablePods(wl, jobPodSets, reclPods)
+			}
+		}
 
 		if !workload.ReclaimablePodsAreEqual(reclPods, wl.Status.ReclaimablePods) {
 			err = workload.UpdateReclaimablePods(ctx, r.client, wl, reclPods)
@@ -474,6 +503,12 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// 5.1 handle the pending preemption
+	if features.Enabled(features.GracefulPreemption) && workload.IsPreemptionPending(wl) {
+		log.V(3).Info("Handling a job with pending preemption")
+		return ctrl.Result{}, r.handlePendingPreemption(ctx, job, wl)
+	}
+
 	// 6. handle eviction
 	if evCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted); evCond != nil && evCond.Status == metav1.ConditionTrue {
 		log.V(3).Info("Handling a job with evicted condition")
@@ -545,6 +580,12 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// 8.1 release the pods of an elastic job covered by its workload slice.
+	if isElasticJob(job) {
+		log.V(3).Info("Job running with admitted workload slice, ungating pods")
+		return ctrl.Result{}, r.ungateElasticPods(ctx, job, wl)
+	}
+
 	// workload is admitted and job is running, nothing to do.
 	log.V(3).Info("Job running with admitted workload, nothing to do")
 	return ctrl.Result{}, nil
@@ -673,6 +714,12 @@ func (r *JobReconciler) ensureOneWorkload(ctx context.Context, job GenericJob, o
 		return wl, nil
 	}
 
+	if isElasticJob(job) {
+		if wl, handled, err := r.ensureWorkloadSlices(ctx, job, object); err != nil || handled {
+			return wl, err
+		}
+	}
+
 	// Find a matching workload first if there is one.
 	var toDelete []*kueue.Workload
 	var match *kueue.Workload
@@ -916,6 +963,9 @@ func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object cli
 	if err != nil {
 		return err
 	}
+	if isElasticJob(job) {
+		gateElasticPods(info)
+	}
 	msg := fmt.Sprintf("Admitted by clusterQueue %v", wl.Status.Admission.ClusterQueue)
 
 	if cj, implements := job.(ComposableJob); implements {
@@ -934,6 +984,38 @@ func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object cli
 	return nil
 }
 
+// handlePendingPreemption requests the graceful stop of the job whose workload
+// is pending preemption, and evicts the workload once the job acknowledges it.
+// If the grace period expires first, the workload is evicted by the workload
+// controller.
+func (r *JobReconciler) handlePendingPreemption(ctx context.Context, job GenericJob, wl *kueue.Workload) error {
+	reason, message := kueue.WorkloadGracefulStopAcknowledged, "The job acknowledged the graceful stop"
+	jgs, implements := job.(JobWithGracefulStop)
+	switch {
+	case !implements:
+		reason, message = kueue.WorkloadGracefulStopUnsupported, "The job doesn't support the graceful stop"
+	case job.IsSuspended():
+		message = "The job isn't running"
+	case !jgs.GracefulStopAcknowledged():
+		pending := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadPreemptionPending)
+		deadline := pending.LastTransitionTime.Add(r.gracefulPreemptionTimeout)
+		requested := false
+		if err := clientutil.Patch(ctx, r.client, job.Object(), true, func() (bool, error) {
+			requested = jgs.RequestGracefulStop(deadline)
+			return requested, nil
+		}); err != nil {
+			return err
+		}
+		if requested {
+			r.record.Eventf(job.Object(), corev1.EventTypeNormal, ReasonGracefulStopRequested, "Requested the graceful stop before %s: %s", deadline.Format(time.RFC3339), pending.Message)
+		}
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Completing the pending preemption", "reason", reason)
+	workload.CompletePreemption(wl, reason, message, r.clock.Now())
+	return workload.ApplyAdmissionStatus(ctx, r.client, wl, true, r.clock)
+}
+
 // stopJob will suspend the job, and also restore node affinity, reset job status if needed.
 // Returns whether any operation was done to stop the job or an error.
 func (r *JobReconciler) stopJob(ctx context.Context, job GenericJob, wl *kueue.Workload, stopReason StopReason, eventMsg string) error {
@@ -1045,6 +1127,14 @@ func (r *JobReconciler) prepareWorkload(ctx context.Context, job GenericJob, wl
 	wl.Spec.Priority = &p
 	wl.Spec.PriorityClassSource = source
 
+	if features.Enabled(features.PreemptionMinimumRuntime) && source == constants.WorkloadPriorityClassSource && wl.Spec.MinimumRuntimeSeconds == nil {
+		wpc := &kueue.WorkloadPriorityClass{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: priorityClassName}, wpc); err != nil {
+			return err
+		}
+		wl.Spec.MinimumRuntimeSeconds = wpc.MinimumRuntimeSeconds
+	}
+
 	wl.Spec.PodSets = clearMinCountsIfFeatureDisabled(wl.Spec.PodSets)
 
 	return nil
@@ -210,6 +210,7 @@ func TestProcessOptions(t *testing.T) {
 					PodSelector: &metav1.LabelSelector{},
 				}),
 				WithLabelKeysToCopy([]string{"toCopyKey"}),
+				WithGracefulPreemption(&configapi.GracefulPreemption{Timeout: &metav1.Duration{Duration: time.Minute}}),
 				WithClock(t, fakeClock),
 			},
 			wantOpts: Options{
@@ -221,8 +222,9 @@ func TestProcessOptions(t *testing.T) {
 						PodSelector: &metav1.LabelSelector{},
 					},
 				},
-				LabelKeysToCopy: []string{"toCopyKey"},
-				Clock:           fakeClock,
+				LabelKeysToCopy:           []string{"toCopyKey"},
+				Clock:                     fakeClock,
+				GracefulPreemptionTimeout: time.Minute,
 			},
 		},
 		"a single option is passed": {
@@ -235,6 +237,7 @@ func TestProcessOptions(t *testing.T) {
 				KubeServerVersion:          nil,
 				IntegrationOptions:         nil,
 				Clock:                      clock.RealClock{},
+				GracefulPreemptionTimeout:  configapi.DefaultGracefulPreemptionTimeout,
 			},
 		},
 		"no options are passed": {
@@ -245,6 +248,7 @@ func TestProcessOptions(t *testing.T) {
 				IntegrationOptions:         nil,
 				LabelKeysToCopy:            nil,
 				Clock:                      clock.RealClock{},
+				GracefulPreemptionTimeout:  configapi.DefaultGracefulPreemptionTimeout,
 			},
 		},
 	}
@@ -45,6 +45,7 @@ var (
 	labelsPath                    = field.NewPath("metadata", "labels")
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	maxExecTimeLabelPath          = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	workloadGroupSizePath         = annotationsPath.Key(constants.WorkloadGroupSizeAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 	supportedPrebuiltWlJobGVKs    = sets.New(
 		batchv1.SchemeGroupVersion.WithKind("Job").String(),
@@ -66,6 +67,7 @@ func ValidateJobOnCreate(job GenericJob) field.ErrorList {
 	allErrs := ValidateQueueName(job.Object())
 	allErrs = append(allErrs, validateCreateForPrebuiltWorkload(job)...)
 	allErrs = append(allErrs, validateCreateForMaxExecTime(job)...)
+	allErrs = append(allErrs, validateCreateForWorkloadGroup(job)...)
 	return allErrs
 }
 
@@ -166,6 +168,21 @@ func validateUpdateForMaxExecTime(oldJob, newJob GenericJob) field.ErrorList {
 	return nil
 }
 
+func validateCreateForWorkloadGroup(job GenericJob) field.ErrorList {
+	if _, found := job.Object().GetLabels()[constants.WorkloadGroupLabel]; !found {
+		return nil
+	}
+	strVal := job.Object().GetAnnotations()[constants.WorkloadGroupSizeAnnotation]
+	v, err := strconv.Atoi(strVal)
+	if err != nil {
+		return field.ErrorList{field.Invalid(workloadGroupSizePath, strVal, err.Error())}
+	}
+	if v <= 0 {
+		return field.ErrorList{field.Invalid(workloadGroupSizePath, v, "should be greater than 0")}
+	}
+	return nil
+}
+
 // ValidateImmutablePodGroupPodSpec function is used for serving workloads to ensure no changes are allowed
 // to the PodSpec except fields that required for role-hash generation.
 func ValidateImmutablePodGroupPodSpec(newPodSpec *corev1.PodSpec, oldPodSpec *corev1.PodSpec, fieldPath *field.Path) field.ErrorList {
@@ -20,6 +20,7 @@ import (
 	"crypto/sha1"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -40,7 +41,17 @@ func GetWorkloadNameForOwnerWithGVK(ownerName string, ownerUID types.UID, ownerG
 	return prefixedName + "-" + getHash(ownerName, ownerUID, ownerGVK)[:hashLength]
 }
 
-func getHash(ownerName string, ownerUID types.UID, gvk schema.GroupVersionKind) string {
+// GetWorkloadSliceNameForOwnerWithGVK returns the name of the workload slice
+// created for the given generation of an elastic job.
+func GetWorkloadSliceNameForOwnerWithGVK(ownerName string, ownerUID types.UID, ownerGVK schema.GroupVersionKind, generation int64) string {
+	prefixedName := strings.ToLower(ownerGVK.Kind) + "-" + ownerName
+	if len(prefixedName) > maxPrefixLength {
+		prefixedName = prefixedName[:maxPrefixLength]
+	}
+	return prefixedName + "-" + getHash(ownerName, ownerUID, ownerGVK, strconv.FormatInt(generation, 10))[:hashLength]
+}
+
+func getHash(ownerName string, ownerUID types.UID, gvk schema.GroupVersionKind, extra ...string) string {
 	h := sha1.New()
 	h.Write([]byte(gvk.Kind))
 	h.Write([]byte("\n"))
@@ -49,6 +60,10 @@ func getHash(ownerName string, ownerUID types.UID, gvk schema.GroupVersionKind)
 	h.Write([]byte(ownerName))
 	h.Write([]byte("\n"))
 	h.Write([]byte(ownerUID))
+	for _, e := range extra {
+		h.Write([]byte("\n"))
+		h.Write([]byte(e))
+	}
 	return hex.EncodeToString(h.Sum(nil))
 }
 
@@ -19,7 +19,9 @@ package job
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +45,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/podset"
 	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 )
 
 var (
@@ -55,6 +58,14 @@ const (
 	JobMinParallelismAnnotation              = "kueue.x-k8s.io/job-min-parallelism"
 	JobCompletionsEqualParallelismAnnotation = "kueue.x-k8s.io/job-completions-equal-parallelism"
 	StoppingAnnotation                       = "kueue.x-k8s.io/stopping"
+	// GracefulStopRequestedAnnotation is set, with the deadline in RFC3339
+	// format, when the Job is requested to stop gracefully because its
+	// workload is preempted.
+	GracefulStopRequestedAnnotation = "kueue.x-k8s.io/graceful-stop-requested"
+	// GracefulStopAcknowledgedAnnotation is set to "true" by the user or by
+	// the Job's pods, for example after saving a checkpoint, to signal that
+	// the Job can be stopped.
+	GracefulStopAcknowledgedAnnotation = "kueue.x-k8s.io/graceful-stop-acknowledged"
 )
 
 func init() {
@@ -146,6 +157,8 @@ var _ jobframework.GenericJob = (*Job)(nil)
 var _ jobframework.JobWithReclaimablePods = (*Job)(nil)
 var _ jobframework.JobWithCustomStop = (*Job)(nil)
 var _ jobframework.JobWithManagedBy = (*Job)(nil)
+var _ jobframework.JobWithGracefulStop = (*Job)(nil)
+var _ jobframework.JobWithElasticPods = (*Job)(nil)
 
 func (j *Job) Object() client.Object {
 	return (*batchv1.Job)(j)
@@ -199,6 +212,8 @@ func (j *Job) Stop(ctx context.Context, c client.Client, podSetsInfo []podset.Po
 	if err := clientutil.Patch(ctx, c, object, true, func() (bool, error) {
 		j.RestorePodSetsInfo(podSetsInfo)
 		delete(j.ObjectMeta.Annotations, StoppingAnnotation)
+		delete(j.ObjectMeta.Annotations, GracefulStopRequestedAnnotation)
+		delete(j.ObjectMeta.Annotations, GracefulStopAcknowledgedAnnotation)
 		return true, nil
 	}); err != nil {
 		return false, fmt.Errorf("restore info: %w", err)
@@ -207,6 +222,22 @@ func (j *Job) Stop(ctx context.Context, c client.Client, podSetsInfo []podset.Po
 	return stoppedNow, nil
 }
 
+func (j *Job) RequestGracefulStop(deadline time.Time) bool {
+	value := deadline.UTC().Format(time.RFC3339)
+	if j.Annotations[GracefulStopRequestedAnnotation] == value {
+		return false
+	}
+	if j.Annotations == nil {
+		j.Annotations = map[string]string{}
+	}
+	j.Annotations[GracefulStopRequestedAnnotation] = value
+	return true
+}
+
+func (j *Job) GracefulStopAcknowledged() bool {
+	return j.Annotations[GracefulStopAcknowledgedAnnotation] == "true"
+}
+
 func (j *Job) GVK() schema.GroupVersionKind {
 	return gvk
 }
@@ -215,6 +246,15 @@ func (j *Job) PodLabelSelector() string {
 	return fmt.Sprintf("%s=%s", batchv1.JobNameLabel, j.Name)
 }
 
+func (j *Job) PodsByPodSet(ctx context.Context, c client.Client) (map[kueue.PodSetReference][]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(j.Namespace), client.MatchingLabels{batchv1.ControllerUidLabel: string(j.UID)}); err != nil {
+		return nil, err
+	}
+	active := slices.DeleteFunc(pods.Items, func(p corev1.Pod) bool { return utilpod.IsTerminated(&p) })
+	return map[kueue.PodSetReference][]corev1.Pod{kueue.DefaultPodSetName: active}, nil
+}
+
 func (j *Job) ReclaimablePods() ([]kueue.ReclaimablePod, error) {
 	parallelism := ptr.Deref(j.Spec.Parallelism, 1)
 	if parallelism == 1 || j.Status.Succeeded == 0 {
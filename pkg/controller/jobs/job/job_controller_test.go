@@ -17,6 +17,7 @@ limitations under the License.
 package job
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -45,6 +46,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/podset"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 )
 
 func TestPodsReady(t *testing.T) {
@@ -475,6 +477,10 @@ func TestReconciler(t *testing.T) {
 
 	cases := map[string]struct {
 		enableTopologyAwareScheduling bool
+		enableGracefulPreemption      bool
+		enableMinimumRuntime          bool
+		enableElasticJobs             bool
+		enableAdvanceReservations     bool
 
 		reconcilerOptions []jobframework.Option
 		job               batchv1.Job
@@ -901,6 +907,65 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is created, it has the reservation label of the job": {
+			enableAdvanceReservations: true,
+			job: *baseJobWrapper.Clone().
+				Label(kueuealpha.ReservationLabel, "reservation").
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Label(kueuealpha.ReservationLabel, "reservation").
+				UID("test-uid").
+				Suspend(true).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+						kueuealpha.ReservationLabel:  "reservation"}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, types.UID("test-uid")),
+				},
+			},
+		},
+		"when workload is created, the reservation label isn't copied without AdvanceReservations": {
+			job: *baseJobWrapper.Clone().
+				Label(kueuealpha.ReservationLabel, "reservation").
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Label(kueuealpha.ReservationLabel, "reservation").
+				UID("test-uid").
+				Suspend(true).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: "test-uid"}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, types.UID("test-uid")),
+				},
+			},
+		},
 		"when workload is admitted the PodSetUpdates are propagated to job": {
 			job: *baseJobWrapper.Clone().
 				Obj(),
@@ -951,6 +1016,98 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when workload is pending preemption, the graceful stop is requested": {
+			enableGracefulPreemption: true,
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithGracefulPreemption(&configapi.GracefulPreemption{Timeout: &metav1.Duration{Duration: 2 * time.Minute}}),
+			},
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				SetAnnotation(GracefulStopRequestedAnnotation, testStartTime.Add(time.Minute).UTC().Format(time.RFC3339)).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadPreemptionPending,
+						Status:             metav1.ConditionTrue,
+						Reason:             kueue.InClusterQueueReason,
+						Message:            "Preempted to accommodate a higher priority Workload",
+						LastTransitionTime: metav1.NewTime(testStartTime.Add(-time.Minute)),
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadPreemptionPending,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.InClusterQueueReason,
+						Message: "Preempted to accommodate a higher priority Workload",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "GracefulStopRequested",
+					Message:   fmt.Sprintf("Requested the graceful stop before %s: Preempted to accommodate a higher priority Workload", testStartTime.Add(time.Minute).Format(time.RFC3339)),
+				},
+			},
+		},
+		"when the graceful stop is acknowledged, the workload is evicted": {
+			enableGracefulPreemption: true,
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				SetAnnotation(GracefulStopRequestedAnnotation, testStartTime.UTC().Format(time.RFC3339)).
+				SetAnnotation(GracefulStopAcknowledgedAnnotation, "true").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				SetAnnotation(GracefulStopRequestedAnnotation, testStartTime.UTC().Format(time.RFC3339)).
+				SetAnnotation(GracefulStopAcknowledgedAnnotation, "true").
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadPreemptionPending,
+						Status:             metav1.ConditionTrue,
+						Reason:             kueue.InClusterQueueReason,
+						Message:            "Preempted to accommodate a higher priority Workload",
+						LastTransitionTime: metav1.NewTime(testStartTime.Add(-time.Minute)),
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadPreemptionPending,
+						Status:  metav1.ConditionFalse,
+						Reason:  kueue.WorkloadGracefulStopAcknowledged,
+						Message: "The job acknowledged the graceful stop",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadEvicted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadEvictedByPreemption,
+						Message: "Preempted to accommodate a higher priority Workload",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadPreempted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.InClusterQueueReason,
+						Message: "Preempted to accommodate a higher priority Workload",
+					}).
+					Obj(),
+			},
+		},
 		"when workload is evicted due to spec.active field being false, job gets suspended and quota is unset": {
 			job: *baseJobWrapper.Clone().
 				Suspend(false).
@@ -1876,6 +2033,152 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"suspended elastic job with matching admitted workload is unsuspended with its pods gated": {
+			enableElasticJobs: true,
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Suspend(false).
+				SchedulingGate(workloadslicing.SchedulingGate).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq",
+				},
+			},
+		},
+		"elastic job scaled down releases the removed pods as reclaimable": {
+			enableElasticJobs: true,
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Suspend(false).
+				Parallelism(6).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Suspend(false).
+				Parallelism(6).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					ReclaimablePods(kueue.ReclaimablePod{Name: kueue.DefaultPodSetName, Count: 4}).
+					Obj(),
+			},
+		},
+		"elastic job scaled up gets a workload slice replacing its admitted workload": {
+			enableElasticJobs: true,
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Suspend(false).
+				Parallelism(12).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Suspend(false).
+				Parallelism(12).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload("job", "ns").
+					Annotation(workloadslicing.ReplacementForAnnotationKey, "ns/wl").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 12).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload slice: ns/" + jobframework.GetWorkloadSliceNameForOwnerWithGVK("job", "", gvk, 0) + ", replacing ns/wl",
+				},
+			},
+		},
+		"elastic job with an admitted workload slice finishes the replaced workload": {
+			enableElasticJobs: true,
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Suspend(false).
+				Parallelism(12).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Suspend(false).
+				Parallelism(12).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload("wl-slice", "ns").
+					Annotation(workloadslicing.ReplacementForAnnotationKey, "ns/wl").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 12).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(12).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Finalizers().
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadFinished,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadFinishedReasonSliceReplaced,
+						Message: "The workload slice is replaced by ns/wl-slice",
+					}).
+					Obj(),
+				*utiltesting.MakeWorkload("wl-slice", "ns").
+					Annotation(workloadslicing.ReplacementForAnnotationKey, "ns/wl").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 12).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(12).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "FinishedWorkload",
+					Message:   "Workload 'ns/wl' is replaced by 'ns/wl-slice'",
+				},
+			},
+		},
 		"non-matching admitted workload is deleted": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -2524,6 +2827,53 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"the workload is created with the minimum runtime of the workloadPriorityClass": {
+			enableMinimumRuntime: true,
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Queue("test-queue").
+				UID("test-uid").
+				WorkloadPriorityClass("test-wpc").
+				Obj(),
+			priorityClasses: []client.Object{
+				utiltesting.MakeWorkloadPriorityClass("test-wpc").PriorityValue(100).MinimumRuntimeSeconds(600).Obj(),
+			},
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				WorkloadPriorityClass("test-wpc").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					MinimumRuntimeSeconds(600).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Stopped",
+					Message:   "Missing Workload; unable to restore pod templates",
+				},
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, types.UID("test-uid")),
+				},
+			},
+		},
 		"the workload is created when queue name is set, with workloadPriorityClass": {
 			job: *baseJobWrapper.
 				Clone().
@@ -3198,6 +3548,10 @@ func TestReconciler(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, tc.enableTopologyAwareScheduling)
+			features.SetFeatureGateDuringTest(t, features.GracefulPreemption, tc.enableGracefulPreemption)
+			features.SetFeatureGateDuringTest(t, features.PreemptionMinimumRuntime, tc.enableMinimumRuntime)
+			features.SetFeatureGateDuringTest(t, features.ElasticJobsViaWorkloadSlices, tc.enableElasticJobs)
+			features.SetFeatureGateDuringTest(t, features.AdvanceReservations, tc.enableAdvanceReservations)
 			ctx, _ := utiltesting.ContextWithLog(t)
 			clientBuilder := utiltesting.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
 			if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
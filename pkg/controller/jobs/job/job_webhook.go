@@ -34,12 +34,15 @@ import (
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 )
 
 var (
 	minPodsCountAnnotationsPath   = field.NewPath("metadata", "annotations").Key(JobMinParallelismAnnotation)
 	syncCompletionAnnotationsPath = field.NewPath("metadata", "annotations").Key(JobCompletionsEqualParallelismAnnotation)
+	elasticJobAnnotationsPath     = field.NewPath("metadata", "annotations").Key(workloadslicing.EnabledAnnotationKey)
 	replicaMetaPath               = field.NewPath("spec", "template", "metadata")
 )
 
@@ -106,6 +109,7 @@ func (w *JobWebhook) validateCreate(job *Job) field.ErrorList {
 	allErrs = append(allErrs, w.validatePartialAdmissionCreate(job)...)
 	allErrs = append(allErrs, w.validateSyncCompletionCreate(job)...)
 	allErrs = append(allErrs, w.validateTopologyRequest(job)...)
+	allErrs = append(allErrs, validateElasticJob(job)...)
 	return allErrs
 }
 
@@ -160,6 +164,27 @@ func (w *JobWebhook) validateUpdate(oldJob, newJob *Job) field.ErrorList {
 	allErrs = append(allErrs, jobframework.ValidateJobOnUpdate(oldJob, newJob)...)
 	allErrs = append(allErrs, validatePartialAdmissionUpdate(oldJob, newJob)...)
 	allErrs = append(allErrs, w.validateTopologyRequest(newJob)...)
+	allErrs = append(allErrs, validateElasticJob(newJob)...)
+	if features.Enabled(features.ElasticJobsViaWorkloadSlices) && !oldJob.IsSuspended() &&
+		oldJob.Annotations[workloadslicing.EnabledAnnotationKey] != newJob.Annotations[workloadslicing.EnabledAnnotationKey] {
+		allErrs = append(allErrs, field.Forbidden(elasticJobAnnotationsPath, fmt.Sprintf("%s while the job is not suspended", apivalidation.FieldImmutableErrorMsg)))
+	}
+	return allErrs
+}
+
+// validateElasticJob checks that the features which can't be combined with
+// the scaling of admitted jobs aren't requested.
+func validateElasticJob(job *Job) field.ErrorList {
+	var allErrs field.ErrorList
+	if !workloadslicing.Enabled(job) {
+		return nil
+	}
+	if _, found := job.Annotations[JobMinParallelismAnnotation]; found {
+		allErrs = append(allErrs, field.Invalid(minPodsCountAnnotationsPath, job.Annotations[JobMinParallelismAnnotation], "can't be set for an elastic job"))
+	}
+	if jobframework.PodSetTopologyRequest(&job.Spec.Template.ObjectMeta, nil, nil, nil) != nil {
+		allErrs = append(allErrs, field.Invalid(replicaMetaPath.Child("annotations"), job.Spec.Template.Annotations, "topology requests can't be set for an elastic job"))
+	}
 	return allErrs
 }
 
@@ -41,6 +41,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 
 	// without this only the job framework is registered
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs/mpijob"
@@ -57,15 +58,17 @@ var (
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	prebuiltWlNameLabelPath       = labelsPath.Key(constants.PrebuiltWorkloadLabel)
 	maxExecTimeLabelPath          = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	workloadGroupSizePath         = annotationsPath.Key(constants.WorkloadGroupSizeAnnotation)
 	queueNameAnnotationsPath      = annotationsPath.Key(constants.QueueAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 )
 
 func TestValidateCreate(t *testing.T) {
 	testcases := []struct {
-		name    string
-		job     *batchv1.Job
-		wantErr field.ErrorList
+		name              string
+		job               *batchv1.Job
+		enableElasticJobs bool
+		wantErr           field.ErrorList
 	}{
 		{
 			name:    "simple",
@@ -237,6 +240,32 @@ func TestValidateCreate(t *testing.T) {
 				Indexed(true).
 				Obj(),
 		},
+		{
+			name: "workload group without size",
+			job: testingutil.MakeJob("job", "default").
+				Label(constants.WorkloadGroupLabel, "group").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(workloadGroupSizePath, "", `strconv.Atoi: parsing "": invalid syntax`),
+			},
+		},
+		{
+			name: "zero workload group size",
+			job: testingutil.MakeJob("job", "default").
+				Label(constants.WorkloadGroupLabel, "group").
+				SetAnnotation(constants.WorkloadGroupSizeAnnotation, "0").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(workloadGroupSizePath, 0, "should be greater than 0"),
+			},
+		},
+		{
+			name: "valid workload group",
+			job: testingutil.MakeJob("job", "default").
+				Label(constants.WorkloadGroupLabel, "group").
+				SetAnnotation(constants.WorkloadGroupSizeAnnotation, "2").
+				Obj(),
+		},
 		{
 			name: "valid topology request",
 			job: testingutil.MakeJob("job", "default").
@@ -275,10 +304,51 @@ func TestValidateCreate(t *testing.T) {
 					invalidLabelKeyMessage),
 			},
 		},
+		{
+			name: "valid elastic job",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Obj(),
+			enableElasticJobs: true,
+		},
+		{
+			name: "elastic job with partial admission",
+			job: testingutil.MakeJob("job", "default").
+				Parallelism(4).
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				SetAnnotation(JobMinParallelismAnnotation, "3").
+				Obj(),
+			enableElasticJobs: true,
+			wantErr: field.ErrorList{
+				field.Invalid(minPodsCountAnnotationsPath, "3", "can't be set for an elastic job"),
+			},
+		},
+		{
+			name: "elastic job with partial admission, feature disabled",
+			job: testingutil.MakeJob("job", "default").
+				Parallelism(4).
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				SetAnnotation(JobMinParallelismAnnotation, "3").
+				Obj(),
+		},
+		{
+			name: "elastic job with topology request",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				PodAnnotation(kueuealpha.PodSetRequiredTopologyAnnotation, "cloud.com/block").
+				Obj(),
+			enableElasticJobs: true,
+			wantErr: field.ErrorList{
+				field.Invalid(replicaMetaPath.Child("annotations"), map[string]string{
+					kueuealpha.PodSetRequiredTopologyAnnotation: "cloud.com/block",
+				}, "topology requests can't be set for an elastic job"),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ElasticJobsViaWorkloadSlices, tc.enableElasticJobs)
 			jw := &JobWebhook{}
 
 			gotErr := jw.validateCreate((*Job)(tc.job))
@@ -292,10 +362,11 @@ func TestValidateCreate(t *testing.T) {
 
 func TestValidateUpdate(t *testing.T) {
 	testcases := []struct {
-		name    string
-		oldJob  *batchv1.Job
-		newJob  *batchv1.Job
-		wantErr field.ErrorList
+		name              string
+		oldJob            *batchv1.Job
+		newJob            *batchv1.Job
+		enableElasticJobs bool
+		wantErr           field.ErrorList
 	}{
 		{
 			name:    "normal update",
@@ -534,10 +605,50 @@ func TestValidateUpdate(t *testing.T) {
 					`must not contain both "kueue.x-k8s.io/podset-required-topology" and "kueue.x-k8s.io/podset-preferred-topology"`),
 			},
 		},
+		{
+			name: "scale a running elastic job",
+			oldJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				Parallelism(4).
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				Parallelism(6).
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Obj(),
+			enableElasticJobs: true,
+		},
+		{
+			name: "enable elastic job while running",
+			oldJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Obj(),
+			enableElasticJobs: true,
+			wantErr: field.ErrorList{
+				field.Forbidden(elasticJobAnnotationsPath, "field is immutable while the job is not suspended"),
+			},
+		},
+		{
+			name: "enable elastic job while suspended",
+			oldJob: testingutil.MakeJob("job", "default").
+				Suspend(true).
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				Suspend(true).
+				SetAnnotation(workloadslicing.EnabledAnnotationKey, workloadslicing.EnabledAnnotationValue).
+				Obj(),
+			enableElasticJobs: true,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ElasticJobsViaWorkloadSlices, tc.enableElasticJobs)
 			gotErr := new(JobWebhook).validateUpdate((*Job)(tc.oldJob), (*Job)(tc.newJob))
 			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{})); diff != "" {
 				t.Errorf("validateUpdate() mismatch (-want +got):\n%s", diff)
@@ -0,0 +1,149 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisionlog
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+const (
+	// fileQueueSize is the number of scheduling cycles buffered while the
+	// file is being written. The decisions of the next cycles are dropped.
+	fileQueueSize = 100
+	// fileMaxSize is the size above which the file is rotated. The previous
+	// decisions are kept in a single backup file, with the ".1" suffix.
+	fileMaxSize = 100 << 20
+)
+
+// FileSink appends the decisions to a file, one JSON object per line. The
+// decisions are written in the background, once the sink is started, and the
+// file is rotated when it grows above its maximum size.
+type FileSink struct {
+	path    string
+	maxSize int64
+	queue   chan []visibility.SchedulingDecision
+
+	file *os.File
+	size int64
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink opens the file to append the decisions to, creating it if
+// needed.
+func NewFileSink(path string) (*FileSink, error) {
+	s := &FileSink{
+		path:    path,
+		maxSize: fileMaxSize,
+		queue:   make(chan []visibility.SchedulingDecision, fileQueueSize),
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Write implements Sink. It drops the decisions if too many cycles are
+// waiting to be written.
+func (s *FileSink) Write(ctx context.Context, decisions []visibility.SchedulingDecision) {
+	select {
+	case s.queue <- decisions:
+	default:
+		ctrl.LoggerFrom(ctx).V(2).Info("Dropping scheduling decisions, the file is too slow", "file", s.path, "decisions", len(decisions))
+	}
+}
+
+// Start implements the Runnable interface to write the decisions until the
+// context is done. The decisions still buffered are written before the file
+// is closed.
+func (s *FileSink) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("decision-log")
+	defer func() {
+		if err := s.file.Close(); err != nil {
+			log.Error(err, "Failed to close the scheduling decision log file", "file", s.path)
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case decisions := <-s.queue:
+					s.writeLogged(log, decisions)
+				default:
+					return nil
+				}
+			}
+		case decisions := <-s.queue:
+			s.writeLogged(log, decisions)
+		}
+	}
+}
+
+func (s *FileSink) writeLogged(log logr.Logger, decisions []visibility.SchedulingDecision) {
+	if err := s.write(decisions); err != nil {
+		log.Error(err, "Failed to write the scheduling decisions", "file", s.path, "decisions", len(decisions))
+	}
+}
+
+func (s *FileSink) write(decisions []visibility.SchedulingDecision) error {
+	data, err := encode(decisions)
+	if err != nil {
+		return err
+	}
+	if s.size > 0 && s.size+int64(len(data)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	return err
+}
+
+// rotate moves the file to its backup, replacing the previous one, and opens
+// a new file.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
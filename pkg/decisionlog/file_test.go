@@ -0,0 +1,111 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisionlog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.jsonl")
+	if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sink.Write(context.Background(), []visibility.SchedulingDecision{decision(1, "cq", "a"), decision(1, "cq", "b")})
+	sink.Write(context.Background(), []visibility.SchedulingDecision{decision(2, "cq", "b")})
+	runFileSink(t, sink)
+
+	want := `{}
+{"time":null,"schedulingCycle":1,"workload":{"namespace":"default","name":"a"},"clusterQueue":"cq","priority":0,"mode":"","borrowing":false,"outcome":""}
+{"time":null,"schedulingCycle":1,"workload":{"namespace":"default","name":"b"},"clusterQueue":"cq","priority":0,"mode":"","borrowing":false,"outcome":""}
+{"time":null,"schedulingCycle":2,"workload":{"namespace":"default","name":"b"},"clusterQueue":"cq","priority":0,"mode":"","borrowing":false,"outcome":""}
+`
+	if diff := cmp.Diff(want, readFile(t, path)); diff != "" {
+		t.Errorf("Unexpected file content (-want,+got):\n%s", diff)
+	}
+}
+
+func TestFileSinkRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.jsonl")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Each decision takes 154 bytes, two of them fit in the file.
+	sink.maxSize = 320
+	for cycle := range int64(3) {
+		sink.Write(context.Background(), []visibility.SchedulingDecision{decision(cycle, "cq", "a")})
+	}
+	runFileSink(t, sink)
+
+	wantBackup := `{"time":null,"schedulingCycle":0,"workload":{"namespace":"default","name":"a"},"clusterQueue":"cq","priority":0,"mode":"","borrowing":false,"outcome":""}
+{"time":null,"schedulingCycle":1,"workload":{"namespace":"default","name":"a"},"clusterQueue":"cq","priority":0,"mode":"","borrowing":false,"outcome":""}
+`
+	if diff := cmp.Diff(wantBackup, readFile(t, path+".1")); diff != "" {
+		t.Errorf("Unexpected backup file content (-want,+got):\n%s", diff)
+	}
+	want := `{"time":null,"schedulingCycle":2,"workload":{"namespace":"default","name":"a"},"clusterQueue":"cq","priority":0,"mode":"","borrowing":false,"outcome":""}
+`
+	if diff := cmp.Diff(want, readFile(t, path)); diff != "" {
+		t.Errorf("Unexpected file content (-want,+got):\n%s", diff)
+	}
+}
+
+func TestFileSinkDropsDecisionsWhenFull(t *testing.T) {
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "decisions.jsonl"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := range fileQueueSize + 1 {
+		sink.Write(context.Background(), []visibility.SchedulingDecision{decision(int64(i), "cq", "a")})
+	}
+	if got := len(sink.queue); got != fileQueueSize {
+		t.Errorf("Unexpected number of queued cycles %d, want %d", got, fileQueueSize)
+	}
+	runFileSink(t, sink)
+}
+
+// runFileSink starts the sink with a context already done, so it writes the
+// buffered decisions and closes the file.
+func runFileSink(t *testing.T, sink *FileSink) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sink.Start(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(got)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisionlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+const (
+	// httpQueueSize is the number of scheduling cycles buffered while the
+	// endpoint is slow or unreachable. The decisions of the next cycles are
+	// dropped.
+	httpQueueSize = 100
+	httpTimeout   = 10 * time.Second
+
+	contentTypeJSONLines = "application/x-ndjson"
+)
+
+// HTTPSink sends the decisions of each scheduling cycle to an endpoint, with a
+// POST request of JSON objects separated by new lines. The requests are sent
+// in the background, once the sink is started.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+	queue  chan []visibility.SchedulingDecision
+}
+
+var _ Sink = (*HTTPSink)(nil)
+
+// NewHTTPSink returns a sink sending the decisions to the URL.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{
+		url:    url,
+		client: &http.Client{Timeout: httpTimeout},
+		queue:  make(chan []visibility.SchedulingDecision, httpQueueSize),
+	}
+}
+
+// Write implements Sink. It drops the decisions if too many cycles are
+// waiting to be sent.
+func (s *HTTPSink) Write(ctx context.Context, decisions []visibility.SchedulingDecision) {
+	select {
+	case s.queue <- decisions:
+	default:
+		ctrl.LoggerFrom(ctx).V(2).Info("Dropping scheduling decisions, the endpoint is too slow", "url", s.url, "decisions", len(decisions))
+	}
+}
+
+// Start implements the Runnable interface to send the decisions until the
+// context is done.
+func (s *HTTPSink) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("decision-log")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case decisions := <-s.queue:
+			if err := s.send(ctx, decisions); err != nil {
+				log.Error(err, "Failed to send the scheduling decisions", "url", s.url, "decisions", len(decisions))
+			}
+		}
+	}
+}
+
+func (s *HTTPSink) send(ctx context.Context, decisions []visibility.SchedulingDecision) error {
+	data, err := encode(decisions)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentTypeJSONLines)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisionlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+func TestHTTPSink(t *testing.T) {
+	received := make(chan []visibility.SchedulingDecision)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != contentTypeJSONLines {
+			t.Errorf("Unexpected request %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		var decisions []visibility.SchedulingDecision
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var d visibility.SchedulingDecision
+			if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+				t.Errorf("Unexpected error decoding %q: %v", scanner.Text(), err)
+			}
+			decisions = append(decisions, d)
+		}
+		received <- decisions
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := NewHTTPSink(server.URL)
+	go func() {
+		_ = sink.Start(ctx)
+	}()
+
+	batches := [][]visibility.SchedulingDecision{
+		{decision(1, "cq", "a"), decision(1, "cq", "b")},
+		{decision(2, "cq", "b")},
+	}
+	for _, batch := range batches {
+		sink.Write(ctx, batch)
+	}
+	for _, want := range batches {
+		select {
+		case got := <-received:
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Unexpected decisions (-want,+got):\n%s", diff)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timed out waiting for the decisions")
+		}
+	}
+}
+
+func TestHTTPSinkDropsDecisionsWhenFull(t *testing.T) {
+	sink := NewHTTPSink("http://localhost")
+	for i := range httpQueueSize + 1 {
+		sink.Write(context.Background(), []visibility.SchedulingDecision{decision(int64(i), "cq", "a")})
+	}
+	if got := len(sink.queue); got != httpQueueSize {
+		t.Errorf("Unexpected number of queued cycles %d, want %d", got, httpQueueSize)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisionlog
+
+import (
+	"context"
+	"sync"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+// Ring keeps the latest decisions in memory, up to its capacity, overwriting
+// the oldest ones.
+type Ring struct {
+	mu        sync.RWMutex
+	decisions []visibility.SchedulingDecision
+	// next is the index of the next decision to write.
+	next int
+	full bool
+}
+
+var _ Sink = (*Ring)(nil)
+
+// NewRing returns a ring keeping up to capacity decisions. The capacity must
+// be positive.
+func NewRing(capacity int) *Ring {
+	return &Ring{
+		decisions: make([]visibility.SchedulingDecision, capacity),
+	}
+}
+
+// Write implements Sink.
+func (r *Ring) Write(_ context.Context, decisions []visibility.SchedulingDecision) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range decisions {
+		r.decisions[r.next] = decisions[i]
+		r.next++
+		if r.next == len(r.decisions) {
+			r.next = 0
+			r.full = true
+		}
+	}
+}
+
+// List returns the decisions kept which match, oldest first.
+func (r *Ring) List(match func(*visibility.SchedulingDecision) bool) []visibility.SchedulingDecision {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []visibility.SchedulingDecision
+	collect := func(decisions []visibility.SchedulingDecision) {
+		for i := range decisions {
+			if match(&decisions[i]) {
+				result = append(result, *decisions[i].DeepCopy())
+			}
+		}
+	}
+	if r.full {
+		collect(r.decisions[r.next:])
+	}
+	collect(r.decisions[:r.next])
+	return result
+}
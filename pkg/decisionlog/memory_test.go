@@ -0,0 +1,90 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisionlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+func decision(cycle int64, cq, name string) visibility.SchedulingDecision {
+	return visibility.SchedulingDecision{
+		SchedulingCycle: cycle,
+		Workload:        visibility.WorkloadReference{Namespace: "default", Name: name},
+		ClusterQueue:    cq,
+	}
+}
+
+func TestRing(t *testing.T) {
+	all := func(*visibility.SchedulingDecision) bool { return true }
+	inCQ := func(cq string) func(*visibility.SchedulingDecision) bool {
+		return func(d *visibility.SchedulingDecision) bool { return d.ClusterQueue == cq }
+	}
+	cases := map[string]struct {
+		capacity int
+		writes   [][]visibility.SchedulingDecision
+		match    func(*visibility.SchedulingDecision) bool
+		want     []visibility.SchedulingDecision
+	}{
+		"empty": {
+			capacity: 3,
+			match:    all,
+		},
+		"not full": {
+			capacity: 3,
+			writes: [][]visibility.SchedulingDecision{
+				{decision(1, "cq-a", "a"), decision(1, "cq-b", "b")},
+			},
+			match: all,
+			want:  []visibility.SchedulingDecision{decision(1, "cq-a", "a"), decision(1, "cq-b", "b")},
+		},
+		"overwrites the oldest decisions": {
+			capacity: 3,
+			writes: [][]visibility.SchedulingDecision{
+				{decision(1, "cq-a", "a"), decision(1, "cq-b", "b")},
+				{decision(2, "cq-a", "a"), decision(2, "cq-b", "b")},
+			},
+			match: all,
+			want:  []visibility.SchedulingDecision{decision(1, "cq-b", "b"), decision(2, "cq-a", "a"), decision(2, "cq-b", "b")},
+		},
+		"filters the decisions": {
+			capacity: 4,
+			writes: [][]visibility.SchedulingDecision{
+				{decision(1, "cq-a", "a"), decision(1, "cq-b", "b")},
+				{decision(2, "cq-a", "a"), decision(2, "cq-b", "b")},
+				{decision(3, "cq-a", "c")},
+			},
+			match: inCQ("cq-a"),
+			want:  []visibility.SchedulingDecision{decision(2, "cq-a", "a"), decision(3, "cq-a", "c")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ring := NewRing(tc.capacity)
+			for _, decisions := range tc.writes {
+				ring.Write(context.Background(), decisions)
+			}
+			if diff := cmp.Diff(tc.want, ring.List(tc.match)); diff != "" {
+				t.Errorf("Unexpected decisions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
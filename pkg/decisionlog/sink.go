@@ -0,0 +1,47 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decisionlog provides the sinks recording the scheduling decisions
+// of the scheduler: a file of JSON lines, an HTTP endpoint, and an in-memory
+// ring of the latest decisions served by the visibility server.
+package decisionlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+// Sink records the scheduling decisions of each scheduling cycle.
+type Sink interface {
+	// Write records the decisions of a scheduling cycle. It's called by the
+	// scheduler, so it must not block on slow destinations.
+	Write(ctx context.Context, decisions []visibility.SchedulingDecision)
+}
+
+// encode returns the decisions as JSON lines.
+func encode(decisions []visibility.SchedulingDecision) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range decisions {
+		if err := enc.Encode(&decisions[i]); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -151,6 +151,84 @@ const (
 	//
 	// Enable to set use LeastAlloactedFit algorithm for TAS
 	TASLeastAllocated featuregate.Feature = "TASLeastAllocated"
+
+	// owner: @vicentefb
+	//
+	// Enable time-windowed quota schedules in ClusterQueues and Cohorts.
+	QuotaSchedules featuregate.Feature = "QuotaSchedules"
+
+	// Enable quotas in LocalQueues, limiting the resources that the workloads
+	// submitted to a LocalQueue can reserve in its ClusterQueue.
+	LocalQueueQuotas featuregate.Feature = "LocalQueueQuotas"
+
+	// Enable ordering the workloads of a ClusterQueue by the usage share of
+	// their LocalQueues, for ClusterQueues using UsageBasedAdmissionFairSharing.
+	AdmissionFairSharing featuregate.Feature = "AdmissionFairSharing"
+
+	// Enable the dispatcher strategies configured in the MultiKueueConfigs,
+	// instead of always dispatching the workloads to all the worker clusters.
+	MultiKueueDispatcher featuregate.Feature = "MultiKueueDispatcher"
+
+	// Enable moving the workloads away from the MultiKueue worker clusters
+	// which become unreachable, and cordoning the worker clusters which fail
+	// repeatedly.
+	MultiKueueFailover featuregate.Feature = "MultiKueueFailover"
+
+	// Enable giving the preempted workloads a grace period to stop gracefully,
+	// for example after saving a checkpoint, before releasing their quota.
+	GracefulPreemption featuregate.Feature = "GracefulPreemption"
+
+	// Enable protecting the admitted workloads from preemption until they run
+	// for their minimum runtime.
+	PreemptionMinimumRuntime featuregate.Feature = "PreemptionMinimumRuntime"
+
+	// Enable scaling the admitted jobs in place, by admitting workload slices
+	// for the scaled up jobs.
+	ElasticJobsViaWorkloadSlices featuregate.Feature = "ElasticJobsViaWorkloadSlices"
+
+	// Enable admitting, in StrictFIFO ClusterQueues, the workloads behind a
+	// blocked head that finish, given their maximum execution time, before the
+	// head can start.
+	BackfillScheduling featuregate.Feature = "BackfillScheduling"
+
+	// Enable the Reservation API, to hold back quota during a time window for
+	// the workloads naming the Reservation.
+	AdvanceReservations featuregate.Feature = "AdvanceReservations"
+
+	// Enable keeping the workloads out of the queues until the workloads they
+	// depend on finish successfully.
+	WorkloadDependencies featuregate.Feature = "WorkloadDependencies"
+
+	// Enable admitting and evicting the workloads of a workload group together.
+	WorkloadGroups featuregate.Feature = "WorkloadGroups"
+
+	// Enable the costs of the ResourceFlavors, and choosing the lowest-cost
+	// flavors for the ClusterQueues with the LowestCost flavorSelectionPolicy.
+	CostAwareFlavorSelection featuregate.Feature = "CostAwareFlavorSelection"
+
+	// Enable raising the effective priority of the pending workloads with
+	// their wait time, for the ClusterQueues with a priority aging policy.
+	PriorityAging featuregate.Feature = "PriorityAging"
+
+	// Enable limiting the number of workloads reserving quota in the
+	// ClusterQueues and LocalQueues setting maxAdmittedWorkloads.
+	MaxAdmittedWorkloads featuregate.Feature = "MaxAdmittedWorkloads"
+
+	// Enable running the scheduling cycle concurrently for the ClusterQueues
+	// of independent cohort trees.
+	ParallelScheduling featuregate.Feature = "ParallelScheduling"
+
+	// Enable recording the decisions of the scheduling cycles to the sinks
+	// configured in the scheduler's decisionLog.
+	SchedulingDecisionLog featuregate.Feature = "SchedulingDecisionLog"
+
+	// Enable estimating the start time of the pending workloads served by
+	// the visibility API.
+	StartTimeEstimation featuregate.Feature = "StartTimeEstimation"
+
+	// Enable explaining, per pod set, resource and flavor, why the pending
+	// workloads served by the visibility API don't fit.
+	InadmissibilityReasons featuregate.Feature = "InadmissibilityReasons"
 )
 
 func init() {
@@ -234,6 +312,64 @@ var defaultVersionedFeatureGates = map[featuregate.Feature]featuregate.Versioned
 	TASLeastAllocated: {
 		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Deprecated},
 	},
+
+	QuotaSchedules: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	LocalQueueQuotas: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	AdmissionFairSharing: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	MultiKueueDispatcher: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	MultiKueueFailover: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	GracefulPreemption: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	PreemptionMinimumRuntime: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	ElasticJobsViaWorkloadSlices: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	BackfillScheduling: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	AdvanceReservations: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	WorkloadDependencies: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	WorkloadGroups: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	CostAwareFlavorSelection: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	PriorityAging: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	MaxAdmittedWorkloads: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	ParallelScheduling: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	SchedulingDecisionLog: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	StartTimeEstimation: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
+	InadmissibilityReasons: {
+		{Version: version.MustParse("0.11"), Default: false, PreRelease: featuregate.Alpha},
+	},
 }
 
 func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) {
@@ -42,3 +42,16 @@ func (c *CycleChecker) HasCycle(cohort CycleCheckable[kueue.CohortReference]) bo
 	c.cycles[cohort.GetName()] = c.HasCycle(cohort.CCParent())
 	return c.cycles[cohort.GetName()]
 }
+
+// HasCycle checks for cycles in the ancestors of the cohort without
+// memoizing the result, so that it only reads the hierarchy.
+func HasCycle[T comparable](cohort CycleCheckable[T]) bool {
+	visited := make(map[T]bool)
+	for ; cohort.HasParent(); cohort = cohort.CCParent() {
+		if visited[cohort.GetName()] {
+			return true
+		}
+		visited[cohort.GetName()] = true
+	}
+	return false
+}
@@ -82,7 +82,7 @@ func (m *Manager[CQ, C]) DeleteClusterQueue(name kueue.ClusterQueueReference) {
 
 func (m *Manager[CQ, C]) AddCohort(cohortName kueue.CohortReference) {
 	oldCohort, ok := m.cohorts[cohortName]
-	if ok && oldCohort.isExplicit() {
+	if ok && oldCohort.IsExplicit() {
 		return
 	}
 	if !ok {
@@ -167,11 +167,54 @@ func (m *Manager[CQ, C]) getOrCreateCohort(cohortName kueue.CohortReference) C {
 }
 
 func (m *Manager[CQ, C]) cleanupCohort(cohort C) {
-	if !cohort.isExplicit() && !cohort.hasChildren() {
+	if !cohort.IsExplicit() && !cohort.hasChildren() {
 		delete(m.cohorts, cohort.GetName())
 	}
 }
 
+// Partition splits the ClusterQueues and Cohorts into Managers of the
+// independent trees: one per Cohort without a parent, holding all its
+// descendants, and one per ClusterQueue without a Cohort. The nodes are
+// shared with m. The nodes in a cycle don't belong to any partition.
+func (m *Manager[CQ, C]) Partition() []Manager[CQ, C] {
+	var partitions []Manager[CQ, C]
+	for _, cohort := range m.cohorts {
+		if cohort.HasParent() {
+			continue
+		}
+		p := NewManager[CQ, C](m.cohortFactory)
+		p.addSubtree(cohort)
+		partitions = append(partitions, p)
+	}
+	for _, cq := range m.clusterQueues {
+		if cq.HasParent() {
+			continue
+		}
+		p := NewManager[CQ, C](m.cohortFactory)
+		p.clusterQueues[cq.GetName()] = cq
+		partitions = append(partitions, p)
+	}
+	return partitions
+}
+
+// Merge adds the ClusterQueues and Cohorts of other, which must be disjoint
+// from the ones of m, to m.
+func (m *Manager[CQ, C]) Merge(other Manager[CQ, C]) {
+	maps.Copy(m.cohorts, other.cohorts)
+	maps.Copy(m.clusterQueues, other.clusterQueues)
+	m.resetCycleChecker()
+}
+
+func (m *Manager[CQ, C]) addSubtree(cohort C) {
+	m.cohorts[cohort.GetName()] = cohort
+	for _, cq := range cohort.ChildCQs() {
+		m.clusterQueues[cq.GetName()] = cq
+	}
+	for _, child := range cohort.ChildCohorts() {
+		m.addSubtree(child)
+	}
+}
+
 func (m *Manager[CQ, C]) resetCycleChecker() {
 	m.CycleChecker = CycleChecker{make(map[kueue.CohortReference]bool, len(m.cohorts))}
 }
@@ -208,7 +251,7 @@ type cohortNode[CQ nodeBase[kueue.ClusterQueueReference], C nodeBase[kueue.Cohor
 	deleteClusterQueue(CQ)
 	hasChildren() bool
 	ChildCQs() []CQ
-	isExplicit() bool
+	IsExplicit() bool
 	markExplicit()
 	nodeBase[kueue.CohortReference]
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package hierarchy
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -483,6 +484,9 @@ func TestCycles(t *testing.T) {
 			mgr := NewManager(newCohort)
 			tc.operations(mgr)
 			for _, cohort := range mgr.Cohorts() {
+				if got := HasCycle[kueue.CohortReference](cohort); got != tc.wantCycles[cohort.GetName()] {
+					t.Errorf("Unmemoized check -want +got: %v %v", tc.wantCycles[cohort.GetName()], got)
+				}
 				got := mgr.CycleChecker.HasCycle(cohort)
 				if got != tc.wantCycles[cohort.GetName()] {
 					t.Errorf("-want +got: %v %v", tc.wantCycles[cohort.GetName()], got)
@@ -527,3 +531,97 @@ func newCq(name kueue.ClusterQueueReference) *testClusterQueue {
 func (t *testClusterQueue) GetName() kueue.ClusterQueueReference {
 	return t.name
 }
+
+func TestPartition(t *testing.T) {
+	type M = Manager[*testClusterQueue, *testCohort]
+	cases := map[string]struct {
+		operations     func(M)
+		wantPartitions [][]string
+	}{
+		"empty": {
+			operations: func(M) {},
+		},
+		"independent trees": {
+			operations: func(m M) {
+				m.AddClusterQueue(newCq("cq-a"))
+				m.AddClusterQueue(newCq("cq-b"))
+				m.AddClusterQueue(newCq("cq-c"))
+				m.AddClusterQueue(newCq("cq-d"))
+				m.AddCohort("root-a")
+				m.AddCohort("left")
+				m.UpdateCohortEdge("left", "root-a")
+				m.UpdateClusterQueueEdge("cq-a", "left")
+				m.UpdateClusterQueueEdge("cq-b", "root-a")
+				m.UpdateClusterQueueEdge("cq-c", "root-b")
+			},
+			wantPartitions: [][]string{
+				{"cq-a", "cq-b", "left", "root-a"},
+				{"cq-c", "root-b"},
+				{"cq-d"},
+			},
+		},
+		"cohort without ClusterQueues": {
+			operations: func(m M) {
+				m.AddCohort("root")
+			},
+			wantPartitions: [][]string{
+				{"root"},
+			},
+		},
+		"cycle": {
+			operations: func(m M) {
+				m.AddClusterQueue(newCq("cq-a"))
+				m.AddClusterQueue(newCq("cq-b"))
+				m.UpdateClusterQueueEdge("cq-a", "cohort-a")
+				m.UpdateCohortEdge("cohort-a", "cohort-b")
+				m.UpdateCohortEdge("cohort-b", "cohort-a")
+			},
+			wantPartitions: [][]string{
+				{"cq-b"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mgr := NewManager(newCohort)
+			tc.operations(mgr)
+			var gotPartitions [][]string
+			for _, p := range mgr.Partition() {
+				var names []string
+				for name := range p.ClusterQueues() {
+					names = append(names, string(name))
+				}
+				for name := range p.Cohorts() {
+					names = append(names, string(name))
+				}
+				slices.Sort(names)
+				gotPartitions = append(gotPartitions, names)
+			}
+			opts := []cmp.Option{
+				cmpopts.EquateEmpty(),
+				cmpopts.SortSlices(func(a, b []string) bool { return a[0] < b[0] }),
+			}
+			if diff := cmp.Diff(tc.wantPartitions, gotPartitions, opts...); diff != "" {
+				t.Errorf("Unexpected partitions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	mgr := NewManager(newCohort)
+	mgr.AddClusterQueue(newCq("cq-a"))
+	mgr.AddClusterQueue(newCq("cq-b"))
+	mgr.UpdateClusterQueueEdge("cq-a", "root")
+	partitions := mgr.Partition()
+	if len(partitions) != 2 {
+		t.Fatalf("Unexpected number of partitions %d, want 2", len(partitions))
+	}
+	partitions[0].Merge(partitions[1])
+	if diff := cmp.Diff(sets.New[kueue.ClusterQueueReference]("cq-a", "cq-b"), sets.New(partitions[0].ClusterQueuesNames()...)); diff != "" {
+		t.Errorf("Unexpected ClusterQueues (-want,+got):\n%s", diff)
+	}
+	if partitions[0].Cohort("root") != mgr.Cohort("root") {
+		t.Error("Expected the merged partition to share the Cohort with the Manager")
+	}
+}
@@ -128,6 +128,15 @@ The label 'result' can have the following values:
 		}, []string{"cluster_queue"},
 	)
 
+	QuotaReservedCostTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "quota_reserved_cost_total",
+			Help: `The total cost of the resources reserved by the workloads per 'cluster_queue',
+as per the costs of the ResourceFlavors`,
+		}, []string{"cluster_queue"},
+	)
+
 	LocalQueueQuotaReservedWorkloadsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Subsystem: constants.KueueName,
@@ -369,6 +378,45 @@ If the ClusterQueue has a weight of zero, this will return 9223372036854775807,
 the maximum possible share value.`,
 		}, []string{"cluster_queue"},
 	)
+
+	// Optional cohort metrics
+
+	CohortSubtreeQuota = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_subtree_quota",
+			Help:      `Reports the cohort's subtree quota within all the flavors`,
+		}, []string{"cohort", "flavor", "resource"},
+	)
+
+	CohortResourceReservations = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_resource_reservation",
+			Help:      `Reports the cohort's total resource reservation within all the flavors`,
+		}, []string{"cohort", "flavor", "resource"},
+	)
+
+	CohortResourceBorrowed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_resource_borrowed",
+			Help:      `Reports the cohort's resources borrowed from its parent within all the flavors`,
+		}, []string{"cohort", "flavor", "resource"},
+	)
+
+	CohortWeightedShare = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_weighted_share",
+			Help: `Reports a value that representing the maximum of the ratios of usage above subtree
+quota to the lendable resources in the parent cohort, among all the resources provided by
+the Cohort, and divided by the weight.
+If zero, it means that the usage of the Cohort is below the subtree quota.
+If the Cohort has a weight of zero, this will return 9223372036854775807,
+the maximum possible share value.`,
+		}, []string{"cohort"},
+	)
 )
 
 func generateExponentialBuckets(count int) []float64 {
@@ -385,6 +433,12 @@ func QuotaReservedWorkload(cqName kueue.ClusterQueueReference, waitTime time.Dur
 	quotaReservedWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
 }
 
+// QuotaReservedCost adds the cost of the resources reserved by a workload
+// to the cost accumulated by the ClusterQueue.
+func QuotaReservedCost(cqName kueue.ClusterQueueReference, cost float64) {
+	QuotaReservedCostTotal.WithLabelValues(string(cqName)).Add(cost)
+}
+
 func LocalQueueQuotaReservedWorkload(lq LocalQueueReference, waitTime time.Duration) {
 	LocalQueueQuotaReservedWorkloadsTotal.WithLabelValues(lq.Name, lq.Namespace).Inc()
 	localQueueQuotaReservedWaitTime.WithLabelValues(lq.Name, lq.Namespace).Observe(waitTime.Seconds())
@@ -451,6 +505,7 @@ func ClearClusterQueueMetrics(cqName string) {
 	PendingWorkloads.DeleteLabelValues(cqName, PendingStatusActive)
 	PendingWorkloads.DeleteLabelValues(cqName, PendingStatusInadmissible)
 	QuotaReservedWorkloadsTotal.DeleteLabelValues(cqName)
+	QuotaReservedCostTotal.DeleteLabelValues(cqName)
 	quotaReservedWaitTime.DeleteLabelValues(cqName)
 	AdmittedWorkloadsTotal.DeleteLabelValues(cqName)
 	admissionWaitTime.DeleteLabelValues(cqName)
@@ -538,6 +593,30 @@ func ReportClusterQueueWeightedShare(cq string, weightedShare int64) {
 	ClusterQueueWeightedShare.WithLabelValues(cq).Set(float64(weightedShare))
 }
 
+func ReportCohortResourceUsage(cohort, flavor, resource string, subtreeQuota, reservation, borrowed float64) {
+	CohortSubtreeQuota.WithLabelValues(cohort, flavor, resource).Set(subtreeQuota)
+	CohortResourceReservations.WithLabelValues(cohort, flavor, resource).Set(reservation)
+	CohortResourceBorrowed.WithLabelValues(cohort, flavor, resource).Set(borrowed)
+}
+
+func ReportCohortWeightedShare(cohort string, weightedShare int64) {
+	CohortWeightedShare.WithLabelValues(cohort).Set(float64(weightedShare))
+}
+
+func ClearCohortResourceMetrics(cohort string) {
+	lbls := prometheus.Labels{
+		"cohort": cohort,
+	}
+	CohortSubtreeQuota.DeletePartialMatch(lbls)
+	CohortResourceReservations.DeletePartialMatch(lbls)
+	CohortResourceBorrowed.DeletePartialMatch(lbls)
+}
+
+func ClearCohortMetrics(cohort string) {
+	ClearCohortResourceMetrics(cohort)
+	CohortWeightedShare.DeleteLabelValues(cohort)
+}
+
 func ClearClusterQueueResourceMetrics(cqName string) {
 	lbls := prometheus.Labels{
 		"cluster_queue": cqName,
@@ -612,6 +691,7 @@ func Register() {
 		ReservingActiveWorkloads,
 		AdmittedActiveWorkloads,
 		QuotaReservedWorkloadsTotal,
+		QuotaReservedCostTotal,
 		quotaReservedWaitTime,
 		AdmittedWorkloadsTotal,
 		EvictedWorkloadsTotal,
@@ -625,6 +705,10 @@ func Register() {
 		ClusterQueueResourceBorrowingLimit,
 		ClusterQueueResourceLendingLimit,
 		ClusterQueueWeightedShare,
+		CohortSubtreeQuota,
+		CohortResourceReservations,
+		CohortResourceBorrowed,
+		CohortWeightedShare,
 	)
 	if features.Enabled(features.LocalQueueMetrics) {
 		RegisterLQMetrics()
@@ -156,6 +156,16 @@ func TestReportAndCleanupClusterQueueEvictedNumber(t *testing.T) {
 	expectFilteredMetricsCount(t, EvictedWorkloadsTotal, 0, "cluster_queue", "cluster_queue1")
 }
 
+func TestReportAndCleanupClusterQueueCost(t *testing.T) {
+	QuotaReservedCost("cluster_queue1", 2.5)
+	QuotaReservedCost("cluster_queue1", 1)
+
+	expectFilteredMetricsCount(t, QuotaReservedCostTotal, 1, "cluster_queue", "cluster_queue1")
+
+	ClearClusterQueueMetrics("cluster_queue1")
+	expectFilteredMetricsCount(t, QuotaReservedCostTotal, 0, "cluster_queue", "cluster_queue1")
+}
+
 func TestReportAndCleanupClusterQueuePreemptedNumber(t *testing.T) {
 	ReportPreemption("cluster_queue1", "InClusterQueue", "cluster_queue1")
 	ReportPreemption("cluster_queue1", "InCohortReclamation", "cluster_queue1")
@@ -173,3 +183,23 @@ func TestReportAndCleanupClusterQueuePreemptedNumber(t *testing.T) {
 	expectFilteredMetricsCount(t, PreemptedWorkloadsTotal, 0, "preempting_cluster_queue", "cluster_queue1")
 	expectFilteredMetricsCount(t, EvictedWorkloadsTotal, 0, "cluster_queue", "cluster_queue1")
 }
+
+func TestReportAndCleanupCohortMetrics(t *testing.T) {
+	ReportCohortResourceUsage("cohort", "flavor", "res", 10, 7, 0)
+	ReportCohortResourceUsage("cohort", "flavor2", "res", 5, 8, 3)
+	ReportCohortResourceUsage("other", "flavor", "res", 5, 0, 0)
+	ReportCohortWeightedShare("cohort", 200)
+
+	expectFilteredMetricsCount(t, CohortSubtreeQuota, 2, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortResourceReservations, 2, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortResourceBorrowed, 2, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortWeightedShare, 1, "cohort", "cohort")
+
+	ClearCohortMetrics("cohort")
+
+	expectFilteredMetricsCount(t, CohortSubtreeQuota, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortResourceReservations, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortResourceBorrowed, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortWeightedShare, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortSubtreeQuota, 1, "cohort", "other")
+}
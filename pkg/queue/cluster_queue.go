@@ -20,6 +20,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -31,9 +32,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/util/heap"
-	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -76,6 +77,22 @@ type ClusterQueue struct {
 
 	queueingStrategy kueue.QueueingStrategy
 
+	// admissionFairSharing indicates whether the workloads are popped
+	// according to the shares of their LocalQueues.
+	admissionFairSharing bool
+	// localQueueHeaps holds the workloads of the heap by LocalQueue, when the
+	// ClusterQueue uses admission fair sharing, to find the head of the
+	// LocalQueue with the lowest share without scanning all the workloads.
+	localQueueHeaps map[string]*heap.Heap[workload.Info]
+
+	// priorityAging raises the priority of the workloads as they wait.
+	priorityAging    *kueue.PriorityAging
+	workloadOrdering workload.Ordering
+	// agedAt is the time at which the priorities ordering the heaps are
+	// computed. It's advanced, and the heaps reordered, at most once per
+	// aging interval, when popping.
+	agedAt time.Time
+
 	rwm sync.RWMutex
 
 	clock clock.Clock
@@ -89,8 +106,8 @@ func workloadKey(i *workload.Info) string {
 	return workload.Key(i.Obj)
 }
 
-func newClusterQueue(cq *kueue.ClusterQueue, wo workload.Ordering) (*ClusterQueue, error) {
-	cqImpl := newClusterQueueImpl(wo, realClock)
+func newClusterQueue(cq *kueue.ClusterQueue, wo workload.Ordering, clock clock.Clock) (*ClusterQueue, error) {
+	cqImpl := newClusterQueueImpl(wo, clock)
 	err := cqImpl.Update(cq)
 	if err != nil {
 		return nil, err
@@ -99,15 +116,16 @@ func newClusterQueue(cq *kueue.ClusterQueue, wo workload.Ordering) (*ClusterQueu
 }
 
 func newClusterQueueImpl(wo workload.Ordering, clock clock.Clock) *ClusterQueue {
-	lessFunc := queueOrderingFunc(wo)
-	return &ClusterQueue{
-		heap:                   *heap.New(workloadKey, lessFunc),
+	c := &ClusterQueue{
 		inadmissibleWorkloads:  make(map[string]*workload.Info),
 		queueInadmissibleCycle: -1,
-		lessFunc:               lessFunc,
+		workloadOrdering:       wo,
 		rwm:                    sync.RWMutex{},
 		clock:                  clock,
 	}
+	c.lessFunc = queueOrderingFunc(wo, c.agedPriority)
+	c.heap = *heap.New(workloadKey, c.lessFunc)
+	return c
 }
 
 // Update updates the properties of this ClusterQueue.
@@ -116,6 +134,25 @@ func (c *ClusterQueue) Update(apiCQ *kueue.ClusterQueue) error {
 	defer c.rwm.Unlock()
 	c.name = kueue.ClusterQueueReference(apiCQ.Name)
 	c.queueingStrategy = apiCQ.Spec.QueueingStrategy
+	var priorityAging *kueue.PriorityAging
+	if features.Enabled(features.PriorityAging) {
+		priorityAging = apiCQ.Spec.PriorityAging
+	}
+	if !equality.Semantic.DeepEqual(c.priorityAging, priorityAging) {
+		c.priorityAging = priorityAging
+		c.agedAt = c.clock.Now()
+		c.heap.Reorder()
+	}
+	c.admissionFairSharing = features.Enabled(features.AdmissionFairSharing) &&
+		apiCQ.Spec.AdmissionScope != nil &&
+		apiCQ.Spec.AdmissionScope.AdmissionMode == kueue.UsageBasedAdmissionFairSharing
+	c.localQueueHeaps = nil
+	if c.admissionFairSharing {
+		c.localQueueHeaps = make(map[string]*heap.Heap[workload.Info])
+		for _, info := range c.heap.List() {
+			c.localQueueHeap(info).PushIfNotPresent(info)
+		}
+	}
 	nsSelector, err := metav1.LabelSelectorAsSelector(apiCQ.Spec.NamespaceSelector)
 	if err != nil {
 		return err
@@ -133,7 +170,7 @@ func (c *ClusterQueue) AddFromLocalQueue(q *LocalQueue) bool {
 	defer c.rwm.Unlock()
 	added := false
 	for _, info := range q.items {
-		if c.heap.PushIfNotPresent(info) {
+		if c.pushIfNotPresent(info) {
 			added = true
 		}
 	}
@@ -168,15 +205,19 @@ func (c *ClusterQueue) PushOrUpdate(wInfo *workload.Info) {
 		c.inadmissibleWorkloads[key] = wInfo
 		return
 	}
-	c.heap.PushOrUpdate(wInfo)
+	c.pushOrUpdate(wInfo)
 }
 
 // backoffWaitingTimeExpired returns true if the current time is after the requeueAt
-// and Requeued condition not present or equal True.
+// and Requeued condition not present or equal True, and the workload isn't waiting
+// for its dependencies.
 func (c *ClusterQueue) backoffWaitingTimeExpired(wInfo *workload.Info) bool {
 	if apimeta.IsStatusConditionFalse(wInfo.Obj.Status.Conditions, kueue.WorkloadRequeued) {
 		return false
 	}
+	if features.Enabled(features.WorkloadDependencies) && workload.WaitingForDependencies(wInfo.Obj) {
+		return false
+	}
 	if wInfo.Obj.Status.RequeueState == nil || wInfo.Obj.Status.RequeueState.RequeueAt == nil {
 		return true
 	}
@@ -197,7 +238,7 @@ func (c *ClusterQueue) Delete(w *kueue.Workload) {
 func (c *ClusterQueue) delete(w *kueue.Workload) {
 	key := workload.Key(w)
 	delete(c.inadmissibleWorkloads, key)
-	c.heap.Delete(key)
+	c.deleteFromHeap(key)
 	c.forgetInflightByKey(key)
 }
 
@@ -235,7 +276,7 @@ func (c *ClusterQueue) requeueIfNotPresent(wInfo *workload.Info, immediate bool)
 			wInfo = inadmissibleWl
 			delete(c.inadmissibleWorkloads, key)
 		}
-		return c.heap.PushIfNotPresent(wInfo)
+		return c.pushIfNotPresent(wInfo)
 	}
 
 	if c.inadmissibleWorkloads[key] != nil {
@@ -275,7 +316,7 @@ func (c *ClusterQueue) QueueInadmissibleWorkloads(ctx context.Context, client cl
 		if err != nil || !c.namespaceSelector.Matches(labels.Set(ns.Labels)) || !c.backoffWaitingTimeExpired(wInfo) {
 			inadmissibleWorkloads[key] = wInfo
 		} else {
-			moved = c.heap.PushIfNotPresent(wInfo) || moved
+			moved = c.pushIfNotPresent(wInfo) || moved
 		}
 	}
 
@@ -310,6 +351,13 @@ func (c *ClusterQueue) PendingInadmissible() int {
 // Pop removes the head of the queue and returns it. It returns nil if the
 // queue is empty.
 func (c *ClusterQueue) Pop() *workload.Info {
+	return c.pop(nil)
+}
+
+// pop removes the head of the queue and returns it. When the ClusterQueue
+// uses admission fair sharing, the head is the head of the LocalQueue with
+// the lowest share, given the shares computed by the caller.
+func (c *ClusterQueue) pop(shares map[string]int) *workload.Info {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
 	c.popCycle++
@@ -317,10 +365,86 @@ func (c *ClusterQueue) Pop() *workload.Info {
 		c.inflight = nil
 		return nil
 	}
-	c.inflight = c.heap.Pop()
+	c.age()
+	head := c.heap.Peek()
+	if c.admissionFairSharing && shares != nil {
+		// The workloads of a LocalQueue have the same share, so the head is
+		// among the heads of the LocalQueues.
+		less := fairSharingOrderingFunc(shares, c.lessFunc)
+		for _, h := range c.localQueueHeaps {
+			if lqHead := h.Peek(); less(lqHead, head) {
+				head = lqHead
+			}
+		}
+	}
+	c.deleteFromHeap(workloadKey(head))
+	c.inflight = head
 	return c.inflight
 }
 
+// usesAdmissionFairSharing returns whether the workloads are popped according
+// to the shares of their LocalQueues.
+func (c *ClusterQueue) usesAdmissionFairSharing() bool {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.admissionFairSharing
+}
+
+// pushIfNotPresent pushes the workload to the heap, and to the heap of its
+// LocalQueue, unless it's already present.
+func (c *ClusterQueue) pushIfNotPresent(info *workload.Info) bool {
+	if !c.heap.PushIfNotPresent(info) {
+		return false
+	}
+	if c.localQueueHeaps != nil {
+		c.localQueueHeap(info).PushIfNotPresent(info)
+	}
+	return true
+}
+
+// pushOrUpdate pushes the workload to the heap, and to the heap of its
+// LocalQueue, or updates it if it's already present.
+func (c *ClusterQueue) pushOrUpdate(info *workload.Info) {
+	if c.localQueueHeaps != nil {
+		if old := c.heap.GetByKey(workloadKey(info)); old != nil && workload.QueueKey(old.Obj) != workload.QueueKey(info.Obj) {
+			c.deleteFromLocalQueueHeap(old)
+		}
+		c.localQueueHeap(info).PushOrUpdate(info)
+	}
+	c.heap.PushOrUpdate(info)
+}
+
+// deleteFromHeap removes the workload from the heap, and from the heap of its
+// LocalQueue.
+func (c *ClusterQueue) deleteFromHeap(key string) {
+	if c.localQueueHeaps != nil {
+		if info := c.heap.GetByKey(key); info != nil {
+			c.deleteFromLocalQueueHeap(info)
+		}
+	}
+	c.heap.Delete(key)
+}
+
+func (c *ClusterQueue) localQueueHeap(info *workload.Info) *heap.Heap[workload.Info] {
+	lqKey := workload.QueueKey(info.Obj)
+	h, found := c.localQueueHeaps[lqKey]
+	if !found {
+		h = heap.New(workloadKey, c.lessFunc)
+		c.localQueueHeaps[lqKey] = h
+	}
+	return h
+}
+
+func (c *ClusterQueue) deleteFromLocalQueueHeap(info *workload.Info) {
+	lqKey := workload.QueueKey(info.Obj)
+	if h, found := c.localQueueHeaps[lqKey]; found {
+		h.Delete(workloadKey(info))
+		if h.Len() == 0 {
+			delete(c.localQueueHeaps, lqKey)
+		}
+	}
+}
+
 // Dump produces a dump of the current workloads in the heap of
 // this ClusterQueue. It returns false if the queue is empty,
 // otherwise returns true.
@@ -353,9 +477,22 @@ func (c *ClusterQueue) DumpInadmissible() ([]string, bool) {
 // Snapshot returns a copy of the current workloads in the heap of
 // this ClusterQueue.
 func (c *ClusterQueue) Snapshot() []*workload.Info {
+	return c.snapshot(nil)
+}
+
+// snapshot returns a copy of the current workloads in the heap of this
+// ClusterQueue, in the order they are popped given the shares of the
+// LocalQueues computed by the caller.
+func (c *ClusterQueue) snapshot(shares map[string]int) []*workload.Info {
 	elements := c.totalElements()
+	less := c.lessFunc
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	if c.admissionFairSharing && shares != nil {
+		less = fairSharingOrderingFunc(shares, c.lessFunc)
+	}
 	sort.Slice(elements, func(i, j int) bool {
-		return c.lessFunc(elements[i], elements[j])
+		return less(elements[i], elements[j])
 	})
 	return elements
 }
@@ -368,6 +505,31 @@ func (c *ClusterQueue) Info(key string) *workload.Info {
 	return c.heap.GetByKey(key)
 }
 
+// PendingInfo returns workload.Info for the workload key, looking up both
+// the active and the inadmissible workloads.
+// Users of this method should not modify the returned object.
+func (c *ClusterQueue) PendingInfo(key string) *workload.Info {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	if info := c.heap.GetByKey(key); info != nil {
+		return info
+	}
+	if info, ok := c.inadmissibleWorkloads[key]; ok {
+		return info
+	}
+	if c.inflight != nil && workloadKey(c.inflight) == key {
+		return c.inflight
+	}
+	return nil
+}
+
+// inflightInfo returns the workload last popped by the scheduler, if any.
+func (c *ClusterQueue) inflightInfo() *workload.Info {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.inflight
+}
+
 func (c *ClusterQueue) totalElements() []*workload.Info {
 	c.rwm.RLock()
 	defer c.rwm.RUnlock()
@@ -383,6 +545,44 @@ func (c *ClusterQueue) totalElements() []*workload.Info {
 	return elements
 }
 
+// EffectivePriority returns the priority of the workload raised by the
+// priority aging of the ClusterQueue, and whether the ClusterQueue has
+// priority aging.
+func (c *ClusterQueue) EffectivePriority(wInfo *workload.Info) (int32, bool) {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.effectivePriority(wInfo), c.priorityAging != nil
+}
+
+func (c *ClusterQueue) effectivePriority(wInfo *workload.Info) int32 {
+	return workload.EffectivePriority(wInfo.Obj, c.priorityAging, c.workloadOrdering, c.clock.Now())
+}
+
+// agedPriority returns the priority ordering the workload in the heaps, which
+// is the effective priority at agedAt.
+func (c *ClusterQueue) agedPriority(wInfo *workload.Info) int32 {
+	return workload.EffectivePriority(wInfo.Obj, c.priorityAging, c.workloadOrdering, c.agedAt)
+}
+
+// age reorders the heaps by the effective priorities of the workloads, if an
+// aging interval passed since they were last reordered. Within an interval,
+// the workloads are raised by at most one increment, so the order of the
+// heaps is kept without reordering them on every pop.
+func (c *ClusterQueue) age() {
+	if c.priorityAging == nil {
+		return
+	}
+	now := c.clock.Now()
+	if now.Sub(c.agedAt) < c.priorityAging.Interval.Duration {
+		return
+	}
+	c.agedAt = now
+	c.heap.Reorder()
+	for _, h := range c.localQueueHeaps {
+		h.Reorder()
+	}
+}
+
 // Active returns true if the queue is active
 func (c *ClusterQueue) Active() bool {
 	c.rwm.RLock()
@@ -407,13 +607,13 @@ func (c *ClusterQueue) RequeueIfNotPresent(wInfo *workload.Info, reason RequeueR
 }
 
 // queueOrderingFunc returns a function used by the clusterQueue heap algorithm
-// to sort workloads. The function sorts workloads based on their priority.
-// When priorities are equal, it uses the workload's creation or eviction
-// time.
-func queueOrderingFunc(wo workload.Ordering) func(a, b *workload.Info) bool {
+// to sort workloads. The function sorts workloads based on the priority
+// returned by priorityFunc. When priorities are equal, it uses the workload's
+// creation or eviction time.
+func queueOrderingFunc(wo workload.Ordering, priorityFunc func(*workload.Info) int32) func(a, b *workload.Info) bool {
 	return func(a, b *workload.Info) bool {
-		p1 := utilpriority.Priority(a.Obj)
-		p2 := utilpriority.Priority(b.Obj)
+		p1 := priorityFunc(a)
+		p2 := priorityFunc(b)
 
 		if p1 != p2 {
 			return p1 > p2
@@ -424,3 +624,17 @@ func queueOrderingFunc(wo workload.Ordering) func(a, b *workload.Info) bool {
 		return !tB.Before(tA)
 	}
 }
+
+// fairSharingOrderingFunc returns a function that sorts workloads based on
+// the shares of their LocalQueues, with the lowest share first.
+// When the shares are equal, it uses the provided function.
+func fairSharingOrderingFunc(shares map[string]int, lessFunc func(a, b *workload.Info) bool) func(a, b *workload.Info) bool {
+	return func(a, b *workload.Info) bool {
+		sA := shares[workload.QueueKey(a.Obj)]
+		sB := shares[workload.QueueKey(b.Obj)]
+		if sA != sB {
+			return sA < sB
+		}
+		return lessFunc(a, b)
+	}
+}
@@ -31,6 +31,8 @@ import (
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -200,6 +202,79 @@ func Test_Pop(t *testing.T) {
 	}
 }
 
+func Test_PopWithPriorityAging(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PriorityAging, true)
+	now := time.Now()
+	fakeClock := testingclock.NewFakeClock(now.Add(15 * time.Minute))
+	cq := newClusterQueueImpl(defaultOrdering, fakeClock)
+	if err := cq.Update(utiltesting.MakeClusterQueue("cq").
+		PriorityAging(kueue.PriorityAging{
+			Interval:     metav1.Duration{Duration: time.Minute},
+			Increment:    100,
+			MaxIncrement: 2000,
+		}).
+		Obj()); err != nil {
+		t.Fatalf("Failed updating the ClusterQueue: %v", err)
+	}
+	oldLow := workload.NewInfo(utiltesting.MakeWorkload("old-low", defaultNamespace).
+		Priority(lowPriority).Creation(now).Obj())
+	oldHigh := workload.NewInfo(utiltesting.MakeWorkload("old-high", defaultNamespace).
+		Priority(highPriority).Creation(now.Add(5 * time.Minute)).Obj())
+	newHigh := workload.NewInfo(utiltesting.MakeWorkload("new-high", defaultNamespace).
+		Priority(highPriority).Creation(now.Add(15 * time.Minute)).Obj())
+	cq.PushOrUpdate(oldLow)
+	cq.PushOrUpdate(oldHigh)
+	cq.PushOrUpdate(newHigh)
+
+	if p, aging := cq.EffectivePriority(oldLow); p != 1500 || !aging {
+		t.Errorf("Unexpected effective priority (%d, %v), want (1500, true)", p, aging)
+	}
+	if got := cq.Pop(); got == nil || got.Obj.Name != "old-high" {
+		t.Errorf("Expected old-high to be popped, got %v", got)
+	}
+	// The raise of old-low is capped, while new-high keeps aging.
+	fakeClock.Step(20 * time.Minute)
+	gotOrder := []string{cq.Pop().Obj.Name, cq.Pop().Obj.Name}
+	if diff := cmp.Diff([]string{"new-high", "old-low"}, gotOrder); diff != "" {
+		t.Errorf("Unexpected order of the popped workloads (-want,+got):\n%s", diff)
+	}
+}
+
+func Test_PopWithPriorityAgingReordersOncePerInterval(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.PriorityAging, true)
+	now := time.Now()
+	fakeClock := testingclock.NewFakeClock(now)
+	cq := newClusterQueueImpl(defaultOrdering, fakeClock)
+	if err := cq.Update(utiltesting.MakeClusterQueue("cq").
+		PriorityAging(kueue.PriorityAging{
+			Interval:     metav1.Duration{Duration: time.Minute},
+			Increment:    100,
+			MaxIncrement: 2000,
+		}).
+		Obj()); err != nil {
+		t.Fatalf("Failed updating the ClusterQueue: %v", err)
+	}
+	cq.PushOrUpdate(workload.NewInfo(utiltesting.MakeWorkload("new", defaultNamespace).
+		Priority(1000).Creation(now).Obj()))
+	cq.PushOrUpdate(workload.NewInfo(utiltesting.MakeWorkload("old", defaultNamespace).
+		Priority(950).Creation(now.Add(-50 * time.Second)).Obj()))
+
+	// The priority of old is raised to 1050, but the heap keeps the order
+	// of the start of the aging interval.
+	fakeClock.Step(30 * time.Second)
+	if got := cq.Pop(); got == nil || got.Obj.Name != "new" {
+		t.Errorf("Expected new to be popped, got %v", got)
+	}
+	// The heap is reordered once the interval passed.
+	fakeClock.Step(30 * time.Second)
+	cq.PushOrUpdate(workload.NewInfo(utiltesting.MakeWorkload("newer", defaultNamespace).
+		Priority(1020).Creation(fakeClock.Now()).Obj()))
+	gotOrder := []string{cq.Pop().Obj.Name, cq.Pop().Obj.Name}
+	if diff := cmp.Diff([]string{"old", "newer"}, gotOrder); diff != "" {
+		t.Errorf("Unexpected order of the popped workloads (-want,+got):\n%s", diff)
+	}
+}
+
 func Test_Delete(t *testing.T) {
 	cq := newClusterQueueImpl(defaultOrdering, testingclock.NewFakeClock(time.Now()))
 	wl1 := utiltesting.MakeWorkload("workload-1", defaultNamespace).Obj()
@@ -507,8 +582,9 @@ func TestBackoffWaitingTimeExpired(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(now)
 
 	cases := map[string]struct {
-		workloadInfo *workload.Info
-		want         bool
+		workloadInfo               *workload.Info
+		enableWorkloadDependencies bool
+		want                       bool
 	}{
 		"workload still have Requeued=false": {
 			workloadInfo: workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").Condition(metav1.Condition{
@@ -546,9 +622,32 @@ func TestBackoffWaitingTimeExpired(t *testing.T) {
 				}).Obj()),
 			want: false,
 		},
+		"workload with dependencies not observed finished": {
+			workloadInfo: workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").Obj()),
+			enableWorkloadDependencies: true,
+			want:                       false,
+		},
+		"workload with dependencies finished": {
+			workloadInfo: workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadRequeued,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadDependenciesFinished,
+				}).Obj()),
+			enableWorkloadDependencies: true,
+			want:                       true,
+		},
+		"workload with dependencies when the feature is disabled": {
+			workloadInfo: workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep").Obj()),
+			want: true,
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.WorkloadDependencies, tc.enableWorkloadDependencies)
 			cq := newClusterQueueImpl(defaultOrdering, fakeClock)
 			got := cq.backoffWaitingTimeExpired(tc.workloadInfo)
 			if tc.want != got {
@@ -613,6 +712,7 @@ func TestBestEffortFIFORequeueIfNotPresent(t *testing.T) {
 					},
 				},
 				workload.Ordering{PodsReadyRequeuingTimestamp: config.EvictionTimestamp},
+				realClock,
 			)
 			wl := utiltesting.MakeWorkload("workload-1", defaultNamespace).Obj()
 			info := workload.NewInfo(wl)
@@ -642,7 +742,8 @@ func TestFIFOClusterQueue(t *testing.T) {
 		},
 		workload.Ordering{
 			PodsReadyRequeuingTimestamp: config.EvictionTimestamp,
-		})
+		},
+		realClock)
 	if err != nil {
 		t.Fatalf("Failed creating ClusterQueue %v", err)
 	}
@@ -845,7 +946,8 @@ func TestStrictFIFO(t *testing.T) {
 						QueueingStrategy: kueue.StrictFIFO,
 					},
 				},
-				*tt.workloadOrdering)
+				*tt.workloadOrdering,
+				realClock)
 			if err != nil {
 				t.Fatalf("Failed creating ClusterQueue %v", err)
 			}
@@ -888,6 +990,7 @@ func TestStrictFIFORequeueIfNotPresent(t *testing.T) {
 					},
 				},
 				workload.Ordering{PodsReadyRequeuingTimestamp: config.EvictionTimestamp},
+				realClock,
 			)
 			wl := utiltesting.MakeWorkload("workload-1", defaultNamespace).Obj()
 			if ok := cq.RequeueIfNotPresent(workload.NewInfo(wl), reason); !ok {
@@ -905,3 +1008,118 @@ func TestStrictFIFORequeueIfNotPresent(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterQueuePopWithAdmissionFairSharing(t *testing.T) {
+	now := time.Now()
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a-old", defaultNamespace).Queue("a").Creation(now.Add(-time.Minute)).Obj(),
+		utiltesting.MakeWorkload("a-new", defaultNamespace).Queue("a").Creation(now).Obj(),
+		utiltesting.MakeWorkload("b-high", defaultNamespace).Queue("b").Priority(highPriority).Creation(now).Obj(),
+		utiltesting.MakeWorkload("b-low", defaultNamespace).Queue("b").Priority(lowPriority).Creation(now.Add(-2 * time.Minute)).Obj(),
+		utiltesting.MakeWorkload("c", defaultNamespace).Queue("c").Creation(now.Add(-time.Hour)).Obj(),
+	}
+	shares := map[string]int{
+		"default/a": 100,
+		"default/b": 0,
+		"default/c": 100,
+	}
+	cases := map[string]struct {
+		enableAdmissionFairSharing bool
+		admissionMode              kueue.AdmissionMode
+		wantOrder                  []string
+	}{
+		"admission fair sharing disabled": {
+			admissionMode: kueue.UsageBasedAdmissionFairSharing,
+			wantOrder:     []string{"b-high", "c", "b-low", "a-old", "a-new"},
+		},
+		"no admission fair sharing": {
+			enableAdmissionFairSharing: true,
+			admissionMode:              kueue.NoAdmissionFairSharing,
+			wantOrder:                  []string{"b-high", "c", "b-low", "a-old", "a-new"},
+		},
+		"usage based admission fair sharing": {
+			enableAdmissionFairSharing: true,
+			admissionMode:              kueue.UsageBasedAdmissionFairSharing,
+			wantOrder:                  []string{"b-high", "b-low", "c", "a-old", "a-new"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.AdmissionFairSharing, tc.enableAdmissionFairSharing)
+			cq, err := newClusterQueue(utiltesting.MakeClusterQueue("cq").AdmissionMode(tc.admissionMode).Obj(), defaultOrdering, realClock)
+			if err != nil {
+				t.Fatalf("Failed creating ClusterQueue %v", err)
+			}
+			for _, wl := range workloads {
+				cq.PushOrUpdate(workload.NewInfo(wl))
+			}
+			var gotOrder []string
+			for _, info := range cq.snapshot(shares) {
+				gotOrder = append(gotOrder, info.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Unexpected order in snapshot (-want,+got):\n%s", diff)
+			}
+			gotOrder = nil
+			for info := cq.pop(shares); info != nil; info = cq.pop(shares) {
+				gotOrder = append(gotOrder, info.Obj.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("Unexpected popped order (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterQueueLocalQueueHeaps(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.AdmissionFairSharing, true)
+	now := time.Now()
+	apiCQ := utiltesting.MakeClusterQueue("cq").AdmissionMode(kueue.UsageBasedAdmissionFairSharing).Obj()
+	cq, err := newClusterQueue(apiCQ, defaultOrdering, realClock)
+	if err != nil {
+		t.Fatalf("Failed creating ClusterQueue %v", err)
+	}
+	for _, wl := range []*kueue.Workload{
+		utiltesting.MakeWorkload("a1", defaultNamespace).Queue("a").Creation(now.Add(-time.Hour)).Obj(),
+		utiltesting.MakeWorkload("a2", defaultNamespace).Queue("a").Creation(now.Add(-time.Minute)).Obj(),
+		utiltesting.MakeWorkload("b1", defaultNamespace).Queue("b").Creation(now).Obj(),
+	} {
+		cq.PushOrUpdate(workload.NewInfo(wl))
+	}
+	// Move a2 to the LocalQueue b, and delete b1.
+	cq.PushOrUpdate(workload.NewInfo(utiltesting.MakeWorkload("a2", defaultNamespace).Queue("b").Creation(now.Add(-time.Minute)).Obj()))
+	cq.Delete(utiltesting.MakeWorkload("b1", defaultNamespace).Obj())
+	// Disabling and enabling the admission fair sharing rebuilds the heaps.
+	if err := cq.Update(utiltesting.MakeClusterQueue("cq").Obj()); err != nil {
+		t.Fatalf("Failed updating ClusterQueue %v", err)
+	}
+	if err := cq.Update(apiCQ); err != nil {
+		t.Fatalf("Failed updating ClusterQueue %v", err)
+	}
+
+	gotHeaps := make(map[string][]string)
+	for lqKey, h := range cq.localQueueHeaps {
+		for _, info := range h.List() {
+			gotHeaps[lqKey] = append(gotHeaps[lqKey], info.Obj.Name)
+		}
+	}
+	wantHeaps := map[string][]string{
+		"default/a": {"a1"},
+		"default/b": {"a2"},
+	}
+	if diff := cmp.Diff(wantHeaps, gotHeaps); diff != "" {
+		t.Errorf("Unexpected LocalQueue heaps (-want,+got):\n%s", diff)
+	}
+
+	shares := map[string]int{"default/a": 100, "default/b": 0}
+	var gotOrder []string
+	for info := cq.pop(shares); info != nil; info = cq.pop(shares) {
+		gotOrder = append(gotOrder, info.Obj.Name)
+	}
+	if diff := cmp.Diff([]string{"a2", "a1"}, gotOrder); diff != "" {
+		t.Errorf("Unexpected popped order (-want,+got):\n%s", diff)
+	}
+	if len(cq.localQueueHeaps) != 0 {
+		t.Errorf("Unexpected LocalQueue heaps left after popping all the workloads: %v", cq.localQueueHeaps)
+	}
+}
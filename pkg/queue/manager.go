@@ -20,11 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -47,6 +49,8 @@ var (
 type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
 	workloadInfoOptions         []workload.InfoOption
+	localQueueShares            LocalQueueSharesProvider
+	clock                       clock.Clock
 }
 
 // Option configures the manager.
@@ -55,6 +59,7 @@ type Option func(*options)
 var defaultOptions = options{
 	podsReadyRequeuingTimestamp: config.EvictionTimestamp,
 	workloadInfoOptions:         []workload.InfoOption{},
+	clock:                       realClock,
 }
 
 // WithPodsReadyRequeuingTimestamp sets the timestamp that is used for ordering
@@ -79,6 +84,21 @@ func WithResourceTransformations(transforms []config.ResourceTransformation) Opt
 	}
 }
 
+// WithLocalQueueShares sets the provider of the LocalQueue shares, used to
+// order the workloads in ClusterQueues using admission fair sharing.
+func WithLocalQueueShares(p LocalQueueSharesProvider) Option {
+	return func(o *options) {
+		o.localQueueShares = p
+	}
+}
+
+// WithClock sets the clock used to order and requeue the workloads.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
 type TopologyUpdateWatcher interface {
 	NotifyTopologyUpdate(oldTopology, newTopology *kueuealpha.Topology)
 }
@@ -98,6 +118,10 @@ type Manager struct {
 
 	workloadInfoOptions []workload.InfoOption
 
+	localQueueShares LocalQueueSharesProvider
+
+	clock clock.Clock
+
 	hm hierarchy.Manager[*ClusterQueue, *cohort]
 
 	topologyUpdateWatchers []TopologyUpdateWatcher
@@ -118,6 +142,8 @@ func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Ma
 			PodsReadyRequeuingTimestamp: options.podsReadyRequeuingTimestamp,
 		},
 		workloadInfoOptions: options.workloadInfoOptions,
+		localQueueShares:    options.localQueueShares,
+		clock:               options.clock,
 		hm:                  hierarchy.NewManager[*ClusterQueue, *cohort](newCohort),
 
 		topologyUpdateWatchers: make([]TopologyUpdateWatcher, 0),
@@ -162,7 +188,7 @@ func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) e
 		return errClusterQueueAlreadyExists
 	}
 
-	cqImpl, err := newClusterQueue(cq, m.workloadOrdering)
+	cqImpl, err := newClusterQueue(cq, m.workloadOrdering, m.clock)
 	if err != nil {
 		return err
 	}
@@ -602,6 +628,16 @@ func (m *Manager) Heads(ctx context.Context) []workload.Info {
 	}
 }
 
+// TryHeads returns the heads of the queues, like Heads, without waiting for
+// workloads to be queued if there are none.
+func (m *Manager) TryHeads(ctx context.Context) []workload.Info {
+	m.Lock()
+	defer m.Unlock()
+	workloads := m.heads()
+	ctrl.LoggerFrom(ctx).V(3).Info("Obtained ClusterQueue heads", "count", len(workloads))
+	return workloads
+}
+
 func (m *Manager) heads() []workload.Info {
 	var workloads []workload.Info
 	for cqName, cq := range m.hm.ClusterQueues() {
@@ -609,7 +645,7 @@ func (m *Manager) heads() []workload.Info {
 		if m.statusChecker != nil && !m.statusChecker.ClusterQueueActive(cqName) {
 			continue
 		}
-		wl := cq.Pop()
+		wl := cq.pop(m.localQueueSharesFor(cq))
 		if wl == nil {
 			continue
 		}
@@ -672,7 +708,69 @@ func (m *Manager) PendingWorkloadsInfo(cqName kueue.ClusterQueueReference) []*wo
 	if cq == nil {
 		return nil
 	}
-	return cq.Snapshot()
+	return cq.snapshot(m.localQueueSharesFor(cq))
+}
+
+// localQueueSharesFor returns the shares of the LocalQueues of the
+// ClusterQueue, or nil if it doesn't use admission fair sharing. The shares
+// are computed before locking the ClusterQueue.
+func (m *Manager) localQueueSharesFor(cq *ClusterQueue) map[string]int {
+	if m.localQueueShares == nil || !cq.usesAdmissionFairSharing() {
+		return nil
+	}
+	return m.localQueueShares.LocalQueueShares(cq.GetName())
+}
+
+// EffectivePriority returns the priority of the workload raised by the
+// priority aging of the ClusterQueue, and whether the ClusterQueue has
+// priority aging.
+func (m *Manager) EffectivePriority(cqName kueue.ClusterQueueReference, wInfo *workload.Info) (int32, bool) {
+	cq := m.getClusterQueue(cqName)
+	if cq == nil {
+		return 0, false
+	}
+	return cq.EffectivePriority(wInfo)
+}
+
+// PendingWorkloadInfo returns a copy of the workload.Info for the pending
+// workload key, with the ClusterQueue set, or nil if the workload is not pending.
+func (m *Manager) PendingWorkloadInfo(key string) *workload.Info {
+	m.RLock()
+	defer m.RUnlock()
+	for cqName, cq := range m.hm.ClusterQueues() {
+		if info := cq.PendingInfo(key); info != nil {
+			infoCopy := *info
+			infoCopy.ClusterQueue = cqName
+			return &infoCopy
+		}
+	}
+	return nil
+}
+
+// GroupClusterQueues returns the ClusterQueues where the pending members of
+// the workload group of the workload are queued, including the members
+// popped by the scheduler.
+func (m *Manager) GroupClusterQueues(wl *kueue.Workload) sets.Set[kueue.ClusterQueueReference] {
+	m.RLock()
+	defer m.RUnlock()
+	cqNames := sets.New[kueue.ClusterQueueReference]()
+	for _, lq := range m.localQueues {
+		if cqNames.Has(lq.ClusterQueue) || !strings.HasPrefix(lq.Key, wl.Namespace+"/") {
+			continue
+		}
+		for _, info := range lq.items {
+			if workload.InSameGroup(wl, info.Obj) {
+				cqNames.Insert(lq.ClusterQueue)
+				break
+			}
+		}
+	}
+	for cqName, cq := range m.hm.ClusterQueues() {
+		if info := cq.inflightInfo(); info != nil && workload.InSameGroup(wl, info.Obj) {
+			cqNames.Insert(cqName)
+		}
+	}
+	return cqNames
 }
 
 // ClusterQueueFromLocalQueue returns ClusterQueue name and whether it's found,
@@ -698,7 +796,7 @@ func (m *Manager) UpdateSnapshot(cqName kueue.ClusterQueueReference, maxCount in
 		return false
 	}
 	newSnapshot := make([]kueue.ClusterQueuePendingWorkload, 0)
-	for index, info := range cq.Snapshot() {
+	for index, info := range cq.snapshot(m.localQueueSharesFor(cq)) {
 		if int32(index) >= maxCount {
 			break
 		}
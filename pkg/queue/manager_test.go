@@ -34,6 +34,7 @@ import (
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -945,6 +946,60 @@ func TestHeads(t *testing.T) {
 	}
 }
 
+type fakeSharesProvider map[string]int
+
+func (p fakeSharesProvider) LocalQueueShares(kueue.ClusterQueueReference) map[string]int {
+	return p
+}
+
+func TestHeadsWithAdmissionFairSharing(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.AdmissionFairSharing, true)
+	now := time.Now().Truncate(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), headsTimeout)
+	defer cancel()
+	manager := NewManager(utiltesting.NewFakeClient(), nil, WithLocalQueueShares(fakeSharesProvider{
+		"default/a": 100,
+		"default/b": 0,
+	}))
+	if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue("cq").AdmissionMode(kueue.UsageBasedAdmissionFairSharing).Obj()); err != nil {
+		t.Fatalf("Failed adding clusterQueue to manager: %v", err)
+	}
+	for _, q := range []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("a", "default").ClusterQueue("cq").Obj(),
+		utiltesting.MakeLocalQueue("b", "default").ClusterQueue("cq").Obj(),
+	} {
+		if err := manager.AddLocalQueue(ctx, q); err != nil {
+			t.Fatalf("Failed adding queue %s: %s", q.Name, err)
+		}
+	}
+	for _, wl := range []*kueue.Workload{
+		utiltesting.MakeWorkload("a1", "default").Creation(now.Add(-time.Hour)).Queue("a").Obj(),
+		utiltesting.MakeWorkload("a2", "default").Creation(now.Add(-time.Minute)).Queue("a").Obj(),
+		utiltesting.MakeWorkload("b1", "default").Creation(now).Queue("b").Obj(),
+	} {
+		if err := manager.AddOrUpdateWorkload(wl); err != nil {
+			t.Fatalf("Failed to add or update workload: %v", err)
+		}
+	}
+
+	var gotPending []string
+	for _, info := range manager.PendingWorkloadsInfo("cq") {
+		gotPending = append(gotPending, info.Obj.Name)
+	}
+	if diff := cmp.Diff([]string{"b1", "a1", "a2"}, gotPending); diff != "" {
+		t.Errorf("Unexpected pending workloads (-want,+got):\n%s", diff)
+	}
+	var gotHeads []string
+	for range 3 {
+		for _, h := range manager.TryHeads(ctx) {
+			gotHeads = append(gotHeads, h.Obj.Name)
+		}
+	}
+	if diff := cmp.Diff([]string{"b1", "a1", "a2"}, gotHeads); diff != "" {
+		t.Errorf("Unexpected heads (-want,+got):\n%s", diff)
+	}
+}
+
 var ignoreTypeMeta = cmpopts.IgnoreTypes(metav1.TypeMeta{})
 
 // TestHeadAsync ensures that Heads call is blocked until the queues are filled
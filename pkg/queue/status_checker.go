@@ -23,3 +23,12 @@ type StatusChecker interface {
 	// ClusterQueueActive returns whether the clusterQueue is active.
 	ClusterQueueActive(name kueue.ClusterQueueReference) bool
 }
+
+// LocalQueueSharesProvider provides the shares of the LocalQueues of a
+// clusterQueue, for admission fair sharing.
+type LocalQueueSharesProvider interface {
+	// LocalQueueShares returns the shares of the LocalQueues of the
+	// clusterQueue by (namespace/name), or nil if the clusterQueue doesn't
+	// use admission fair sharing.
+	LocalQueueShares(name kueue.ClusterQueueReference) map[string]int
+}
@@ -0,0 +1,67 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"math"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// Cost returns the cost of the requests as per the costs of a flavor.
+// The resources without a cost are free.
+func (r Requests) Cost(costs []kueue.ResourceCost) float64 {
+	var total float64
+	for i := range costs {
+		if v, found := r[costs[i].Name]; found {
+			total += resourceCost(&costs[i], v)
+		}
+	}
+	return total
+}
+
+// Cost returns the cost of the quantities as per the costs of the flavors,
+// and whether any of the flavors has costs.
+func (q FlavorResourceQuantities) Cost(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) (float64, bool) {
+	var total float64
+	var hasCosts bool
+	for fr, v := range q {
+		flavor, found := flavors[fr.Flavor]
+		if !found || len(flavor.Spec.Costs) == 0 {
+			continue
+		}
+		hasCosts = true
+		total += Requests{fr.Resource: v}.Cost(flavor.Spec.Costs)
+	}
+	return total, hasCosts
+}
+
+// CostQuantity returns the cost as a quantity, rounded to milli-units.
+func CostQuantity(cost float64) *resource.Quantity {
+	return resource.NewMilliQuantity(int64(math.Round(cost*1000)), resource.DecimalSI)
+}
+
+func resourceCost(cost *kueue.ResourceCost, v int64) float64 {
+	q := ResourceQuantity(cost.Name, v)
+	amount := q.AsApproximateFloat64()
+	if cost.Unit != nil && !cost.Unit.IsZero() {
+		amount /= cost.Unit.AsApproximateFloat64()
+	}
+	return cost.Price.AsApproximateFloat64() * amount
+}
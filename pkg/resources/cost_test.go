@@ -0,0 +1,97 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestCost(t *testing.T) {
+	costs := []kueue.ResourceCost{
+		{Name: corev1.ResourceCPU, Price: resource.MustParse("2")},
+		{Name: corev1.ResourceMemory, Price: resource.MustParse("0.5"), Unit: ptr.To(resource.MustParse("1Gi"))},
+	}
+	cases := map[string]struct {
+		requests Requests
+		want     float64
+	}{
+		"no requests": {},
+		"cpu in milli-units": {
+			requests: Requests{corev1.ResourceCPU: 1500},
+			want:     3,
+		},
+		"memory per unit": {
+			requests: Requests{corev1.ResourceMemory: 4 * 1024 * 1024 * 1024},
+			want:     2,
+		},
+		"resource without a cost": {
+			requests: Requests{corev1.ResourceCPU: 1000, "example.com/gpu": 4},
+			want:     2,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.requests.Cost(costs); got != tc.want {
+				t.Errorf("Unexpected cost %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFlavorResourceQuantitiesCost(t *testing.T) {
+	flavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"spot": {Spec: kueue.ResourceFlavorSpec{Costs: []kueue.ResourceCost{
+			{Name: corev1.ResourceCPU, Price: resource.MustParse("1")},
+		}}},
+		"on-demand": {Spec: kueue.ResourceFlavorSpec{Costs: []kueue.ResourceCost{
+			{Name: corev1.ResourceCPU, Price: resource.MustParse("3")},
+		}}},
+		"free": {},
+	}
+	cases := map[string]struct {
+		quantities   FlavorResourceQuantities
+		want         float64
+		wantHasCosts bool
+	}{
+		"flavors without costs": {
+			quantities: FlavorResourceQuantities{{Flavor: "free", Resource: corev1.ResourceCPU}: 1000},
+		},
+		"flavors with costs": {
+			quantities: FlavorResourceQuantities{
+				{Flavor: "spot", Resource: corev1.ResourceCPU}:      2000,
+				{Flavor: "on-demand", Resource: corev1.ResourceCPU}: 1000,
+				{Flavor: "free", Resource: corev1.ResourceCPU}:      1000,
+			},
+			want:         5,
+			wantHasCosts: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotHasCosts := tc.quantities.Cost(flavors)
+			if got != tc.want || gotHasCosts != tc.wantHasCosts {
+				t.Errorf("Unexpected cost (%v, %v), want (%v, %v)", got, gotHasCosts, tc.want, tc.wantHasCosts)
+			}
+		})
+	}
+}
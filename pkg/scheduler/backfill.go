@@ -0,0 +1,174 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// backfillMaxCandidates is the maximum number of workloads behind a blocked
+// head considered for backfilling in a scheduling cycle, to bound the time
+// spent backfilling long queues.
+const backfillMaxCandidates = 100
+
+// canBackfill returns whether the workloads behind a blocked head of the
+// ClusterQueue can be backfilled.
+func canBackfill(cq *cache.ClusterQueueSnapshot) bool {
+	return features.Enabled(features.BackfillScheduling) && cq.QueueingStrategy == kueue.StrictFIFO
+}
+
+// backfill admits the workloads behind the blocked head of a ClusterQueue
+// that fit in the available quota and that, given their maximum execution
+// time, finish before the earliest time at which the head can start.
+// It returns the entries of the admitted workloads.
+func (s *Scheduler) backfill(ctx context.Context, head *entry, cq *cache.ClusterQueueSnapshot, snap *cache.Snapshot) []entry {
+	log := ctrl.LoggerFrom(ctx)
+	if len(head.assignment.PodSets) == 0 {
+		// The head isn't blocked by the lack of quota.
+		return nil
+	}
+	if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+		return nil
+	}
+	shadowTime, found := s.shadowTime(log, head, snap)
+	if !found {
+		log.V(3).Info("Not backfilling, the start time of the blocked workload is unknown")
+		return nil
+	}
+	log.V(3).Info("Backfilling the workloads behind the blocked workload", "shadowTime", shadowTime)
+
+	var backfilled []entry
+	now := s.clock.Now()
+	headKey := workload.Key(head.Obj)
+	candidates := 0
+	for _, info := range s.queues.PendingWorkloadsInfo(cq.Name) {
+		if workload.Key(info.Obj) == headKey {
+			continue
+		}
+		if candidates == backfillMaxCandidates {
+			log.V(3).Info("Stopped backfilling, too many candidates considered", "candidates", candidates)
+			break
+		}
+		candidates++
+		maxExecutionTime := info.Obj.Spec.MaximumExecutionTimeSeconds
+		if maxExecutionTime == nil || now.Add(time.Duration(*maxExecutionTime)*time.Second).After(shadowTime) {
+			continue
+		}
+		candidate := *info
+		candidate.ClusterQueue = cq.Name
+		if s.cache.IsAssumedOrAdmittedWorkload(candidate) {
+			continue
+		}
+		// The maximum execution time is counted once the workload is admitted,
+		// so the workloads waiting for admission checks can't be backfilled.
+		if len(workload.AdmissionChecksForWorkload(log, candidate.Obj, cq.AdmissionChecks)) > 0 {
+			continue
+		}
+		entries := s.nominate(ctx, []workload.Info{candidate}, snap)
+		if len(entries) == 0 {
+			continue
+		}
+		e := &entries[0]
+		if e.replacedWorkloadSlice != nil || e.assignment.RepresentativeMode() != flavorassigner.Fit {
+			continue
+		}
+		usage := e.assignmentUsage()
+		if !fits(cq, e, &usage, nil, nil) {
+			continue
+		}
+		log := log.WithValues("workload", klog.KObj(e.Obj))
+		s.admissionMu.Lock()
+		// The workloads admitted since the start of the cycle, by this or
+		// another cohort tree, can block the admissions until their pods are
+		// ready.
+		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+			s.admissionMu.Unlock()
+			log.V(3).Info("Stopped backfilling, waiting for all admitted workloads to be in the PodsReady condition")
+			break
+		}
+		reserve(cq, e, usage)
+		e.status = nominated
+		err := s.admit(ctrl.LoggerInto(ctx, log), e, cq)
+		s.admissionMu.Unlock()
+		if err != nil {
+			log.Error(err, "Failed to backfill workload")
+			continue
+		}
+		log.V(2).Info("Workload backfilled")
+		backfilled = append(backfilled, *e)
+	}
+	return backfilled
+}
+
+// shadowTime returns the earliest time at which the head fits in the quota,
+// considering that the workloads using quota in its cohort finish once they
+// exceed their maximum execution time.
+// It returns false if the head doesn't fit once all the workloads declaring
+// a maximum execution time finish.
+func (s *Scheduler) shadowTime(log logr.Logger, head *entry, snap *cache.Snapshot) (time.Time, bool) {
+	cq := snap.ClusterQueue(head.ClusterQueue)
+	cqs := []*cache.ClusterQueueSnapshot{cq}
+	if cq.HasParent() {
+		cqs = cq.Parent().Root().SubtreeClusterQueues()
+	}
+	type finishing struct {
+		info     *workload.Info
+		deadline time.Time
+	}
+	var running []finishing
+	for _, c := range cqs {
+		for _, wl := range c.Workloads {
+			if deadline, found := workload.MaxExecutionDeadline(wl.Obj); found {
+				running = append(running, finishing{info: wl, deadline: deadline})
+			}
+		}
+	}
+	slices.SortFunc(running, func(a, b finishing) int {
+		return a.deadline.Compare(b.deadline)
+	})
+
+	var reverts []func()
+	defer func() {
+		for i := len(reverts) - 1; i >= 0; i-- {
+			reverts[i]()
+		}
+	}()
+	// Consider all the flavors, regardless of the flavors tried by the head.
+	info := head.Info
+	info.LastAssignment = nil
+	flvAssigner := flavorassigner.New(&info, cq, snap.ResourceFlavors, s.fairSharing.Enable, preemption.NewOracle(s.preemptor, snap), s.framework)
+	for _, r := range running {
+		reverts = append(reverts, snap.ClusterQueue(r.info.ClusterQueue).SimulateUsageRemoval([]*workload.Info{r.info}))
+		if assignment := flvAssigner.Assign(log, nil); assignment.RepresentativeMode() == flavorassigner.Fit {
+			return r.deadline, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,150 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/routine"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestBackfill(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		podsReadyTracking bool
+		// queued is the number of workloads without a maximum execution
+		// time queued behind the head, before the short workloads.
+		queued        int
+		short         int
+		wantScheduled []string
+	}{
+		"backfill the short workloads": {
+			short:         2,
+			wantScheduled: []string{"default/short-0", "default/short-1"},
+		},
+		"only the first candidates behind the head are considered": {
+			queued:        backfillMaxCandidates - 1,
+			short:         2,
+			wantScheduled: []string{"default/short-0"},
+		},
+		"stop backfilling once an admitted workload blocks the admissions until its pods are ready": {
+			podsReadyTracking: true,
+			short:             2,
+			wantScheduled:     []string{"default/short-0"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.BackfillScheduling, true)
+			fakeClock := testingclock.NewFakeClock(now)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			cq := utiltesting.MakeClusterQueue("cq").
+				QueueingStrategy(kueue.StrictFIFO).
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			lq := utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("cq").Obj()
+			running := utiltesting.MakeWorkload("running", "default").
+				Queue("lq").
+				MaximumExecutionTimeSeconds(600).
+				Request(corev1.ResourceCPU, "8").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "8").Obj()).
+				AdmittedAt(true, now).
+				Condition(metav1.Condition{Type: kueue.WorkloadPodsReady, Status: metav1.ConditionTrue, Reason: "PodsReady"}).
+				Obj()
+			created := now.Add(-time.Hour)
+			pending := []kueue.Workload{
+				*utiltesting.MakeWorkload("head", "default").Queue("lq").Creation(created).Request(corev1.ResourceCPU, "4").Obj(),
+			}
+			for i := range tc.queued {
+				created = created.Add(time.Second)
+				pending = append(pending, *utiltesting.MakeWorkload(fmt.Sprintf("queued-%d", i), "default").
+					Queue("lq").
+					Creation(created).
+					Request(corev1.ResourceCPU, "1").
+					Obj())
+			}
+			for i := range tc.short {
+				created = created.Add(time.Second)
+				pending = append(pending, *utiltesting.MakeWorkload(fmt.Sprintf("short-%d", i), "default").
+					Queue("lq").
+					Creation(created).
+					MaximumExecutionTimeSeconds(300).
+					Request(corev1.ResourceCPU, "1").
+					Obj())
+			}
+
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: append(pending, *running)}, &kueue.LocalQueueList{Items: []kueue.LocalQueue{*lq}}).
+				WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}).
+				Build()
+			cqCache := cache.New(cl, cache.WithClock(fakeClock), cache.WithPodsReadyTracking(tc.podsReadyTracking))
+			qManager := queue.NewManager(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Inserting queue %s/%s in manager: %v", lq.Namespace, lq.Name, err)
+			}
+			cqCache.AddOrUpdateWorkload(running)
+
+			scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{}, WithClock(t, fakeClock))
+			var mu sync.Mutex
+			var gotScheduled []string
+			scheduler.applyAdmission = func(_ context.Context, w *kueue.Workload) error {
+				mu.Lock()
+				gotScheduled = append(gotScheduled, workload.Key(w))
+				mu.Unlock()
+				return nil
+			}
+			wg := sync.WaitGroup{}
+			scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+				func() { wg.Add(1) },
+				func() { wg.Done() },
+			))
+
+			ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+			defer cancel()
+			scheduler.schedule(ctx)
+			wg.Wait()
+
+			if diff := cmp.Diff(tc.wantScheduled, gotScheduled, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Unexpected scheduled workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// DryRunResult is the outcome of simulating the nomination of a single
+// pending workload.
+type DryRunResult struct {
+	Assignment        flavorassigner.Assignment
+	PreemptionTargets []*preemption.Target
+	// ProtectedWorkloads are the admitted workloads which can't be preempted
+	// yet, because they didn't run for their minimum runtime.
+	ProtectedWorkloads []*preemption.ProtectedWorkload
+	// InadmissibleMsg explains why the workload can't be admitted, if any.
+	InadmissibleMsg string
+}
+
+// Admissible returns true if the workload fits, or if it can be admitted
+// after preempting the targets.
+func (r *DryRunResult) Admissible() bool {
+	switch r.Assignment.RepresentativeMode() {
+	case flavorassigner.Fit:
+		return true
+	case flavorassigner.Preempt:
+		return len(r.PreemptionTargets) > 0
+	}
+	return false
+}
+
+// PreemptionDryRun runs the nomination of the workload against a fresh
+// snapshot of the cache, the same way it happens during a scheduling cycle,
+// without reserving quota nor issuing preemptions.
+// The ClusterQueue of the workload info must be set.
+func (s *Scheduler) PreemptionDryRun(ctx context.Context, wl workload.Info) (*DryRunResult, error) {
+	snapshot, err := s.cache.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := s.nominate(ctx, []workload.Info{wl}, snapshot)
+	if len(entries) == 0 {
+		return &DryRunResult{
+			InadmissibleMsg: fmt.Sprintf("Workload is already assumed or admitted in ClusterQueue %s", wl.ClusterQueue),
+		}, nil
+	}
+	e := entries[0]
+	result := &DryRunResult{
+		Assignment:        e.assignment,
+		PreemptionTargets: e.preemptionTargets,
+		InadmissibleMsg:   e.inadmissibleMsg,
+	}
+	if e.assignment.RepresentativeMode() == flavorassigner.Preempt {
+		result.ProtectedWorkloads = s.preemptor.ProtectedWorkloads(e.Info, e.assignment, snapshot)
+	}
+	return result, nil
+}
+
+// FlavorsDryRunResult is the outcome of assigning flavors to a pending
+// workload.
+type FlavorsDryRunResult struct {
+	Assignment flavorassigner.Assignment
+	// InadmissibleMsg explains why the workload doesn't fit, if any.
+	InadmissibleMsg string
+}
+
+// flavorsDryRunMaxAge is how long the results of FlavorsDryRun are reused
+// when no scheduling cycle runs in between.
+const flavorsDryRunMaxAge = 5 * time.Second
+
+// flavorsDryRunCache keeps the snapshot and the results of FlavorsDryRun
+// until the next scheduling cycle, so that repeated requests don't build a
+// snapshot and assign flavors to all the pending workloads each time.
+type flavorsDryRunCache struct {
+	sync.Mutex
+	snapshot *cache.Snapshot
+	taken    time.Time
+	results  map[flavorsDryRunKey]FlavorsDryRunResult
+}
+
+// flavorsDryRunKey identifies a version of a workload evaluated for a
+// ClusterQueue.
+type flavorsDryRunKey struct {
+	clusterQueue    kueue.ClusterQueueReference
+	workload        string
+	resourceVersion string
+}
+
+// invalidate drops the results, once the scheduling cycle changed the usage
+// of the ClusterQueues.
+func (c *flavorsDryRunCache) invalidate() {
+	c.Lock()
+	defer c.Unlock()
+	c.snapshot = nil
+	c.results = nil
+}
+
+// FlavorsDryRun assigns flavors to each of the pending workloads of the
+// ClusterQueue, separately. Unlike PreemptionDryRun, it doesn't look for the
+// workloads to preempt. The results are computed against a snapshot of the
+// cache which is reused, along with the results, until the next scheduling
+// cycle or for flavorsDryRunMaxAge.
+func (s *Scheduler) FlavorsDryRun(ctx context.Context, cqName kueue.ClusterQueueReference, wls []*workload.Info) ([]FlavorsDryRunResult, error) {
+	s.flavorsDryRuns.Lock()
+	defer s.flavorsDryRuns.Unlock()
+	now := s.clock.Now()
+	if s.flavorsDryRuns.snapshot == nil || now.Sub(s.flavorsDryRuns.taken) > flavorsDryRunMaxAge {
+		snapshot, err := s.cache.Snapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.flavorsDryRuns.snapshot = snapshot
+		s.flavorsDryRuns.taken = now
+		s.flavorsDryRuns.results = make(map[flavorsDryRunKey]FlavorsDryRunResult)
+	}
+	snapshot := s.flavorsDryRuns.snapshot
+
+	results := make([]FlavorsDryRunResult, len(wls))
+	cq := snapshot.ClusterQueue(cqName)
+	inadmissibleMsg := ""
+	if snapshot.InactiveClusterQueueSets.Has(cqName) {
+		inadmissibleMsg = fmt.Sprintf("ClusterQueue %s is inactive", cqName)
+	} else if cq == nil {
+		inadmissibleMsg = fmt.Sprintf("ClusterQueue %s not found", cqName)
+	}
+	if inadmissibleMsg != "" {
+		for i := range results {
+			results[i].InadmissibleMsg = inadmissibleMsg
+		}
+		return results, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	var oracle *preemption.PreemptionOracle
+	for i, wl := range wls {
+		key := flavorsDryRunKey{
+			clusterQueue:    cqName,
+			workload:        workload.Key(wl.Obj),
+			resourceVersion: wl.Obj.ResourceVersion,
+		}
+		if result, found := s.flavorsDryRuns.results[key]; found {
+			results[i] = result
+			continue
+		}
+		if oracle == nil {
+			oracle = preemption.NewOracle(s.preemptor, snapshot)
+		}
+		// Evaluate all the flavors, not only the ones following the flavors
+		// tried in the last scheduling cycle.
+		info := *wl
+		info.ClusterQueue = cqName
+		info.LastAssignment = nil
+		assignment := flavorassigner.New(&info, cq, snapshot.ResourceFlavors, s.fairSharing.Enable, oracle, s.framework).WithQuotaChecks().Assign(log, nil)
+		results[i] = FlavorsDryRunResult{
+			Assignment:      assignment,
+			InadmissibleMsg: assignment.Message(),
+		}
+		s.flavorsDryRuns.results[key] = results[i]
+	}
+	return results, nil
+}
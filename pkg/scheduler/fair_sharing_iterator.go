@@ -27,7 +27,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
-	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -42,11 +42,13 @@ type fairSharingIterator struct {
 	log           logr.Logger
 }
 
-func makeFairSharingIterator(ctx context.Context, entries []entry, workloadOrdering workload.Ordering) *fairSharingIterator {
+func makeFairSharingIterator(ctx context.Context, entries []entry, workloadOrdering workload.Ordering, queueSort framework.QueueSortPlugin, historicalUsage bool) *fairSharingIterator {
 	f := fairSharingIterator{
 		cqToEntry: make(map[*cache.ClusterQueueSnapshot]*entry, len(entries)),
 		entryComparer: entryComparer{
 			workloadOrdering: workloadOrdering,
+			queueSort:        queueSort,
+			historicalUsage:  historicalUsage,
 		},
 		log: ctrl.LoggerFrom(ctx),
 	}
@@ -162,20 +164,39 @@ type drsKey struct {
 type entryComparer struct {
 	drsValues        map[drsKey]int
 	workloadOrdering workload.Ordering
+	queueSort        framework.QueueSortPlugin
+	// historicalUsage indicates whether the nodes are compared first by
+	// the share of their average usage in the past.
+	historicalUsage  bool
+	historicalShares map[drsKey]int
 }
 
 func (e *entryComparer) less(a, b *entry, parentCohort kueue.CohortReference) bool {
-	aDrs := e.drsValues[drsKey{parentCohort: parentCohort, workloadKey: workload.Key(a.Obj)}]
-	bDrs := e.drsValues[drsKey{parentCohort: parentCohort, workloadKey: workload.Key(b.Obj)}]
+	aKey := drsKey{parentCohort: parentCohort, workloadKey: workload.Key(a.Obj)}
+	bKey := drsKey{parentCohort: parentCohort, workloadKey: workload.Key(b.Obj)}
+	// 0: Historical usage
+	if e.historicalUsage {
+		if aShare, bShare := e.historicalShares[aKey], e.historicalShares[bKey]; aShare != bShare {
+			return aShare < bShare
+		}
+	}
+
+	aDrs := e.drsValues[aKey]
+	bDrs := e.drsValues[bKey]
 	// 1: DRF
 	if aDrs != bDrs {
 		return aDrs < bDrs
 	}
 
+	// The queueSort plugin replaces the priority and FIFO ordering.
+	if e.queueSort != nil {
+		return e.queueSort.Less(&a.Info, &b.Info)
+	}
+
 	// 2: Priority
 	if features.Enabled(features.PrioritySortingWithinCohort) {
-		p1 := priority.Priority(a.Obj)
-		p2 := priority.Priority(b.Obj)
+		p1 := a.priority()
+		p2 := b.priority()
 		if p1 != p2 {
 			return p1 > p2
 		}
@@ -192,13 +213,19 @@ func (e *entryComparer) less(a, b *entry, parentCohort kueue.CohortReference) bo
 // root-1.  During the tournament, these values are used to compare
 // all children the parentCohort, to select the child with the lowest
 // DRS after admission of its nominated workload.
+// When accounting for the historical usage, it also calculates the share of
+// the average usage in the past for the same nodes.
 func (ec *entryComparer) computeDRS(rootCohort *cache.CohortSnapshot, cqToEntry map[*cache.ClusterQueueSnapshot]*entry) {
 	ec.drsValues = make(map[drsKey]int)
+	ec.historicalShares = make(map[drsKey]int)
 	for _, cq := range rootCohort.SubtreeClusterQueues() {
 		entry, ok := cqToEntry[cq]
 		if !ok {
 			continue
 		}
+		if ec.historicalUsage {
+			ec.computeHistoricalShares(cq, workload.Key(entry.Obj))
+		}
 		// We add workload's usage to CQ, so that all
 		// subsequent DRS include the admission of workload.
 		cq.AddUsage(entry.assignmentUsage())
@@ -220,6 +247,17 @@ func (ec *entryComparer) computeDRS(rootCohort *cache.CohortSnapshot, cqToEntry
 	}
 }
 
+// computeHistoricalShares calculates the share of the average usage in the
+// past for the CQ and all the Cohorts on path to root-1.
+func (ec *entryComparer) computeHistoricalShares(cq *cache.ClusterQueueSnapshot, workloadKey string) {
+	ec.historicalShares[drsKey{parentCohort: cq.Parent().GetName(), workloadKey: workloadKey}] = cq.HistoricalShare()
+	cohort := cq.Parent()
+	for cohort.HasParent() {
+		ec.historicalShares[drsKey{parentCohort: cohort.Parent().GetName(), workloadKey: workloadKey}] = cohort.HistoricalShare()
+		cohort = cohort.Parent()
+	}
+}
+
 func (ec *entryComparer) logDrsValuesWhenVerbose(log logr.Logger) {
 	if logV := log.V(5); logV.Enabled() {
 		serializableDrs := make([]string, 0, len(ec.drsValues))
@@ -0,0 +1,198 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavorassigner
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// FlavorDecisionReason describes why a flavor was chosen for the resources of
+// a resource group.
+type FlavorDecisionReason string
+
+const (
+	// FlavorFits means that the flavor fits without borrowing.
+	FlavorFits FlavorDecisionReason = "Fit"
+	// FlavorBorrows means that the flavor fits by borrowing, which the flavor
+	// fungibility allows before trying the next flavor.
+	FlavorBorrows FlavorDecisionReason = "Borrow"
+	// FlavorPreempts means that the flavor fits by preempting, which the
+	// flavor fungibility allows before trying the next flavor.
+	FlavorPreempts FlavorDecisionReason = "Preempt"
+	// FlavorBestMode means that no flavor satisfies the flavor fungibility,
+	// and the flavor with the best mode was chosen among all of them.
+	FlavorBestMode FlavorDecisionReason = "BestMode"
+	// FlavorScored means that the flavors were scored, by cost or by the
+	// flavorScore plugins, and the best one was chosen.
+	FlavorScored FlavorDecisionReason = "Score"
+)
+
+// FlavorRejection describes why a flavor can't be assigned to the resources
+// of a resource group.
+type FlavorRejection string
+
+const (
+	// RejectedNotFound means that the ResourceFlavor doesn't exist.
+	RejectedNotFound FlavorRejection = "NotFound"
+	// RejectedByTaints means that the pod set doesn't tolerate the taints
+	// of the flavor.
+	RejectedByTaints FlavorRejection = "Taints"
+	// RejectedByNodeAffinity means that the node selector or the node
+	// affinity of the pod set doesn't match the labels of the flavor.
+	RejectedByNodeAffinity FlavorRejection = "NodeAffinity"
+	// RejectedByQuota means that there isn't enough unused quota for the
+	// resources in the flavor, in the ClusterQueue or in the LocalQueue.
+	RejectedByQuota FlavorRejection = "Quota"
+	// RejectedByTopology means that the flavor doesn't support the topology
+	// requested by the pod set, or that its topology domains don't have
+	// enough capacity for the pods.
+	RejectedByTopology FlavorRejection = "Topology"
+	// RejectedByPlugin means that a filter plugin rejected the flavor.
+	RejectedByPlugin FlavorRejection = "Plugin"
+)
+
+// FlavorDecision records how a flavor was chosen for the resources of a
+// resource group requested by a pod set.
+type FlavorDecision struct {
+	PodSet    kueue.PodSetReference
+	Resources []corev1.ResourceName
+	// Attempts are the flavors evaluated, in order.
+	Attempts []FlavorAttempt
+	// Flavor is the chosen flavor, empty if none fits.
+	Flavor kueue.ResourceFlavorReference
+	Reason FlavorDecisionReason
+}
+
+// FlavorAttempt is the evaluation of a flavor for the resources of a resource
+// group.
+type FlavorAttempt struct {
+	Flavor    kueue.ResourceFlavorReference
+	Mode      FlavorAssignmentMode
+	Borrowing bool
+	// Rejection is why the flavor doesn't fit, or can't be used, if any.
+	Rejection FlavorRejection
+	// Message explains why the flavor doesn't fit, or can't be used, if any.
+	Message string
+	// Quota is the quota of the flavor for each resource requested, sorted
+	// by resource. It's empty if the flavor doesn't exist, or if the
+	// assigner doesn't record the quota checks.
+	Quota []ResourceQuotaCheck
+}
+
+// ResourceQuotaCheck compares the quantity of a resource requested by a pod
+// set with the quota of a flavor.
+type ResourceQuotaCheck struct {
+	Resource corev1.ResourceName
+	// Requested includes the quantity assigned to the flavor for the previous
+	// pod sets of the workload.
+	Requested int64
+	// Available is the unused quota of the ClusterQueue, including the quota
+	// it can borrow from its cohort, capped by the unused quota of the
+	// LocalQueue, if it declares one.
+	Available int64
+	// Borrowable is the part of the available quota which is borrowed from
+	// the cohort.
+	Borrowable int64
+}
+
+// attempt and choose are no-ops on a nil decision, when the assigner doesn't
+// record the flavor decisions.
+
+func (d *FlavorDecision) attempt(flavor kueue.ResourceFlavorReference, mode granularMode, borrowing bool, rejection FlavorRejection, reasons []string, quota []ResourceQuotaCheck) {
+	if d == nil {
+		return
+	}
+	d.Attempts = append(d.Attempts, FlavorAttempt{
+		Flavor:    flavor,
+		Mode:      mode.flavorAssignmentMode(),
+		Borrowing: borrowing,
+		Rejection: rejection,
+		Message:   strings.Join(reasons, ", "),
+		Quota:     quota,
+	})
+}
+
+func (d *FlavorDecision) choose(flavor kueue.ResourceFlavorReference, reason FlavorDecisionReason) {
+	if d != nil {
+		d.Flavor = flavor
+		d.Reason = reason
+	}
+}
+
+// rejectForTopology records that the topology domains of the flavor chosen
+// for the pod set don't have enough capacity for its pods.
+func (a *Assignment) rejectForTopology(psName kueue.PodSetReference, reason string) {
+	for i := range a.flavorDecisions {
+		d := &a.flavorDecisions[i]
+		if d.PodSet != psName || d.Flavor == "" {
+			continue
+		}
+		if idx := slices.IndexFunc(d.Attempts, func(at FlavorAttempt) bool { return at.Flavor == d.Flavor }); idx >= 0 {
+			d.Attempts[idx].Rejection = RejectedByTopology
+			d.Attempts[idx].Message = reason
+		}
+	}
+}
+
+// quotaChecks returns the quota of the flavor for the requests of a pod set,
+// adding the usage of the previous pod sets, or nil if the assigner doesn't
+// record the quota checks.
+func (a *FlavorAssigner) quotaChecks(flavor kueue.ResourceFlavorReference, requests resources.Requests, assignmentUsage resources.FlavorResourceQuantities) []ResourceQuotaCheck {
+	if !a.withQuotaChecks {
+		return nil
+	}
+	lq := a.cq.LocalQueueFor(a.wl.Obj)
+	checks := make([]ResourceQuotaCheck, 0, len(requests))
+	for _, rName := range slices.Sorted(maps.Keys(requests)) {
+		fr := resources.FlavorResource{Flavor: flavor, Resource: rName}
+		available := a.cq.Available(fr)
+		nominalAvailable := max(0, a.cq.QuotaFor(fr).Nominal-a.cq.ResourceNode.Usage[fr]-a.cq.ResourceNode.Held[fr])
+		borrowable := max(0, available-nominalAvailable)
+		if lq != nil {
+			if lqAvailable, limited := lq.Available(fr); limited {
+				available = min(available, lqAvailable)
+				borrowable = min(borrowable, available)
+			}
+		}
+		checks = append(checks, ResourceQuotaCheck{
+			Resource:   rName,
+			Requested:  requests[rName] + assignmentUsage[fr],
+			Available:  available,
+			Borrowable: borrowable,
+		})
+	}
+	return checks
+}
+
+// fungibilityDecisionReason returns why a flavor satisfying the flavor
+// fungibility was chosen without trying the next flavors.
+func fungibilityDecisionReason(mode granularMode, borrowing bool) FlavorDecisionReason {
+	switch {
+	case mode.isPreemptMode():
+		return FlavorPreempts
+	case borrowing:
+		return FlavorBorrows
+	}
+	return FlavorFits
+}
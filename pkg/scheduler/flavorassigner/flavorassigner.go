@@ -19,6 +19,7 @@ package flavorassigner
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
 	"strings"
@@ -27,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
@@ -51,6 +53,16 @@ type Assignment struct {
 
 	// representativeMode is the cached representative mode for this assignment.
 	representativeMode *FlavorAssignmentMode
+
+	// flavorDecisions records how the flavors were chosen for the resource
+	// groups requested by the pod sets.
+	flavorDecisions []FlavorDecision
+}
+
+// FlavorDecisions returns how the flavors were chosen for the resource groups
+// requested by each pod set, in the order they were evaluated.
+func (a *Assignment) FlavorDecisions() []FlavorDecision {
+	return a.flavorDecisions
 }
 
 // UpdateForTASResult updates the Assignment with the TAS result
@@ -118,6 +130,44 @@ func (a *Assignment) RepresentativeMode() FlavorAssignmentMode {
 	return mode
 }
 
+// KeepFlavors marks the assignment as NoFit if it assigns to any pod set
+// a flavor different from the given admission. It's used for the workload
+// slices, as the pods of the replaced slice keep running on their flavors.
+func (a *Assignment) KeepFlavors(admission *kueue.Admission) {
+	for i := range a.PodSets {
+		psa := &a.PodSets[i]
+		idx := slices.IndexFunc(admission.PodSetAssignments, func(admitted kueue.PodSetAssignment) bool {
+			return admitted.Name == psa.Name
+		})
+		if idx == -1 {
+			continue
+		}
+		admitted := admission.PodSetAssignments[idx]
+		for rName, flv := range psa.Flavors {
+			if admittedFlavor, found := admitted.Flavors[rName]; found && admittedFlavor != flv.Name {
+				psa.reason(fmt.Sprintf("flavor %s for %s doesn't match the flavor %s of the replaced workload slice", flv.Name, rName, admittedFlavor))
+				psa.updateMode(NoFit)
+				a.representativeMode = ptr.To(NoFit)
+			}
+		}
+	}
+}
+
+// LimitMode lowers the mode of the assignment to the given mode. It's used
+// when the workload fits in the quota, but exceeds the maximum number of
+// admitted workloads of its queues.
+func (a *Assignment) LimitMode(mode FlavorAssignmentMode) {
+	if a.RepresentativeMode() <= mode {
+		return
+	}
+	for i := range a.PodSets {
+		if psa := &a.PodSets[i]; psa.RepresentativeMode() > mode {
+			psa.updateMode(mode)
+		}
+	}
+	a.representativeMode = ptr.To(mode)
+}
+
 func (a *Assignment) Message() string {
 	var builder strings.Builder
 	for _, ps := range a.PodSets {
@@ -138,6 +188,23 @@ func (a *Assignment) Message() string {
 	return builder.String()
 }
 
+// Cost returns the total cost of the pod sets, or nil if none of the assigned
+// flavors has costs.
+func (a *Assignment) Cost() *resource.Quantity {
+	var total float64
+	var hasCosts bool
+	for _, ps := range a.PodSets {
+		if ps.Cost != nil {
+			total += *ps.Cost
+			hasCosts = true
+		}
+	}
+	if !hasCosts {
+		return nil
+	}
+	return resources.CostQuantity(total)
+}
+
 func (a *Assignment) ToAPI() []kueue.PodSetAssignment {
 	psFlavors := make([]kueue.PodSetAssignment, len(a.PodSets))
 	for i := range psFlavors {
@@ -215,6 +282,10 @@ type PodSetAssignment struct {
 	Requests corev1.ResourceList
 	Count    int32
 
+	// Cost is the cost of the requests of the pod set in the assigned
+	// flavors, when any of them has costs.
+	Cost *float64
+
 	TopologyAssignment *kueue.TopologyAssignment
 }
 
@@ -338,24 +409,60 @@ type preemptionOracle interface {
 	IsReclaimPossible(log logr.Logger, cq *cache.ClusterQueueSnapshot, wl workload.Info, fr resources.FlavorResource, quantity int64) bool
 }
 
+// FlavorPlugins runs the scheduler plugins extending the flavor assignment.
+type FlavorPlugins interface {
+	// FilterFlavor returns the reason why the flavor can't be assigned to the
+	// requests of the pod set with index psID, or an empty string if it can.
+	FilterFlavor(wl *workload.Info, psID int, flavor *kueue.ResourceFlavor, requests resources.Requests) string
+	// ScoresFlavors returns whether the flavors are scored.
+	ScoresFlavors() bool
+	// ScoreFlavor returns the score of assigning the flavor to the requests
+	// of the pod set with index psID.
+	ScoreFlavor(wl *workload.Info, psID int, flavor *kueue.ResourceFlavor, requests resources.Requests) int64
+}
+
 type FlavorAssigner struct {
 	wl                *workload.Info
 	cq                *cache.ClusterQueueSnapshot
 	resourceFlavors   map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	enableFairSharing bool
 	oracle            preemptionOracle
+	plugins           FlavorPlugins
+	// withDecisions records how the flavors were chosen in the assignment.
+	withDecisions bool
+	// withQuotaChecks records the quota of the evaluated flavors in the
+	// flavor decisions.
+	withQuotaChecks bool
 }
 
-func New(wl *workload.Info, cq *cache.ClusterQueueSnapshot, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, enableFairSharing bool, oracle preemptionOracle) *FlavorAssigner {
+func New(wl *workload.Info, cq *cache.ClusterQueueSnapshot, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, enableFairSharing bool, oracle preemptionOracle, plugins FlavorPlugins) *FlavorAssigner {
 	return &FlavorAssigner{
 		wl:                wl,
 		cq:                cq,
 		resourceFlavors:   resourceFlavors,
 		enableFairSharing: enableFairSharing,
 		oracle:            oracle,
+		plugins:           plugins,
 	}
 }
 
+// WithDecisions makes the assigner record how the flavors were chosen in the
+// flavor decisions of the assignment. They are only recorded when they are
+// logged or served.
+func (a *FlavorAssigner) WithDecisions() *FlavorAssigner {
+	a.withDecisions = true
+	return a
+}
+
+// WithQuotaChecks makes the assigner record the flavor decisions, along with
+// the quota of each evaluated flavor, to explain why a workload doesn't fit.
+// The scheduler doesn't need them, so they are only computed on demand.
+func (a *FlavorAssigner) WithQuotaChecks() *FlavorAssigner {
+	a.withDecisions = true
+	a.withQuotaChecks = true
+	return a
+}
+
 func lastAssignmentOutdated(wl *workload.Info, cq *cache.ClusterQueueSnapshot) bool {
 	return cq.AllocatableResourceGeneration > wl.LastAssignment.ClusterQueueGeneration
 }
@@ -417,7 +524,14 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 				// No need to compute again.
 				continue
 			}
-			flavors, status := a.findFlavorForPodSetResource(log, i, podSet.Requests, resName, assignment.Usage.Quota)
+			var decision *FlavorDecision
+			if a.withDecisions {
+				decision = &FlavorDecision{PodSet: podSet.Name}
+			}
+			flavors, status := a.findFlavorForPodSetResource(log, i, podSet.Requests, resName, assignment.Usage.Quota, decision)
+			if decision != nil {
+				assignment.flavorDecisions = append(assignment.flavorDecisions, *decision)
+			}
 			if status.IsError() || len(flavors) == 0 {
 				psAssignment.Flavors = nil
 				psAssignment.Status = status
@@ -425,6 +539,9 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 			}
 			psAssignment.append(flavors, status)
 		}
+		if features.Enabled(features.CostAwareFlavorSelection) && len(psAssignment.Flavors) > 0 {
+			psAssignment.Cost = a.podSetCost(podSet.Requests, psAssignment.Flavors)
+		}
 
 		assignment.append(podSet.Requests, &psAssignment)
 		if psAssignment.Status.IsError() || (len(podSet.Requests) > 0 && len(psAssignment.Flavors) == 0) {
@@ -443,6 +560,7 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 				// There is at least one PodSet which does not fit
 				psAssignment := assignment.podSetAssignmentByName(failure.PodSetName)
 				psAssignment.reason(failure.Reason)
+				assignment.rejectForTopology(failure.PodSetName, failure.Reason)
 				// update the mode for all flavors and the representative mode
 				psAssignment.updateMode(Preempt)
 				assignment.representativeMode = ptr.To(Preempt)
@@ -457,6 +575,7 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 				// There is at least one PodSet which does not fit even if
 				// all workloads are preempted.
 				psAssignment := assignment.podSetAssignmentByName(failure.PodSetName)
+				assignment.rejectForTopology(failure.PodSetName, failure.Reason)
 				// update the mode for all flavors and the representative mode
 				psAssignment.updateMode(NoFit)
 				assignment.representativeMode = ptr.To(NoFit)
@@ -466,6 +585,19 @@ func (a *FlavorAssigner) assignFlavors(log logr.Logger, counts []int32) Assignme
 	return assignment
 }
 
+// podSetCost returns the cost of the requests of a pod set in the assigned
+// flavors, or nil if none of them has costs.
+func (a *FlavorAssigner) podSetCost(requests resources.Requests, flavors ResourceAssignment) *float64 {
+	usage := make(resources.FlavorResourceQuantities, len(flavors))
+	for rName, flv := range flavors {
+		usage[resources.FlavorResource{Flavor: flv.Name, Resource: rName}] = requests[rName]
+	}
+	if cost, hasCosts := usage.Cost(a.resourceFlavors); hasCosts {
+		return &cost
+	}
+	return nil
+}
+
 func (psa *PodSetAssignment) append(flavors ResourceAssignment, status *Status) {
 	for resource, assignment := range flavors {
 		psa.Flavors[resource] = assignment
@@ -495,16 +627,21 @@ func (a *Assignment) append(requests resources.Requests, psAssignment *PodSetAss
 // for all resources in the same group as resName.
 // Returns the chosen flavor, along with the information about resources that need to be borrowed.
 // If the flavor cannot be immediately assigned, it returns a status with
-// reasons or failure.
+// reasons or failure. The evaluated flavors and the choice are recorded in
+// the decision, if not nil.
 func (a *FlavorAssigner) findFlavorForPodSetResource(
 	log logr.Logger,
 	psID int,
 	requests resources.Requests,
 	resName corev1.ResourceName,
 	assignmentUsage resources.FlavorResourceQuantities,
+	decision *FlavorDecision,
 ) (ResourceAssignment, *Status) {
 	resourceGroup := a.cq.RGByResource(resName)
 	if resourceGroup == nil {
+		if decision != nil {
+			decision.Resources = []corev1.ResourceName{resName}
+		}
 		return nil, &Status{
 			reasons: []string{fmt.Sprintf("resource %s unavailable in ClusterQueue", resName)},
 		}
@@ -512,11 +649,21 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 
 	status := &Status{}
 	requests = filterRequestedResources(requests, resourceGroup.CoveredResources)
+	if decision != nil {
+		decision.Resources = slices.Sorted(maps.Keys(requests))
+	}
 	ps := &a.wl.Obj.Spec.PodSets[psID]
 	podSpec := &ps.Template.Spec
 
 	var bestAssignment ResourceAssignment
 	bestAssignmentMode := noFit
+	// When the flavors are scored, or chosen by cost, all of them are
+	// evaluated, and the best flavor is chosen among the acceptable ones, or
+	// among the ones with the best mode if none is acceptable.
+	lowestCost := features.Enabled(features.CostAwareFlavorSelection) && a.cq.FlavorSelectionPolicy == kueue.LowestCostFlavorSelection
+	pluginScoring := a.plugins != nil && a.plugins.ScoresFlavors()
+	scoring := lowestCost || pluginScoring
+	var best *flavorScore
 
 	// We will only check against the flavors' labels for the resource.
 	selector := flavorSelector(podSpec, resourceGroup.LabelKeys)
@@ -525,16 +672,20 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 	for ; idx < len(resourceGroup.Flavors); idx++ {
 		attemptedFlavorIdx = idx
 		fName := resourceGroup.Flavors[idx]
+		reasonsFrom := len(status.reasons)
 		flavor, exist := a.resourceFlavors[fName]
 		if !exist {
 			log.Error(nil, "Flavor not found", "Flavor", fName)
 			status.appendf("flavor %s not found", fName)
+			decision.attempt(fName, noFit, false, RejectedNotFound, status.reasons[reasonsFrom:], nil)
 			continue
 		}
+		quota := a.quotaChecks(fName, requests, assignmentUsage)
 		if features.Enabled(features.TopologyAwareScheduling) {
 			if message := checkPodSetAndFlavorMatchForTAS(a.cq, ps, flavor); message != nil {
 				log.Error(nil, *message)
 				status.appendf("%s", *message)
+				decision.attempt(fName, noFit, false, RejectedByTopology, status.reasons[reasonsFrom:], quota)
 				continue
 			}
 		}
@@ -543,6 +694,7 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 		})
 		if untolerated {
 			status.appendf("untolerated taint %s in flavor %s", taint, fName)
+			decision.attempt(fName, noFit, false, RejectedByTaints, status.reasons[reasonsFrom:], quota)
 			continue
 		}
 		if match, err := selector.Match(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: flavor.Spec.NodeLabels}}); !match || err != nil {
@@ -551,8 +703,16 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 				return nil, status
 			}
 			status.appendf("flavor %s doesn't match node affinity", fName)
+			decision.attempt(fName, noFit, false, RejectedByNodeAffinity, status.reasons[reasonsFrom:], quota)
 			continue
 		}
+		if a.plugins != nil {
+			if reason := a.plugins.FilterFlavor(a.wl, psID, flavor, requests); reason != "" {
+				status.appendf("flavor %s rejected by %s", fName, reason)
+				decision.attempt(fName, noFit, false, RejectedByPlugin, status.reasons[reasonsFrom:], quota)
+				continue
+			}
+		}
 		needsBorrowing := false
 		assignments := make(ResourceAssignment, len(requests))
 		// Calculate representativeMode for this assignment as the worst mode among all requests.
@@ -580,11 +740,40 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 				borrow: borrow,
 			}
 		}
+		var rejection FlavorRejection
+		if len(status.reasons) > reasonsFrom {
+			rejection = RejectedByQuota
+		}
+		decision.attempt(fName, representativeMode, needsBorrowing, rejection, status.reasons[reasonsFrom:], quota)
+
+		if scoring {
+			if representativeMode == noFit {
+				continue
+			}
+			acceptable := representativeMode == fit
+			if features.Enabled(features.FlavorFungibility) {
+				acceptable = !shouldTryNextFlavor(representativeMode, a.cq.FlavorFungibility, needsBorrowing)
+			}
+			score := &flavorScore{acceptable: acceptable, mode: representativeMode}
+			if lowestCost {
+				score.cost = requests.Cost(flavor.Spec.Costs)
+			}
+			if pluginScoring {
+				score.score = a.plugins.ScoreFlavor(a.wl, psID, flavor, requests)
+			}
+			if score.isBetterThan(best) {
+				bestAssignment = assignments
+				bestAssignmentMode = representativeMode
+				best = score
+			}
+			continue
+		}
 
 		if features.Enabled(features.FlavorFungibility) {
 			if !shouldTryNextFlavor(representativeMode, a.cq.FlavorFungibility, needsBorrowing) {
 				bestAssignment = assignments
 				bestAssignmentMode = representativeMode
+				decision.choose(fName, fungibilityDecisionReason(representativeMode, needsBorrowing))
 				break
 			}
 			if representativeMode > bestAssignmentMode {
@@ -596,10 +785,21 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			bestAssignmentMode = representativeMode
 			if bestAssignmentMode == fit {
 				// All the resources fit in the cohort, no need to check more flavors.
+				decision.choose(fName, fungibilityDecisionReason(representativeMode, needsBorrowing))
 				return bestAssignment, nil
 			}
 		}
 	}
+	if decision != nil && decision.Flavor == "" {
+		for _, assignment := range bestAssignment {
+			if scoring {
+				decision.choose(assignment.Name, FlavorScored)
+			} else {
+				decision.choose(assignment.Name, FlavorBestMode)
+			}
+			break
+		}
+	}
 
 	if features.Enabled(features.FlavorFungibility) {
 		for _, assignment := range bestAssignment {
@@ -614,9 +814,41 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			return bestAssignment, nil
 		}
 	}
+	if scoring && bestAssignmentMode == fit {
+		return bestAssignment, nil
+	}
 	return bestAssignment, status
 }
 
+// flavorScore holds the evaluation of a flavor when all the flavors of a
+// resource group are evaluated.
+type flavorScore struct {
+	acceptable bool
+	mode       granularMode
+	cost       float64
+	score      int64
+}
+
+// isBetterThan returns whether the flavor is better than the best one so far:
+// acceptable flavors are preferred, then the flavors with the best mode if
+// none is acceptable, then the flavors with the lowest cost, then the flavors
+// with the highest score, then the earlier flavors.
+func (s *flavorScore) isBetterThan(best *flavorScore) bool {
+	if best == nil {
+		return true
+	}
+	if s.acceptable != best.acceptable {
+		return s.acceptable
+	}
+	if !s.acceptable && s.mode != best.mode {
+		return s.mode > best.mode
+	}
+	if s.cost != best.cost {
+		return s.cost < best.cost
+	}
+	return s.score > best.score
+}
+
 func shouldTryNextFlavor(representativeMode granularMode, flavorFungibility kueue.FlavorFungibility, needsBorrowing bool) bool {
 	policyPreempt := flavorFungibility.WhenCanPreempt
 	policyBorrow := flavorFungibility.WhenCanBorrow
@@ -692,6 +924,11 @@ func flavorSelector(spec *corev1.PodSpec, allowedKeys sets.Set[string]) nodeaffi
 func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorResource, val int64, rQuota cache.ResourceQuota) (granularMode, bool, *Status) {
 	var status Status
 
+	lqMode, lqFits := a.fitsLocalQueueQuota(fr, val, &status)
+	if lqMode == noFit {
+		return noFit, false, &status
+	}
+
 	borrow := a.cq.BorrowingWith(fr, val) && a.cq.HasParent()
 	available := a.cq.Available(fr)
 	maxCapacity := a.cq.PotentialAvailable(fr)
@@ -705,7 +942,10 @@ func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorR
 
 	// Fit
 	if val <= available {
-		return fit, borrow, nil
+		if lqFits {
+			return fit, borrow, nil
+		}
+		return lqMode, borrow, &status
 	}
 
 	// Check if preemption is possible
@@ -722,7 +962,38 @@ func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorR
 	status.appendf("insufficient unused quota for %s in flavor %s, %s more needed",
 		fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val-available))
 
-	return mode, borrow, &status
+	return min(mode, lqMode), borrow, &status
+}
+
+// fitsLocalQueueQuota returns how this flavor could be assigned to the
+// resource, according to the remaining quota in the LocalQueue of the
+// workload, and whether it fits without preemption.
+// The quota of the LocalQueue can only be released by preempting workloads
+// from the same LocalQueue, which requires preemption within the ClusterQueue.
+func (a *FlavorAssigner) fitsLocalQueueQuota(fr resources.FlavorResource, val int64, status *Status) (granularMode, bool) {
+	lq := a.cq.LocalQueueFor(a.wl.Obj)
+	if lq == nil {
+		return fit, true
+	}
+	maxUsage, limited := lq.Quotas[fr]
+	if !limited {
+		return fit, true
+	}
+	if val > maxUsage {
+		status.appendf("insufficient quota for %s in flavor %s in LocalQueue %s, request > maximum capacity (%s > %s)",
+			fr.Resource, fr.Flavor, a.wl.Obj.Spec.QueueName, resources.ResourceQuantityString(fr.Resource, val), resources.ResourceQuantityString(fr.Resource, maxUsage))
+		return noFit, false
+	}
+	available, _ := lq.Available(fr)
+	if val <= available {
+		return fit, true
+	}
+	status.appendf("insufficient unused quota for %s in flavor %s in LocalQueue %s, %s more needed",
+		fr.Resource, fr.Flavor, a.wl.Obj.Spec.QueueName, resources.ResourceQuantityString(fr.Resource, val-available))
+	if a.cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyNever {
+		return noFit, false
+	}
+	return preempt, false
 }
 
 func (a *FlavorAssigner) canPreemptWhileBorrowing() bool {
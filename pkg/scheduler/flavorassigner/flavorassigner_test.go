@@ -25,6 +25,8 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
@@ -1978,7 +1980,7 @@ func TestAssignFlavors(t *testing.T) {
 				secondaryClusterQueue.AddUsage(workload.Usage{Quota: tc.secondaryClusterQueueUsage})
 			}
 
-			flvAssigner := New(wlInfo, clusterQueue, resourceFlavors, tc.enableFairSharing, &testOracle{})
+			flvAssigner := New(wlInfo, clusterQueue, resourceFlavors, tc.enableFairSharing, &testOracle{}, nil)
 			assignment := flvAssigner.Assign(log, nil)
 			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
 				t.Errorf("e.assignFlavors(_).RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
@@ -2130,7 +2132,7 @@ func TestReclaimBeforePriorityPreemption(t *testing.T) {
 			testClusterQueue := snapshot.ClusterQueue("test-clusterqueue")
 			testClusterQueue.AddUsage(workload.Usage{Quota: tc.testClusterQueueUsage})
 
-			flvAssigner := New(wlInfo, testClusterQueue, resourceFlavors, false, &testOracle{})
+			flvAssigner := New(wlInfo, testClusterQueue, resourceFlavors, false, &testOracle{}, nil)
 			log := testr.NewWithOptions(t, testr.Options{Verbosity: 2})
 			assignment := flvAssigner.Assign(log, nil)
 			if gotRepMode := assignment.RepresentativeMode(); gotRepMode != tc.wantMode {
@@ -2261,7 +2263,7 @@ func TestDeletedFlavors(t *testing.T) {
 			cache.DeleteResourceFlavor(flavorMap["deleted-flavor"])
 			delete(flavorMap, "deleted-flavor")
 
-			flvAssigner := New(wlInfo, clusterQueue, flavorMap, false, &testOracle{})
+			flvAssigner := New(wlInfo, clusterQueue, flavorMap, false, &testOracle{}, nil)
 
 			assignment := flvAssigner.Assign(log, nil)
 			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
@@ -2322,3 +2324,498 @@ func TestLastAssignmentOutdated(t *testing.T) {
 		})
 	}
 }
+
+func TestAssignFlavorsLocalQueueQuotas(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one": utiltesting.MakeResourceFlavor("one").Obj(),
+		"two": utiltesting.MakeResourceFlavor("two").Obj(),
+	}
+	cases := map[string]struct {
+		request          string
+		localQueueUsage  resources.FlavorResourceQuantities
+		withinCQ         kueue.PreemptionPolicy
+		enableLQQuotas   bool
+		wantRepMode      FlavorAssignmentMode
+		wantFlavor       kueue.ResourceFlavorReference
+		wantMessageEmpty bool
+	}{
+		"feature disabled, quotas ignored": {
+			request:          "6",
+			withinCQ:         kueue.PreemptionPolicyNever,
+			wantRepMode:      Fit,
+			wantFlavor:       "one",
+			wantMessageEmpty: true,
+		},
+		"fits in the LocalQueue quota": {
+			request:          "4",
+			withinCQ:         kueue.PreemptionPolicyNever,
+			enableLQQuotas:   true,
+			wantRepMode:      Fit,
+			wantFlavor:       "one",
+			wantMessageEmpty: true,
+		},
+		"request above the LocalQueue quota, falls back to the next flavor": {
+			request:          "6",
+			withinCQ:         kueue.PreemptionPolicyLowerPriority,
+			enableLQQuotas:   true,
+			wantRepMode:      Fit,
+			wantFlavor:       "two",
+			wantMessageEmpty: true,
+		},
+		"LocalQueue quota used in the first flavor, falls back to the next flavor": {
+			request: "4",
+			localQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+			},
+			withinCQ:         kueue.PreemptionPolicyNever,
+			enableLQQuotas:   true,
+			wantRepMode:      Fit,
+			wantFlavor:       "two",
+			wantMessageEmpty: true,
+		},
+		"LocalQueue quota used, preemption within the ClusterQueue disabled": {
+			request: "4",
+			localQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 6_000,
+			},
+			withinCQ:       kueue.PreemptionPolicyNever,
+			enableLQQuotas: true,
+			wantRepMode:    NoFit,
+		},
+		"LocalQueue quota used, can preempt within the ClusterQueue": {
+			request: "4",
+			localQueueUsage: resources.FlavorResourceQuantities{
+				{Flavor: "one", Resource: corev1.ResourceCPU}: 3_000,
+				{Flavor: "two", Resource: corev1.ResourceCPU}: 6_000,
+			},
+			withinCQ:       kueue.PreemptionPolicyLowerPriority,
+			enableLQQuotas: true,
+			wantRepMode:    Preempt,
+			wantFlavor:     "one",
+		},
+		"request above the LocalQueue quota in all flavors": {
+			request:        "9",
+			withinCQ:       kueue.PreemptionPolicyLowerPriority,
+			enableLQQuotas: true,
+			wantRepMode:    NoFit,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.LocalQueueQuotas, tc.enableLQQuotas)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("two").Resource(corev1.ResourceCPU, "10").Obj(),
+				).
+				Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: tc.withinCQ}).
+				Obj()
+			lq := utiltesting.MakeLocalQueue("lq", "ns").
+				ClusterQueue("cq").
+				Quota("one", corev1.ResourceCPU, "5").
+				Quota("two", corev1.ResourceCPU, "8").
+				Obj()
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Request(corev1.ResourceCPU, tc.request).
+				Obj())
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			if err := cache.AddLocalQueue(lq); err != nil {
+				t.Fatalf("Failed to add LQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			clusterQueue := snapshot.ClusterQueue("cq")
+			if lqSnapshot := clusterQueue.LocalQueueFor(wlInfo.Obj); lqSnapshot != nil {
+				for fr, v := range tc.localQueueUsage {
+					lqSnapshot.Usage[fr] += v
+				}
+			}
+
+			assignment := New(wlInfo, clusterQueue, resourceFlavors, false, &testOracle{}, nil).Assign(log, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
+				t.Errorf("RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
+			}
+			if tc.wantFlavor != "" {
+				if got := assignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; got != tc.wantFlavor {
+					t.Errorf("Assigned flavor %q, want %q", got, tc.wantFlavor)
+				}
+			}
+			if tc.wantMessageEmpty != (assignment.Message() == "") {
+				t.Errorf("Unexpected assignment message %q", assignment.Message())
+			}
+		})
+	}
+}
+
+type testFlavorPlugins struct {
+	rejected sets.Set[kueue.ResourceFlavorReference]
+	scores   map[kueue.ResourceFlavorReference]int64
+}
+
+func (p *testFlavorPlugins) FilterFlavor(_ *workload.Info, _ int, flavor *kueue.ResourceFlavor, _ resources.Requests) string {
+	if p.rejected.Has(kueue.ResourceFlavorReference(flavor.Name)) {
+		return "test: rejected"
+	}
+	return ""
+}
+
+func (p *testFlavorPlugins) ScoresFlavors() bool {
+	return p.scores != nil
+}
+
+func (p *testFlavorPlugins) ScoreFlavor(_ *workload.Info, _ int, flavor *kueue.ResourceFlavor, _ resources.Requests) int64 {
+	return p.scores[kueue.ResourceFlavorReference(flavor.Name)]
+}
+
+func TestAssignFlavorsWithPlugins(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one":   utiltesting.MakeResourceFlavor("one").Obj(),
+		"two":   utiltesting.MakeResourceFlavor("two").Obj(),
+		"three": utiltesting.MakeResourceFlavor("three").Obj(),
+	}
+	cases := map[string]struct {
+		request     string
+		plugins     *testFlavorPlugins
+		wantRepMode FlavorAssignmentMode
+		wantFlavor  kueue.ResourceFlavorReference
+	}{
+		"no plugins": {
+			request:     "2",
+			plugins:     &testFlavorPlugins{},
+			wantRepMode: Fit,
+			wantFlavor:  "one",
+		},
+		"filtered flavor": {
+			request:     "2",
+			plugins:     &testFlavorPlugins{rejected: sets.New[kueue.ResourceFlavorReference]("one")},
+			wantRepMode: Fit,
+			wantFlavor:  "two",
+		},
+		"all flavors filtered": {
+			request:     "2",
+			plugins:     &testFlavorPlugins{rejected: sets.New[kueue.ResourceFlavorReference]("one", "two", "three")},
+			wantRepMode: NoFit,
+		},
+		"highest scored fitting flavor": {
+			request:     "2",
+			plugins:     &testFlavorPlugins{scores: map[kueue.ResourceFlavorReference]int64{"one": 1, "two": 3, "three": 2}},
+			wantRepMode: Fit,
+			wantFlavor:  "two",
+		},
+		"highest scored flavor among the fitting ones": {
+			request:     "5",
+			plugins:     &testFlavorPlugins{scores: map[kueue.ResourceFlavorReference]int64{"one": 1, "two": 3, "three": 2}},
+			wantRepMode: Fit,
+			wantFlavor:  "three",
+		},
+		"earlier flavor on a tie": {
+			request:     "2",
+			plugins:     &testFlavorPlugins{scores: map[kueue.ResourceFlavorReference]int64{"one": 1, "two": 2, "three": 2}},
+			wantRepMode: Fit,
+			wantFlavor:  "two",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, log := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("two").Resource(corev1.ResourceCPU, "4").Obj(),
+					*utiltesting.MakeFlavorQuotas("three").Resource(corev1.ResourceCPU, "10").Obj(),
+				).
+				Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+				Obj()
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, tc.request).
+				Obj())
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			assignment := New(wlInfo, snapshot.ClusterQueue("cq"), resourceFlavors, false, &testOracle{}, tc.plugins).Assign(log, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
+				t.Errorf("RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
+			}
+			if tc.wantFlavor != "" {
+				if got := assignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; got != tc.wantFlavor {
+					t.Errorf("Assigned flavor %q, want %q", got, tc.wantFlavor)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsWithCosts(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"on-demand": utiltesting.MakeResourceFlavor("on-demand").Cost(corev1.ResourceCPU, "3").Obj(),
+		"spot":      utiltesting.MakeResourceFlavor("spot").Cost(corev1.ResourceCPU, "1").Obj(),
+		"reserved":  utiltesting.MakeResourceFlavor("reserved").Cost(corev1.ResourceCPU, "0.5").Obj(),
+	}
+	cases := map[string]struct {
+		disableFeature bool
+		policy         kueue.FlavorSelectionPolicy
+		request        string
+		wantRepMode    FlavorAssignmentMode
+		wantFlavor     kueue.ResourceFlavorReference
+		wantCost       *resource.Quantity
+	}{
+		"in order": {
+			policy:      kueue.InOrderFlavorSelection,
+			request:     "2",
+			wantRepMode: Fit,
+			wantFlavor:  "on-demand",
+			wantCost:    ptr.To(resource.MustParse("6")),
+		},
+		"lowest cost": {
+			policy:      kueue.LowestCostFlavorSelection,
+			request:     "2",
+			wantRepMode: Fit,
+			wantFlavor:  "reserved",
+			wantCost:    ptr.To(resource.MustParse("1")),
+		},
+		"lowest cost among the fitting flavors": {
+			policy:      kueue.LowestCostFlavorSelection,
+			request:     "5",
+			wantRepMode: Fit,
+			wantFlavor:  "spot",
+			wantCost:    ptr.To(resource.MustParse("5")),
+		},
+		"no fitting flavor": {
+			policy:      kueue.LowestCostFlavorSelection,
+			request:     "12",
+			wantRepMode: NoFit,
+		},
+		"feature disabled": {
+			disableFeature: true,
+			policy:         kueue.LowestCostFlavorSelection,
+			request:        "2",
+			wantRepMode:    Fit,
+			wantFlavor:     "on-demand",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.CostAwareFlavorSelection, !tc.disableFeature)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("reserved").Resource(corev1.ResourceCPU, "4").Obj(),
+				).
+				FlavorSelectionPolicy(tc.policy).
+				Obj()
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, tc.request).
+				Obj())
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			assignment := New(wlInfo, snapshot.ClusterQueue("cq"), resourceFlavors, false, &testOracle{}, nil).Assign(log, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
+				t.Errorf("RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
+			}
+			if tc.wantFlavor != "" {
+				if got := assignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; got != tc.wantFlavor {
+					t.Errorf("Assigned flavor %q, want %q", got, tc.wantFlavor)
+				}
+				if diff := cmp.Diff(tc.wantCost, assignment.Cost()); diff != "" {
+					t.Errorf("Unexpected cost (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestFlavorDecisions(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"tainted": utiltesting.MakeResourceFlavor("tainted").Taint(corev1.Taint{
+			Key:    "instance",
+			Value:  "gpu",
+			Effect: corev1.TaintEffectNoSchedule,
+		}).Cost(corev1.ResourceCPU, "1").Obj(),
+		"small": utiltesting.MakeResourceFlavor("small").Cost(corev1.ResourceCPU, "1").Obj(),
+		"large": utiltesting.MakeResourceFlavor("large").Cost(corev1.ResourceCPU, "3").Obj(),
+		"spot":  utiltesting.MakeResourceFlavor("spot").Cost(corev1.ResourceCPU, "2").Obj(),
+	}
+	cases := map[string]struct {
+		policy  kueue.FlavorSelectionPolicy
+		request string
+		want    []FlavorDecision
+	}{
+		"first flavor which fits": {
+			request: "4",
+			want: []FlavorDecision{{
+				PodSet:    kueue.DefaultPodSetName,
+				Resources: []corev1.ResourceName{corev1.ResourceCPU},
+				Attempts: []FlavorAttempt{
+					{
+						Flavor:    "tainted",
+						Mode:      NoFit,
+						Rejection: RejectedByTaints,
+						Message:   "untolerated taint {instance gpu NoSchedule <nil>} in flavor tainted",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 4000, Available: 10000}},
+					},
+					{
+						Flavor:    "small",
+						Mode:      NoFit,
+						Rejection: RejectedByQuota,
+						Message:   "insufficient quota for cpu in flavor small, request > maximum capacity (4 > 2)",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 4000, Available: 2000}},
+					},
+					{
+						Flavor: "large",
+						Mode:   Fit,
+						Quota:  []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 4000, Available: 10000}},
+					},
+				},
+				Flavor: "large",
+				Reason: FlavorFits,
+			}},
+		},
+		"flavors scored by cost": {
+			policy:  kueue.LowestCostFlavorSelection,
+			request: "4",
+			want: []FlavorDecision{{
+				PodSet:    kueue.DefaultPodSetName,
+				Resources: []corev1.ResourceName{corev1.ResourceCPU},
+				Attempts: []FlavorAttempt{
+					{
+						Flavor:    "tainted",
+						Mode:      NoFit,
+						Rejection: RejectedByTaints,
+						Message:   "untolerated taint {instance gpu NoSchedule <nil>} in flavor tainted",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 4000, Available: 10000}},
+					},
+					{
+						Flavor:    "small",
+						Mode:      NoFit,
+						Rejection: RejectedByQuota,
+						Message:   "insufficient quota for cpu in flavor small, request > maximum capacity (4 > 2)",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 4000, Available: 2000}},
+					},
+					{
+						Flavor: "large",
+						Mode:   Fit,
+						Quota:  []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 4000, Available: 10000}},
+					},
+					{
+						Flavor: "spot",
+						Mode:   Fit,
+						Quota:  []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 4000, Available: 10000}},
+					},
+				},
+				Flavor: "spot",
+				Reason: FlavorScored,
+			}},
+		},
+		"no flavor fits": {
+			request: "20",
+			want: []FlavorDecision{{
+				PodSet:    kueue.DefaultPodSetName,
+				Resources: []corev1.ResourceName{corev1.ResourceCPU},
+				Attempts: []FlavorAttempt{
+					{
+						Flavor:    "tainted",
+						Mode:      NoFit,
+						Rejection: RejectedByTaints,
+						Message:   "untolerated taint {instance gpu NoSchedule <nil>} in flavor tainted",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 20000, Available: 10000}},
+					},
+					{
+						Flavor:    "small",
+						Mode:      NoFit,
+						Rejection: RejectedByQuota,
+						Message:   "insufficient quota for cpu in flavor small, request > maximum capacity (20 > 2)",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 20000, Available: 2000}},
+					},
+					{
+						Flavor:    "large",
+						Mode:      NoFit,
+						Rejection: RejectedByQuota,
+						Message:   "insufficient quota for cpu in flavor large, request > maximum capacity (20 > 10)",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 20000, Available: 10000}},
+					},
+					{
+						Flavor:    "spot",
+						Mode:      NoFit,
+						Rejection: RejectedByQuota,
+						Message:   "insufficient quota for cpu in flavor spot, request > maximum capacity (20 > 10)",
+						Quota:     []ResourceQuotaCheck{{Resource: corev1.ResourceCPU, Requested: 20000, Available: 10000}},
+					},
+				},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.CostAwareFlavorSelection, true)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("tainted").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("small").Resource(corev1.ResourceCPU, "2").Obj(),
+					*utiltesting.MakeFlavorQuotas("large").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+				).
+				FlavorSelectionPolicy(tc.policy).
+				Obj()
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, tc.request).
+				Obj())
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			for _, rf := range resourceFlavors {
+				cache.AddOrUpdateResourceFlavor(rf)
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			assignment := New(wlInfo, snapshot.ClusterQueue("cq"), resourceFlavors, false, &testOracle{}, nil).WithQuotaChecks().Assign(log, nil)
+			if diff := cmp.Diff(tc.want, assignment.FlavorDecisions()); diff != "" {
+				t.Errorf("Unexpected flavor decisions (-want,+got):\n%s", diff)
+			}
+			assignment = New(wlInfo, snapshot.ClusterQueue("cq"), resourceFlavors, false, &testOracle{}, nil).Assign(log, nil)
+			if got := assignment.FlavorDecisions(); got != nil {
+				t.Errorf("Unexpected flavor decisions recorded without WithDecisions: %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+type weightedFlavorScorePlugin struct {
+	FlavorScorePlugin
+	weight int64
+}
+
+// Framework runs the plugins enabled at each extension point.
+// A nil Framework runs no plugins. A Framework is safe for concurrent use
+// when its plugins are, see Plugin.
+type Framework struct {
+	flavorFilterPlugins []FlavorFilterPlugin
+	flavorScorePlugins  []weightedFlavorScorePlugin
+	queueSortPlugin     QueueSortPlugin
+	postFilterPlugins   []PostFilterPlugin
+}
+
+var _ flavorassigner.FlavorPlugins = (*Framework)(nil)
+
+// New builds the plugins enabled in the configuration, out of the registry.
+func New(registry Registry, cfg *config.Scheduler, c client.Client) (*Framework, error) {
+	f := &Framework{}
+	if cfg == nil || cfg.Plugins == nil {
+		return f, nil
+	}
+	plugins := make(map[string]Plugin)
+	get := func(name string) (Plugin, error) {
+		if p, found := plugins[name]; found {
+			return p, nil
+		}
+		factory, found := registry[name]
+		if !found {
+			return nil, fmt.Errorf("plugin %q not registered", name)
+		}
+		p, err := factory(c)
+		if err != nil {
+			return nil, fmt.Errorf("building plugin %q: %w", name, err)
+		}
+		plugins[name] = p
+		return p, nil
+	}
+	for _, pc := range cfg.Plugins.FlavorFilter {
+		p, err := get(pc.Name)
+		if err != nil {
+			return nil, err
+		}
+		filter, ok := p.(FlavorFilterPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q doesn't extend flavorFilter", pc.Name)
+		}
+		f.flavorFilterPlugins = append(f.flavorFilterPlugins, filter)
+	}
+	for _, pc := range cfg.Plugins.FlavorScore {
+		p, err := get(pc.Name)
+		if err != nil {
+			return nil, err
+		}
+		score, ok := p.(FlavorScorePlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q doesn't extend flavorScore", pc.Name)
+		}
+		weight := int64(ptr.Deref(pc.Weight, config.DefaultSchedulerPluginWeight))
+		f.flavorScorePlugins = append(f.flavorScorePlugins, weightedFlavorScorePlugin{FlavorScorePlugin: score, weight: weight})
+	}
+	for _, pc := range cfg.Plugins.QueueSort {
+		p, err := get(pc.Name)
+		if err != nil {
+			return nil, err
+		}
+		queueSort, ok := p.(QueueSortPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q doesn't extend queueSort", pc.Name)
+		}
+		f.queueSortPlugin = queueSort
+	}
+	for _, pc := range cfg.Plugins.PostFilter {
+		p, err := get(pc.Name)
+		if err != nil {
+			return nil, err
+		}
+		postFilter, ok := p.(PostFilterPlugin)
+		if !ok {
+			return nil, fmt.Errorf("plugin %q doesn't extend postFilter", pc.Name)
+		}
+		f.postFilterPlugins = append(f.postFilterPlugins, postFilter)
+	}
+	return f, nil
+}
+
+// FilterFlavor runs the flavorFilter plugins, returning the reason of the
+// first plugin rejecting the flavor, prefixed with the plugin name.
+func (f *Framework) FilterFlavor(wl *workload.Info, psID int, flavor *kueue.ResourceFlavor, requests resources.Requests) string {
+	if f == nil {
+		return ""
+	}
+	for _, p := range f.flavorFilterPlugins {
+		if reason := p.FilterFlavor(wl, psID, flavor, requests); reason != "" {
+			return fmt.Sprintf("%s: %s", p.Name(), reason)
+		}
+	}
+	return ""
+}
+
+// ScoresFlavors returns whether any flavorScore plugin is enabled.
+func (f *Framework) ScoresFlavors() bool {
+	return f != nil && len(f.flavorScorePlugins) > 0
+}
+
+// ScoreFlavor returns the sum of the weighted scores of the flavorScore
+// plugins.
+func (f *Framework) ScoreFlavor(wl *workload.Info, psID int, flavor *kueue.ResourceFlavor, requests resources.Requests) int64 {
+	if f == nil {
+		return 0
+	}
+	var score int64
+	for _, p := range f.flavorScorePlugins {
+		score += p.weight * p.ScoreFlavor(wl, psID, flavor, requests)
+	}
+	return score
+}
+
+// QueueSortPlugin returns the queueSort plugin, or nil if none is enabled.
+func (f *Framework) QueueSortPlugin() QueueSortPlugin {
+	if f == nil {
+		return nil
+	}
+	return f.queueSortPlugin
+}
+
+// PostFilter runs the postFilter plugins, returning the preemption targets
+// of the first plugin finding any.
+func (f *Framework) PostFilter(log logr.Logger, wl *workload.Info, assignment flavorassigner.Assignment, snap *cache.Snapshot) []*preemption.Target {
+	if f == nil {
+		return nil
+	}
+	for _, p := range f.postFilterPlugins {
+		if targets := p.PostFilter(log, wl, assignment, snap); len(targets) > 0 {
+			log.V(3).Info("Preemption targets selected by plugin", "plugin", p.Name(), "targets", len(targets))
+			return targets
+		}
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+type testPlugin struct {
+	name   string
+	reject kueue.ResourceFlavorReference
+	score  int64
+}
+
+func (p *testPlugin) Name() string {
+	return p.name
+}
+
+func (p *testPlugin) FilterFlavor(_ *workload.Info, _ int, flavor *kueue.ResourceFlavor, _ resources.Requests) string {
+	if kueue.ResourceFlavorReference(flavor.Name) == p.reject {
+		return "rejected"
+	}
+	return ""
+}
+
+func (p *testPlugin) ScoreFlavor(*workload.Info, int, *kueue.ResourceFlavor, resources.Requests) int64 {
+	return p.score
+}
+
+func (p *testPlugin) PostFilter(logr.Logger, *workload.Info, flavorassigner.Assignment, *cache.Snapshot) []*preemption.Target {
+	return nil
+}
+
+type testQueueSortPlugin struct{}
+
+func (testQueueSortPlugin) Name() string {
+	return "sort"
+}
+
+func (testQueueSortPlugin) Less(a, b *workload.Info) bool {
+	return a.Obj.Name < b.Obj.Name
+}
+
+func testRegistry() Registry {
+	r := NewInTreeRegistry()
+	for _, p := range []*testPlugin{
+		{name: "a", reject: "one", score: 1},
+		{name: "b", reject: "two", score: 10},
+	} {
+		_ = r.Register(p.name, func(client.Client) (Plugin, error) { return p, nil })
+	}
+	_ = r.Register("sort", func(client.Client) (Plugin, error) { return testQueueSortPlugin{}, nil })
+	return r
+}
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		plugins *config.SchedulerPlugins
+		wantErr bool
+	}{
+		"no plugins": {},
+		"valid plugins": {
+			plugins: &config.SchedulerPlugins{
+				FlavorFilter: []config.SchedulerPlugin{{Name: "a"}, {Name: "b"}},
+				FlavorScore:  []config.SchedulerPlugin{{Name: "a"}},
+				QueueSort:    []config.SchedulerPlugin{{Name: "sort"}},
+				PostFilter:   []config.SchedulerPlugin{{Name: "b"}},
+			},
+		},
+		"unregistered plugin": {
+			plugins: &config.SchedulerPlugins{
+				FlavorFilter: []config.SchedulerPlugin{{Name: "c"}},
+			},
+			wantErr: true,
+		},
+		"plugin not extending the extension point": {
+			plugins: &config.SchedulerPlugins{
+				QueueSort: []config.SchedulerPlugin{{Name: "a"}},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(testRegistry(), &config.Scheduler{Plugins: tc.plugins}, nil)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Unexpected error: %v, want error: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := testRegistry().Register("a", nil); err == nil {
+		t.Error("Expected an error registering a plugin twice")
+	}
+}
+
+// outOfTreePlugin is registered like the plugins built out of the tree of
+// Kueue, from the init function of its package.
+type outOfTreePlugin struct{}
+
+func (outOfTreePlugin) Name() string {
+	return "out-of-tree"
+}
+
+func (outOfTreePlugin) FilterFlavor(_ *workload.Info, _ int, flavor *kueue.ResourceFlavor, _ resources.Requests) string {
+	if flavor.Name == "reserved" {
+		return "the flavor is reserved"
+	}
+	return ""
+}
+
+func init() {
+	if err := Register("out-of-tree", func(client.Client) (Plugin, error) { return outOfTreePlugin{}, nil }); err != nil {
+		panic(err)
+	}
+}
+
+func TestRunOutOfTreePlugin(t *testing.T) {
+	if !IsRegistered("out-of-tree") {
+		t.Fatal("Expected the out-of-tree plugin to be registered")
+	}
+	if err := Register("out-of-tree", nil); err == nil {
+		t.Error("Expected an error registering a plugin twice")
+	}
+	f, err := New(NewInTreeRegistry(), &config.Scheduler{
+		Plugins: &config.SchedulerPlugins{
+			FlavorFilter: []config.SchedulerPlugin{{Name: "out-of-tree"}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wl := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").Obj())
+	if got := f.FilterFlavor(wl, 0, utiltesting.MakeResourceFlavor("reserved").Obj(), nil); got != "out-of-tree: the flavor is reserved" {
+		t.Errorf("Unexpected filter reason %q", got)
+	}
+	if got := f.FilterFlavor(wl, 0, utiltesting.MakeResourceFlavor("default").Obj(), nil); got != "" {
+		t.Errorf("Unexpected filter reason %q", got)
+	}
+}
+
+func TestRunPlugins(t *testing.T) {
+	f, err := New(testRegistry(), &config.Scheduler{
+		Plugins: &config.SchedulerPlugins{
+			FlavorFilter: []config.SchedulerPlugin{{Name: "a"}, {Name: "b"}},
+			FlavorScore: []config.SchedulerPlugin{
+				{Name: "a", Weight: ptr.To[int32](3)},
+				{Name: "b", Weight: ptr.To[int32](1)},
+			},
+			QueueSort: []config.SchedulerPlugin{{Name: "sort"}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wl := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").Obj())
+	if got := f.FilterFlavor(wl, 0, utiltesting.MakeResourceFlavor("two").Obj(), nil); got != "b: rejected" {
+		t.Errorf("Unexpected filter reason %q", got)
+	}
+	if got := f.FilterFlavor(wl, 0, utiltesting.MakeResourceFlavor("three").Obj(), nil); got != "" {
+		t.Errorf("Unexpected filter reason %q", got)
+	}
+	if !f.ScoresFlavors() {
+		t.Error("Expected the flavors to be scored")
+	}
+	if got := f.ScoreFlavor(wl, 0, utiltesting.MakeResourceFlavor("three").Obj(), nil); got != 13 {
+		t.Errorf("Unexpected score %d, want 13", got)
+	}
+	if f.QueueSortPlugin() == nil {
+		t.Error("Expected a queueSort plugin")
+	}
+
+	var nilFramework *Framework
+	if nilFramework.ScoresFlavors() || nilFramework.QueueSortPlugin() != nil || nilFramework.FilterFlavor(wl, 0, utiltesting.MakeResourceFlavor("one").Obj(), nil) != "" {
+		t.Error("Expected a nil framework to run no plugins")
+	}
+}
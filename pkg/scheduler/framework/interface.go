@@ -0,0 +1,80 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework provides the extension points of the scheduler, through
+// which plugins can filter and score the resource flavors, order the
+// nominated workloads, and select the workloads to preempt.
+package framework
+
+import (
+	"github.com/go-logr/logr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// Plugin is the parent type of all the scheduler plugins.
+//
+// A single instance of each plugin is shared by the whole scheduler. When the
+// ParallelScheduling feature gate is enabled, the plugins are called
+// concurrently for the ClusterQueues of independent cohort trees, so they
+// must be safe for concurrent use: any state kept across calls must be
+// synchronized by the plugin. The plugins must not modify the workloads,
+// flavors, assignments or snapshots they are given; the snapshot passed to
+// PostFilter only holds the cohort tree of the workload.
+type Plugin interface {
+	Name() string
+}
+
+// FlavorFilterPlugin can reject a resource flavor for the resources of a pod
+// set, on top of the taints and the node affinity.
+type FlavorFilterPlugin interface {
+	Plugin
+	// FilterFlavor returns the reason why the flavor can't be assigned to the
+	// requests of the pod set with index psID, or an empty string if it can.
+	FilterFlavor(wl *workload.Info, psID int, flavor *kueue.ResourceFlavor, requests resources.Requests) string
+}
+
+// FlavorScorePlugin scores the resource flavors for the resources of a pod
+// set. The flavor with the highest weighted score is chosen among the flavors
+// satisfying the flavor fungibility policy of the ClusterQueue.
+type FlavorScorePlugin interface {
+	Plugin
+	// ScoreFlavor returns the score of assigning the flavor to the requests
+	// of the pod set with index psID.
+	ScoreFlavor(wl *workload.Info, psID int, flavor *kueue.ResourceFlavor, requests resources.Requests) int64
+}
+
+// QueueSortPlugin orders the workloads nominated in a scheduling cycle.
+type QueueSortPlugin interface {
+	Plugin
+	// Less returns whether the workload a should be considered for admission
+	// before the workload b.
+	Less(a, b *workload.Info) bool
+}
+
+// PostFilterPlugin selects the workloads to preempt for a workload which
+// doesn't fit.
+type PostFilterPlugin interface {
+	Plugin
+	// PostFilter returns the workloads to preempt so that the workload can
+	// be admitted with the assignment, or none if the plugin can't find any.
+	PostFilter(log logr.Logger, wl *workload.Info, assignment flavorassigner.Assignment, snap *cache.Snapshot) []*preemption.Target
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PluginFactory builds a plugin.
+type PluginFactory func(c client.Client) (Plugin, error)
+
+// Registry maps the names of the plugins to their factories.
+type Registry map[string]PluginFactory
+
+var (
+	registeredMu sync.RWMutex
+	registered   = Registry{}
+)
+
+// Register adds the plugin factory to the plugins available to the scheduler.
+// Out-of-tree plugins are registered from the init function of their package,
+// which is imported by the build of Kueue, so that they are known when the
+// configuration is loaded and validated.
+func Register(name string, factory PluginFactory) error {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	return registered.Register(name, factory)
+}
+
+// IsRegistered returns whether a plugin is registered with the name.
+func IsRegistered(name string) bool {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+	_, found := registered[name]
+	return found
+}
+
+// RegisteredNames returns the sorted names of the registered plugins.
+func RegisteredNames() []string {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+	return slices.Sorted(maps.Keys(registered))
+}
+
+// NewInTreeRegistry returns a registry with the plugins registered with
+// Register.
+func NewInTreeRegistry() Registry {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+	return maps.Clone(registered)
+}
+
+// Register adds the plugin factory to the registry.
+func (r Registry) Register(name string, factory PluginFactory) error {
+	if _, found := r[name]; found {
+		return fmt.Errorf("plugin %q already registered", name)
+	}
+	r[name] = factory
+	return nil
+}
@@ -17,14 +17,29 @@ limitations under the License.
 package scheduler
 
 import (
+	"context"
+	"time"
+
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 )
 
+// Outcomes of the scheduling decisions.
+const (
+	decisionAdmitted     = "Admitted"
+	decisionPreempting   = "Preempting"
+	decisionSkipped      = "Skipped"
+	decisionInadmissible = "Inadmissible"
+)
+
 func logAdmissionAttemptIfVerbose(log logr.Logger, e *entry) {
 	logV := log.V(3)
 	if !logV.Enabled() {
@@ -52,3 +67,82 @@ func logSnapshotIfVerbose(log logr.Logger, s *cache.Snapshot) {
 func getWorkloadReferences(targets []*preemption.Target) []klog.ObjectRef {
 	return slices.Map(targets, func(t **preemption.Target) klog.ObjectRef { return klog.KObj((*t).WorkloadInfo.Obj) })
 }
+
+// recordDecisions writes the decisions taken for the entries of the
+// scheduling cycle to the decision sinks, if any.
+func (s *Scheduler) recordDecisions(ctx context.Context, startTime time.Time, entries []entry) {
+	if len(s.decisionSinks) == 0 || len(entries) == 0 {
+		return
+	}
+	decisions := make([]visibility.SchedulingDecision, 0, len(entries))
+	for i := range entries {
+		decisions = append(decisions, newSchedulingDecision(&entries[i], s.schedulingCycle, startTime))
+	}
+	for _, sink := range s.decisionSinks {
+		sink.Write(ctx, decisions)
+	}
+}
+
+func newSchedulingDecision(e *entry, cycle int64, startTime time.Time) visibility.SchedulingDecision {
+	mode := e.assignment.RepresentativeMode()
+	decision := visibility.SchedulingDecision{
+		Time:            metav1.NewTime(startTime),
+		SchedulingCycle: cycle,
+		Workload: visibility.WorkloadReference{
+			Namespace: e.Obj.Namespace,
+			Name:      e.Obj.Name,
+		},
+		ClusterQueue: string(e.ClusterQueue),
+		Priority:     e.priority(),
+		Mode:         mode.String(),
+		Borrowing:    e.assignment.Borrows(),
+		Outcome:      decisionOutcome(e, mode),
+		Message:      e.inadmissibleMsg,
+	}
+	for _, d := range e.assignment.FlavorDecisions() {
+		if n := len(decision.PodSets); n == 0 || decision.PodSets[n-1].Name != string(d.PodSet) {
+			decision.PodSets = append(decision.PodSets, visibility.PodSetDecision{Name: string(d.PodSet)})
+		}
+		rg := visibility.ResourceGroupDecision{
+			Resources: d.Resources,
+			Attempts:  make([]visibility.FlavorAttempt, 0, len(d.Attempts)),
+			Flavor:    string(d.Flavor),
+			Reason:    string(d.Reason),
+		}
+		for _, a := range d.Attempts {
+			rg.Attempts = append(rg.Attempts, visibility.FlavorAttempt{
+				Flavor:    string(a.Flavor),
+				Mode:      a.Mode.String(),
+				Borrowing: a.Borrowing,
+				Message:   a.Message,
+			})
+		}
+		ps := &decision.PodSets[len(decision.PodSets)-1]
+		ps.ResourceGroups = append(ps.ResourceGroups, rg)
+	}
+	for _, target := range e.preemptionTargets {
+		decision.PreemptionTargets = append(decision.PreemptionTargets, visibility.PreemptionTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.WorkloadInfo.Obj.Name,
+				Namespace: target.WorkloadInfo.Obj.Namespace,
+				UID:       target.WorkloadInfo.Obj.UID,
+			},
+			ClusterQueue: string(target.WorkloadInfo.ClusterQueue),
+			Priority:     priority.Priority(target.WorkloadInfo.Obj),
+			Reason:       target.Reason,
+		})
+	}
+	return decision
+}
+
+func decisionOutcome(e *entry, mode flavorassigner.FlavorAssignmentMode) string {
+	switch {
+	case e.status == assumed:
+		return decisionAdmitted
+	case e.status == skipped:
+		return decisionSkipped
+	case e.status == notNominated && mode == flavorassigner.Preempt && len(e.preemptionTargets) > 0:
+		return decisionPreempting
+	}
+	return decisionInadmissible
+}
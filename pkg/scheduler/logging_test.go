@@ -0,0 +1,288 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/decisionlog"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/routine"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestScheduleRecordsDecisions(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	ctx, _ := utiltesting.ContextWithLog(t)
+
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("small").Resource(corev1.ResourceCPU, "1").Obj(),
+				*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj(),
+			).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-c").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+	}
+	queues := []kueue.LocalQueue{
+		*utiltesting.MakeLocalQueue("a", "default").ClusterQueue("cq-a").Obj(),
+		*utiltesting.MakeLocalQueue("b", "default").ClusterQueue("cq-b").Obj(),
+		*utiltesting.MakeLocalQueue("c", "default").ClusterQueue("cq-c").Obj(),
+	}
+	admitted := utiltesting.MakeWorkload("low", "default").
+		Queue("a").
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+		Obj()
+	pending := []kueue.Workload{
+		*utiltesting.MakeWorkload("high", "default").Queue("a").Priority(100).Request(corev1.ResourceCPU, "2").Obj(),
+		*utiltesting.MakeWorkload("fits", "default").Queue("b").Request(corev1.ResourceCPU, "2").Obj(),
+		*utiltesting.MakeWorkload("big", "default").Queue("c").Request(corev1.ResourceCPU, "6").Obj(),
+	}
+
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: append(pending, *admitted)}, &kueue.LocalQueueList{Items: queues}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}).
+		Build()
+	cqCache := cache.New(cl, cache.WithClock(fakeClock))
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("small").Obj())
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+		}
+	}
+	for _, q := range queues {
+		if err := qManager.AddLocalQueue(ctx, &q); err != nil {
+			t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+		}
+	}
+	cqCache.AddOrUpdateWorkload(admitted)
+
+	ring := decisionlog.NewRing(10)
+	scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{}, WithClock(t, fakeClock), WithDecisionSinks(ring))
+	scheduler.applyAdmission = func(context.Context, *kueue.Workload) error { return nil }
+	scheduler.preemptor.OverrideApply(func(context.Context, *kueue.Workload, string, string) error { return nil })
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	defer cancel()
+	scheduler.schedule(ctx)
+	wg.Wait()
+
+	got := ring.List(func(*visibility.SchedulingDecision) bool { return true })
+	sort.Slice(got, func(i, j int) bool { return got[i].ClusterQueue < got[j].ClusterQueue })
+	want := []visibility.SchedulingDecision{
+		{
+			Time:            metav1.NewTime(now),
+			SchedulingCycle: 1,
+			Workload:        visibility.WorkloadReference{Namespace: "default", Name: "high"},
+			ClusterQueue:    "cq-a",
+			Priority:        100,
+			Mode:            "Preempt",
+			PodSets: []visibility.PodSetDecision{{
+				Name: "main",
+				ResourceGroups: []visibility.ResourceGroupDecision{{
+					Resources: []corev1.ResourceName{corev1.ResourceCPU},
+					Attempts: []visibility.FlavorAttempt{
+						{Flavor: "default", Mode: "Preempt", Message: "insufficient unused quota for cpu in flavor default, 1 more needed"},
+					},
+					Flavor: "default",
+					Reason: "BestMode",
+				}},
+			}},
+			PreemptionTargets: []visibility.PreemptionTarget{{
+				ObjectMeta:   metav1.ObjectMeta{Name: "low", Namespace: "default"},
+				ClusterQueue: "cq-a",
+				Reason:       kueue.InClusterQueueReason,
+			}},
+			Outcome: decisionPreempting,
+			Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed. Pending the preemption of 1 workload(s)",
+		},
+		{
+			Time:            metav1.NewTime(now),
+			SchedulingCycle: 1,
+			Workload:        visibility.WorkloadReference{Namespace: "default", Name: "fits"},
+			ClusterQueue:    "cq-b",
+			Mode:            "Fit",
+			PodSets: []visibility.PodSetDecision{{
+				Name: "main",
+				ResourceGroups: []visibility.ResourceGroupDecision{{
+					Resources: []corev1.ResourceName{corev1.ResourceCPU},
+					Attempts: []visibility.FlavorAttempt{
+						{Flavor: "small", Mode: "NoFit", Message: "insufficient quota for cpu in flavor small, request > maximum capacity (2 > 1)"},
+						{Flavor: "default", Mode: "Fit"},
+					},
+					Flavor: "default",
+					Reason: "Fit",
+				}},
+			}},
+			Outcome: decisionAdmitted,
+		},
+		{
+			Time:            metav1.NewTime(now),
+			SchedulingCycle: 1,
+			Workload:        visibility.WorkloadReference{Namespace: "default", Name: "big"},
+			ClusterQueue:    "cq-c",
+			Mode:            "NoFit",
+			PodSets: []visibility.PodSetDecision{{
+				Name: "main",
+				ResourceGroups: []visibility.ResourceGroupDecision{{
+					Resources: []corev1.ResourceName{corev1.ResourceCPU},
+					Attempts: []visibility.FlavorAttempt{
+						{Flavor: "default", Mode: "NoFit", Message: "insufficient quota for cpu in flavor default, request > maximum capacity (6 > 4)"},
+					},
+				}},
+			}},
+			Outcome: decisionInadmissible,
+			Message: "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor default, request > maximum capacity (6 > 4)",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected decisions (-want,+got):\n%s", diff)
+	}
+}
+
+func TestScheduleRecordsBackfilledDecisions(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.BackfillScheduling, true)
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	ctx, _ := utiltesting.ContextWithLog(t)
+
+	cq := utiltesting.MakeClusterQueue("cq").
+		QueueingStrategy(kueue.StrictFIFO).
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "default").ClusterQueue("cq").Obj()
+	running := utiltesting.MakeWorkload("running", "default").
+		Queue("lq").
+		MaximumExecutionTimeSeconds(600).
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+		AdmittedAt(true, now).
+		Obj()
+	pending := []kueue.Workload{
+		*utiltesting.MakeWorkload("head", "default").Queue("lq").Creation(now.Add(-2*time.Second)).Request(corev1.ResourceCPU, "2").Obj(),
+		*utiltesting.MakeWorkload("short", "default").Queue("lq").Creation(now.Add(-time.Second)).MaximumExecutionTimeSeconds(300).Request(corev1.ResourceCPU, "1").Obj(),
+	}
+
+	cl := utiltesting.NewClientBuilder().
+		WithLists(&kueue.WorkloadList{Items: append(pending, *running)}, &kueue.LocalQueueList{Items: []kueue.LocalQueue{*lq}}).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}).
+		Build()
+	cqCache := cache.New(cl, cache.WithClock(fakeClock))
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+	}
+	if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+	}
+	if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+		t.Fatalf("Inserting queue %s/%s in manager: %v", lq.Namespace, lq.Name, err)
+	}
+	cqCache.AddOrUpdateWorkload(running)
+
+	ring := decisionlog.NewRing(10)
+	scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{}, WithClock(t, fakeClock), WithDecisionSinks(ring))
+	scheduler.applyAdmission = func(context.Context, *kueue.Workload) error { return nil }
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	defer cancel()
+	scheduler.schedule(ctx)
+	wg.Wait()
+
+	got := ring.List(func(*visibility.SchedulingDecision) bool { return true })
+	want := []visibility.SchedulingDecision{
+		{
+			Time:            metav1.NewTime(now),
+			SchedulingCycle: 1,
+			Workload:        visibility.WorkloadReference{Namespace: "default", Name: "head"},
+			ClusterQueue:    "cq",
+			Mode:            "Preempt",
+			PodSets: []visibility.PodSetDecision{{
+				Name: "main",
+				ResourceGroups: []visibility.ResourceGroupDecision{{
+					Resources: []corev1.ResourceName{corev1.ResourceCPU},
+					Attempts: []visibility.FlavorAttempt{
+						{Flavor: "default", Mode: "Preempt", Message: "insufficient unused quota for cpu in flavor default, 1 more needed"},
+					},
+					Flavor: "default",
+					Reason: "BestMode",
+				}},
+			}},
+			Outcome: decisionInadmissible,
+			Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+		},
+		{
+			Time:            metav1.NewTime(now),
+			SchedulingCycle: 1,
+			Workload:        visibility.WorkloadReference{Namespace: "default", Name: "short"},
+			ClusterQueue:    "cq",
+			Mode:            "Fit",
+			PodSets: []visibility.PodSetDecision{{
+				Name: "main",
+				ResourceGroups: []visibility.ResourceGroupDecision{{
+					Resources: []corev1.ResourceName{corev1.ResourceCPU},
+					Attempts: []visibility.FlavorAttempt{
+						{Flavor: "default", Mode: "Fit"},
+					},
+					Flavor: "default",
+					Reason: "Fit",
+				}},
+			}},
+			Outcome: decisionAdmitted,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected decisions (-want,+got):\n%s", diff)
+	}
+}
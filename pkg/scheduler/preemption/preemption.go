@@ -32,6 +32,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,6 +40,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
@@ -50,6 +52,9 @@ import (
 
 const parallelPreemptions = 8
 
+// Preemptor selects and issues the preemptions of the workloads. It is safe
+// for concurrent use, the state of each search for targets is kept in a
+// preemptionCtx.
 type Preemptor struct {
 	clock clock.Clock
 
@@ -122,6 +127,14 @@ type Target struct {
 	Reason       string
 }
 
+// ProtectedWorkload is an admitted workload which can't be preempted yet,
+// because it didn't run for its minimum runtime.
+type ProtectedWorkload struct {
+	WorkloadInfo *workload.Info
+	// Until is the time at which the protection ends.
+	Until time.Time
+}
+
 // GetTargets returns the list of workloads that should be evicted in
 // order to make room for wl.
 func (p *Preemptor) GetTargets(log logr.Logger, wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*Target {
@@ -138,12 +151,87 @@ func (p *Preemptor) GetTargets(log logr.Logger, wl workload.Info, assignment fla
 	})
 }
 
+// GetMaxAdmittedWorkloadsTargets returns the workloads to preempt, besides the
+// targets, for count workloads of the LocalQueue of wl to be admitted without
+// exceeding the maximum number of admitted workloads of the ClusterQueue and
+// the LocalQueue. The candidates are the workloads of the ClusterQueue that
+// can be preempted as per the withinClusterQueue policy. It returns nil if
+// preempting them isn't enough.
+func (p *Preemptor) GetMaxAdmittedWorkloadsTargets(log logr.Logger, wl workload.Info, count int, snapshot *cache.Snapshot, targets []*Target) []*Target {
+	cq := snapshot.ClusterQueue(wl.ClusterQueue)
+	if cq == nil || cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyNever {
+		return nil
+	}
+	excluded := sets.New[string]()
+	targetInfos := make([]*workload.Info, 0, len(targets))
+	for _, t := range targets {
+		excluded.Insert(workload.Key(t.WorkloadInfo.Obj))
+		targetInfos = append(targetInfos, t.WorkloadInfo)
+	}
+	defer cq.SimulateUsageRemoval(targetInfos)()
+
+	_, lqReached := cq.MaxAdmittedWorkloadsReached(wl.Obj, count)
+	now := p.clock.Now()
+	wlPriority := p.preemptorPriority(wl.Obj, cq, now)
+	considerSamePrio := cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyLowerOrNewerEqualPriority
+	preemptorTS := p.workloadOrdering.GetQueueOrderTimestamp(wl.Obj)
+	var candidates []*workload.Info
+	for key, candidateWl := range cq.Workloads {
+		if excluded.Has(key) {
+			continue
+		}
+		// When the LocalQueue reached its maximum, only the workloads of the
+		// LocalQueue free a slot for the workload.
+		if lqReached && workload.QueueKey(candidateWl.Obj) != workload.QueueKey(wl.Obj) {
+			continue
+		}
+		candidatePriority := priority.Priority(candidateWl.Obj)
+		if candidatePriority > wlPriority {
+			continue
+		}
+		if candidatePriority == wlPriority && !(considerSamePrio && preemptorTS.Before(p.workloadOrdering.GetQueueOrderTimestamp(candidateWl.Obj))) {
+			continue
+		}
+		if _, isProtected := protectedUntil(candidateWl.Obj, cq, now); isProtected {
+			continue
+		}
+		candidates = append(candidates, candidateWl)
+	}
+	sort.Slice(candidates, candidatesOrdering(snapshot, candidates, cq.Name, now))
+
+	var newTargets []*Target
+	for _, candidate := range candidates {
+		if cqReached, lqReached := cq.MaxAdmittedWorkloadsReached(wl.Obj, count); !cqReached && !lqReached {
+			break
+		}
+		defer cq.SimulateUsageRemoval([]*workload.Info{candidate})()
+		newTargets = append(newTargets, &Target{WorkloadInfo: candidate, Reason: kueue.InClusterQueueReason})
+	}
+	if cqReached, lqReached := cq.MaxAdmittedWorkloadsReached(wl.Obj, count); cqReached || lqReached {
+		log.V(3).Info("Preempting workloads can't free enough slots for the workload", "candidates", len(candidates))
+		return nil
+	}
+	return newTargets
+}
+
+// ProtectedWorkloads returns the admitted workloads which would be candidates
+// for preemption to make room for wl, if they weren't protected by their
+// minimum runtime.
+func (p *Preemptor) ProtectedWorkloads(wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*ProtectedWorkload {
+	cq := snapshot.ClusterQueue(wl.ClusterQueue)
+	if cq == nil {
+		return nil
+	}
+	_, protected := p.findCandidates(wl.Obj, cq, flavorResourcesNeedPreemption(assignment))
+	return protected
+}
+
 func (p *Preemptor) getTargets(preemptionCtx *preemptionCtx) []*Target {
-	candidates := p.findCandidates(preemptionCtx.preemptor.Obj, preemptionCtx.preemptorCQ, preemptionCtx.frsNeedPreemption)
+	candidates, _ := p.findCandidates(preemptionCtx.preemptor.Obj, preemptionCtx.preemptorCQ, preemptionCtx.frsNeedPreemption)
 	if len(candidates) == 0 {
 		return nil
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, preemptionCtx.preemptorCQ.Name, p.clock.Now()))
+	sort.Slice(candidates, candidatesOrdering(preemptionCtx.snapshot, candidates, preemptionCtx.preemptorCQ.Name, p.clock.Now()))
 
 	sameQueueCandidates := candidatesOnlyFromQueue(candidates, preemptionCtx.preemptorCQ.Name)
 
@@ -234,7 +322,7 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, preemptor *workload.In
 	defer cancel()
 	workqueue.ParallelizeUntil(ctx, parallelPreemptions, len(targets), func(i int) {
 		target := targets[i]
-		if !meta.IsStatusConditionTrue(target.WorkloadInfo.Obj.Status.Conditions, kueue.WorkloadEvicted) {
+		if !meta.IsStatusConditionTrue(target.WorkloadInfo.Obj.Status.Conditions, kueue.WorkloadEvicted) && !workload.IsPreemptionPending(target.WorkloadInfo.Obj) {
 			message := preemptionMessage(preemptor.Obj, target.Reason)
 			err := p.applyPreemption(ctx, target.WorkloadInfo.Obj, target.Reason, message)
 			if err != nil {
@@ -255,6 +343,12 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, preemptor *workload.In
 
 func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w *kueue.Workload, reason, message string) error {
 	w = w.DeepCopy()
+	if features.Enabled(features.GracefulPreemption) {
+		// The workload keeps its quota until the job acknowledges the
+		// graceful stop or the grace period expires.
+		workload.SetPreemptionPendingCondition(w, reason, message)
+		return workload.ApplyAdmissionStatus(ctx, p.client, w, true, p.clock)
+	}
 	workload.SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, message)
 	workload.ResetChecksOnEviction(w, p.clock.Now())
 	workload.SetPreemptedCondition(w, reason, message)
@@ -516,9 +610,11 @@ func flavorResourcesNeedPreemption(assignment flavorassigner.Assignment) sets.Se
 // findCandidates obtains candidates for preemption within the ClusterQueue and
 // cohort that respect the preemption policy and are using a resource that the
 // preempting workload needs.
-func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[resources.FlavorResource]) []*workload.Info {
+func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[resources.FlavorResource]) ([]*workload.Info, []*ProtectedWorkload) {
 	var candidates []*workload.Info
-	wlPriority := priority.Priority(wl)
+	var protected []*ProtectedWorkload
+	now := p.clock.Now()
+	wlPriority := p.preemptorPriority(wl, cq, now)
 
 	if cq.Preemption.WithinClusterQueue != kueue.PreemptionPolicyNever {
 		considerSamePrio := (cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyLowerOrNewerEqualPriority)
@@ -537,6 +633,10 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 			if !workloadUsesResources(candidateWl, frsNeedPreemption) {
 				continue
 			}
+			if until, isProtected := protectedUntil(candidateWl.Obj, cq, now); isProtected {
+				protected = append(protected, &ProtectedWorkload{WorkloadInfo: candidateWl, Until: until})
+				continue
+			}
 			candidates = append(candidates, candidateWl)
 		}
 	}
@@ -549,17 +649,55 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 				continue
 			}
 			for _, candidateWl := range cohortCQ.Workloads {
-				if onlyLowerPriority && priority.Priority(candidateWl.Obj) >= priority.Priority(wl) {
+				if onlyLowerPriority && priority.Priority(candidateWl.Obj) >= wlPriority {
 					continue
 				}
 				if !workloadUsesResources(candidateWl, frsNeedPreemption) {
 					continue
 				}
+				if until, isProtected := protectedUntil(candidateWl.Obj, cohortCQ, now); isProtected {
+					protected = append(protected, &ProtectedWorkload{WorkloadInfo: candidateWl, Until: until})
+					continue
+				}
 				candidates = append(candidates, candidateWl)
 			}
 		}
 	}
-	return candidates
+	return candidates, protected
+}
+
+// preemptorPriority returns the priority of the preemptor compared to the
+// priorities of the candidates: its effective priority when the priority
+// aging of the ClusterQueue is used in preemption, or its priority.
+func (p *Preemptor) preemptorPriority(wl *kueue.Workload, cq *cache.ClusterQueueSnapshot, now time.Time) int32 {
+	if cq.PriorityAging != nil && cq.PriorityAging.UseInPreemption {
+		return workload.EffectivePriority(wl, cq.PriorityAging, p.workloadOrdering, now)
+	}
+	return priority.Priority(wl)
+}
+
+// protectedUntil returns the time until which the admitted workload is
+// protected from preemption, and whether it's still protected at the given
+// time. The minimum runtime of the workload, if set, takes precedence over
+// the minimum runtime of its ClusterQueue. The runtime is counted since the
+// workload was admitted.
+func protectedUntil(wl *kueue.Workload, cq *cache.ClusterQueueSnapshot, now time.Time) (time.Time, bool) {
+	if !features.Enabled(features.PreemptionMinimumRuntime) {
+		return time.Time{}, false
+	}
+	minimumRuntime := wl.Spec.MinimumRuntimeSeconds
+	if minimumRuntime == nil {
+		minimumRuntime = cq.Preemption.MinimumRuntimeSeconds
+	}
+	if ptr.Deref(minimumRuntime, 0) <= 0 {
+		return time.Time{}, false
+	}
+	admitted := meta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
+	if admitted == nil || admitted.Status != metav1.ConditionTrue {
+		return time.Time{}, false
+	}
+	until := admitted.LastTransitionTime.Add(time.Duration(*minimumRuntime) * time.Second)
+	return until, now.Before(until)
 }
 
 func cqIsBorrowing(cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[resources.FlavorResource]) bool {
@@ -589,6 +727,9 @@ func workloadUsesResources(wl *workload.Info, frsNeedPreemption sets.Set[resourc
 // requestable resources and simulated usage of the ClusterQueue and its cohort,
 // if it belongs to one.
 func workloadFits(preemptionCtx *preemptionCtx, allowBorrowing bool) bool {
+	if !preemptionCtx.preemptorCQ.FitsInLocalQueue(preemptionCtx.preemptor.Obj, preemptionCtx.requests) {
+		return false
+	}
 	for fr, v := range preemptionCtx.requests {
 		if !allowBorrowing && preemptionCtx.preemptorCQ.BorrowingWith(fr, v) {
 			return false
@@ -614,27 +755,52 @@ func queueUnderNominalInResourcesNeedingPreemption(preemptionCtx *preemptionCtx)
 // 0. Workloads already marked for preemption first.
 // 1. Workloads from other ClusterQueues in the cohort before the ones in the
 // same ClusterQueue as the preemptor.
-// 2. Workloads with lower priority first.
-// 3. Workloads admitted more recently first.
-func candidatesOrdering(candidates []*workload.Info, cq kueue.ClusterQueueReference, now time.Time) func(int, int) bool {
+// 2. Workloads from LocalQueues using more than their quotas first.
+// 3. Workloads from LocalQueues with a higher share first, in ClusterQueues
+// using admission fair sharing.
+// 4. Workloads with lower priority first.
+// 5. Workloads freeing cheaper resources first, when the ClusterQueue of the
+// preemptor uses the LowestCost flavor selection policy.
+// 6. Workloads admitted more recently first.
+func candidatesOrdering(snapshot *cache.Snapshot, candidates []*workload.Info, cq kueue.ClusterQueueReference, now time.Time) func(int, int) bool {
+	lqOverQuota := localQueuesOverQuota(snapshot, candidates)
+	lqShares := localQueueShares(snapshot, candidates)
+	costs := freedCosts(snapshot, candidates, cq)
 	return func(i, j int) bool {
 		a := candidates[i]
 		b := candidates[j]
-		aEvicted := meta.IsStatusConditionTrue(a.Obj.Status.Conditions, kueue.WorkloadEvicted)
-		bEvicted := meta.IsStatusConditionTrue(b.Obj.Status.Conditions, kueue.WorkloadEvicted)
-		if aEvicted != bEvicted {
-			return aEvicted
+		// Workloads which are already being preempted go first, as their
+		// quota is going to be released anyway.
+		aPreempting := meta.IsStatusConditionTrue(a.Obj.Status.Conditions, kueue.WorkloadEvicted) || workload.IsPreemptionPending(a.Obj)
+		bPreempting := meta.IsStatusConditionTrue(b.Obj.Status.Conditions, kueue.WorkloadEvicted) || workload.IsPreemptionPending(b.Obj)
+		if aPreempting != bPreempting {
+			return aPreempting
 		}
 		aInCQ := a.ClusterQueue == cq
 		bInCQ := b.ClusterQueue == cq
 		if aInCQ != bInCQ {
 			return !aInCQ
 		}
+		aOverQuota := lqOverQuota.Has(workload.QueueKey(a.Obj))
+		bOverQuota := lqOverQuota.Has(workload.QueueKey(b.Obj))
+		if aOverQuota != bOverQuota {
+			return aOverQuota
+		}
+		aShare := lqShares[workload.QueueKey(a.Obj)]
+		bShare := lqShares[workload.QueueKey(b.Obj)]
+		if aShare != bShare {
+			return aShare > bShare
+		}
 		pa := priority.Priority(a.Obj)
 		pb := priority.Priority(b.Obj)
 		if pa != pb {
 			return pa < pb
 		}
+		costA := costs[workload.Key(a.Obj)]
+		costB := costs[workload.Key(b.Obj)]
+		if costA != costB {
+			return costA < costB
+		}
 		timeA := quotaReservationTime(a.Obj, now)
 		timeB := quotaReservationTime(b.Obj, now)
 		if !timeA.Equal(timeB) {
@@ -645,6 +811,61 @@ func candidatesOrdering(candidates []*workload.Info, cq kueue.ClusterQueueRefere
 	}
 }
 
+// freedCosts returns the costs of the resources freed by preempting the
+// candidates, when the ClusterQueue of the preemptor uses the LowestCost
+// flavor selection policy.
+func freedCosts(snapshot *cache.Snapshot, candidates []*workload.Info, cq kueue.ClusterQueueReference) map[string]float64 {
+	if snapshot == nil || !features.Enabled(features.CostAwareFlavorSelection) {
+		return nil
+	}
+	if preemptorCQ := snapshot.ClusterQueue(cq); preemptorCQ == nil || preemptorCQ.FlavorSelectionPolicy != kueue.LowestCostFlavorSelection {
+		return nil
+	}
+	costs := make(map[string]float64, len(candidates))
+	for _, cand := range candidates {
+		costs[workload.Key(cand.Obj)], _ = cand.FlavorResourceUsage().Cost(snapshot.ResourceFlavors)
+	}
+	return costs
+}
+
+// localQueuesOverQuota returns the keys of the LocalQueues of the candidates
+// which use more than their quotas.
+func localQueuesOverQuota(snapshot *cache.Snapshot, candidates []*workload.Info) sets.Set[string] {
+	overQuota := sets.New[string]()
+	if snapshot == nil {
+		return overQuota
+	}
+	for _, cand := range candidates {
+		cq := snapshot.ClusterQueue(cand.ClusterQueue)
+		if cq == nil {
+			continue
+		}
+		if lq := cq.LocalQueueFor(cand.Obj); lq != nil && lq.OverQuota() {
+			overQuota.Insert(workload.QueueKey(cand.Obj))
+		}
+	}
+	return overQuota
+}
+
+// localQueueShares returns the shares of the LocalQueues of the candidates,
+// for the ClusterQueues using admission fair sharing.
+func localQueueShares(snapshot *cache.Snapshot, candidates []*workload.Info) map[string]int {
+	shares := make(map[string]int)
+	if snapshot == nil {
+		return shares
+	}
+	for _, cand := range candidates {
+		key := workload.QueueKey(cand.Obj)
+		if _, found := shares[key]; found {
+			continue
+		}
+		if cq := snapshot.ClusterQueue(cand.ClusterQueue); cq != nil {
+			shares[key] = cq.LocalQueueShare(cand.Obj)
+		}
+	}
+	return shares
+}
+
 func quotaReservationTime(wl *kueue.Workload, now time.Time) time.Time {
 	cond := meta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
 	if cond == nil || cond.Status != metav1.ConditionTrue {
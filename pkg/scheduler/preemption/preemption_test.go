@@ -35,6 +35,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
@@ -289,6 +290,9 @@ func TestPreemption(t *testing.T) {
 		assignment          flavorassigner.Assignment
 		wantPreempted       sets.Set[string]
 		disableLendingLimit bool
+		localQueues         []*kueue.LocalQueue
+		enableLQQuotas      bool
+		enableAFS           bool
 	}{
 		"preempt lowest priority": {
 			clusterQueues: defaultClusterQueues,
@@ -1808,12 +1812,284 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New(targetKeyReason("/to-be-preempted", kueue.InCohortReclamationReason)),
 		},
+		"LocalQueue quota used; preempt within the LocalQueue": {
+			clusterQueues: defaultClusterQueues,
+			localQueues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("team-a", "a").
+					ClusterQueue("standalone").
+					Quota("default", corev1.ResourceCPU, "3").
+					Obj(),
+				utiltesting.MakeLocalQueue("team-b", "b").ClusterQueue("standalone").Obj(),
+			},
+			enableLQQuotas: true,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "b").
+					Queue("team-b").
+					Priority(-2).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "a").
+					Queue("team-a").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "b").
+					Queue("team-b").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "a").
+				Queue("team-a").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("a/mid", kueue.InClusterQueueReason)),
+		},
+		"LocalQueue quotas disabled; preempt lowest priority": {
+			clusterQueues: defaultClusterQueues,
+			localQueues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("team-a", "a").
+					ClusterQueue("standalone").
+					Quota("default", corev1.ResourceCPU, "3").
+					Obj(),
+				utiltesting.MakeLocalQueue("team-b", "b").ClusterQueue("standalone").Obj(),
+			},
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "b").
+					Queue("team-b").
+					Priority(-2).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "a").
+					Queue("team-a").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "b").
+					Queue("team-b").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "a").
+				Queue("team-a").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("b/low", kueue.InClusterQueueReason)),
+		},
+		"LocalQueue over its quota; preempt from the LocalQueue first": {
+			clusterQueues: defaultClusterQueues,
+			localQueues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("team-a", "a").
+					ClusterQueue("standalone").
+					Quota("default", corev1.ResourceCPU, "3").
+					Obj(),
+				utiltesting.MakeLocalQueue("team-b", "b").ClusterQueue("standalone").Obj(),
+			},
+			enableLQQuotas: true,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "b").
+					Queue("team-b").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("mid-1", "a").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("mid-2", "a").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now.Add(time.Second),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "b").
+				Queue("team-b").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("a/mid-2", kueue.InClusterQueueReason)),
+		},
+		"admission fair sharing; preempt from the LocalQueue with the highest share": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("afs").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "6").
+						Obj(),
+					).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					AdmissionMode(kueue.UsageBasedAdmissionFairSharing).
+					Obj(),
+			},
+			localQueues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("team-a", "a").ClusterQueue("afs").Obj(),
+				utiltesting.MakeLocalQueue("team-b", "b").ClusterQueue("afs").Obj(),
+			},
+			enableAFS: true,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("a1", "a").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("afs").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("a2", "a").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("afs").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now.Add(time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("b1", "b").
+					Queue("team-b").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("afs").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now.Add(2*time.Second),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "b").
+				Queue("team-b").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "afs",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("a/a2", kueue.InClusterQueueReason)),
+		},
+		"admission fair sharing disabled; preempt the most recently admitted": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("afs").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "6").
+						Obj(),
+					).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					AdmissionMode(kueue.UsageBasedAdmissionFairSharing).
+					Obj(),
+			},
+			localQueues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("team-a", "a").ClusterQueue("afs").Obj(),
+				utiltesting.MakeLocalQueue("team-b", "b").ClusterQueue("afs").Obj(),
+			},
+			enableAFS: false,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("a1", "a").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("afs").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now,
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("a2", "a").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("afs").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now.Add(time.Second),
+					).
+					Obj(),
+				*utiltesting.MakeWorkload("b1", "b").
+					Queue("team-b").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(
+						utiltesting.MakeAdmission("afs").Assignment(corev1.ResourceCPU, "default", "2").Obj(),
+						now.Add(2*time.Second),
+					).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "b").
+				Queue("team-b").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "afs",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New(targetKeyReason("b/b1", kueue.InClusterQueueReason)),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			if tc.disableLendingLimit {
 				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
 			}
+			features.SetFeatureGateDuringTest(t, features.LocalQueueQuotas, tc.enableLQQuotas)
+			features.SetFeatureGateDuringTest(t, features.AdmissionFairSharing, tc.enableAFS)
 			ctx, log := utiltesting.ContextWithLog(t)
 			cl := utiltesting.NewClientBuilder().
 				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
@@ -1833,6 +2109,11 @@ func TestPreemption(t *testing.T) {
 					t.Fatalf("Couldn't add Cohort to cache: %v", err)
 				}
 			}
+			for _, lq := range tc.localQueues {
+				if err := cqCache.AddLocalQueue(lq); err != nil {
+					t.Fatalf("Couldn't add LocalQueue to cache: %v", err)
+				}
+			}
 
 			var lock sync.Mutex
 			gotPreempted := sets.New[string]()
@@ -2394,6 +2675,192 @@ func TestFairPreemptions(t *testing.T) {
 	}
 }
 
+func TestMinimumRuntime(t *testing.T) {
+	now := time.Now()
+	cq := utiltesting.MakeClusterQueue("standalone").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			Resource(corev1.ResourceCPU, "4").
+			Obj(),
+		).
+		Preemption(kueue.ClusterQueuePreemption{
+			WithinClusterQueue:    kueue.PreemptionPolicyLowerPriority,
+			MinimumRuntimeSeconds: ptr.To[int32](600),
+		}).
+		Obj()
+	admittedAt := func(name string, priority int32, at time.Time) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "").
+			Priority(priority).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(), at).
+			AdmittedAt(true, at)
+	}
+	cases := map[string]struct {
+		admitted      []kueue.Workload
+		disableGate   bool
+		wantPreempted sets.Set[string]
+		wantProtected map[string]time.Time
+	}{
+		"the recently admitted workload is protected": {
+			admitted: []kueue.Workload{
+				*admittedAt("low", -1, now.Add(-time.Minute)).Obj(),
+				*admittedAt("mid", 0, now.Add(-20*time.Minute)).Obj(),
+			},
+			wantPreempted: sets.New(targetKeyReason("/mid", kueue.InClusterQueueReason)),
+			wantProtected: map[string]time.Time{
+				"/low": now.Add(9 * time.Minute),
+			},
+		},
+		"the minimum runtime of the workload takes precedence": {
+			admitted: []kueue.Workload{
+				*admittedAt("low", -1, now.Add(-time.Minute)).Obj(),
+				*admittedAt("mid", 0, now.Add(-20*time.Minute)).MinimumRuntimeSeconds(3600).Obj(),
+			},
+			wantProtected: map[string]time.Time{
+				"/low": now.Add(9 * time.Minute),
+				"/mid": now.Add(40 * time.Minute),
+			},
+		},
+		"a zero minimum runtime of the workload disables the protection": {
+			admitted: []kueue.Workload{
+				*admittedAt("low", -1, now.Add(-time.Minute)).MinimumRuntimeSeconds(0).Obj(),
+				*admittedAt("mid", 0, now.Add(-time.Minute)).Obj(),
+			},
+			wantPreempted: sets.New(targetKeyReason("/low", kueue.InClusterQueueReason)),
+			wantProtected: map[string]time.Time{
+				"/mid": now.Add(9 * time.Minute),
+			},
+		},
+		"feature gate disabled": {
+			admitted: []kueue.Workload{
+				*admittedAt("low", -1, now.Add(-time.Minute)).Obj(),
+				*admittedAt("mid", 0, now.Add(-time.Minute)).Obj(),
+			},
+			disableGate:   true,
+			wantPreempted: sets.New(targetKeyReason("/low", kueue.InClusterQueueReason)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PreemptionMinimumRuntime, !tc.disableGate)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
+				Build()
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+			}
+			recorder := record.NewBroadcaster().NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now))
+
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj())
+			wlInfo.ClusterQueue = "standalone"
+			assignment := singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default", Mode: flavorassigner.Preempt,
+				},
+			})
+			targets := preemptor.GetTargets(log, *wlInfo, assignment, snapshot)
+			gotPreempted := sets.New(slices.Map(targets, func(t **Target) string {
+				return targetKeyReason(workload.Key((*t).WorkloadInfo.Obj), (*t).Reason)
+			})...)
+			if diff := cmp.Diff(tc.wantPreempted, gotPreempted, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected targets (-want,+got):\n%s", diff)
+			}
+			gotProtected := make(map[string]time.Time)
+			for _, p := range preemptor.ProtectedWorkloads(*wlInfo, assignment, snapshot) {
+				gotProtected[workload.Key(p.WorkloadInfo.Obj)] = p.Until
+			}
+			if diff := cmp.Diff(tc.wantProtected, gotProtected, cmpopts.EquateEmpty(), cmpopts.EquateApproxTime(time.Second)); diff != "" {
+				t.Errorf("Unexpected protected workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPriorityAgingInPreemption(t *testing.T) {
+	now := time.Now()
+	admitted := []kueue.Workload{
+		*utiltesting.MakeWorkload("mid", "").
+			Priority(5).
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+			Obj(),
+	}
+	cases := map[string]struct {
+		useInPreemption bool
+		wantPreempted   sets.Set[string]
+	}{
+		"base priority": {},
+		"effective priority": {
+			useInPreemption: true,
+			wantPreempted:   sets.New(targetKeyReason("/mid", kueue.InClusterQueueReason)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.PriorityAging, true)
+			ctx, log := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: admitted}).
+				Build()
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			cq := utiltesting.MakeClusterQueue("standalone").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "4").
+					Obj(),
+				).
+				Preemption(kueue.ClusterQueuePreemption{
+					WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+				}).
+				PriorityAging(kueue.PriorityAging{
+					Interval:        metav1.Duration{Duration: time.Minute},
+					Increment:       1,
+					MaxIncrement:    10,
+					UseInPreemption: tc.useInPreemption,
+				}).
+				Obj()
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+			}
+			recorder := record.NewBroadcaster().NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now))
+
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Creation(now.Add(-10*time.Minute)).
+				Request(corev1.ResourceCPU, "4").
+				Obj())
+			wlInfo.ClusterQueue = "standalone"
+			assignment := singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default", Mode: flavorassigner.Preempt,
+				},
+			})
+			targets := preemptor.GetTargets(log, *wlInfo, assignment, snapshot)
+			gotPreempted := sets.New(slices.Map(targets, func(t **Target) string {
+				return targetKeyReason(workload.Key((*t).WorkloadInfo.Obj), (*t).Reason)
+			})...)
+			if diff := cmp.Diff(tc.wantPreempted, gotPreempted, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected targets (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func targetKeyReason(key, reason string) string {
 	return fmt.Sprintf("%s:%s", key, reason)
 }
@@ -2420,6 +2887,15 @@ func TestCandidatesOrdering(t *testing.T) {
 				LastTransitionTime: metav1.NewTime(now),
 			}).
 			Obj()),
+		workload.NewInfo(utiltesting.MakeWorkload("preemption-pending", "").
+			ReserveQuotaAt(utiltesting.MakeAdmission("self").Obj(), now).
+			Priority(10).
+			SetOrReplaceCondition(metav1.Condition{
+				Type:               kueue.WorkloadPreemptionPending,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now),
+			}).
+			Obj()),
 		workload.NewInfo(utiltesting.MakeWorkload("old-a", "").
 			UID("old-a").
 			ReserveQuotaAt(utiltesting.MakeAdmission("self").Obj(), now).
@@ -2432,17 +2908,83 @@ func TestCandidatesOrdering(t *testing.T) {
 			ReserveQuotaAt(utiltesting.MakeAdmission("self").Obj(), now.Add(time.Second)).
 			Obj()),
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, "self", now))
+	sort.Slice(candidates, candidatesOrdering(nil, candidates, "self", now))
 	gotNames := make([]string, len(candidates))
 	for i, c := range candidates {
 		gotNames[i] = workload.Key(c.Obj)
 	}
-	wantCandidates := []string{"/evicted", "/other", "/low", "/current", "/old-a", "/old-b", "/high"}
+	wantCandidates := []string{"/evicted", "/preemption-pending", "/other", "/low", "/current", "/old-a", "/old-b", "/high"}
 	if diff := cmp.Diff(wantCandidates, gotNames); diff != "" {
 		t.Errorf("Sorted with wrong order (-want,+got):\n%s", diff)
 	}
 }
 
+func TestCandidatesOrderingByCost(t *testing.T) {
+	now := time.Now()
+	cases := map[string]struct {
+		disableFeature bool
+		policy         kueue.FlavorSelectionPolicy
+		wantCandidates []string
+	}{
+		"lowest cost": {
+			policy:         kueue.LowestCostFlavorSelection,
+			wantCandidates: []string{"/spot", "/on-demand"},
+		},
+		"in order": {
+			policy:         kueue.InOrderFlavorSelection,
+			wantCandidates: []string{"/on-demand", "/spot"},
+		},
+		"feature disabled": {
+			disableFeature: true,
+			policy:         kueue.LowestCostFlavorSelection,
+			wantCandidates: []string{"/on-demand", "/spot"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.CostAwareFlavorSelection, !tc.disableFeature)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cqCache := cache.New(utiltesting.NewFakeClient())
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("on-demand").Cost(corev1.ResourceCPU, "3").Obj())
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("spot").Cost(corev1.ResourceCPU, "1").Obj())
+			cq := utiltesting.MakeClusterQueue("self").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+					*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+				).
+				FlavorSelectionPolicy(tc.policy).
+				Obj()
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache: %v", err)
+			}
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			candidates := []*workload.Info{
+				workload.NewInfo(utiltesting.MakeWorkload("on-demand", "").
+					UID("a").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("self").Assignment(corev1.ResourceCPU, "on-demand", "2").Obj(), now).
+					Obj()),
+				workload.NewInfo(utiltesting.MakeWorkload("spot", "").
+					UID("b").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("self").Assignment(corev1.ResourceCPU, "spot", "2").Obj(), now).
+					Obj()),
+			}
+			sort.Slice(candidates, candidatesOrdering(snapshot, candidates, "self", now))
+			gotNames := make([]string, len(candidates))
+			for i, c := range candidates {
+				gotNames[i] = workload.Key(c.Obj)
+			}
+			if diff := cmp.Diff(tc.wantCandidates, gotNames); diff != "" {
+				t.Errorf("Sorted with wrong order (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func singlePodSetAssignment(assignments flavorassigner.ResourceAssignment) flavorassigner.Assignment {
 	return flavorassigner.Assignment{
 		PodSets: []flavorassigner.PodSetAssignment{{
@@ -2484,3 +3026,74 @@ func TestPreemptionMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyPreemption(t *testing.T) {
+	now := time.Now()
+	const message = "Preempted to accommodate a workload (UID: uid, JobUID: UNKNOWN) due to prioritization in the ClusterQueue"
+	cases := map[string]struct {
+		enableGracefulPreemption bool
+		wantConditions           []metav1.Condition
+	}{
+		"the workload is evicted": {
+			wantConditions: []metav1.Condition{
+				{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionTrue,
+					Reason:  "AdmittedByTest",
+					Message: "Admitted by ClusterQueue cq",
+				},
+				{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: message,
+				},
+				{
+					Type:    kueue.WorkloadPreempted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.InClusterQueueReason,
+					Message: message,
+				},
+			},
+		},
+		"the preemption is pending, with graceful preemption": {
+			enableGracefulPreemption: true,
+			wantConditions: []metav1.Condition{
+				{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionTrue,
+					Reason:  "AdmittedByTest",
+					Message: "Admitted by ClusterQueue cq",
+				},
+				{
+					Type:    kueue.WorkloadPreemptionPending,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.InClusterQueueReason,
+					Message: message,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.GracefulPreemption, tc.enableGracefulPreemption)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			wl := utiltesting.MakeWorkload("wl", "").
+				ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now).
+				Obj()
+			cl := utiltesting.NewFakeClientSSAAsSM(wl)
+			recorder := record.NewBroadcaster().NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now))
+			if err := preemptor.applyPreemption(ctx, wl, kueue.InClusterQueueReason, message); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := &kueue.Workload{}
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(wl), got); err != nil {
+				t.Fatalf("Unexpected error getting the workload: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantConditions, got.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"), cmpopts.SortSlices(func(a, b metav1.Condition) bool { return a.Type < b.Type })); diff != "" {
+				t.Errorf("Unexpected conditions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
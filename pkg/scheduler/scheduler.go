@@ -22,33 +22,43 @@ import (
 	"maps"
 	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/decisionlog"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
 	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/util/parallelize"
 	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/util/wait"
 	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 )
 
 const (
@@ -57,6 +67,13 @@ const (
 	errLimitRangeConstraintsUnsatisfiedResources = "resources didn't satisfy LimitRange constraints"
 )
 
+const (
+	// quotaSchedulesResolvePeriod is how often the quota schedules are
+	// re-evaluated, independently of the scheduling cycles. Schedules have
+	// a resolution of one minute.
+	quotaSchedulesResolvePeriod = 30 * time.Second
+)
+
 var (
 	realClock = clock.RealClock{}
 )
@@ -76,6 +93,21 @@ type Scheduler struct {
 	// attempts since the last restart.
 	schedulingCycle int64
 
+	// admissionMu serializes the admissions of the cohort trees scheduled
+	// concurrently, so that blocking the admissions until the admitted
+	// workloads are in the PodsReady condition applies across them.
+	admissionMu sync.Mutex
+
+	// framework runs the plugins extending the scheduler.
+	framework *framework.Framework
+
+	// decisionSinks record the decisions of each scheduling cycle.
+	decisionSinks []decisionlog.Sink
+
+	// flavorsDryRuns keeps the results of FlavorsDryRun until the next
+	// scheduling cycle.
+	flavorsDryRuns flavorsDryRunCache
+
 	// Stubs.
 	applyAdmission func(context.Context, *kueue.Workload) error
 }
@@ -84,6 +116,9 @@ type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
 	fairSharing                 config.FairSharing
 	clock                       clock.Clock
+	framework                   *framework.Framework
+	admissionRoutineWrapper     routine.Wrapper
+	decisionSinks               []decisionlog.Sink
 }
 
 // Option configures the reconciler.
@@ -92,6 +127,7 @@ type Option func(*options)
 var defaultOptions = options{
 	podsReadyRequeuingTimestamp: config.EvictionTimestamp,
 	clock:                       realClock,
+	admissionRoutineWrapper:     routine.DefaultWrapper,
 }
 
 // WithPodsReadyRequeuingTimestamp sets the timestamp that is used for ordering
@@ -110,6 +146,37 @@ func WithFairSharing(fs *config.FairSharing) Option {
 	}
 }
 
+// WithFramework sets the plugins extending the scheduler.
+func WithFramework(f *framework.Framework) Option {
+	return func(o *options) {
+		o.framework = f
+	}
+}
+
+// WithAdmissionRoutineWrapper sets the wrapper of the routines that update
+// the admitted workloads in the apiserver.
+func WithAdmissionRoutineWrapper(w routine.Wrapper) Option {
+	return func(o *options) {
+		o.admissionRoutineWrapper = w
+	}
+}
+
+// WithDecisionSinks sets the sinks recording the decisions taken for the
+// workloads evaluated in each scheduling cycle.
+func WithDecisionSinks(sinks ...decisionlog.Sink) Option {
+	return func(o *options) {
+		o.decisionSinks = append(o.decisionSinks, sinks...)
+	}
+}
+
+// WithTimeSource sets the clock used by the scheduler, for instance to replay
+// a trace of workloads in simulated time.
+func WithTimeSource(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
 func WithClock(_ testing.TB, c clock.Clock) Option {
 	return func(o *options) {
 		o.clock = c
@@ -131,9 +198,11 @@ func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder r
 		client:                  cl,
 		recorder:                recorder,
 		preemptor:               preemption.New(cl, wo, recorder, options.fairSharing, options.clock),
-		admissionRoutineWrapper: routine.DefaultWrapper,
+		admissionRoutineWrapper: options.admissionRoutineWrapper,
 		workloadOrdering:        wo,
 		clock:                   options.clock,
+		framework:               options.framework,
+		decisionSinks:           options.decisionSinks,
 	}
 	s.applyAdmission = s.applyAdmissionWithSSA
 	return s
@@ -144,9 +213,23 @@ func (s *Scheduler) Start(ctx context.Context) error {
 	log := ctrl.LoggerFrom(ctx).WithName("scheduler")
 	ctx = ctrl.LoggerInto(ctx, log)
 	go wait.UntilWithBackoff(ctx, s.schedule)
+	if features.Enabled(features.QuotaSchedules) {
+		go apimachinerywait.UntilWithContext(ctx, s.resolveQuotaSchedules, quotaSchedulesResolvePeriod)
+	}
 	return nil
 }
 
+// resolveQuotaSchedules re-evaluates the quota schedules in the cache and
+// requeues the inadmissible workloads of the ClusterQueues affected by a
+// change of the quotas, so that they are reconsidered, possibly reclaiming
+// quota by preemption.
+func (s *Scheduler) resolveQuotaSchedules(ctx context.Context) {
+	if cqs := s.cache.ResolveQuotaSchedules(); cqs.Len() > 0 {
+		ctrl.LoggerFrom(ctx).V(2).Info("Quota schedules changed, requeueing inadmissible workloads", "clusterQueues", sets.List(cqs))
+		s.queues.QueueInadmissibleWorkloads(ctx, cqs)
+	}
+}
+
 // NeedLeaderElection Implements LeaderElectionRunnable interface to make scheduler
 // run in leader election mode
 func (s *Scheduler) NeedLeaderElection() bool {
@@ -174,9 +257,7 @@ func reportSkippedPreemptions(p map[kueue.ClusterQueueReference]int) {
 }
 
 func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
-	s.schedulingCycle++
-	log := ctrl.LoggerFrom(ctx).WithValues("schedulingCycle", s.schedulingCycle)
-	ctx = ctrl.LoggerInto(ctx, log)
+	ctx = s.startCycle(ctx)
 
 	// 1. Get the heads from the queues, including their desired clusterQueue.
 	// This operation blocks while the queues are empty.
@@ -185,9 +266,36 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	if len(headWorkloads) == 0 {
 		return wait.KeepGoing
 	}
+	return s.scheduleHeads(ctx, headWorkloads)
+}
+
+// RunCycle runs a single scheduling cycle with the heads of the queues,
+// without waiting for workloads to be queued if there are none, and returns
+// the heads. It allows driving the scheduler step by step, like the simulator
+// does, instead of starting it.
+func (s *Scheduler) RunCycle(ctx context.Context) []workload.Info {
+	ctx = s.startCycle(ctx)
+	headWorkloads := s.queues.TryHeads(ctx)
+	if len(headWorkloads) != 0 {
+		s.scheduleHeads(ctx, headWorkloads)
+	}
+	return headWorkloads
+}
+
+func (s *Scheduler) startCycle(ctx context.Context) context.Context {
+	s.schedulingCycle++
+	log := ctrl.LoggerFrom(ctx).WithValues("schedulingCycle", s.schedulingCycle)
+	return ctrl.LoggerInto(ctx, log)
+}
+
+// scheduleHeads runs the steps 2 to 6 of the scheduling cycle for the heads.
+func (s *Scheduler) scheduleHeads(ctx context.Context, headWorkloads []workload.Info) wait.SpeedSignal {
+	defer s.flavorsDryRuns.invalidate()
+	log := ctrl.LoggerFrom(ctx)
 	startTime := s.clock.Now()
 
-	// 2. Take a snapshot of the cache.
+	// 2. Take a snapshot of the cache, with the quotas resolved at the current time.
+	s.resolveQuotaSchedules(ctx)
 	snapshot, err := s.cache.Snapshot(ctx)
 	if err != nil {
 		log.Error(err, "failed to build snapshot for scheduling")
@@ -195,11 +303,48 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	}
 	logSnapshotIfVerbose(log, snapshot)
 
+	// Nominate and admit the heads, see scheduleSnapshot for steps 3 to 5.
+	// The independent cohort trees are scheduled concurrently, if enabled.
+	var entries []entry
+	var skippedPreemptions map[kueue.ClusterQueueReference]int
+	if features.Enabled(features.ParallelScheduling) {
+		entries, skippedPreemptions = s.schedulePartitions(ctx, headWorkloads, snapshot)
+	} else {
+		entries, skippedPreemptions = s.scheduleSnapshot(ctx, headWorkloads, snapshot)
+	}
+
+	s.recordDecisions(ctx, startTime, entries)
+
+	// 6. Requeue the heads that were not scheduled.
+	result := metrics.AdmissionResultInadmissible
+	for _, e := range entries {
+		logAdmissionAttemptIfVerbose(log, &e)
+		if e.status != assumed {
+			s.requeueAndUpdate(ctx, e)
+		} else {
+			result = metrics.AdmissionResultSuccess
+		}
+	}
+	reportSkippedPreemptions(skippedPreemptions)
+	metrics.AdmissionAttempt(result, s.clock.Since(startTime))
+	if result != metrics.AdmissionResultSuccess {
+		return wait.SlowDown
+	}
+	return wait.KeepGoing
+}
+
+// scheduleSnapshot nominates the head workloads in the snapshot and admits
+// the ones that fit, returning the entries of the heads, followed by the
+// entries of the backfilled workloads, and the number of skipped preemptions
+// per ClusterQueue.
+func (s *Scheduler) scheduleSnapshot(ctx context.Context, headWorkloads []workload.Info, snapshot *cache.Snapshot) ([]entry, map[kueue.ClusterQueueReference]int) {
+	log := ctrl.LoggerFrom(ctx)
+
 	// 3. Calculate requirements (resource flavors, borrowing) for admitting workloads.
 	entries := s.nominate(ctx, headWorkloads, snapshot)
 
 	// 4. Create iterator which returns ordered entries.
-	iterator := makeIterator(ctx, entries, s.workloadOrdering, s.fairSharing.Enable)
+	iterator := makeIterator(ctx, entries, s.workloadOrdering, s.framework.QueueSortPlugin(), s.fairSharing.Enable, s.fairSharing.UsageHalfLifeTime != nil)
 
 	// 5. Admit entries, ensuring that no more than one workload gets
 	// admitted by a cohort (if borrowing).
@@ -208,6 +353,7 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	// of other clusterQueues.
 	preemptedWorkloads := make(preemptedWorkloads)
 	skippedPreemptions := make(map[kueue.ClusterQueueReference]int)
+	var backfilled []entry
 	for iterator.hasNext() {
 		e := iterator.pop()
 
@@ -221,12 +367,18 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		mode := e.assignment.RepresentativeMode()
 		if mode == flavorassigner.NoFit {
 			log.V(3).Info("Skipping workload as FlavorAssigner assigned NoFit mode")
+			if canBackfill(cq) {
+				backfilled = append(backfilled, s.backfill(ctx, e, cq, snapshot)...)
+			}
 			continue
 		}
 		log.V(2).Info("Attempting to schedule workload")
 
 		if mode == flavorassigner.Preempt && len(e.preemptionTargets) == 0 {
 			log.V(2).Info("Workload requires preemption, but there are no candidate workloads allowed for preemption", "preemption", cq.Preemption)
+			if canBackfill(cq) {
+				backfilled = append(backfilled, s.backfill(ctx, e, cq, snapshot)...)
+			}
 			// we use resourcesToReserve to block capacity up to either the nominal capacity,
 			// or the borrowing limit when borrowing, so that a lower priority workload cannot
 			// admit before us.
@@ -241,8 +393,14 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 			continue
 		}
 
+		if e.replacedWorkloadSlice != nil {
+			snapshot.RemoveWorkload(e.replacedWorkloadSlice)
+		}
 		usage := e.assignmentUsage()
-		if !fits(cq, &usage, preemptedWorkloads, e.preemptionTargets) {
+		if !fits(cq, e, &usage, preemptedWorkloads, e.preemptionTargets) {
+			if e.replacedWorkloadSlice != nil {
+				snapshot.AddWorkload(e.replacedWorkloadSlice)
+			}
 			setSkipped(e, "Workload no longer fits after processing another workload")
 			if mode == flavorassigner.Preempt {
 				skippedPreemptions[cq.Name]++
@@ -250,7 +408,7 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 			continue
 		}
 		preemptedWorkloads.insert(e.preemptionTargets)
-		cq.AddUsage(usage)
+		reserve(cq, e, usage)
 
 		if e.assignment.RepresentativeMode() == flavorassigner.Preempt {
 			// If preemptions are issued, the next attempt should try all the flavors.
@@ -265,6 +423,7 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 			}
 			continue
 		}
+		s.admissionMu.Lock()
 		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
 			log.V(5).Info("Waiting for all admitted workloads to be in the PodsReady condition")
 			// If WaitForPodsReady is enabled and WaitForPodsReady.BlockAdmission is true
@@ -281,24 +440,71 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		if err := s.admit(ctx, e, cq); err != nil {
 			e.inadmissibleMsg = fmt.Sprintf("Failed to admit workload: %v", err)
 		}
+		s.admissionMu.Unlock()
 	}
+	// The backfilled workloads are admitted, so they are only recorded in
+	// the decisions of the cycle.
+	return append(entries, backfilled...), skippedPreemptions
+}
 
-	// 6. Requeue the heads that were not scheduled.
-	result := metrics.AdmissionResultInadmissible
-	for _, e := range entries {
-		logAdmissionAttemptIfVerbose(log, &e)
-		if e.status != assumed {
-			s.requeueAndUpdate(ctx, e)
-		} else {
-			result = metrics.AdmissionResultSuccess
+// schedulePartitions splits the snapshot into the independent cohort trees
+// and schedules the heads of each of them concurrently. The trees don't share
+// any quota, so the result is the same as scheduling them sequentially.
+//
+// Each partition only writes to its own snapshot and entries. The state of the
+// scheduler shared by the partitions is safe for concurrent use: the preemptor
+// keeps no state across calls, the plugins follow the contract of
+// framework.Plugin, the cache, the queues, the client and the event recorder
+// are synchronized, and the admissions are serialized by admissionMu. The
+// decisions are recorded once all the partitions are done.
+func (s *Scheduler) schedulePartitions(ctx context.Context, headWorkloads []workload.Info, snapshot *cache.Snapshot) ([]entry, map[kueue.ClusterQueueReference]int) {
+	partitions := snapshot.Partition()
+	partitionOf := make(map[kueue.ClusterQueueReference]int)
+	for i, p := range partitions {
+		for _, name := range p.ClusterQueuesNames() {
+			partitionOf[name] = i
 		}
 	}
-	reportSkippedPreemptions(skippedPreemptions)
-	metrics.AdmissionAttempt(result, s.clock.Since(startTime))
-	if result != metrics.AdmissionResultSuccess {
-		return wait.SlowDown
+	heads := make([][]workload.Info, len(partitions)+1)
+	for _, w := range headWorkloads {
+		i, found := partitionOf[w.ClusterQueue]
+		if !found {
+			// The ClusterQueue is missing or inactive, nominate reports it.
+			i = len(partitions)
+		}
+		heads[i] = append(heads[i], w)
 	}
-	return wait.KeepGoing
+	var scheduled []int
+	for i := range heads {
+		if len(heads[i]) > 0 {
+			scheduled = append(scheduled, i)
+		}
+	}
+	if len(scheduled) == 1 {
+		return s.scheduleSnapshot(ctx, headWorkloads, snapshot)
+	}
+	log := ctrl.LoggerFrom(ctx)
+	log.V(3).Info("Scheduling the cohort trees concurrently", "partitions", len(scheduled))
+	results := make([][]entry, len(scheduled))
+	skipped := make([]map[kueue.ClusterQueueReference]int, len(scheduled))
+	// The heads of all the partitions need to be processed to be requeued,
+	// even if the context is cancelled.
+	_ = parallelize.Until(context.WithoutCancel(ctx), len(scheduled), func(i int) error {
+		p := scheduled[i]
+		partition := snapshot
+		if p < len(partitions) {
+			partition = partitions[p]
+		}
+		results[i], skipped[i] = s.scheduleSnapshot(ctx, heads[p], partition)
+		return nil
+	})
+	entries := make([]entry, 0, len(headWorkloads))
+	skippedPreemptions := make(map[kueue.ClusterQueueReference]int)
+	for i := range results {
+		entries = append(entries, results[i]...)
+		maps.Copy(skippedPreemptions, skipped[i])
+	}
+	return entries, skippedPreemptions
 }
 
 type entryStatus string
@@ -325,6 +531,26 @@ type entry struct {
 	requeueReason        queue.RequeueReason
 	preemptionTargets    []*preemption.Target
 	clusterQueueSnapshot *cache.ClusterQueueSnapshot
+	// replacedWorkloadSlice is the admitted workload slice replaced by the
+	// workload, if any.
+	replacedWorkloadSlice *workload.Info
+	// group holds the pending members of the workload group of the workload,
+	// starting with the workload, which are admitted together.
+	group []*workload.Info
+	// groupInfo combines the podsets of the group members. The assignment
+	// and the preemption targets are computed for it.
+	groupInfo *workload.Info
+	// effectivePriority is the priority of the workload raised by the
+	// priority aging of the ClusterQueue, if any.
+	effectivePriority *int32
+}
+
+// priority returns the priority the entry is ordered by.
+func (e *entry) priority() int32 {
+	if e.effectivePriority != nil {
+		return *e.effectivePriority
+	}
+	return priority.Priority(e.Obj)
 }
 
 func (e *entry) assignmentUsage() workload.Usage {
@@ -341,6 +567,9 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 		ns := corev1.Namespace{}
 		e := entry{Info: w}
 		e.clusterQueueSnapshot = snap.ClusterQueue(w.ClusterQueue)
+		if e.clusterQueueSnapshot != nil && e.clusterQueueSnapshot.PriorityAging != nil {
+			e.effectivePriority = ptr.To(workload.EffectivePriority(w.Obj, e.clusterQueueSnapshot.PriorityAging, s.workloadOrdering, s.clock.Now()))
+		}
 		if s.cache.IsAssumedOrAdmittedWorkload(w) {
 			log.Info("Workload skipped from admission because it's already assumed or admitted", "workload", klog.KObj(w.Obj))
 			continue
@@ -355,28 +584,190 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 		} else if !e.clusterQueueSnapshot.NamespaceSelector.Matches(labels.Set(ns.Labels)) {
 			e.inadmissibleMsg = "Workload namespace doesn't match ClusterQueue selector"
 			e.requeueReason = queue.RequeueReasonNamespaceMismatch
-		} else if err := workload.ValidateResources(&w); err != nil {
+		} else if msg := s.setWorkloadGroup(&e); msg != "" {
+			e.inadmissibleMsg = msg
+		} else if err := workload.ValidateResources(e.admissionInfo()); err != nil {
 			e.inadmissibleMsg = fmt.Sprintf("%s: %v", errInvalidWLResources, err.ToAggregate())
-		} else if err := workload.ValidateLimitRange(ctx, s.client, &w); err != nil {
+		} else if err := workload.ValidateLimitRange(ctx, s.client, e.admissionInfo()); err != nil {
 			e.inadmissibleMsg = fmt.Sprintf("%s: %v", errLimitRangeConstraintsUnsatisfiedResources, err.ToAggregate())
+		} else if e.groupInfo != nil {
+			e.assignment, e.preemptionTargets = s.getAssignments(log, e.groupInfo, snap)
+			e.inadmissibleMsg = e.assignment.Message()
+			s.limitAdmittedWorkloads(log, &e, snap)
 		} else {
+			e.replacedWorkloadSlice = workloadslicing.ReplacedSlice(w.Obj, e.clusterQueueSnapshot.Workloads)
 			e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, snap)
 			e.inadmissibleMsg = e.assignment.Message()
 			e.Info.LastAssignment = &e.assignment.LastState
+			s.limitAdmittedWorkloads(log, &e, snap)
 		}
 		entries = append(entries, e)
 	}
 	return entries
 }
 
-func fits(cq *cache.ClusterQueueSnapshot, usage *workload.Usage, preemptedWorkloads preemptedWorkloads, newTargets []*preemption.Target) bool {
+// admissionInfo returns the Info the entry is assigned flavors for: the
+// workload, or the combination of its workload group.
+func (e *entry) admissionInfo() *workload.Info {
+	if e.groupInfo != nil {
+		return e.groupInfo
+	}
+	return &e.Info
+}
+
+// workloads returns the workloads admitted with the entry: the workload, or
+// the members of its workload group.
+func (e *entry) workloads() []*workload.Info {
+	if e.group != nil {
+		return e.group
+	}
+	return []*workload.Info{&e.Info}
+}
+
+// limitAdmittedWorkloads checks that admitting the entry doesn't exceed the
+// maximum numbers of admitted workloads of its ClusterQueue and LocalQueue,
+// once the preemption targets are evicted. Otherwise, the entry needs to
+// preempt other workloads of the ClusterQueue to free enough slots, or it
+// doesn't fit.
+func (s *Scheduler) limitAdmittedWorkloads(log logr.Logger, e *entry, snap *cache.Snapshot) {
+	mode := e.assignment.RepresentativeMode()
+	if mode == flavorassigner.NoFit || (mode == flavorassigner.Preempt && len(e.preemptionTargets) == 0) {
+		return
+	}
+	cq := e.clusterQueueSnapshot
+	info := e.admissionInfo()
+	count := len(e.workloads())
+	targets := make([]*workload.Info, 0, len(e.preemptionTargets))
+	for _, t := range e.preemptionTargets {
+		targets = append(targets, t.WorkloadInfo)
+	}
+	revertUsage := cq.SimulateUsageRemoval(targets)
+	cqReached, lqReached := cq.MaxAdmittedWorkloadsReached(info.Obj, count)
+	revertUsage()
+	if !cqReached && !lqReached {
+		return
+	}
+	var reasons []string
+	if cqReached {
+		reasons = append(reasons, fmt.Sprintf("the ClusterQueue reached its maximum of %d admitted workloads", *cq.MaxAdmittedWorkloads))
+	}
+	if lqReached {
+		reasons = append(reasons, fmt.Sprintf("the LocalQueue reached its maximum of %d admitted workloads", *cq.LocalQueueFor(info.Obj).MaxAdmittedWorkloads))
+	}
+	e.inadmissibleMsg = "couldn't admit the workload: " + strings.Join(reasons, "; ")
+	newTargets := s.preemptor.GetMaxAdmittedWorkloadsTargets(log, *info, count, snap, e.preemptionTargets)
+	if len(newTargets) == 0 {
+		e.assignment.LimitMode(flavorassigner.NoFit)
+		e.preemptionTargets = nil
+		return
+	}
+	e.assignment.LimitMode(flavorassigner.Preempt)
+	e.preemptionTargets = append(e.preemptionTargets, newTargets...)
+}
+
+// setWorkloadGroup gathers the pending members of the workload group of the
+// entry's workload, if any. It returns a message if the workload can't be
+// admitted because some members aren't pending in its ClusterQueue. As the
+// members are only gathered from the ClusterQueue of the entry, a group
+// spread across ClusterQueues is never admitted, and the message says so.
+func (s *Scheduler) setWorkloadGroup(e *entry) string {
+	if !features.Enabled(features.WorkloadGroups) {
+		return ""
+	}
+	name, size := workload.Group(e.Obj)
+	if size <= 1 {
+		return ""
+	}
+	head := e.Info
+	members := []*workload.Info{&head}
+	key := workload.Key(e.Obj)
+	for _, info := range s.queues.PendingWorkloadsInfo(e.ClusterQueue) {
+		if len(members) == size {
+			break
+		}
+		if workload.Key(info.Obj) != key && workload.InSameGroup(e.Obj, info.Obj) && !s.cache.IsAssumedOrAdmittedWorkload(*info) {
+			members = append(members, info)
+		}
+	}
+	if len(members) < size {
+		if others := s.queues.GroupClusterQueues(e.Obj).Delete(e.ClusterQueue); others.Len() > 0 {
+			return fmt.Sprintf("Members of the workload group %s are queued in other ClusterQueues %v; all the members of a workload group must be queued in the same ClusterQueue", name, sets.List(others))
+		}
+		return fmt.Sprintf("Waiting for %d more workload(s) of the workload group %s to be pending in the ClusterQueue", size-len(members), name)
+	}
+	e.group = members
+	e.groupInfo = workload.MergeGroup(members)
+	return ""
+}
+
+func fits(cq *cache.ClusterQueueSnapshot, e *entry, usage *workload.Usage, preemptedWorkloads preemptedWorkloads, newTargets []*preemption.Target) bool {
 	workloads := slices.Collect(maps.Values(preemptedWorkloads))
 	for _, target := range newTargets {
 		workloads = append(workloads, target.WorkloadInfo)
 	}
 	revertUsage := cq.SimulateUsageRemoval(workloads)
 	defer revertUsage()
-	return cq.Fits(*usage)
+	defer cq.ReleaseReservation(e.Obj)()
+	if e.group == nil {
+		if cqReached, lqReached := cq.MaxAdmittedWorkloadsReached(e.Obj, 1); cqReached || lqReached {
+			return false
+		}
+		return cq.Fits(*usage) && cq.FitsInLocalQueue(e.Obj, usage.Quota)
+	}
+	if cqReached, _ := cq.MaxAdmittedWorkloadsReached(e.Obj, len(e.group)); cqReached {
+		return false
+	}
+	return cq.Fits(*usage) && fitsInLocalQueues(cq, e)
+}
+
+// fitsInLocalQueues returns whether the members of the workload group of the
+// entry fit in their LocalQueues, charging each member's share of the
+// assignment to its own LocalQueue.
+func fitsInLocalQueues(cq *cache.ClusterQueueSnapshot, e *entry) bool {
+	type localQueueCharge struct {
+		wl        *kueue.Workload
+		workloads int
+		quota     resources.FlavorResourceQuantities
+	}
+	charges := make(map[string]*localQueueCharge)
+	for _, member := range e.memberEntries() {
+		key := workload.QueueKey(member.Obj)
+		charge, found := charges[key]
+		if !found {
+			charge = &localQueueCharge{wl: member.Obj, quota: make(resources.FlavorResourceQuantities)}
+			charges[key] = charge
+		}
+		charge.workloads++
+		for fr, q := range member.assignment.TotalRequestsFor(&member.Info) {
+			charge.quota[fr] += q
+		}
+	}
+	for _, charge := range charges {
+		if _, lqReached := cq.MaxAdmittedWorkloadsReached(charge.wl, charge.workloads); lqReached {
+			return false
+		}
+		if !cq.FitsInLocalQueue(charge.wl, charge.quota) {
+			return false
+		}
+	}
+	return true
+}
+
+// reserve adds the usage of the entry to the ClusterQueue, and counts its
+// workloads towards the maximum numbers of admitted workloads. The capacity
+// held back by the Reservations named by the workloads is reduced by the
+// quota they use.
+func reserve(cq *cache.ClusterQueueSnapshot, e *entry, usage workload.Usage) {
+	cq.AddUsage(usage)
+	if e.group == nil {
+		cq.AddReservingWorkload(e.Obj)
+		cq.ConsumeReservation(e.Obj, usage.Quota)
+		return
+	}
+	for _, member := range e.memberEntries() {
+		cq.AddReservingWorkload(member.Obj)
+		cq.ConsumeReservation(member.Obj, member.assignment.TotalRequestsFor(&member.Info))
+	}
 }
 
 // resourcesToReserve calculates how much of the available resources in cq/cohort assignment should be reserved.
@@ -398,10 +789,10 @@ func quotaResourcesToReserve(e *entry, cq *cache.ClusterQueueSnapshot) resources
 			if cqQuota.BorrowingLimit == nil {
 				reservedUsage[fr] = usage
 			} else {
-				reservedUsage[fr] = min(usage, cqQuota.Nominal+*cqQuota.BorrowingLimit-cq.ResourceNode.Usage[fr])
+				reservedUsage[fr] = min(usage, cqQuota.Nominal+*cqQuota.BorrowingLimit-cq.ResourceNode.Usage[fr]-cq.ResourceNode.Held[fr])
 			}
 		} else {
-			reservedUsage[fr] = max(0, min(usage, cqQuota.Nominal-cq.ResourceNode.Usage[fr]))
+			reservedUsage[fr] = max(0, min(usage, cqQuota.Nominal-cq.ResourceNode.Usage[fr]-cq.ResourceNode.Held[fr]))
 		}
 	}
 	return reservedUsage
@@ -413,15 +804,31 @@ type partialAssignment struct {
 }
 
 func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
-	assignment, targets := s.getInitialAssignments(log, wl, snap)
 	cq := snap.ClusterQueue(wl.ClusterQueue)
+	// The capacity held back by the Reservation named by the workload is
+	// available to it, possibly by preempting the workloads using it.
+	defer cq.ReleaseReservation(wl.Obj)()
+	replaced := workloadslicing.ReplacedSlice(wl.Obj, cq.Workloads)
+	if replaced != nil {
+		// The usage of the replaced slice is released once the workload
+		// reserves quota, so it's neither counted nor preempted.
+		snap.RemoveWorkload(replaced)
+		defer snap.AddWorkload(replaced)
+	}
+	assignment, targets := s.getInitialAssignments(log, wl, snap)
 	updateAssignmentForTAS(cq, wl, &assignment, targets)
+	if replaced != nil && replaced.Obj.Status.Admission != nil {
+		assignment.KeepFlavors(replaced.Obj.Status.Admission)
+	}
 	return assignment, targets
 }
 
 func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
 	cq := snap.ClusterQueue(wl.ClusterQueue)
-	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, s.fairSharing.Enable, preemption.NewOracle(s.preemptor, snap))
+	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, s.fairSharing.Enable, preemption.NewOracle(s.preemptor, snap), s.framework)
+	if len(s.decisionSinks) > 0 {
+		flvAssigner.WithDecisions()
+	}
 	fullAssignment := flvAssigner.Assign(log, nil)
 
 	arm := fullAssignment.RepresentativeMode()
@@ -430,7 +837,7 @@ func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, sn
 	}
 
 	if arm == flavorassigner.Preempt {
-		faPreemptionTargets := s.preemptor.GetTargets(log, *wl, fullAssignment, snap)
+		faPreemptionTargets := s.preemptionTargets(log, wl, fullAssignment, snap)
 		if len(faPreemptionTargets) > 0 {
 			return fullAssignment, faPreemptionTargets
 		}
@@ -445,7 +852,7 @@ func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, sn
 			}
 
 			if mode == flavorassigner.Preempt {
-				preemptionTargets := s.preemptor.GetTargets(log, *wl, assignment, snap)
+				preemptionTargets := s.preemptionTargets(log, wl, assignment, snap)
 				if len(preemptionTargets) > 0 {
 					return &partialAssignment{assignment: assignment, preemptionTargets: preemptionTargets}, true
 				}
@@ -459,6 +866,16 @@ func (s *Scheduler) getInitialAssignments(log logr.Logger, wl *workload.Info, sn
 	return fullAssignment, nil
 }
 
+// preemptionTargets returns the workloads to preempt for the workload to be
+// admitted with the assignment, selected by the postFilter plugins or, if
+// they find none, by the built-in preemption.
+func (s *Scheduler) preemptionTargets(log logr.Logger, wl *workload.Info, assignment flavorassigner.Assignment, snap *cache.Snapshot) []*preemption.Target {
+	if targets := s.framework.PostFilter(log, wl, assignment, snap); len(targets) > 0 {
+		return targets
+	}
+	return s.preemptor.GetTargets(log, *wl, assignment, snap)
+}
+
 func updateAssignmentForTAS(cq *cache.ClusterQueueSnapshot, wl *workload.Info, assignment *flavorassigner.Assignment, targets []*preemption.Target) {
 	if features.Enabled(features.TopologyAwareScheduling) && assignment.RepresentativeMode() == flavorassigner.Preempt && wl.IsRequestingTAS() {
 		tasRequests := assignment.WorkloadsTopologyRequests(wl, cq)
@@ -488,47 +905,50 @@ func updateAssignmentForTAS(cq *cache.ClusterQueueSnapshot, wl *workload.Info, a
 // the entry, and asynchronously updates the object in the apiserver after
 // assuming it in the cache.
 func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) error {
+	if e.group != nil {
+		return s.admitGroup(ctx, e, cq)
+	}
+	log := ctrl.LoggerFrom(ctx)
+	newWorkload := s.workloadWithAdmission(ctx, e, cq)
+	if err := s.cache.AssumeWorkload(newWorkload); err != nil {
+		return err
+	}
+	e.status = assumed
+	log.V(2).Info("Workload assumed in the cache")
+	s.applyAdmissionAsync(ctx, e, newWorkload)
+	return nil
+}
+
+// workloadWithAdmission returns a copy of the workload of the entry with the
+// quota reserved in the ClusterQueue, and admitted if it has all the
+// admission checks.
+func (s *Scheduler) workloadWithAdmission(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) *kueue.Workload {
 	log := ctrl.LoggerFrom(ctx)
 	newWorkload := e.Obj.DeepCopy()
 	admission := &kueue.Admission{
 		ClusterQueue:      e.ClusterQueue,
 		PodSetAssignments: e.assignment.ToAPI(),
 	}
+	if features.Enabled(features.CostAwareFlavorSelection) {
+		admission.Cost = e.assignment.Cost()
+	}
 
 	workload.SetQuotaReservation(newWorkload, admission, s.clock)
 	if workload.HasAllChecks(newWorkload, workload.AdmissionChecksForWorkload(log, newWorkload, cq.AdmissionChecks)) {
 		// sync Admitted, ignore the result since an API update is always done.
 		_ = workload.SyncAdmittedCondition(newWorkload, s.clock.Now())
 	}
-	if err := s.cache.AssumeWorkload(newWorkload); err != nil {
-		return err
-	}
-	e.status = assumed
-	log.V(2).Info("Workload assumed in the cache")
+	return newWorkload
+}
 
+// applyAdmissionAsync updates the assumed workload in the apiserver. If the
+// update fails, the workload is forgotten and the entry is requeued.
+func (s *Scheduler) applyAdmissionAsync(ctx context.Context, e *entry, newWorkload *kueue.Workload) {
+	log := ctrl.LoggerFrom(ctx)
 	s.admissionRoutineWrapper.Run(func() {
 		err := s.applyAdmission(ctx, newWorkload)
 		if err == nil {
-			waitTime := workload.QueuedWaitTime(newWorkload)
-			s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "QuotaReserved", "Quota reserved in ClusterQueue %v, wait time since queued was %.0fs", admission.ClusterQueue, waitTime.Seconds())
-			metrics.QuotaReservedWorkload(admission.ClusterQueue, waitTime)
-			if features.Enabled(features.LocalQueueMetrics) {
-				metrics.LocalQueueQuotaReservedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
-			}
-			if workload.IsAdmitted(newWorkload) {
-				s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was 0s", admission.ClusterQueue)
-				metrics.AdmittedWorkload(admission.ClusterQueue, waitTime)
-				if features.Enabled(features.LocalQueueMetrics) {
-					metrics.LocalQueueAdmittedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
-				}
-				if len(newWorkload.Status.AdmissionChecks) > 0 {
-					metrics.AdmissionChecksWaitTime(admission.ClusterQueue, 0)
-					if features.Enabled(features.LocalQueueMetrics) {
-						metrics.LocalQueueAdmissionChecksWaitTime(metrics.LQRefFromWorkload(newWorkload), 0)
-					}
-				}
-			}
-			log.V(2).Info("Workload successfully admitted and assigned flavors", "assignments", admission.PodSetAssignments)
+			s.reportAdmission(log, newWorkload)
 			return
 		}
 		// Ignore errors because the workload or clusterQueue could have been deleted
@@ -542,10 +962,134 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueS
 		log.Error(err, errCouldNotAdmitWL)
 		s.requeueAndUpdate(ctx, *e)
 	})
+}
+
+// reportAdmission records the events and metrics of a workload whose
+// admission was applied in the apiserver.
+func (s *Scheduler) reportAdmission(log logr.Logger, newWorkload *kueue.Workload) {
+	admission := newWorkload.Status.Admission
+	waitTime := workload.QueuedWaitTime(newWorkload)
+	s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "QuotaReserved", "Quota reserved in ClusterQueue %v, wait time since queued was %.0fs", admission.ClusterQueue, waitTime.Seconds())
+	metrics.QuotaReservedWorkload(admission.ClusterQueue, waitTime)
+	if admission.Cost != nil {
+		metrics.QuotaReservedCost(admission.ClusterQueue, admission.Cost.AsApproximateFloat64())
+	}
+	if features.Enabled(features.LocalQueueMetrics) {
+		metrics.LocalQueueQuotaReservedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
+	}
+	if workload.IsAdmitted(newWorkload) {
+		s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was 0s", admission.ClusterQueue)
+		metrics.AdmittedWorkload(admission.ClusterQueue, waitTime)
+		if features.Enabled(features.LocalQueueMetrics) {
+			metrics.LocalQueueAdmittedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
+		}
+		if len(newWorkload.Status.AdmissionChecks) > 0 {
+			metrics.AdmissionChecksWaitTime(admission.ClusterQueue, 0)
+			if features.Enabled(features.LocalQueueMetrics) {
+				metrics.LocalQueueAdmissionChecksWaitTime(metrics.LQRefFromWorkload(newWorkload), 0)
+			}
+		}
+	}
+	log.V(2).Info("Workload successfully admitted and assigned flavors", "assignments", admission.PodSetAssignments)
+}
 
+// memberEntries splits the entry of a workload group into an entry for each
+// member, with its share of the assignment of the group.
+func (e *entry) memberEntries() []*entry {
+	entries := make([]*entry, 0, len(e.group))
+	offset := 0
+	for _, member := range e.group {
+		memberEntry := &entry{
+			Info:                 *member,
+			status:               e.status,
+			clusterQueueSnapshot: e.clusterQueueSnapshot,
+		}
+		memberEntry.ClusterQueue = e.ClusterQueue
+		memberEntry.LastAssignment = nil
+		podSets := slices.Clone(e.assignment.PodSets[offset : offset+len(member.TotalRequests)])
+		for i := range podSets {
+			podSets[i].Name = member.TotalRequests[i].Name
+		}
+		offset += len(member.TotalRequests)
+		memberEntry.assignment = flavorassigner.Assignment{
+			PodSets:   podSets,
+			Borrowing: e.assignment.Borrowing,
+		}
+		entries = append(entries, memberEntry)
+	}
+	return entries
+}
+
+// admitGroup admits the members of the workload group of the entry, splitting
+// the assignment of the group between them. The members are assumed in the
+// cache all at once, and the apiserver is updated for all of them in a single
+// routine: if the update of any member fails, all the members are forgotten,
+// the quota reservation of the ones already updated is released, and the
+// members are requeued, so that the group is never partially admitted.
+func (s *Scheduler) admitGroup(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) error {
+	log := ctrl.LoggerFrom(ctx)
+	members := e.memberEntries()
+	newWorkloads := make([]*kueue.Workload, len(members))
+	for i, member := range members {
+		newWorkloads[i] = s.workloadWithAdmission(ctx, member, cq)
+	}
+	if err := s.cache.AssumeWorkloads(newWorkloads); err != nil {
+		return err
+	}
+	e.status = assumed
+	for _, member := range members {
+		member.status = assumed
+		log.V(2).Info("Workload group member assumed in the cache", "groupMember", klog.KObj(member.Obj))
+	}
+	s.admissionRoutineWrapper.Run(func() {
+		for i, member := range members {
+			err := s.applyAdmission(ctx, newWorkloads[i])
+			if err == nil {
+				continue
+			}
+			memberLog := log.WithValues("groupMember", klog.KObj(member.Obj))
+			deleted := apierrors.IsNotFound(err)
+			if deleted {
+				memberLog.V(2).Info("Workload group not admitted because a member was deleted")
+			} else {
+				memberLog.Error(err, errCouldNotAdmitWL)
+			}
+			s.rollbackGroupAdmission(ctx, members, newWorkloads, i, deleted, fmt.Sprintf("couldn't admit the workload group member %s: %v", klog.KObj(member.Obj), err))
+			return
+		}
+		for i, member := range members {
+			s.reportAdmission(log.WithValues("groupMember", klog.KObj(member.Obj)), newWorkloads[i])
+		}
+	})
 	return nil
 }
 
+// rollbackGroupAdmission forgets all the members of a workload group whose
+// admission failed to be applied for the member at index failed, releases the
+// quota reservation of the members admitted in the apiserver before it, and
+// requeues the members, except the failed one if it was deleted.
+func (s *Scheduler) rollbackGroupAdmission(ctx context.Context, members []*entry, newWorkloads []*kueue.Workload, failed int, deleted bool, msg string) {
+	log := ctrl.LoggerFrom(ctx)
+	for _, w := range newWorkloads {
+		// Ignore errors because the workload or clusterQueue could have been deleted
+		// by an event.
+		_ = s.cache.ForgetWorkload(w)
+	}
+	for _, w := range newWorkloads[:failed] {
+		unreserved := w.DeepCopy()
+		workload.UnsetQuotaReservationWithCondition(unreserved, "Pending", msg, s.clock.Now())
+		if err := s.applyAdmission(ctx, unreserved); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "Could not release the quota reservation of the workload group member", "groupMember", klog.KObj(w))
+		}
+	}
+	for i, member := range members {
+		if i == failed && deleted {
+			continue
+		}
+		s.requeueAndUpdate(ctx, *member)
+	}
+}
+
 func (s *Scheduler) applyAdmissionWithSSA(ctx context.Context, w *kueue.Workload) error {
 	return workload.ApplyAdmissionStatus(ctx, s.client, w, false, s.clock)
 }
@@ -553,6 +1097,7 @@ func (s *Scheduler) applyAdmissionWithSSA(ctx context.Context, w *kueue.Workload
 type entryOrdering struct {
 	entries          []entry
 	workloadOrdering workload.Ordering
+	queueSort        framework.QueueSortPlugin
 }
 
 func (e entryOrdering) Len() int {
@@ -575,10 +1120,15 @@ func (e entryOrdering) Less(i, j int) bool {
 		return !aBorrows
 	}
 
+	// The queueSort plugin replaces the priority and FIFO ordering.
+	if e.queueSort != nil {
+		return e.queueSort.Less(&a.Info, &b.Info)
+	}
+
 	// 2. Higher priority first if not disabled.
 	if features.Enabled(features.PrioritySortingWithinCohort) {
-		p1 := priority.Priority(a.Obj)
-		p2 := priority.Priority(b.Obj)
+		p1 := a.priority()
+		p2 := b.priority()
 		if p1 != p2 {
 			return p1 > p2
 		}
@@ -597,11 +1147,11 @@ type entryIterator interface {
 	hasNext() bool
 }
 
-func makeIterator(ctx context.Context, entries []entry, workloadOrdering workload.Ordering, enableFairSharing bool) entryIterator {
+func makeIterator(ctx context.Context, entries []entry, workloadOrdering workload.Ordering, queueSort framework.QueueSortPlugin, enableFairSharing, historicalUsage bool) entryIterator {
 	if enableFairSharing {
-		return makeFairSharingIterator(ctx, entries, workloadOrdering)
+		return makeFairSharingIterator(ctx, entries, workloadOrdering, queueSort, historicalUsage)
 	}
-	return makeClassicalIterator(entries, workloadOrdering)
+	return makeClassicalIterator(entries, workloadOrdering, queueSort)
 }
 
 // classicalIterator returns entries ordered on:
@@ -623,10 +1173,11 @@ func (co *classicalIterator) pop() *entry {
 	return head
 }
 
-func makeClassicalIterator(entries []entry, workloadOrdering workload.Ordering) *classicalIterator {
+func makeClassicalIterator(entries []entry, workloadOrdering workload.Ordering, queueSort framework.QueueSortPlugin) *classicalIterator {
 	sort.Sort(entryOrdering{
 		entries:          entries,
 		workloadOrdering: workloadOrdering,
+		queueSort:        queueSort,
 	})
 	return &classicalIterator{
 		entries: entries,
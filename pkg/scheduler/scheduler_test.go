@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -46,12 +47,14 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	tasindexer "sigs.k8s.io/kueue/pkg/controller/tas/indexer"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/framework"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/util/slices"
@@ -60,6 +63,7 @@ import (
 	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
 	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
 	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/pkg/workloadslicing"
 )
 
 const (
@@ -77,8 +81,8 @@ func TestSchedule(t *testing.T) {
 
 	resourceFlavors := []*kueue.ResourceFlavor{
 		utiltesting.MakeResourceFlavor("default").Obj(),
-		utiltesting.MakeResourceFlavor("on-demand").Obj(),
-		utiltesting.MakeResourceFlavor("spot").Obj(),
+		utiltesting.MakeResourceFlavor("on-demand").Cost(corev1.ResourceCPU, "3").Obj(),
+		utiltesting.MakeResourceFlavor("spot").Cost(corev1.ResourceCPU, "1").Obj(),
 		utiltesting.MakeResourceFlavor("model-a").Obj(),
 	}
 	clusterQueues := []kueue.ClusterQueue{
@@ -245,16 +249,30 @@ func TestSchedule(t *testing.T) {
 		disableLendingLimit     bool
 		disablePartialAdmission bool
 		enableFairSharing       bool
+		enableBackfill          bool
+		enableWorkloadGroups    bool
+		enableCostAware         bool
+		enableMaxAdmitted       bool
+		enableParallel          bool
+		// plugins enables the counting plugin at the extension points.
+		plugins *config.SchedulerPlugins
+		// fairSharingUsageHalfLifeTime makes fair sharing account for
+		// the historical usage, if non-zero.
+		fairSharingUsageHalfLifeTime time.Duration
 
 		workloads      []kueue.Workload
 		objects        []client.Object
 		admissionError error
+		// admissionErrors are the errors of the admission of specific
+		// workloads, by key.
+		admissionErrors map[string]error
 
 		// additional*Queues can hold any extra queues needed by the tc
 		additionalClusterQueues []kueue.ClusterQueue
 		additionalLocalQueues   []kueue.LocalQueue
 
-		cohorts []kueuealpha.Cohort
+		cohorts      []kueuealpha.Cohort
+		reservations []kueuealpha.Reservation
 
 		// wantAssignments is a summary of all the admissions in the cache after this cycle.
 		wantAssignments map[string]kueue.Admission
@@ -564,6 +582,189 @@ func TestSchedule(t *testing.T) {
 			},
 			wantScheduled: []string{"eng-alpha/new", "eng-beta/new"},
 		},
+		"parallel: independent cohort trees are scheduled": {
+			enableParallel: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("main").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					Request(corev1.ResourceCPU, "40").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					Request(corev1.ResourceCPU, "60").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "lend").
+					Queue("lend-a-queue").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakeWorkload("missing-flavor", "sales").
+					Queue("flavor-nonexistent-queue").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new":     *utiltesting.MakeAdmission("sales").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+				"eng-alpha/new": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "40").Obj(),
+				"eng-beta/new":  *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "60").Obj(),
+				"lend/new":      *utiltesting.MakeAdmission("lend-a").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantScheduled: []string{"sales/new", "eng-alpha/new", "eng-beta/new", "lend/new"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"flavor-nonexistent-cq": {"sales/missing-flavor"},
+			},
+		},
+		"parallel: independent cohort trees run the plugins concurrently": {
+			enableParallel: true,
+			plugins: &config.SchedulerPlugins{
+				FlavorFilter: []config.SchedulerPlugin{{Name: countingPluginName}},
+				FlavorScore:  []config.SchedulerPlugin{{Name: countingPluginName}},
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("main").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					Request(corev1.ResourceCPU, "40").
+					Obj(),
+				*utiltesting.MakeWorkload("new", "lend").
+					Queue("lend-a-queue").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new":     *utiltesting.MakeAdmission("sales").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+				"eng-alpha/new": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "40").Obj(),
+				"lend/new":      *utiltesting.MakeAdmission("lend-a").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantScheduled: []string{"sales/new", "eng-alpha/new", "lend/new"},
+		},
+		"parallel: independent cohort trees preempt concurrently": {
+			enableParallel: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("standalone").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("standalone", "sales").ClusterQueue("standalone").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					Priority(4).
+					Request("example.com/gpu", "20").
+					Obj(),
+				*utiltesting.MakeWorkload("old", "eng-beta").
+					Priority(-4).
+					Request("example.com/gpu", "10").
+					ReserveQuota(utiltesting.MakeAdmission("eng-beta").Assignment("example.com/gpu", "model-a", "10").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "sales").
+					Queue("standalone").
+					Priority(10).
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("low", "sales").
+					Priority(0).
+					Request(corev1.ResourceCPU, "10").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "10").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "lend").
+					Queue("lend-a-queue").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-beta/old": *utiltesting.MakeAdmission("eng-beta").Assignment("example.com/gpu", "model-a", "10").Obj(),
+				"sales/low":    *utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "10").Obj(),
+				"lend/new":     *utiltesting.MakeAdmission("lend-a").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantScheduled: []string{"lend/new"},
+			wantPreempted: sets.New("eng-beta/old", "sales/low"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"eng-beta":   {"eng-beta/new"},
+				"standalone": {"sales/high"},
+			},
+		},
+		"parallel: independent cohort trees backfill and admit workload groups concurrently": {
+			enableParallel:       true,
+			enableBackfill:       true,
+			enableWorkloadGroups: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("groups").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "20").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("groups", "sales").ClusterQueue("groups").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "sales").
+					Queue("main").
+					MaximumExecutionTimeSeconds(600).
+					Request(corev1.ResourceCPU, "40").
+					ReserveQuota(utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "40").Obj()).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltesting.MakeWorkload("head", "sales").
+					Queue("main").
+					Creation(now.Add(-3*time.Second)).
+					Request(corev1.ResourceCPU, "20").
+					Obj(),
+				*utiltesting.MakeWorkload("short", "sales").
+					Queue("main").
+					Creation(now.Add(-time.Second)).
+					MaximumExecutionTimeSeconds(300).
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+				*utiltesting.MakeWorkload("server", "sales").
+					Queue("groups").
+					Creation(now.Add(-2*time.Second)).
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+				*utiltesting.MakeWorkload("workers", "sales").
+					Queue("groups").
+					Creation(now.Add(-time.Second)).
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					PodSets(*utiltesting.MakePodSet("worker", 2).
+						Request(corev1.ResourceCPU, "5").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					Request(corev1.ResourceCPU, "40").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/running": *utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "40").Obj(),
+				"sales/short":   *utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "5").Obj(),
+				"sales/server":  *utiltesting.MakeAdmission("groups", "main").Assignment(corev1.ResourceCPU, "default", "5").Obj(),
+				"sales/workers": *utiltesting.MakeAdmission("groups", "worker").
+					Assignment(corev1.ResourceCPU, "default", "10").
+					AssignmentPodCount(2).
+					Obj(),
+				"eng-alpha/new": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "40").Obj(),
+			},
+			wantScheduled: []string{"sales/short", "sales/server", "sales/workers", "eng-alpha/new"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales":  {"sales/head", "sales/short"},
+				"groups": {"sales/workers"},
+			},
+		},
 		"assign multiple resources and flavors": {
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("new", "eng-beta").
@@ -1923,6 +2124,64 @@ func TestSchedule(t *testing.T) {
 				"b": {"eng-alpha/b1"},
 			},
 		},
+		"fair sharing schedule lower historical usage first": {
+			enableFairSharing:            true,
+			fairSharingUsageHalfLifeTime: time.Hour,
+			cohorts: []kueuealpha.Cohort{
+				utiltesting.MakeCohort("A").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").
+							Resource(corev1.ResourceCPU, "10").Obj(),
+					).Cohort,
+			},
+			additionalClusterQueues: []kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("b").
+					Cohort("A").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").
+							Resource(corev1.ResourceCPU, "0").Obj(),
+					).
+					ClusterQueue,
+				// c used 8 CPUs on average in the past.
+				utiltesting.MakeClusterQueue("c").
+					Cohort("A").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").
+							Resource(corev1.ResourceCPU, "0").Obj(),
+					).
+					ConsumedResources(corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("41549.6"),
+					}, now).
+					ClusterQueue,
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq-b", "eng-alpha").ClusterQueue("b").Obj(),
+				*utiltesting.MakeLocalQueue("lq-c", "eng-alpha").ClusterQueue("c").Obj(),
+			},
+			workloads: []kueue.Workload{
+				utiltesting.MakeWorkload("b1", "eng-alpha").
+					Creation(now.Add(time.Second)).
+					Queue("lq-b").
+					PodSets(utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "10").
+						PodSet).
+					Workload,
+				utiltesting.MakeWorkload("c1", "eng-alpha").
+					Creation(now).
+					Queue("lq-c").
+					PodSets(utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "10").
+						PodSet).
+					Workload,
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/b1": *utiltesting.MakeAdmission("b", "one").Assignment(corev1.ResourceCPU, "on-demand", "10").Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/b1"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"c": {"eng-alpha/c1"},
+			},
+		},
 		"minimal preemptions when target queue is exhausted": {
 			additionalClusterQueues: []kueue.ClusterQueue{
 				*utiltesting.MakeClusterQueue("other-alpha").
@@ -2945,111 +3204,618 @@ func TestSchedule(t *testing.T) {
 				"eng-gamma/Admitted-Workload-3": *utiltesting.MakeAdmission("CQ3").Assignment("gpu", "on-demand", "5").Obj(),
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			metrics.AdmissionCyclePreemptionSkips.Reset()
-			if tc.disableLendingLimit {
-				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
-			}
-			if tc.disablePartialAdmission {
-				features.SetFeatureGateDuringTest(t, features.PartialAdmission, false)
-			}
-			ctx, _ := utiltesting.ContextWithLog(t)
-
-			allQueues := append(queues, tc.additionalLocalQueues...)
-			allClusterQueues := append(clusterQueues, tc.additionalClusterQueues...)
-
-			clientBuilder := utiltesting.NewClientBuilder().
-				WithLists(&kueue.WorkloadList{Items: tc.workloads}, &kueue.LocalQueueList{Items: allQueues}).
-				WithObjects(append(
-					[]client.Object{
-						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-alpha", Labels: map[string]string{"dep": "eng"}}},
-						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-beta", Labels: map[string]string{"dep": "eng"}}},
-						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-gamma", Labels: map[string]string{"dep": "eng"}}},
-						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sales", Labels: map[string]string{"dep": "sales"}}},
-						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "lend", Labels: map[string]string{"dep": "lend"}}},
-					}, tc.objects...,
-				)...)
-			cl := clientBuilder.Build()
-			recorder := &utiltesting.EventRecorder{}
-			cqCache := cache.New(cl)
-			qManager := queue.NewManager(cl, cqCache)
-			// Workloads are loaded into queues or clusterQueues as we add them.
-			for _, q := range allQueues {
-				if err := qManager.AddLocalQueue(ctx, &q); err != nil {
-					t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
-				}
-			}
-			for i := range resourceFlavors {
-				cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
-			}
-			for _, cq := range allClusterQueues {
-				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
-					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
-				}
-				if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
-					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
-				}
-				if err := cl.Create(ctx, &cq); err != nil {
-					t.Errorf("couldn't create the cluster queue: %v", err)
-				}
-			}
-
-			for _, cohort := range tc.cohorts {
-				if err := cqCache.AddOrUpdateCohort(&cohort); err != nil {
-					t.Fatalf("Inserting Cohort %s in cache: %v", cohort.Name, err)
-				}
-			}
-
-			scheduler := New(qManager, cqCache, cl, recorder, WithFairSharing(&config.FairSharing{Enable: tc.enableFairSharing}), WithClock(t, fakeClock))
-			gotScheduled := make(map[string]kueue.Admission)
-			var mu sync.Mutex
-			scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
-				if tc.admissionError != nil {
-					return tc.admissionError
-				}
-				mu.Lock()
-				gotScheduled[workload.Key(w)] = *w.Status.Admission
-				mu.Unlock()
-				return nil
-			}
-			wg := sync.WaitGroup{}
-			scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
-				func() { wg.Add(1) },
-				func() { wg.Done() },
-			))
-			gotPreempted := sets.New[string]()
-			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
-				mu.Lock()
-				gotPreempted.Insert(workload.Key(w))
-				mu.Unlock()
-				return nil
-			})
-
-			ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
-			go qManager.CleanUpOnContext(ctx)
-			defer cancel()
-
-			scheduler.schedule(ctx)
-			wg.Wait()
-
-			wantScheduled := make(map[string]kueue.Admission)
-			for _, key := range tc.wantScheduled {
-				wantScheduled[key] = tc.wantAssignments[key]
-			}
-			if diff := cmp.Diff(wantScheduled, gotScheduled); diff != "" {
-				t.Errorf("Unexpected scheduled workloads (-want,+got):\n%s", diff)
-			}
-
-			if diff := cmp.Diff(tc.wantPreempted, gotPreempted); diff != "" {
-				t.Errorf("Unexpected preemptions (-want,+got):\n%s", diff)
-			}
-
-			// Verify assignments in cache.
-			gotAssignments := make(map[string]kueue.Admission)
-			var gotWorkloads []kueue.Workload
+		"workload slice only needs the quota exceeding the replaced workload slice": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 40).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("sales", "one").
+						Assignment(corev1.ResourceCPU, "default", "40000m").
+						AssignmentPodCount(40).
+						Obj()).
+					Admitted(true).
+					Obj(),
+				*utiltesting.MakeWorkload("foo-slice", "sales").
+					Queue("main").
+					Annotation(workloadslicing.ReplacementForAnnotationKey, "sales/foo").
+					PodSets(*utiltesting.MakePodSet("one", 45).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/foo-slice": {
+					ClusterQueue: "sales",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("45000m"),
+							},
+							Count: ptr.To[int32](45),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"sales/foo-slice"},
+		},
+		"backfill the workloads finishing before the blocked head can start": {
+			enableBackfill: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "sales").
+					Queue("main").
+					MaximumExecutionTimeSeconds(600).
+					Request(corev1.ResourceCPU, "40").
+					ReserveQuota(utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "40").Obj()).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltesting.MakeWorkload("head", "sales").
+					Queue("main").
+					Creation(now.Add(-3*time.Second)).
+					Request(corev1.ResourceCPU, "20").
+					Obj(),
+				*utiltesting.MakeWorkload("long", "sales").
+					Queue("main").
+					Creation(now.Add(-2*time.Second)).
+					MaximumExecutionTimeSeconds(3600).
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+				*utiltesting.MakeWorkload("short", "sales").
+					Queue("main").
+					Creation(now.Add(-time.Second)).
+					MaximumExecutionTimeSeconds(300).
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/running": *utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "40").Obj(),
+				"sales/short":   *utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "5").Obj(),
+			},
+			wantScheduled: []string{"sales/short"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/head", "sales/long", "sales/short"},
+			},
+		},
+		"no backfill when the blocked head can't start as the running workloads exceed their maximum execution time": {
+			enableBackfill: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("running", "sales").
+					Queue("main").
+					Request(corev1.ResourceCPU, "40").
+					ReserveQuota(utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "40").Obj()).
+					AdmittedAt(true, now).
+					Obj(),
+				*utiltesting.MakeWorkload("head", "sales").
+					Queue("main").
+					Creation(now.Add(-2*time.Second)).
+					Request(corev1.ResourceCPU, "20").
+					Obj(),
+				*utiltesting.MakeWorkload("short", "sales").
+					Queue("main").
+					Creation(now.Add(-time.Second)).
+					MaximumExecutionTimeSeconds(300).
+					Request(corev1.ResourceCPU, "5").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/running": *utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "40").Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/head", "sales/short"},
+			},
+		},
+		"workload naming a reservation uses the reserved capacity": {
+			reservations: []kueuealpha.Reservation{
+				*utiltesting.MakeReservation("reservation", now.Add(-time.Minute), time.Hour).
+					ClusterQueue("sales").
+					Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")}).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("reserved", "sales").
+					Queue("main").
+					Label(kueuealpha.ReservationLabel, "reservation").
+					Request(corev1.ResourceCPU, "40").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/reserved": *utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "40").Obj(),
+			},
+			wantScheduled: []string{"sales/reserved"},
+		},
+		"capacity of a reservation used by a workload admitted in the cycle is no longer held": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("reserving").
+					Cohort("reservations").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "20").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("borrowing").
+					Cohort("reservations").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "0").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("reserving", "sales").ClusterQueue("reserving").Obj(),
+				*utiltesting.MakeLocalQueue("borrowing", "sales").ClusterQueue("borrowing").Obj(),
+			},
+			reservations: []kueuealpha.Reservation{
+				*utiltesting.MakeReservation("reservation", now.Add(-time.Minute), time.Hour).
+					ClusterQueue("reserving").
+					Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("reserved", "sales").
+					Queue("reserving").
+					Label(kueuealpha.ReservationLabel, "reservation").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("borrower", "sales").
+					Queue("borrowing").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/reserved": *utiltesting.MakeAdmission("reserving").Assignment(corev1.ResourceCPU, "default", "10").Obj(),
+				"sales/borrower": *utiltesting.MakeAdmission("borrowing").Assignment(corev1.ResourceCPU, "default", "10").Obj(),
+			},
+			wantScheduled: []string{"sales/reserved", "sales/borrower"},
+		},
+		"workload not naming a reservation can't use the reserved capacity": {
+			reservations: []kueuealpha.Reservation{
+				*utiltesting.MakeReservation("reservation", now.Add(-time.Minute), time.Hour).
+					ClusterQueue("sales").
+					Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")}).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("other", "sales").
+					Queue("main").
+					Request(corev1.ResourceCPU, "40").
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/other"},
+			},
+		},
+		"workload naming a reservation can't use the capacity held back before the time window": {
+			reservations: []kueuealpha.Reservation{
+				*utiltesting.MakeReservation("reservation", now.Add(time.Minute), time.Hour).
+					ClusterQueue("sales").
+					Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")}).
+					DrainDuration(10 * time.Minute).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("reserved", "sales").
+					Queue("main").
+					Label(kueuealpha.ReservationLabel, "reservation").
+					Request(corev1.ResourceCPU, "40").
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/reserved"},
+			},
+		},
+		"workload group members are admitted together": {
+			enableWorkloadGroups: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("server", "sales").
+					Queue("main").
+					Creation(now.Add(-2*time.Second)).
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("workers", "sales").
+					Queue("main").
+					Creation(now.Add(-time.Second)).
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					PodSets(*utiltesting.MakePodSet("worker", 4).
+						Request(corev1.ResourceCPU, "5").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/server": *utiltesting.MakeAdmission("sales", "main").Assignment(corev1.ResourceCPU, "default", "10").Obj(),
+				"sales/workers": *utiltesting.MakeAdmission("sales", "worker").
+					Assignment(corev1.ResourceCPU, "default", "20").
+					AssignmentPodCount(4).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/server", "sales/workers"},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/workers"},
+			},
+		},
+		"workload group members are not admitted when the admission of a member fails": {
+			enableWorkloadGroups: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("server", "sales").
+					Queue("main").
+					Creation(now.Add(-2*time.Second)).
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("workers", "sales").
+					Queue("main").
+					Creation(now.Add(-time.Second)).
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					PodSets(*utiltesting.MakePodSet("worker", 4).
+						Request(corev1.ResourceCPU, "5").
+						Obj()).
+					Obj(),
+			},
+			admissionErrors: map[string]error{
+				"sales/workers": errors.New("admission"),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/server", "sales/workers"},
+			},
+		},
+		"lowest-cost flavor is assigned": {
+			enableCostAware: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("lowest-cost").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					FlavorSelectionPolicy(kueue.LowestCostFlavorSelection).
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+						*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+					).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lowest-cost", "sales").ClusterQueue("lowest-cost").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("lowest-cost").
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/foo": *utiltesting.MakeAdmission("lowest-cost").
+					Assignment(corev1.ResourceCPU, "spot", "4").
+					Cost("4").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/foo"},
+		},
+		"workload isn't admitted when the ClusterQueue reached maxAdmittedWorkloads": {
+			enableMaxAdmitted: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("limited").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					MaxAdmittedWorkloads(1).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("limited", "sales").ClusterQueue("limited").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("admitted", "sales").
+					Queue("limited").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuota(utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("limited").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/admitted": *utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"limited": {"sales/new"},
+			},
+		},
+		"workload isn't admitted when the LocalQueue reached maxAdmittedWorkloads": {
+			enableMaxAdmitted: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("limited").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("limited", "sales").ClusterQueue("limited").MaxAdmittedWorkloads(1).Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("admitted", "sales").
+					Queue("limited").
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuota(utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("limited").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/admitted": *utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"limited": {"sales/new"},
+			},
+		},
+		"workload preempts a lower priority workload to fit in maxAdmittedWorkloads": {
+			enableMaxAdmitted: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("limited").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					MaxAdmittedWorkloads(1).
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("limited", "sales").ClusterQueue("limited").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "sales").
+					Queue("limited").
+					Priority(0).
+					Request(corev1.ResourceCPU, "1").
+					ReserveQuota(utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "sales").
+					Queue("limited").
+					Priority(10).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/low": *utiltesting.MakeAdmission("limited").Assignment(corev1.ResourceCPU, "default", "1").Obj(),
+			},
+			wantPreempted: sets.New("sales/low"),
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"limited": {"sales/high"},
+			},
+		},
+		"workload group waits for all the members": {
+			enableWorkloadGroups: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("server", "sales").
+					Queue("main").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "3").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("workers", "sales").
+					Queue("main").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "3").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/server", "sales/workers"},
+			},
+		},
+		"workload group spread across ClusterQueues isn't admitted": {
+			enableWorkloadGroups: true,
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("other").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "50").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("other", "sales").ClusterQueue("other").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("server", "sales").
+					Queue("main").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+				*utiltesting.MakeWorkload("workers", "sales").
+					Queue("other").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Request(corev1.ResourceCPU, "10").
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/server"},
+			},
+			wantInadmissibleLeft: map[kueue.ClusterQueueReference][]string{
+				"other": {"sales/workers"},
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "server"},
+					EventType: corev1.EventTypeWarning,
+					Reason:    "Pending",
+					Message:   "Members of the workload group group are queued in other ClusterQueues [other]; all the members of a workload group must be queued in the same ClusterQueue",
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "workers"},
+					EventType: corev1.EventTypeWarning,
+					Reason:    "Pending",
+					Message:   "Members of the workload group group are queued in other ClusterQueues [sales]; all the members of a workload group must be queued in the same ClusterQueue",
+				},
+			},
+			eventCmpOpts: []cmp.Option{cmpopts.SortSlices(func(a, b utiltesting.EventRecord) bool { return a.Key.String() < b.Key.String() })},
+		},
+		"workload group isn't admitted if the members don't fit together": {
+			enableWorkloadGroups: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("server", "sales").
+					Queue("main").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Request(corev1.ResourceCPU, "30").
+					Obj(),
+				*utiltesting.MakeWorkload("workers", "sales").
+					Queue("main").
+					Label(controllerconsts.WorkloadGroupLabel, "group").
+					Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "2").
+					Request(corev1.ResourceCPU, "30").
+					Obj(),
+			},
+			wantLeft: map[kueue.ClusterQueueReference][]string{
+				"sales": {"sales/server", "sales/workers"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			metrics.AdmissionCyclePreemptionSkips.Reset()
+			if tc.disableLendingLimit {
+				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
+			}
+			if tc.disablePartialAdmission {
+				features.SetFeatureGateDuringTest(t, features.PartialAdmission, false)
+			}
+			features.SetFeatureGateDuringTest(t, features.BackfillScheduling, tc.enableBackfill)
+			features.SetFeatureGateDuringTest(t, features.WorkloadGroups, tc.enableWorkloadGroups)
+			features.SetFeatureGateDuringTest(t, features.CostAwareFlavorSelection, tc.enableCostAware)
+			features.SetFeatureGateDuringTest(t, features.MaxAdmittedWorkloads, tc.enableMaxAdmitted)
+			features.SetFeatureGateDuringTest(t, features.ParallelScheduling, tc.enableParallel)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			allQueues := append(queues, tc.additionalLocalQueues...)
+			allClusterQueues := append(clusterQueues, tc.additionalClusterQueues...)
+
+			clientBuilder := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: tc.workloads}, &kueue.LocalQueueList{Items: allQueues}).
+				WithObjects(append(
+					[]client.Object{
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-alpha", Labels: map[string]string{"dep": "eng"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-beta", Labels: map[string]string{"dep": "eng"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-gamma", Labels: map[string]string{"dep": "eng"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sales", Labels: map[string]string{"dep": "sales"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "lend", Labels: map[string]string{"dep": "lend"}}},
+					}, tc.objects...,
+				)...)
+			cl := clientBuilder.Build()
+			recorder := &utiltesting.EventRecorder{}
+			cacheOpts := []cache.Option{cache.WithClock(fakeClock)}
+			fairSharing := &config.FairSharing{Enable: tc.enableFairSharing}
+			if tc.fairSharingUsageHalfLifeTime > 0 {
+				cacheOpts = append(cacheOpts, cache.WithUsageHalfLifeTime(tc.fairSharingUsageHalfLifeTime))
+				fairSharing.UsageHalfLifeTime = &metav1.Duration{Duration: tc.fairSharingUsageHalfLifeTime}
+			}
+			cqCache := cache.New(cl, cacheOpts...)
+			qManager := queue.NewManager(cl, cqCache)
+			// Workloads are loaded into queues or clusterQueues as we add them.
+			for _, q := range allQueues {
+				if err := qManager.AddLocalQueue(ctx, &q); err != nil {
+					t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+				}
+			}
+			for i := range resourceFlavors {
+				cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
+			}
+			for _, cq := range allClusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+				}
+				if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+				}
+				if err := cl.Create(ctx, &cq); err != nil {
+					t.Errorf("couldn't create the cluster queue: %v", err)
+				}
+			}
+
+			for _, cohort := range tc.cohorts {
+				if err := cqCache.AddOrUpdateCohort(&cohort); err != nil {
+					t.Fatalf("Inserting Cohort %s in cache: %v", cohort.Name, err)
+				}
+			}
+			for i := range tc.reservations {
+				cqCache.AddOrUpdateReservation(&tc.reservations[i])
+			}
+
+			schedulerOpts := []Option{WithFairSharing(fairSharing), WithClock(t, fakeClock)}
+			plugin := &countingPlugin{}
+			if tc.plugins != nil {
+				registry := framework.Registry{
+					countingPluginName: func(client.Client) (framework.Plugin, error) { return plugin, nil },
+				}
+				f, err := framework.New(registry, &config.Scheduler{Plugins: tc.plugins}, cl)
+				if err != nil {
+					t.Fatalf("Building the plugins: %v", err)
+				}
+				schedulerOpts = append(schedulerOpts, WithFramework(f))
+			}
+			scheduler := New(qManager, cqCache, cl, recorder, schedulerOpts...)
+			gotScheduled := make(map[string]kueue.Admission)
+			var mu sync.Mutex
+			scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
+				if tc.admissionError != nil {
+					return tc.admissionError
+				}
+				if err := tc.admissionErrors[workload.Key(w)]; err != nil {
+					return err
+				}
+				mu.Lock()
+				if w.Status.Admission != nil {
+					gotScheduled[workload.Key(w)] = *w.Status.Admission
+				} else {
+					delete(gotScheduled, workload.Key(w))
+				}
+				mu.Unlock()
+				return nil
+			}
+			wg := sync.WaitGroup{}
+			scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+				func() { wg.Add(1) },
+				func() { wg.Done() },
+			))
+			gotPreempted := sets.New[string]()
+			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
+				mu.Lock()
+				gotPreempted.Insert(workload.Key(w))
+				mu.Unlock()
+				return nil
+			})
+
+			ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+			go qManager.CleanUpOnContext(ctx)
+			defer cancel()
+
+			scheduler.schedule(ctx)
+			wg.Wait()
+
+			if tc.plugins != nil && (plugin.filtered.Load() == 0 || plugin.scored.Load() == 0) {
+				t.Errorf("Expected the plugin to be called, filtered %d and scored %d flavors", plugin.filtered.Load(), plugin.scored.Load())
+			}
+
+			wantScheduled := make(map[string]kueue.Admission)
+			for _, key := range tc.wantScheduled {
+				wantScheduled[key] = tc.wantAssignments[key]
+			}
+			if diff := cmp.Diff(wantScheduled, gotScheduled); diff != "" {
+				t.Errorf("Unexpected scheduled workloads (-want,+got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantPreempted, gotPreempted); diff != "" {
+				t.Errorf("Unexpected preemptions (-want,+got):\n%s", diff)
+			}
+
+			// Verify assignments in cache.
+			gotAssignments := make(map[string]kueue.Admission)
+			var gotWorkloads []kueue.Workload
 			snapshot, err := cqCache.Snapshot(ctx)
 			if err != nil {
 				t.Fatalf("unexpected error while building snapshot: %v", err)
@@ -3359,7 +4125,7 @@ func TestEntryOrdering(t *testing.T) {
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.PrioritySortingWithinCohort, tc.prioritySorting)
-			iter := makeIterator(context.Background(), tc.input, tc.workloadOrdering, false)
+			iter := makeIterator(context.Background(), tc.input, tc.workloadOrdering, nil, false, false)
 			order := make([]string, len(tc.input))
 			for i := range tc.input {
 				order[i] = iter.pop().Obj.Name
@@ -3374,6 +4140,97 @@ func TestEntryOrdering(t *testing.T) {
 	}
 }
 
+const countingPluginName = "counting"
+
+// countingPlugin counts the flavors it filters and scores, without rejecting
+// any. It keeps state across calls, like the plugins may do as long as they
+// are safe for concurrent use.
+type countingPlugin struct {
+	filtered atomic.Int64
+	scored   atomic.Int64
+}
+
+func (*countingPlugin) Name() string {
+	return countingPluginName
+}
+
+func (p *countingPlugin) FilterFlavor(*workload.Info, int, *kueue.ResourceFlavor, resources.Requests) string {
+	p.filtered.Add(1)
+	return ""
+}
+
+func (p *countingPlugin) ScoreFlavor(*workload.Info, int, *kueue.ResourceFlavor, resources.Requests) int64 {
+	p.scored.Add(1)
+	return 0
+}
+
+type nameQueueSortPlugin struct{}
+
+func (nameQueueSortPlugin) Name() string {
+	return "name"
+}
+
+func (nameQueueSortPlugin) Less(a, b *workload.Info) bool {
+	return a.Obj.Name < b.Obj.Name
+}
+
+func TestEntryOrderingWithQueueSortPlugin(t *testing.T) {
+	now := time.Now()
+	makeEntry := func(name string, priority int32, borrowing bool) entry {
+		return entry{
+			Info: workload.Info{
+				Obj: &kueue.Workload{
+					ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now)},
+					Spec:       kueue.WorkloadSpec{Priority: ptr.To(priority)},
+				},
+			},
+			assignment: flavorassigner.Assignment{Borrowing: borrowing},
+		}
+	}
+	input := []entry{
+		makeEntry("c", 10, false),
+		makeEntry("a", 0, true),
+		makeEntry("b", 0, false),
+	}
+	iter := makeIterator(context.Background(), input, workload.Ordering{}, nameQueueSortPlugin{}, false, false)
+	var order []string
+	for iter.hasNext() {
+		order = append(order, iter.pop().Obj.Name)
+	}
+	// The workloads fitting within the nominal quota still go first.
+	if diff := cmp.Diff([]string{"b", "c", "a"}, order); diff != "" {
+		t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+	}
+}
+
+func TestEntryOrderingWithEffectivePriority(t *testing.T) {
+	now := time.Now()
+	makeEntry := func(name string, priority int32, effectivePriority *int32) entry {
+		return entry{
+			Info: workload.Info{
+				Obj: &kueue.Workload{
+					ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now)},
+					Spec:       kueue.WorkloadSpec{Priority: ptr.To(priority)},
+				},
+			},
+			effectivePriority: effectivePriority,
+		}
+	}
+	input := []entry{
+		makeEntry("high", 10, nil),
+		makeEntry("aged-low", 0, ptr.To[int32](20)),
+		makeEntry("low", 5, nil),
+	}
+	iter := makeIterator(context.Background(), input, workload.Ordering{}, nil, false, false)
+	var order []string
+	for iter.hasNext() {
+		order = append(order, iter.pop().Obj.Name)
+	}
+	if diff := cmp.Diff([]string{"aged-low", "high", "low"}, order); diff != "" {
+		t.Errorf("Unexpected order (-want,+got):\n%s", diff)
+	}
+}
+
 func TestLastSchedulingContext(t *testing.T) {
 	now := time.Now()
 	fakeClock := testingclock.NewFakeClock(now)
@@ -4024,6 +4881,199 @@ func TestRequeueAndUpdate(t *testing.T) {
 	}
 }
 
+func TestAdmitWorkloadGroup(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	server := utiltesting.MakeWorkload("server", "ns").Queue("main").Request(corev1.ResourceCPU, "1").Obj()
+	workers := utiltesting.MakeWorkload("workers", "ns").Queue("main").Request(corev1.ResourceCPU, "2").Obj()
+	cl := utiltesting.NewClientBuilder().WithObjects(server, workers).Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue %s to cache: %v", cq.Name, err)
+	}
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+
+	// The second member is assumed behind the scheduler's back, so that
+	// assuming it again fails.
+	assumedWorkers := workers.DeepCopy()
+	workload.SetQuotaReservation(assumedWorkers, utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj(), testingclock.NewFakeClock(time.Now()))
+	if err := cqCache.AssumeWorkload(assumedWorkers); err != nil {
+		t.Fatalf("Assuming workload %s: %v", workload.Key(workers), err)
+	}
+
+	scheduler := New(qManager, cqCache, cl, &utiltesting.EventRecorder{})
+	var applied []string
+	scheduler.applyAdmission = func(_ context.Context, w *kueue.Workload) error {
+		applied = append(applied, workload.Key(w))
+		return nil
+	}
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	podSetAssignment := func(cpu string) flavorassigner.PodSetAssignment {
+		return flavorassigner.PodSetAssignment{
+			Name:     kueue.DefaultPodSetName,
+			Flavors:  flavorassigner.ResourceAssignment{corev1.ResourceCPU: &flavorassigner.FlavorAssignment{Name: "default", Mode: flavorassigner.Fit}},
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			Count:    1,
+		}
+	}
+	serverInfo := workload.NewInfo(server)
+	workersInfo := workload.NewInfo(workers)
+	e := entry{
+		Info:  *serverInfo,
+		group: []*workload.Info{serverInfo, workersInfo},
+		assignment: flavorassigner.Assignment{
+			PodSets: []flavorassigner.PodSetAssignment{podSetAssignment("1"), podSetAssignment("2")},
+		},
+	}
+	e.ClusterQueue = "cq"
+
+	if err := scheduler.admit(ctx, &e, snapshot.ClusterQueue("cq")); err == nil {
+		t.Fatal("Expected the admission of the workload group to fail")
+	}
+	wg.Wait()
+	if e.status == assumed {
+		t.Error("The workload group shouldn't be assumed")
+	}
+	if cqCache.IsAssumedOrAdmittedWorkload(*serverInfo) {
+		t.Errorf("Workload %s shouldn't stay assumed after the group failed to be assumed", workload.Key(server))
+	}
+	if len(applied) != 0 {
+		t.Errorf("Unexpected admissions in the apiserver: %v", applied)
+	}
+}
+
+func TestFitsWorkloadGroupInLocalQueues(t *testing.T) {
+	cases := map[string]struct {
+		serverCPU string
+		workerCPU string
+		want      bool
+	}{
+		"each member fits in its own LocalQueue": {
+			serverCPU: "3",
+			workerCPU: "3",
+			want:      true,
+		},
+		"a member doesn't fit in its own LocalQueue": {
+			serverCPU: "1",
+			workerCPU: "5",
+			want:      false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.LocalQueueQuotas, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			cl := utiltesting.NewClientBuilder().Build()
+			cqCache := cache.New(cl)
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s to cache: %v", cq.Name, err)
+			}
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, lq := range []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("servers", "ns").ClusterQueue("cq").Quota("default", corev1.ResourceCPU, "4").Obj(),
+				utiltesting.MakeLocalQueue("workers", "ns").ClusterQueue("cq").Quota("default", corev1.ResourceCPU, "4").Obj(),
+			} {
+				if err := cqCache.AddLocalQueue(lq); err != nil {
+					t.Fatalf("Inserting localQueue %s to cache: %v", lq.Name, err)
+				}
+			}
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			server := workload.NewInfo(utiltesting.MakeWorkload("server", "ns").Queue("servers").Request(corev1.ResourceCPU, tc.serverCPU).Obj())
+			worker := workload.NewInfo(utiltesting.MakeWorkload("worker", "ns").Queue("workers").Request(corev1.ResourceCPU, tc.workerCPU).Obj())
+			podSetAssignment := func(cpu string) flavorassigner.PodSetAssignment {
+				return flavorassigner.PodSetAssignment{
+					Name:     kueue.DefaultPodSetName,
+					Flavors:  flavorassigner.ResourceAssignment{corev1.ResourceCPU: &flavorassigner.FlavorAssignment{Name: "default", Mode: flavorassigner.Fit}},
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					Count:    1,
+				}
+			}
+			e := &entry{
+				Info:  *server,
+				group: []*workload.Info{server, worker},
+				assignment: flavorassigner.Assignment{
+					PodSets: []flavorassigner.PodSetAssignment{podSetAssignment(tc.serverCPU), podSetAssignment(tc.workerCPU)},
+				},
+			}
+			e.ClusterQueue = "cq"
+			usage := workload.Usage{Quota: resources.FlavorResourceQuantities{
+				{Flavor: "default", Resource: corev1.ResourceCPU}: server.TotalRequests[0].Requests[corev1.ResourceCPU] + worker.TotalRequests[0].Requests[corev1.ResourceCPU],
+			}}
+			if got := fits(snapshot.ClusterQueue("cq"), e, &usage, nil, nil); got != tc.want {
+				t.Errorf("fits() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFlavorsDryRunReusesResults(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	cl := utiltesting.NewClientBuilder().Build()
+	cqCache := cache.New(cl)
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue %s to cache: %v", cq.Name, err)
+	}
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	scheduler := New(queue.NewManager(cl, cqCache), cqCache, cl, &utiltesting.EventRecorder{}, WithClock(t, fakeClock))
+
+	pending := []*workload.Info{workload.NewInfo(utiltesting.MakeWorkload("pending", "ns").Request(corev1.ResourceCPU, "3").Obj())}
+	fits := func() bool {
+		t.Helper()
+		results, err := scheduler.FlavorsDryRun(ctx, "cq", pending)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return results[0].Assignment.RepresentativeMode() == flavorassigner.Fit
+	}
+
+	if !fits() {
+		t.Fatal("Expected the workload to fit in the empty ClusterQueue")
+	}
+	admitted := utiltesting.MakeWorkload("admitted", "ns").
+		Request(corev1.ResourceCPU, "2").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+		Obj()
+	cqCache.AddOrUpdateWorkload(admitted)
+	if !fits() {
+		t.Error("Expected the result to be reused until the next scheduling cycle")
+	}
+	scheduler.flavorsDryRuns.invalidate()
+	if fits() {
+		t.Error("Expected the workload not to fit after the scheduling cycle")
+	}
+
+	if err := cqCache.DeleteWorkload(admitted); err != nil {
+		t.Fatalf("Deleting workload: %v", err)
+	}
+	fakeClock.Step(flavorsDryRunMaxAge + time.Second)
+	if !fits() {
+		t.Error("Expected the result to expire")
+	}
+}
+
 func TestResourcesToReserve(t *testing.T) {
 	resourceFlavors := []*kueue.ResourceFlavor{
 		utiltesting.MakeResourceFlavor("on-demand").Obj(),
@@ -0,0 +1,119 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// Result is the outcome of a simulation. The times are relative to the start
+// of the trace.
+type Result struct {
+	// Summary of the simulation.
+	Summary Summary `json:"summary"`
+
+	// Workloads in the order of submission.
+	Workloads []WorkloadResult `json:"workloads"`
+
+	// ClusterQueues in the order of their names.
+	ClusterQueues []ClusterQueueResult `json:"clusterQueues"`
+}
+
+// Summary aggregates the results of the workloads.
+type Summary struct {
+	// Duration is the time until the last event of the simulation.
+	Duration metav1.Duration `json:"duration"`
+
+	// Workloads is the number of submitted workloads.
+	Workloads int32 `json:"workloads"`
+
+	// Finished is the number of workloads that ran to completion.
+	Finished int32 `json:"finished"`
+
+	// Pending is the number of workloads pending at the end of the simulation.
+	Pending int32 `json:"pending"`
+
+	// Preemptions is the total number of preemptions.
+	Preemptions int32 `json:"preemptions"`
+
+	// AverageWaitTime is the average time the workloads spent pending.
+	AverageWaitTime metav1.Duration `json:"averageWaitTime"`
+
+	// MaxWaitTime is the longest time a workload spent pending.
+	MaxWaitTime metav1.Duration `json:"maxWaitTime"`
+}
+
+// WorkloadResult is the outcome of a workload submission.
+type WorkloadResult struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// ClusterQueue that last admitted the workload, if any.
+	ClusterQueue kueue.ClusterQueueReference `json:"clusterQueue,omitempty"`
+
+	// Submitted is the time of the submission.
+	Submitted metav1.Duration `json:"submitted"`
+
+	// Admitted is the time of the last admission, unless the workload was
+	// preempted afterwards.
+	Admitted *metav1.Duration `json:"admitted,omitempty"`
+
+	// Finished is the time of the completion, if any.
+	Finished *metav1.Duration `json:"finished,omitempty"`
+
+	// WaitTime is the total time the workload spent pending, including the
+	// time after its preemptions.
+	WaitTime metav1.Duration `json:"waitTime"`
+
+	// Preemptions is the number of times the workload was preempted.
+	Preemptions int32 `json:"preemptions"`
+}
+
+// ClusterQueueResult is the outcome of the simulation for a ClusterQueue.
+type ClusterQueueResult struct {
+	Name kueue.ClusterQueueReference `json:"name"`
+
+	// AdmittedWorkloads is the number of admissions in the ClusterQueue.
+	AdmittedWorkloads int32 `json:"admittedWorkloads"`
+
+	// Preemptions is the number of workloads preempted in the ClusterQueue.
+	Preemptions int32 `json:"preemptions"`
+
+	// Resources is the utilization of the quotas of the ClusterQueue.
+	Resources []ResourceUtilization `json:"resources"`
+}
+
+// ResourceUtilization is the utilization of the quota of a flavor resource
+// over the simulation.
+type ResourceUtilization struct {
+	Flavor   kueue.ResourceFlavorReference `json:"flavor"`
+	Resource corev1.ResourceName           `json:"resource"`
+
+	// NominalQuota of the resource.
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+
+	// AverageUsage is the usage averaged over the duration of the simulation.
+	AverageUsage resource.Quantity `json:"averageUsage"`
+
+	// Utilization is the average usage over the nominal quota, which is
+	// above 1 if the ClusterQueue borrowed, and 0 without nominal quota.
+	Utilization float64 `json:"utilization"`
+}
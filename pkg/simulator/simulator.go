@@ -0,0 +1,675 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator replays a trace of workload submissions against a set of
+// Kueue objects offline. It drives the scheduler, the queues and the cache
+// with a fake client and a virtual clock, and reports the wait times, the
+// preemptions and the utilization of the quotas.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/util/heap"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/util/routine"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// startTime is the virtual time at which the trace starts. The results are
+// relative to it.
+var startTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type options struct {
+	fairSharing *config.FairSharing
+}
+
+// Option configures the simulation.
+type Option func(*options)
+
+// WithFairSharing enables fair sharing in the cache and the scheduler.
+func WithFairSharing(fs *config.FairSharing) Option {
+	return func(o *options) {
+		o.fairSharing = fs
+	}
+}
+
+type simulation struct {
+	client    client.Client
+	clock     *testingclock.FakeClock
+	cache     *cache.Cache
+	queues    *queue.Manager
+	scheduler *scheduler.Scheduler
+
+	clusterQueues []*kueue.ClusterQueue
+
+	// admissions tracks the routines that update the admitted workloads.
+	admissions sync.WaitGroup
+
+	// patched holds the keys of the workloads whose status was patched by
+	// the scheduler since the last scheduling cycle.
+	patchedMu sync.Mutex
+	patched   sets.Set[string]
+
+	workloads   map[string]*workloadState
+	arrivals    []*workloadState
+	nextArrival int
+	completions *heap.Heap[completion]
+
+	clusterQueueResults map[kueue.ClusterQueueReference]*ClusterQueueResult
+	// usage is the usage of the ClusterQueues integrated over time, in
+	// resource values times seconds.
+	usage map[kueue.ClusterQueueReference]map[resources.FlavorResource]float64
+}
+
+type workloadState struct {
+	trace        *TraceWorkload
+	key          string
+	submitted    time.Time
+	pendingSince time.Time
+	admitted     bool
+	result       WorkloadResult
+}
+
+type completion struct {
+	key string
+	at  time.Time
+}
+
+// Run replays the trace against the objects, which can be Namespaces,
+// ResourceFlavors, Cohorts, ClusterQueues, LocalQueues and
+// WorkloadPriorityClasses. The missing namespaces of the LocalQueues and the
+// workloads are created.
+//
+// The scheduler runs cycles after every submission and completion until it
+// can't admit or preempt workloads anymore. The preempted workloads are
+// requeued immediately, and run for their whole duration once readmitted.
+func Run(ctx context.Context, objs []client.Object, trace *Trace, opts ...Option) (*Result, error) {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := validateTrace(trace); err != nil {
+		return nil, err
+	}
+	s, err := newSimulation(ctx, objs, trace, &options)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.run(ctx); err != nil {
+		return nil, err
+	}
+	return s.result(), nil
+}
+
+func newSimulation(ctx context.Context, objs []client.Object, trace *Trace, options *options) (*simulation, error) {
+	s := &simulation{
+		clock:               testingclock.NewFakeClock(startTime),
+		patched:             sets.New[string](),
+		workloads:           make(map[string]*workloadState, len(trace.Workloads)),
+		clusterQueueResults: make(map[kueue.ClusterQueueReference]*ClusterQueueResult),
+		usage:               make(map[kueue.ClusterQueueReference]map[resources.FlavorResource]float64),
+		completions: heap.New(
+			func(c *completion) string { return c.key },
+			func(a, b *completion) bool {
+				if a.at.Equal(b.at) {
+					return a.key < b.key
+				}
+				return a.at.Before(b.at)
+			},
+		),
+	}
+
+	var (
+		flavors            []*kueue.ResourceFlavor
+		cohorts            []*kueuealpha.Cohort
+		localQueues        []*kueue.LocalQueue
+		namespaces         = sets.New[string]()
+		requiredNamespaces = sets.New[string]()
+	)
+	for _, obj := range objs {
+		obj.SetResourceVersion("")
+		switch o := obj.(type) {
+		case *corev1.Namespace:
+			namespaces.Insert(o.Name)
+		case *kueue.ResourceFlavor:
+			flavors = append(flavors, o)
+		case *kueuealpha.Cohort:
+			cohorts = append(cohorts, o)
+		case *kueue.ClusterQueue:
+			setClusterQueueDefaults(o)
+			s.clusterQueues = append(s.clusterQueues, o)
+		case *kueue.LocalQueue:
+			localQueues = append(localQueues, o)
+			requiredNamespaces.Insert(o.Namespace)
+		case *kueue.WorkloadPriorityClass:
+		default:
+			return nil, fmt.Errorf("unsupported object %s %s", reflect.TypeOf(obj).Elem().Name(), client.ObjectKeyFromObject(obj))
+		}
+	}
+	for i := range trace.Workloads {
+		requiredNamespaces.Insert(trace.Workloads[i].Namespace)
+	}
+	for _, ns := range sets.List(requiredNamespaces.Difference(namespaces)) {
+		objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+	}
+
+	s.client = fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&kueue.LocalQueue{}, indexer.QueueClusterQueueKey, indexer.IndexQueueClusterQueue).
+		WithIndex(&kueue.Workload{}, indexer.WorkloadQueueKey, indexer.IndexWorkloadQueue).
+		WithIndex(&kueue.Workload{}, indexer.WorkloadClusterQueueKey, indexer.IndexWorkloadClusterQueue).
+		WithIndex(&kueue.Workload{}, indexer.OwnerReferenceUID, indexer.IndexOwnerUID).
+		WithObjects(objs...).
+		WithStatusSubresource(&kueue.Workload{}).
+		WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: s.patchStatus}).
+		Build()
+
+	cacheOpts := []cache.Option{cache.WithClock(s.clock)}
+	if options.fairSharing != nil && options.fairSharing.Enable {
+		cacheOpts = append(cacheOpts, cache.WithFairSharing(true))
+		if options.fairSharing.UsageHalfLifeTime != nil {
+			cacheOpts = append(cacheOpts, cache.WithUsageHalfLifeTime(options.fairSharing.UsageHalfLifeTime.Duration))
+		}
+	}
+	s.cache = cache.New(s.client, cacheOpts...)
+	s.queues = queue.NewManager(s.client, s.cache, queue.WithClock(s.clock))
+	s.scheduler = scheduler.New(s.queues, s.cache, s.client, &record.FakeRecorder{},
+		scheduler.WithTimeSource(s.clock),
+		scheduler.WithFairSharing(options.fairSharing),
+		scheduler.WithAdmissionRoutineWrapper(routine.NewWrapper(
+			func() { s.admissions.Add(1) },
+			func() { s.admissions.Done() },
+		)),
+	)
+
+	for _, rf := range flavors {
+		s.cache.AddOrUpdateResourceFlavor(rf)
+	}
+	for _, cohort := range cohorts {
+		if err := s.cache.AddOrUpdateCohort(cohort); err != nil {
+			return nil, fmt.Errorf("adding cohort %s: %w", cohort.Name, err)
+		}
+		s.queues.AddOrUpdateCohort(ctx, cohort)
+	}
+	slices.SortFunc(s.clusterQueues, func(a, b *kueue.ClusterQueue) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, cq := range s.clusterQueues {
+		if err := s.cache.AddClusterQueue(ctx, cq); err != nil {
+			return nil, fmt.Errorf("adding ClusterQueue %s: %w", cq.Name, err)
+		}
+		if err := s.queues.AddClusterQueue(ctx, cq); err != nil {
+			return nil, fmt.Errorf("adding ClusterQueue %s: %w", cq.Name, err)
+		}
+		cqName := kueue.ClusterQueueReference(cq.Name)
+		s.clusterQueueResults[cqName] = &ClusterQueueResult{Name: cqName}
+		s.usage[cqName] = make(map[resources.FlavorResource]float64)
+	}
+	// The cache loads the LocalQueues of the ClusterQueues from the client,
+	// while the manager loads the workloads of the LocalQueues.
+	for _, lq := range localQueues {
+		if err := s.queues.AddLocalQueue(ctx, lq); err != nil {
+			return nil, fmt.Errorf("adding LocalQueue %s: %w", client.ObjectKeyFromObject(lq), err)
+		}
+	}
+
+	for i := range trace.Workloads {
+		twl := &trace.Workloads[i]
+		state := &workloadState{
+			trace:     twl,
+			key:       twl.Namespace + "/" + twl.Name,
+			submitted: startTime.Add(twl.SubmitTime.Duration),
+			result: WorkloadResult{
+				Name:      twl.Name,
+				Namespace: twl.Namespace,
+				Submitted: twl.SubmitTime,
+			},
+		}
+		s.workloads[state.key] = state
+		s.arrivals = append(s.arrivals, state)
+	}
+	slices.SortStableFunc(s.arrivals, func(a, b *workloadState) int {
+		return a.submitted.Compare(b.submitted)
+	})
+	return s, nil
+}
+
+// setClusterQueueDefaults sets the defaults of the CRD, as the objects are not
+// created through the apiserver.
+func setClusterQueueDefaults(cq *kueue.ClusterQueue) {
+	if cq.Spec.QueueingStrategy == "" {
+		cq.Spec.QueueingStrategy = kueue.BestEffortFIFO
+	}
+	if cq.Spec.FlavorFungibility == nil {
+		cq.Spec.FlavorFungibility = &kueue.FlavorFungibility{}
+	}
+	if cq.Spec.FlavorFungibility.WhenCanBorrow == "" {
+		cq.Spec.FlavorFungibility.WhenCanBorrow = kueue.Borrow
+	}
+	if cq.Spec.FlavorFungibility.WhenCanPreempt == "" {
+		cq.Spec.FlavorFungibility.WhenCanPreempt = kueue.TryNextFlavor
+	}
+	if cq.Spec.Preemption == nil {
+		cq.Spec.Preemption = &kueue.ClusterQueuePreemption{}
+	}
+	if cq.Spec.Preemption.ReclaimWithinCohort == "" {
+		cq.Spec.Preemption.ReclaimWithinCohort = kueue.PreemptionPolicyNever
+	}
+	if cq.Spec.Preemption.WithinClusterQueue == "" {
+		cq.Spec.Preemption.WithinClusterQueue = kueue.PreemptionPolicyNever
+	}
+	if cq.Spec.Preemption.BorrowWithinCohort == nil {
+		cq.Spec.Preemption.BorrowWithinCohort = &kueue.BorrowWithinCohort{}
+	}
+	if cq.Spec.Preemption.BorrowWithinCohort.Policy == "" {
+		cq.Spec.Preemption.BorrowWithinCohort.Policy = kueue.BorrowWithinCohortPolicyNever
+	}
+}
+
+// patchStatus applies the status patches as strategic merge patches, as the
+// fake client doesn't support server-side apply, and records the patched
+// workloads.
+func (s *simulation) patchStatus(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if patch.Type() == types.ApplyPatchType {
+		patch = &applyAsStrategicMerge{Patch: patch}
+	}
+	if err := c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	if wl, isWorkload := obj.(*kueue.Workload); isWorkload {
+		s.patchedMu.Lock()
+		s.patched.Insert(workload.Key(wl))
+		s.patchedMu.Unlock()
+	}
+	return nil
+}
+
+type applyAsStrategicMerge struct {
+	client.Patch
+}
+
+func (*applyAsStrategicMerge) Type() types.PatchType {
+	return types.StrategicMergePatchType
+}
+
+func (s *simulation) run(ctx context.Context) error {
+	for {
+		next, found := s.nextEvent()
+		if !found {
+			return nil
+		}
+		s.advance(next)
+		if err := s.completeWorkloads(ctx); err != nil {
+			return err
+		}
+		if err := s.submitWorkloads(ctx); err != nil {
+			return err
+		}
+		if err := s.schedule(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *simulation) nextEvent() (time.Time, bool) {
+	var next time.Time
+	found := false
+	if s.nextArrival < len(s.arrivals) {
+		next = s.arrivals[s.nextArrival].submitted
+		found = true
+	}
+	if s.completions.Len() > 0 {
+		c := s.completions.Pop()
+		s.completions.PushOrUpdate(c)
+		if !found || c.at.Before(next) {
+			next = c.at
+			found = true
+		}
+	}
+	return next, found
+}
+
+// advance integrates the usage of the ClusterQueues until the time, and sets
+// the clock to it.
+func (s *simulation) advance(t time.Time) {
+	elapsed := t.Sub(s.clock.Now()).Seconds()
+	if elapsed > 0 {
+		for _, cq := range s.clusterQueues {
+			stats, err := s.cache.Usage(cq)
+			if err != nil {
+				continue
+			}
+			usage := s.usage[kueue.ClusterQueueReference(cq.Name)]
+			for _, fu := range stats.ReservedResources {
+				for _, ru := range fu.Resources {
+					fr := resources.FlavorResource{Flavor: fu.Name, Resource: ru.Name}
+					usage[fr] += float64(resources.ResourceValue(ru.Name, ru.Total)) * elapsed
+				}
+			}
+		}
+	}
+	s.clock.SetTime(t)
+}
+
+func (s *simulation) completeWorkloads(ctx context.Context) error {
+	for s.completions.Len() > 0 {
+		c := s.completions.Pop()
+		if c.at.After(s.clock.Now()) {
+			s.completions.PushOrUpdate(c)
+			return nil
+		}
+		if err := s.finish(ctx, s.workloads[c.key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish marks the workload as finished, like the job reconciler does once
+// the job completes, and releases its quota, like the workload reconciler
+// does.
+func (s *simulation) finish(ctx context.Context, state *workloadState) error {
+	wl, err := s.getWorkload(ctx, state)
+	if err != nil {
+		return err
+	}
+	apimeta.SetStatusCondition(&wl.Status.Conditions, metav1.Condition{
+		Type:               kueue.WorkloadFinished,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.WorkloadFinishedReasonSucceeded,
+		Message:            "The workload ran for its duration",
+		LastTransitionTime: metav1.NewTime(s.clock.Now()),
+	})
+	if err := s.client.Status().Update(ctx, wl); err != nil {
+		return fmt.Errorf("finishing workload %s: %w", state.key, err)
+	}
+	s.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
+		_ = s.cache.DeleteWorkload(wl)
+	})
+	state.admitted = false
+	state.result.Finished = s.offset()
+	return nil
+}
+
+func (s *simulation) submitWorkloads(ctx context.Context) error {
+	for ; s.nextArrival < len(s.arrivals); s.nextArrival++ {
+		state := s.arrivals[s.nextArrival]
+		if state.submitted.After(s.clock.Now()) {
+			return nil
+		}
+		wl, err := s.newWorkload(ctx, state.trace)
+		if err != nil {
+			return fmt.Errorf("submitting workload %s: %w", state.key, err)
+		}
+		if err := s.client.Create(ctx, wl); err != nil {
+			return fmt.Errorf("submitting workload %s: %w", state.key, err)
+		}
+		if err := s.queues.AddOrUpdateWorkload(wl); err != nil {
+			return fmt.Errorf("submitting workload %s: %w", state.key, err)
+		}
+		state.pendingSince = s.clock.Now()
+	}
+	return nil
+}
+
+func (s *simulation) newWorkload(ctx context.Context, twl *TraceWorkload) (*kueue.Workload, error) {
+	wl := &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              twl.Name,
+			Namespace:         twl.Namespace,
+			CreationTimestamp: metav1.NewTime(s.clock.Now()),
+		},
+		Spec: kueue.WorkloadSpec{
+			QueueName: twl.Queue,
+			Priority:  twl.Priority,
+			Active:    ptr.To(true),
+		},
+	}
+	if twl.PriorityClassName != "" {
+		name, source, value, err := priority.GetPriorityFromWorkloadPriorityClass(ctx, s.client, twl.PriorityClassName)
+		if err != nil {
+			return nil, err
+		}
+		wl.Spec.PriorityClassName = name
+		wl.Spec.PriorityClassSource = source
+		wl.Spec.Priority = &value
+	}
+	for _, ps := range twl.PodSets {
+		wl.Spec.PodSets = append(wl.Spec.PodSets, kueue.PodSet{
+			Name:  ps.Name,
+			Count: ps.Count,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:      "c",
+						Resources: corev1.ResourceRequirements{Requests: ps.Requests},
+					}},
+				},
+			},
+		})
+	}
+	return wl, nil
+}
+
+// schedule runs scheduling cycles until the scheduler can't admit or preempt
+// workloads anymore: either there are no heads left, or a cycle without
+// progress considers the same heads as an earlier one.
+func (s *simulation) schedule(ctx context.Context) error {
+	seenHeads := sets.New[string]()
+	for {
+		heads := s.scheduler.RunCycle(ctx)
+		s.admissions.Wait()
+		if len(heads) == 0 {
+			return nil
+		}
+		progress, err := s.processPatchedWorkloads(ctx)
+		if err != nil {
+			return err
+		}
+		if progress {
+			seenHeads.Clear()
+			continue
+		}
+		keys := make([]string, 0, len(heads))
+		for _, h := range heads {
+			keys = append(keys, workload.Key(h.Obj))
+		}
+		slices.Sort(keys)
+		headsKey := strings.Join(keys, ",")
+		if seenHeads.Has(headsKey) {
+			return nil
+		}
+		seenHeads.Insert(headsKey)
+	}
+}
+
+// processPatchedWorkloads handles the workloads admitted and preempted by the
+// scheduler, and returns whether there were any.
+func (s *simulation) processPatchedWorkloads(ctx context.Context) (bool, error) {
+	s.patchedMu.Lock()
+	keys := sets.List(s.patched)
+	s.patched.Clear()
+	s.patchedMu.Unlock()
+
+	progress := false
+	for _, key := range keys {
+		state := s.workloads[key]
+		if state == nil {
+			continue
+		}
+		wl, err := s.getWorkload(ctx, state)
+		if err != nil {
+			return false, err
+		}
+		evicted := workload.IsEvicted(wl) || workload.IsPreemptionPending(wl)
+		switch {
+		case !state.admitted && workload.HasQuotaReservation(wl) && !evicted:
+			s.admit(state, wl)
+			progress = true
+		case state.admitted && evicted:
+			if err := s.evict(ctx, state, wl); err != nil {
+				return false, err
+			}
+			progress = true
+		}
+	}
+	return progress, nil
+}
+
+// admit accounts the workload in the cache, like the workload reconciler
+// does, and schedules its completion.
+func (s *simulation) admit(state *workloadState, wl *kueue.Workload) {
+	s.queues.DeleteWorkload(wl)
+	s.cache.AddOrUpdateWorkload(wl)
+	now := s.clock.Now()
+	state.admitted = true
+	state.result.WaitTime.Duration += now.Sub(state.pendingSince)
+	state.result.Admitted = s.offset()
+	state.result.ClusterQueue = wl.Status.Admission.ClusterQueue
+	if cqResult := s.clusterQueueResults[wl.Status.Admission.ClusterQueue]; cqResult != nil {
+		cqResult.AdmittedWorkloads++
+	}
+	s.completions.PushOrUpdate(&completion{key: state.key, at: now.Add(state.trace.Duration.Duration)})
+}
+
+// evict releases the quota of the workload and requeues it, like the job and
+// workload reconcilers do once the job stops.
+func (s *simulation) evict(ctx context.Context, state *workloadState, wl *kueue.Workload) error {
+	reason, message := kueue.WorkloadEvictedByPreemption, "Preempted"
+	if cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadPreempted); cond != nil {
+		message = cond.Message
+	}
+	now := s.clock.Now()
+	workload.SetRequeuedCondition(wl, reason, message, true)
+	_ = workload.UnsetQuotaReservationWithCondition(wl, "Pending", message, now)
+	for i := range wl.Status.Conditions {
+		if wl.Status.Conditions[i].Type == kueue.WorkloadRequeued {
+			wl.Status.Conditions[i].LastTransitionTime = metav1.NewTime(now)
+		}
+	}
+	if err := s.client.Status().Update(ctx, wl); err != nil {
+		return fmt.Errorf("requeuing workload %s: %w", state.key, err)
+	}
+	s.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
+		_ = s.cache.DeleteWorkload(wl)
+		_ = s.queues.AddOrUpdateWorkloadWithoutLock(wl)
+	})
+	s.completions.Delete(state.key)
+	if cqResult := s.clusterQueueResults[state.result.ClusterQueue]; cqResult != nil {
+		cqResult.Preemptions++
+	}
+	state.admitted = false
+	state.pendingSince = now
+	state.result.Admitted = nil
+	state.result.Preemptions++
+	return nil
+}
+
+func (s *simulation) getWorkload(ctx context.Context, state *workloadState) (*kueue.Workload, error) {
+	wl := &kueue.Workload{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: state.trace.Namespace, Name: state.trace.Name}, wl); err != nil {
+		return nil, fmt.Errorf("getting workload %s: %w", state.key, err)
+	}
+	return wl, nil
+}
+
+func (s *simulation) offset() *metav1.Duration {
+	return &metav1.Duration{Duration: s.clock.Since(startTime)}
+}
+
+func (s *simulation) result() *Result {
+	duration := s.clock.Since(startTime)
+	result := &Result{
+		Summary: Summary{
+			Duration:  metav1.Duration{Duration: duration},
+			Workloads: int32(len(s.arrivals)),
+		},
+	}
+	var totalWait time.Duration
+	for _, state := range s.arrivals {
+		if state.result.Admitted == nil && state.result.Finished == nil {
+			// The workload is still pending at the end of the simulation.
+			state.result.WaitTime.Duration += s.clock.Now().Sub(state.pendingSince)
+			result.Summary.Pending++
+		}
+		if state.result.Finished != nil {
+			result.Summary.Finished++
+		}
+		result.Summary.Preemptions += state.result.Preemptions
+		totalWait += state.result.WaitTime.Duration
+		result.Summary.MaxWaitTime.Duration = max(result.Summary.MaxWaitTime.Duration, state.result.WaitTime.Duration)
+		result.Workloads = append(result.Workloads, state.result)
+	}
+	if len(s.arrivals) > 0 {
+		result.Summary.AverageWaitTime.Duration = totalWait / time.Duration(len(s.arrivals))
+	}
+
+	for _, cq := range s.clusterQueues {
+		cqName := kueue.ClusterQueueReference(cq.Name)
+		cqResult := s.clusterQueueResults[cqName]
+		for _, rg := range cq.Spec.ResourceGroups {
+			for _, fq := range rg.Flavors {
+				for _, rq := range fq.Resources {
+					fr := resources.FlavorResource{Flavor: fq.Name, Resource: rq.Name}
+					var average float64
+					if duration > 0 {
+						average = s.usage[cqName][fr] / duration.Seconds()
+					}
+					utilization := ResourceUtilization{
+						Flavor:       fq.Name,
+						Resource:     rq.Name,
+						NominalQuota: rq.NominalQuota,
+						AverageUsage: resources.ResourceQuantity(rq.Name, int64(math.Round(average))),
+					}
+					if nominal := resources.ResourceValue(rq.Name, rq.NominalQuota); nominal > 0 {
+						utilization.Utilization = math.Round(average/float64(nominal)*1000) / 1000
+					}
+					cqResult.Resources = append(cqResult.Resources, utilization)
+				}
+			}
+		}
+		result.ClusterQueues = append(result.ClusterQueues, *cqResult)
+	}
+	return result
+}
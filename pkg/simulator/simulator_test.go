@@ -0,0 +1,215 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func minutes(m int) metav1.Duration {
+	return metav1.Duration{Duration: time.Duration(m) * time.Minute}
+}
+
+func traceWorkload(name string, submit, duration int, cpu string) TraceWorkload {
+	return TraceWorkload{
+		Name:       name,
+		Queue:      "main",
+		SubmitTime: minutes(submit),
+		Duration:   minutes(duration),
+		PodSets: []TracePodSet{{
+			Count:    1,
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+		}},
+	}
+}
+
+func TestRun(t *testing.T) {
+	objs := func(preemption kueue.ClusterQueuePreemption) []client.Object {
+		return []client.Object{
+			utiltesting.MakeResourceFlavor("default").Obj(),
+			utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+				Preemption(preemption).
+				Obj(),
+			utiltesting.MakeLocalQueue("main", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+			utiltesting.MakeWorkloadPriorityClass("high").PriorityValue(100).Obj(),
+		}
+	}
+	cases := map[string]struct {
+		objs          []client.Object
+		trace         Trace
+		wantSummary   Summary
+		wantWorkloads []WorkloadResult
+		wantCQs       []ClusterQueueResult
+		wantErr       string
+	}{
+		"workloads wait for quota": {
+			objs: objs(kueue.ClusterQueuePreemption{}),
+			trace: Trace{Workloads: []TraceWorkload{
+				traceWorkload("a", 0, 10, "2"),
+				traceWorkload("b", 0, 10, "2"),
+				traceWorkload("c", 1, 10, "2"),
+			}},
+			wantSummary: Summary{
+				Duration:        minutes(20),
+				Workloads:       3,
+				Finished:        3,
+				AverageWaitTime: minutes(3),
+				MaxWaitTime:     minutes(9),
+			},
+			wantWorkloads: []WorkloadResult{
+				{Name: "a", Namespace: "default", ClusterQueue: "cq", Admitted: ptr.To(minutes(0)), Finished: ptr.To(minutes(10))},
+				{Name: "b", Namespace: "default", ClusterQueue: "cq", Admitted: ptr.To(minutes(0)), Finished: ptr.To(minutes(10))},
+				{Name: "c", Namespace: "default", ClusterQueue: "cq", Submitted: minutes(1), Admitted: ptr.To(minutes(10)), Finished: ptr.To(minutes(20)), WaitTime: minutes(9)},
+			},
+			wantCQs: []ClusterQueueResult{{
+				Name:              "cq",
+				AdmittedWorkloads: 3,
+				Resources: []ResourceUtilization{{
+					Flavor:       "default",
+					Resource:     corev1.ResourceCPU,
+					NominalQuota: resource.MustParse("4"),
+					AverageUsage: resource.MustParse("3"),
+					Utilization:  0.75,
+				}},
+			}},
+		},
+		"higher priority workload preempts": {
+			objs: objs(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}),
+			trace: Trace{Workloads: []TraceWorkload{
+				traceWorkload("low", 0, 60, "4"),
+				func() TraceWorkload {
+					twl := traceWorkload("high", 10, 10, "4")
+					twl.PriorityClassName = "high"
+					return twl
+				}(),
+			}},
+			wantSummary: Summary{
+				Duration:        minutes(80),
+				Workloads:       2,
+				Finished:        2,
+				Preemptions:     1,
+				AverageWaitTime: minutes(5),
+				MaxWaitTime:     minutes(10),
+			},
+			wantWorkloads: []WorkloadResult{
+				{Name: "low", Namespace: "default", ClusterQueue: "cq", Admitted: ptr.To(minutes(20)), Finished: ptr.To(minutes(80)), WaitTime: minutes(10), Preemptions: 1},
+				{Name: "high", Namespace: "default", ClusterQueue: "cq", Submitted: minutes(10), Admitted: ptr.To(minutes(10)), Finished: ptr.To(minutes(20))},
+			},
+			wantCQs: []ClusterQueueResult{{
+				Name:              "cq",
+				AdmittedWorkloads: 3,
+				Preemptions:       1,
+				Resources: []ResourceUtilization{{
+					Flavor:       "default",
+					Resource:     corev1.ResourceCPU,
+					NominalQuota: resource.MustParse("4"),
+					AverageUsage: resource.MustParse("4"),
+					Utilization:  1,
+				}},
+			}},
+		},
+		"workload that never fits": {
+			objs: objs(kueue.ClusterQueuePreemption{}),
+			trace: Trace{Workloads: []TraceWorkload{
+				traceWorkload("big", 0, 10, "8"),
+				traceWorkload("small", 5, 10, "1"),
+			}},
+			wantSummary: Summary{
+				Duration:        minutes(15),
+				Workloads:       2,
+				Finished:        1,
+				Pending:         1,
+				AverageWaitTime: metav1.Duration{Duration: 450 * time.Second},
+				MaxWaitTime:     minutes(15),
+			},
+			wantWorkloads: []WorkloadResult{
+				{Name: "big", Namespace: "default", WaitTime: minutes(15)},
+				{Name: "small", Namespace: "default", ClusterQueue: "cq", Submitted: minutes(5), Admitted: ptr.To(minutes(5)), Finished: ptr.To(minutes(15))},
+			},
+			wantCQs: []ClusterQueueResult{{
+				Name:              "cq",
+				AdmittedWorkloads: 1,
+				Resources: []ResourceUtilization{{
+					Flavor:       "default",
+					Resource:     corev1.ResourceCPU,
+					NominalQuota: resource.MustParse("4"),
+					AverageUsage: resource.MustParse("667m"),
+					Utilization:  0.167,
+				}},
+			}},
+		},
+		"missing LocalQueue": {
+			objs: objs(kueue.ClusterQueuePreemption{}),
+			trace: Trace{Workloads: []TraceWorkload{
+				func() TraceWorkload {
+					twl := traceWorkload("a", 0, 10, "1")
+					twl.Queue = "other"
+					return twl
+				}(),
+			}},
+			wantErr: "submitting workload default/a: localQueue doesn't exist or inactive",
+		},
+		"unsupported object": {
+			objs:    []client.Object{utiltesting.MakeWorkload("wl", "default").Obj()},
+			wantErr: "unsupported object Workload default/wl",
+		},
+		"invalid trace": {
+			objs: objs(kueue.ClusterQueuePreemption{}),
+			trace: Trace{Workloads: []TraceWorkload{
+				traceWorkload("a", 0, 10, "1"),
+				traceWorkload("a", 0, 10, "1"),
+			}},
+			wantErr: "workloads[1]: duplicated workload default/a",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Run(context.Background(), tc.objs, &tc.trace)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Unexpected error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantSummary, got.Summary); diff != "" {
+				t.Errorf("Unexpected summary (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, got.Workloads); diff != "" {
+				t.Errorf("Unexpected workloads (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantCQs, got.ClusterQueues, cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 })); diff != "" {
+				t.Errorf("Unexpected ClusterQueues (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
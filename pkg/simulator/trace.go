@@ -0,0 +1,196 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kueue.AddToScheme(scheme))
+	utilruntime.Must(kueuealpha.AddToScheme(scheme))
+}
+
+// Trace is the list of workload submissions to replay.
+type Trace struct {
+	Workloads []TraceWorkload `json:"workloads"`
+}
+
+// TraceWorkload is a workload submission.
+type TraceWorkload struct {
+	// Name of the workload.
+	Name string `json:"name"`
+
+	// Namespace of the workload, "default" if empty.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Queue is the name of the LocalQueue the workload is submitted to.
+	Queue string `json:"queue"`
+
+	// PriorityClassName is the name of a WorkloadPriorityClass.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Priority of the workload, ignored if PriorityClassName is set.
+	Priority *int32 `json:"priority,omitempty"`
+
+	// SubmitTime is the time of the submission, since the start of the trace.
+	SubmitTime metav1.Duration `json:"submitTime"`
+
+	// Duration is the time the workload runs for once admitted. A preempted
+	// workload runs for the whole duration again once readmitted.
+	Duration metav1.Duration `json:"duration"`
+
+	// PodSets of the workload.
+	PodSets []TracePodSet `json:"podSets"`
+}
+
+// TracePodSet is a set of homogeneous pods of a workload.
+type TracePodSet struct {
+	// Name of the pod set, "main" if empty.
+	Name kueue.PodSetReference `json:"name,omitempty"`
+
+	// Count is the number of pods.
+	Count int32 `json:"count"`
+
+	// Requests of each pod.
+	Requests corev1.ResourceList `json:"requests"`
+}
+
+// LoadTrace reads a YAML or JSON trace.
+func LoadTrace(r io.Reader) (*Trace, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trace := &Trace{}
+	if err := yaml.UnmarshalStrict(data, trace); err != nil {
+		return nil, fmt.Errorf("decoding the trace: %w", err)
+	}
+	return trace, nil
+}
+
+func validateTrace(trace *Trace) error {
+	var errs []error
+	keys := sets.New[string]()
+	for i := range trace.Workloads {
+		twl := &trace.Workloads[i]
+		if twl.Namespace == "" {
+			twl.Namespace = metav1.NamespaceDefault
+		}
+		key := twl.Namespace + "/" + twl.Name
+		switch {
+		case twl.Name == "":
+			errs = append(errs, fmt.Errorf("workloads[%d]: name is required", i))
+		case keys.Has(key):
+			errs = append(errs, fmt.Errorf("workloads[%d]: duplicated workload %s", i, key))
+		case twl.Queue == "":
+			errs = append(errs, fmt.Errorf("workloads[%d]: queue is required", i))
+		case twl.SubmitTime.Duration < 0 || twl.Duration.Duration < 0:
+			errs = append(errs, fmt.Errorf("workloads[%d]: submitTime and duration must not be negative", i))
+		case len(twl.PodSets) == 0:
+			errs = append(errs, fmt.Errorf("workloads[%d]: at least one podSet is required", i))
+		}
+		keys.Insert(key)
+		for j := range twl.PodSets {
+			ps := &twl.PodSets[j]
+			if ps.Name == "" {
+				ps.Name = kueue.DefaultPodSetName
+			}
+			if ps.Count <= 0 {
+				errs = append(errs, fmt.Errorf("workloads[%d].podSets[%d]: count must be positive", i, j))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadObjects reads the Kueue objects of the simulation from a YAML or JSON
+// stream of documents. Lists, like the output of "kubectl get -o yaml", are
+// flattened.
+func LoadObjects(r io.Reader) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var objs []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decoding object: %w", err)
+		}
+		decoded, err := flattenObject(decoder, obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, decoded...)
+	}
+}
+
+func flattenObject(decoder runtime.Decoder, obj runtime.Object) ([]client.Object, error) {
+	list, isList := obj.(*corev1.List)
+	if !isList {
+		cObj, isClientObj := obj.(client.Object)
+		if !isClientObj {
+			return nil, fmt.Errorf("unsupported object %s", obj.GetObjectKind().GroupVersionKind())
+		}
+		return []client.Object{cObj}, nil
+	}
+	var objs []client.Object
+	for _, item := range list.Items {
+		itemObj, _, err := decoder.Decode(item.Raw, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decoding list item: %w", err)
+		}
+		decoded, err := flattenObject(decoder, itemObj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, decoded...)
+	}
+	return objs, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestLoadTrace(t *testing.T) {
+	cases := map[string]struct {
+		data    string
+		want    *Trace
+		wantErr bool
+	}{
+		"yaml": {
+			data: `workloads:
+- name: a
+  queue: main
+  submitTime: 1m
+  duration: 1h30m
+  podSets:
+  - count: 2
+    requests:
+      cpu: 500m
+`,
+			want: &Trace{Workloads: []TraceWorkload{{
+				Name:       "a",
+				Queue:      "main",
+				SubmitTime: metav1.Duration{Duration: time.Minute},
+				Duration:   metav1.Duration{Duration: 90 * time.Minute},
+				PodSets: []TracePodSet{{
+					Count:    2,
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				}},
+			}}},
+		},
+		"json": {
+			data: `{"workloads": [{"name": "a", "namespace": "ns", "queue": "main", "priority": 10}]}`,
+			want: &Trace{Workloads: []TraceWorkload{{
+				Name:      "a",
+				Namespace: "ns",
+				Queue:     "main",
+				Priority:  ptr.To[int32](10),
+			}}},
+		},
+		"unknown field": {
+			data:    `workloads: [{name: a, queue: main, runtime: 1m}]`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := LoadTrace(strings.NewReader(tc.data))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error: %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 })); diff != "" {
+				t.Errorf("Unexpected trace (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadObjects(t *testing.T) {
+	cases := map[string]struct {
+		data     string
+		wantKeys []string
+		wantErr  bool
+	}{
+		"documents and lists": {
+			data: `apiVersion: kueue.x-k8s.io/v1beta1
+kind: ResourceFlavor
+metadata:
+  name: default
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: ClusterQueue
+  metadata:
+    name: cq
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: LocalQueue
+  metadata:
+    name: main
+    namespace: default
+---
+`,
+			wantKeys: []string{"ResourceFlavor /default", "ClusterQueue /cq", "LocalQueue default/main"},
+		},
+		"unknown kind": {
+			data: `apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: a
+`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs, err := LoadObjects(strings.NewReader(tc.data))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error: %v, want error: %v", err, tc.wantErr)
+			}
+			var gotKeys []string
+			for _, obj := range objs {
+				gotKeys = append(gotKeys, obj.GetObjectKind().GroupVersionKind().Kind+" "+client.ObjectKeyFromObject(obj).String())
+			}
+			if diff := cmp.Diff(tc.wantKeys, gotKeys); diff != "" {
+				t.Errorf("Unexpected objects (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
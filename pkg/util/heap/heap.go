@@ -148,6 +148,21 @@ func (h *Heap[T]) Pop() *T {
 	return heap.Pop(&h.data).(*T)
 }
 
+// Peek returns the head of the heap without removing it, or nil if the heap
+// is empty.
+func (h *Heap[T]) Peek() *T {
+	if h.Len() == 0 {
+		return nil
+	}
+	return h.data.items[h.data.keys[0]].obj
+}
+
+// Reorder restores the heap order after the ordering of the items
+// changed without updating them.
+func (h *Heap[T]) Reorder() {
+	heap.Init(&h.data)
+}
+
 // GetByKey returns the requested item, or sets exists=false.
 func (h *Heap[T]) GetByKey(key string) *T {
 	item, exists := h.data.items[key]
@@ -214,6 +214,42 @@ func TestHeap_Update(t *testing.T) {
 	}
 }
 
+// TestHeap_Reorder tests that Heap.Reorder restores the heap order after
+// the items changed in place.
+func TestHeap_Reorder(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	foo := mkHeapObj("foo", 10)
+	h.PushOrUpdate(foo)
+	h.PushOrUpdate(mkHeapObj("bar", 1))
+	h.PushOrUpdate(mkHeapObj("bal", 31))
+
+	foo.val = 0
+	h.Reorder()
+	if item := h.Pop(); item.name != "foo" {
+		t.Fatalf("expected foo to be at the head, got %s", item.name)
+	}
+	if item := h.Pop(); item.name != "bar" {
+		t.Fatalf("expected bar to be at the head, got %s", item.name)
+	}
+}
+
+func TestHeap_Peek(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	if item := h.Peek(); item != nil {
+		t.Fatalf("expected no head in an empty heap, got %s", item.name)
+	}
+	h.PushOrUpdate(mkHeapObj("foo", 10))
+	h.PushOrUpdate(mkHeapObj("bar", 1))
+	h.PushOrUpdate(mkHeapObj("bal", 31))
+
+	if item := h.Peek(); item.name != "bar" {
+		t.Fatalf("expected bar to be at the head, got %s", item.name)
+	}
+	if h.Len() != 3 {
+		t.Fatalf("expected 3 items after peeking, got %d", h.Len())
+	}
+}
+
 // TestHeap_GetByKey tests Heap.GetByKey and is very similar to TestHeap_Get.
 func TestHeap_GetByKey(t *testing.T) {
 	h := New(testHeapObjectKeyFunc, compareInts)
@@ -329,6 +329,13 @@ func (w *WorkloadWrapper) DeletionTimestamp(t time.Time) *WorkloadWrapper {
 	return w
 }
 
+// ClusterName sets the MultiKueue worker cluster in which the workload got
+// quota reservation.
+func (w *WorkloadWrapper) ClusterName(name string) *WorkloadWrapper {
+	w.Status.ClusterName = &name
+	return w
+}
+
 func (w *WorkloadWrapper) RequeueState(count *int32, requeueAt *metav1.Time) *WorkloadWrapper {
 	if count == nil && requeueAt == nil {
 		w.Status.RequeueState = nil
@@ -356,6 +363,11 @@ func (w *WorkloadWrapper) MaximumExecutionTimeSeconds(v int32) *WorkloadWrapper
 	return w
 }
 
+func (w *WorkloadWrapper) MinimumRuntimeSeconds(v int32) *WorkloadWrapper {
+	w.Spec.MinimumRuntimeSeconds = &v
+	return w
+}
+
 func (w *WorkloadWrapper) PastAdmittedTime(v int32) *WorkloadWrapper {
 	w.Status.AccumulatedPastExexcutionTimeSeconds = &v
 	return w
@@ -602,6 +614,12 @@ func (w *AdmissionWrapper) PodSets(podSets ...kueue.PodSetAssignment) *Admission
 	return w
 }
 
+// Cost sets the cost of the admission.
+func (w *AdmissionWrapper) Cost(cost string) *AdmissionWrapper {
+	w.Admission.Cost = ptr.To(resource.MustParse(cost))
+	return w
+}
+
 // LocalQueueWrapper wraps a Queue.
 type LocalQueueWrapper struct{ kueue.LocalQueue }
 
@@ -647,6 +665,37 @@ func (q *LocalQueueWrapper) StopPolicy(p kueue.StopPolicy) *LocalQueueWrapper {
 	return q
 }
 
+// FairWeight sets the weight of the LocalQueue for admission fair sharing.
+func (q *LocalQueueWrapper) FairWeight(w resource.Quantity) *LocalQueueWrapper {
+	if q.Spec.FairSharing == nil {
+		q.Spec.FairSharing = &kueue.FairSharing{}
+	}
+	q.Spec.FairSharing.Weight = &w
+	return q
+}
+
+// Quota sets the maximum usage of the resource in the flavor.
+func (q *LocalQueueWrapper) Quota(flavor kueue.ResourceFlavorReference, resourceName corev1.ResourceName, maxUsage string) *LocalQueueWrapper {
+	rq := kueue.LocalQueueResourceQuota{Name: resourceName, Max: resource.MustParse(maxUsage)}
+	for i := range q.Spec.Quotas {
+		if q.Spec.Quotas[i].Name == flavor {
+			q.Spec.Quotas[i].Resources = append(q.Spec.Quotas[i].Resources, rq)
+			return q
+		}
+	}
+	q.Spec.Quotas = append(q.Spec.Quotas, kueue.LocalQueueFlavorQuotas{
+		Name:      flavor,
+		Resources: []kueue.LocalQueueResourceQuota{rq},
+	})
+	return q
+}
+
+// MaxAdmittedWorkloads sets the maximum number of admitted workloads.
+func (q *LocalQueueWrapper) MaxAdmittedWorkloads(n int32) *LocalQueueWrapper {
+	q.Spec.MaxAdmittedWorkloads = &n
+	return q
+}
+
 // PendingWorkloads updates the pendingWorkloads in status.
 func (q *LocalQueueWrapper) PendingWorkloads(n int32) *LocalQueueWrapper {
 	q.Status.PendingWorkloads = n
@@ -719,6 +768,61 @@ func (c *CohortWrapper) FairWeight(w resource.Quantity) *CohortWrapper {
 	return c
 }
 
+// ReservationWrapper wraps a Reservation.
+type ReservationWrapper struct {
+	kueuealpha.Reservation
+}
+
+// MakeReservation creates a wrapper for a Reservation starting at the given
+// time and lasting for the given duration.
+func MakeReservation(name string, start time.Time, duration time.Duration) *ReservationWrapper {
+	return &ReservationWrapper{kueuealpha.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kueuealpha.ReservationSpec{
+			StartTime: metav1.NewTime(start),
+			Duration:  metav1.Duration{Duration: duration},
+		},
+	}}
+}
+
+// Obj returns the inner Reservation.
+func (r *ReservationWrapper) Obj() *kueuealpha.Reservation {
+	return &r.Reservation
+}
+
+// ClusterQueue sets the ClusterQueue whose quota is reserved.
+func (r *ReservationWrapper) ClusterQueue(name kueue.ClusterQueueReference) *ReservationWrapper {
+	r.Spec.ClusterQueue = name
+	return r
+}
+
+// Cohort sets the Cohort whose quota is reserved.
+func (r *ReservationWrapper) Cohort(name kueue.CohortReference) *ReservationWrapper {
+	r.Spec.Cohort = name
+	return r
+}
+
+// Flavor adds the reserved quantities of a flavor.
+func (r *ReservationWrapper) Flavor(name kueue.ResourceFlavorReference, resources corev1.ResourceList) *ReservationWrapper {
+	r.Spec.Flavors = append(r.Spec.Flavors, kueuealpha.ReservedFlavor{Name: name, Resources: resources})
+	return r
+}
+
+// DrainDuration sets the time during which the capacity is held back before
+// the time window.
+func (r *ReservationWrapper) DrainDuration(d time.Duration) *ReservationWrapper {
+	r.Spec.DrainDuration = &metav1.Duration{Duration: d}
+	return r
+}
+
+// Generation sets the generation of the Reservation.
+func (r *ReservationWrapper) Generation(g int64) *ReservationWrapper {
+	r.ObjectMeta.Generation = g
+	return r
+}
+
 // ClusterQueueWrapper wraps a ClusterQueue.
 type ClusterQueueWrapper struct{ kueue.ClusterQueue }
 
@@ -827,6 +931,24 @@ func (c *ClusterQueueWrapper) FlavorFungibility(p kueue.FlavorFungibility) *Clus
 	return c
 }
 
+// FlavorSelectionPolicy sets the flavor selection policy.
+func (c *ClusterQueueWrapper) FlavorSelectionPolicy(p kueue.FlavorSelectionPolicy) *ClusterQueueWrapper {
+	c.Spec.FlavorSelectionPolicy = p
+	return c
+}
+
+// PriorityAging sets the priority aging policy.
+func (c *ClusterQueueWrapper) PriorityAging(p kueue.PriorityAging) *ClusterQueueWrapper {
+	c.Spec.PriorityAging = &p
+	return c
+}
+
+// MaxAdmittedWorkloads sets the maximum number of admitted workloads.
+func (c *ClusterQueueWrapper) MaxAdmittedWorkloads(n int32) *ClusterQueueWrapper {
+	c.Spec.MaxAdmittedWorkloads = &n
+	return c
+}
+
 // StopPolicy sets the stop policy.
 func (c *ClusterQueueWrapper) StopPolicy(p kueue.StopPolicy) *ClusterQueueWrapper {
 	c.Spec.StopPolicy = &p
@@ -855,6 +977,12 @@ func (c *ClusterQueueWrapper) FairWeight(w resource.Quantity) *ClusterQueueWrapp
 	return c
 }
 
+// AdmissionMode sets the admission mode of the ClusterQueue's admission scope.
+func (c *ClusterQueueWrapper) AdmissionMode(mode kueue.AdmissionMode) *ClusterQueueWrapper {
+	c.Spec.AdmissionScope = &kueue.AdmissionScope{AdmissionMode: mode}
+	return c
+}
+
 // Condition sets a condition on the ClusterQueue.
 func (c *ClusterQueueWrapper) Condition(conditionType string, status metav1.ConditionStatus, reason, message string) *ClusterQueueWrapper {
 	apimeta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
@@ -890,6 +1018,19 @@ func (c *ClusterQueueWrapper) AdmittedWorkloads(n int32) *ClusterQueueWrapper {
 	return c
 }
 
+// ConsumedResources sets the resources consumed in the past in the fair
+// sharing status.
+func (c *ClusterQueueWrapper) ConsumedResources(resources corev1.ResourceList, lastUpdate time.Time) *ClusterQueueWrapper {
+	if c.Status.FairSharing == nil {
+		c.Status.FairSharing = &kueue.FairSharingStatus{}
+	}
+	c.Status.FairSharing.ConsumedResources = &kueue.ConsumedResources{
+		Resources:  resources,
+		LastUpdate: metav1.NewTime(lastUpdate),
+	}
+	return c
+}
+
 // FlavorQuotasWrapper wraps a FlavorQuotas object.
 type FlavorQuotasWrapper struct{ kueue.FlavorQuotas }
 
@@ -952,6 +1093,18 @@ func (rq *ResourceQuotaWrapper) LendingLimit(quantity string) *ResourceQuotaWrap
 	return rq
 }
 
+// Schedule appends a QuotaSchedule overriding the nominalQuota between start and end.
+func (rq *ResourceQuotaWrapper) Schedule(name, start, end, nominalQuota string, days ...kueue.DayOfWeek) *ResourceQuotaWrapper {
+	rq.ResourceQuota.Schedules = append(rq.ResourceQuota.Schedules, kueue.QuotaSchedule{
+		Name:         name,
+		DaysOfWeek:   days,
+		StartTime:    start,
+		EndTime:      end,
+		NominalQuota: ptr.To(resource.MustParse(nominalQuota)),
+	})
+	return rq
+}
+
 // Append appends the ResourceQuotaWrapper to its parent
 func (rq *ResourceQuotaWrapper) Append() *FlavorQuotasWrapper {
 	rq.parent.Resources = append(rq.parent.Resources, rq.ResourceQuota)
@@ -1011,6 +1164,12 @@ func (rf *ResourceFlavorWrapper) Toleration(t corev1.Toleration) *ResourceFlavor
 	return rf
 }
 
+// Cost adds the price of a unit of the resource to the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) Cost(r corev1.ResourceName, price string) *ResourceFlavorWrapper {
+	rf.Spec.Costs = append(rf.Spec.Costs, kueue.ResourceCost{Name: r, Price: resource.MustParse(price)})
+	return rf
+}
+
 // Creation sets the creation timestamp of the LocalQueue.
 func (rf *ResourceFlavorWrapper) Creation(t time.Time) *ResourceFlavorWrapper {
 	rf.CreationTimestamp = metav1.NewTime(t)
@@ -1252,6 +1411,12 @@ func (p *WorkloadPriorityClassWrapper) PriorityValue(v int32) *WorkloadPriorityC
 	return p
 }
 
+// MinimumRuntimeSeconds updates the minimumRuntimeSeconds of the WorkloadPriorityClass.
+func (p *WorkloadPriorityClassWrapper) MinimumRuntimeSeconds(v int32) *WorkloadPriorityClassWrapper {
+	p.WorkloadPriorityClass.MinimumRuntimeSeconds = &v
+	return p
+}
+
 // Obj returns the inner WorkloadPriorityClass.
 func (p *WorkloadPriorityClassWrapper) Obj() *kueue.WorkloadPriorityClass {
 	return &p.WorkloadPriorityClass
@@ -1280,6 +1445,11 @@ func (mkc *MultiKueueConfigWrapper) Clusters(clusters ...string) *MultiKueueConf
 	return mkc
 }
 
+func (mkc *MultiKueueConfigWrapper) Dispatcher(d *kueue.MultiKueueDispatcher) *MultiKueueConfigWrapper {
+	mkc.Spec.Dispatcher = d
+	return mkc
+}
+
 type MultiKueueClusterWrapper struct {
 	kueue.MultiKueueCluster
 }
@@ -1318,6 +1488,18 @@ func (mkc *MultiKueueClusterWrapper) Active(state metav1.ConditionStatus, reason
 	return mkc
 }
 
+func (mkc *MultiKueueClusterWrapper) Cordoned(state metav1.ConditionStatus, reason, message string, generation int64) *MultiKueueClusterWrapper {
+	cond := metav1.Condition{
+		Type:               kueue.MultiKueueClusterCordoned,
+		Status:             state,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+	apimeta.SetStatusCondition(&mkc.Status.Conditions, cond)
+	return mkc
+}
+
 // Generation sets the generation of the MultiKueueCluster.
 func (mkc *MultiKueueClusterWrapper) Generation(num int64) *MultiKueueClusterWrapper {
 	mkc.ObjectMeta.Generation = num
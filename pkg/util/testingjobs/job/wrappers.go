@@ -150,6 +150,12 @@ func (j *JobWrapper) Toleration(t corev1.Toleration) *JobWrapper {
 	return j
 }
 
+// SchedulingGate adds a scheduling gate to the pod template of the job.
+func (j *JobWrapper) SchedulingGate(name string) *JobWrapper {
+	j.Spec.Template.Spec.SchedulingGates = append(j.Spec.Template.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: name})
+	return j
+}
+
 // NodeSelector adds a node selector to the job.
 func (j *JobWrapper) NodeSelector(k, v string) *JobWrapper {
 	j.Spec.Template.Spec.NodeSelector[k] = v
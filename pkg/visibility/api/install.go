@@ -25,6 +25,7 @@ import (
 	genericapiserver "k8s.io/apiserver/pkg/server"
 
 	visibilityv1beta1 "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
 	apiv1beta1 "sigs.k8s.io/kueue/pkg/visibility/api/v1beta1"
 )
@@ -42,8 +43,8 @@ func init() {
 }
 
 // Install installs API scheme and registers storages
-func Install(server *genericapiserver.GenericAPIServer, kueueMgr *queue.Manager) error {
+func Install(server *genericapiserver.GenericAPIServer, kueueMgr *queue.Manager, cache *cache.Cache, dryRunner apiv1beta1.DryRunner, decisions apiv1beta1.SchedulingDecisionsLister) error {
 	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(visibilityv1beta1.GroupVersion.Group, Scheme, ParameterCodec, Codecs)
-	apiGroupInfo.VersionedResourcesStorageMap[visibilityv1beta1.GroupVersion.Version] = apiv1beta1.NewStorage(kueueMgr)
+	apiGroupInfo.VersionedResourcesStorageMap[visibilityv1beta1.GroupVersion.Version] = apiv1beta1.NewStorage(kueueMgr, cache, dryRunner, decisions)
 	return server.InstallAPIGroups(&apiGroupInfo)
 }
@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+)
+
+type admittedWorkloadsInCqREST struct {
+	cache *cache.Cache
+	log   logr.Logger
+}
+
+var _ rest.Storage = &admittedWorkloadsInCqREST{}
+var _ rest.GetterWithOptions = &admittedWorkloadsInCqREST{}
+var _ rest.Scoper = &admittedWorkloadsInCqREST{}
+
+func NewAdmittedWorkloadsInCqREST(cache *cache.Cache) *admittedWorkloadsInCqREST {
+	return &admittedWorkloadsInCqREST{
+		cache: cache,
+		log:   ctrl.Log.WithName("admitted-workload-in-cq"),
+	}
+}
+
+// New implements rest.Storage interface
+func (m *admittedWorkloadsInCqREST) New() runtime.Object {
+	return &visibility.AdmittedWorkloadsSummary{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *admittedWorkloadsInCqREST) Destroy() {}
+
+// Get implements rest.GetterWithOptions interface
+// It fetches information about the workloads reserving quota in the ClusterQueue,
+// or in its cohort, and returns according to query params
+func (m *admittedWorkloadsInCqREST) Get(_ context.Context, name string, opts runtime.Object) (runtime.Object, error) {
+	admittedWorkloadOpts, ok := opts.(*visibility.AdmittedWorkloadOptions)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+
+	if err := validateAdmittedWorkloadOptions(admittedWorkloadOpts); err != nil {
+		return nil, err
+	}
+
+	admitted, err := m.cache.AdmittedWorkloads(kueue.ClusterQueueReference(name), admittedWorkloadOpts.Cohort)
+	if errors.Is(err, cache.ErrCqNotFound) {
+		return nil, apierrors.NewNotFound(visibility.Resource("clusterqueue"), name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &visibility.AdmittedWorkloadsSummary{Items: admittedWorkloadsPage(admitted, admittedWorkloadOpts)}, nil
+}
+
+// NewGetOptions creates a new options object
+func (m *admittedWorkloadsInCqREST) NewGetOptions() (runtime.Object, bool, string) {
+	// If no query parameters were passed the generated defaults function are not executed so it's necessary to set default values here as well
+	return &visibility.AdmittedWorkloadOptions{
+		Limit: constants.DefaultAdmittedWorkloadsLimit,
+	}, false, ""
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *admittedWorkloadsInCqREST) NamespaceScoped() bool {
+	return false
+}
@@ -0,0 +1,207 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestAdmittedWorkloadsInCQ(t *testing.T) {
+	const nsName = "ns"
+
+	now := time.Now().Truncate(time.Second)
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-c").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a1", nsName).
+			Queue("lq-a").
+			Priority(10).
+			Request(corev1.ResourceCPU, "3").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj(), now).
+			AdmittedAt(true, now.Add(time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("a2", nsName).
+			Queue("lq-a").
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now.Add(2*time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("b1", nsName).
+			Queue("lq-b").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "1").Obj(), now.Add(time.Second)).
+			AdmittedAt(true, now.Add(time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("c1", nsName).
+			Queue("lq-c").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq-c").Assignment(corev1.ResourceCPU, "default", "1").Obj(), now).
+			AdmittedAt(true, now).
+			Obj(),
+	}
+	admitted := func(name, lq, cq, cpu string, priority int32, borrowing bool, reservedAt time.Time, admittedAt *time.Time) visibility.AdmittedWorkload {
+		wl := visibility.AdmittedWorkload{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: nsName},
+			Priority:       priority,
+			LocalQueueName: lq,
+			ClusterQueue:   cq,
+			PodSets: []visibility.PodSetUsage{{
+				Name:          "main",
+				Count:         1,
+				Flavors:       map[corev1.ResourceName]string{corev1.ResourceCPU: "default"},
+				ResourceUsage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			}},
+			Borrowing:            borrowing,
+			QuotaReservationTime: metav1.NewTime(reservedAt),
+		}
+		if admittedAt != nil {
+			wl.AdmissionTime = ptr.To(metav1.NewTime(*admittedAt))
+		}
+		return wl
+	}
+	a1 := admitted("a1", "lq-a", "cq-a", "3", 10, true, now, ptr.To(now.Add(time.Second)))
+	a2 := admitted("a2", "lq-a", "cq-a", "2", 0, true, now.Add(2*time.Second), nil)
+	b1 := admitted("b1", "lq-b", "cq-b", "1", 0, false, now.Add(time.Second), ptr.To(now.Add(time.Second)))
+	c1 := admitted("c1", "lq-c", "cq-c", "1", 0, false, now, ptr.To(now))
+
+	defaultOpts := &visibility.AdmittedWorkloadOptions{
+		Limit: constants.DefaultAdmittedWorkloadsLimit,
+	}
+	cases := map[string]struct {
+		cqName       string
+		opts         *visibility.AdmittedWorkloadOptions
+		want         []visibility.AdmittedWorkload
+		wantErrMatch func(error) bool
+	}{
+		"workloads in the ClusterQueue": {
+			cqName: "cq-a",
+			opts:   defaultOpts,
+			want:   []visibility.AdmittedWorkload{a1, a2},
+		},
+		"workloads in the cohort": {
+			cqName: "cq-b",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Limit:  constants.DefaultAdmittedWorkloadsLimit,
+				Cohort: true,
+			},
+			want: []visibility.AdmittedWorkload{a1, b1, a2},
+		},
+		"workloads in the cohort with offset and limit": {
+			cqName: "cq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Offset: 1,
+				Limit:  1,
+				Cohort: true,
+			},
+			want: []visibility.AdmittedWorkload{b1},
+		},
+		"offset past the last workload": {
+			cqName: "cq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Offset: 5,
+				Limit:  constants.DefaultAdmittedWorkloadsLimit,
+			},
+			want: []visibility.AdmittedWorkload{},
+		},
+		"ClusterQueue without cohort": {
+			cqName: "cq-c",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Limit:  constants.DefaultAdmittedWorkloadsLimit,
+				Cohort: true,
+			},
+			want: []visibility.AdmittedWorkload{c1},
+		},
+		"nonexistent ClusterQueue": {
+			cqName:       "nonexistent-queue",
+			opts:         defaultOpts,
+			wantErrMatch: errors.IsNotFound,
+		},
+		"negative offset": {
+			cqName: "cq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Offset: -1,
+				Limit:  constants.DefaultAdmittedWorkloadsLimit,
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
+		"negative limit": {
+			cqName: "cq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Limit: -1,
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cqCache := cache.New(utiltesting.NewFakeClient())
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
+				}
+			}
+			for _, wl := range workloads {
+				cqCache.AddOrUpdateWorkload(wl)
+			}
+
+			info, err := NewAdmittedWorkloadsInCqREST(cqCache).Get(ctx, tc.cqName, tc.opts)
+			if tc.wantErrMatch != nil {
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			summary, ok := info.(*visibility.AdmittedWorkloadsSummary)
+			if !ok {
+				t.Fatalf("Not an AdmittedWorkloadsSummary")
+			}
+			if diff := cmp.Diff(tc.want, summary.Items, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Admitted workloads differ: (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
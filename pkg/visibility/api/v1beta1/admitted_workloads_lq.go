@@ -0,0 +1,104 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+type admittedWorkloadsInLqREST struct {
+	queueMgr *queue.Manager
+	cache    *cache.Cache
+	log      logr.Logger
+}
+
+var _ rest.Storage = &admittedWorkloadsInLqREST{}
+var _ rest.GetterWithOptions = &admittedWorkloadsInLqREST{}
+var _ rest.Scoper = &admittedWorkloadsInLqREST{}
+
+func NewAdmittedWorkloadsInLqREST(kueueMgr *queue.Manager, cache *cache.Cache) *admittedWorkloadsInLqREST {
+	return &admittedWorkloadsInLqREST{
+		queueMgr: kueueMgr,
+		cache:    cache,
+		log:      ctrl.Log.WithName("admitted-workload-in-lq"),
+	}
+}
+
+// New implements rest.Storage interface
+func (m *admittedWorkloadsInLqREST) New() runtime.Object {
+	return &visibility.AdmittedWorkloadsSummary{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *admittedWorkloadsInLqREST) Destroy() {}
+
+// Get implements rest.GetterWithOptions interface
+// It fetches information about the workloads reserving quota through the LocalQueue
+// and returns according to query params
+func (m *admittedWorkloadsInLqREST) Get(ctx context.Context, name string, opts runtime.Object) (runtime.Object, error) {
+	admittedWorkloadOpts, ok := opts.(*visibility.AdmittedWorkloadOptions)
+	if !ok {
+		return nil, fmt.Errorf("invalid options object: %#v", opts)
+	}
+
+	if err := validateAdmittedWorkloadOptions(admittedWorkloadOpts); err != nil {
+		return nil, err
+	}
+
+	namespace := genericapirequest.NamespaceValue(ctx)
+	cqName, ok := m.queueMgr.ClusterQueueFromLocalQueue(queue.QueueKey(namespace, name))
+	if !ok {
+		return nil, errors.NewNotFound(visibility.Resource("localqueue"), name)
+	}
+
+	// The ClusterQueue might not be in the cache yet, or anymore, in which
+	// case no workload reserves quota in it.
+	admitted, _ := m.cache.AdmittedWorkloads(cqName, false)
+	inLq := admitted[:0]
+	for _, aw := range admitted {
+		if aw.Info.Obj.Namespace == namespace && aw.Info.Obj.Spec.QueueName == name {
+			inLq = append(inLq, aw)
+		}
+	}
+	return &visibility.AdmittedWorkloadsSummary{Items: admittedWorkloadsPage(inLq, admittedWorkloadOpts)}, nil
+}
+
+// NewGetOptions creates a new options object
+func (m *admittedWorkloadsInLqREST) NewGetOptions() (runtime.Object, bool, string) {
+	// If no query parameters were passed the generated defaults function are not executed so it's necessary to set default values here as well
+	return &visibility.AdmittedWorkloadOptions{
+		Limit: constants.DefaultAdmittedWorkloadsLimit,
+	}, false, ""
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *admittedWorkloadsInLqREST) NamespaceScoped() bool {
+	return true
+}
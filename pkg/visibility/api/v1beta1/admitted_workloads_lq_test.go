@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestAdmittedWorkloadsInLQ(t *testing.T) {
+	const (
+		nsName = "ns"
+		cqName = "cq"
+	)
+
+	now := time.Now().Truncate(time.Second)
+	cq := utiltesting.MakeClusterQueue(cqName).
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	queues := []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("lq-a", nsName).ClusterQueue(cqName).Obj(),
+		utiltesting.MakeLocalQueue("lq-b", nsName).ClusterQueue(cqName).Obj(),
+		utiltesting.MakeLocalQueue("lq-a", "other").ClusterQueue(cqName).Obj(),
+	}
+	admittedWl := func(name, namespace, lq string, reservedAt time.Time) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, namespace).
+			Queue(lq).
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuotaAt(utiltesting.MakeAdmission(cqName).Assignment(corev1.ResourceCPU, "default", "1").Obj(), reservedAt).
+			Obj()
+	}
+	workloads := []*kueue.Workload{
+		admittedWl("a2", nsName, "lq-a", now.Add(time.Second)),
+		admittedWl("a1", nsName, "lq-a", now),
+		admittedWl("b1", nsName, "lq-b", now),
+		admittedWl("a1", "other", "lq-a", now),
+	}
+	admitted := func(name string, reservedAt time.Time) visibility.AdmittedWorkload {
+		return visibility.AdmittedWorkload{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: nsName},
+			LocalQueueName: "lq-a",
+			ClusterQueue:   cqName,
+			PodSets: []visibility.PodSetUsage{{
+				Name:          "main",
+				Count:         1,
+				Flavors:       map[corev1.ResourceName]string{corev1.ResourceCPU: "default"},
+				ResourceUsage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}},
+			QuotaReservationTime: metav1.NewTime(reservedAt),
+		}
+	}
+
+	cases := map[string]struct {
+		lqName       string
+		opts         *visibility.AdmittedWorkloadOptions
+		want         []visibility.AdmittedWorkload
+		wantErrMatch func(error) bool
+	}{
+		"workloads in the LocalQueue": {
+			lqName: "lq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Limit: constants.DefaultAdmittedWorkloadsLimit,
+			},
+			want: []visibility.AdmittedWorkload{
+				admitted("a1", now),
+				admitted("a2", now.Add(time.Second)),
+			},
+		},
+		"workloads in the LocalQueue with offset and limit": {
+			lqName: "lq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Offset: 1,
+				Limit:  1,
+			},
+			want: []visibility.AdmittedWorkload{
+				admitted("a2", now.Add(time.Second)),
+			},
+		},
+		"cohort is ignored": {
+			lqName: "lq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Offset: 1,
+				Limit:  constants.DefaultAdmittedWorkloadsLimit,
+				Cohort: true,
+			},
+			want: []visibility.AdmittedWorkload{
+				admitted("a2", now.Add(time.Second)),
+			},
+		},
+		"nonexistent LocalQueue": {
+			lqName: "nonexistent-queue",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Limit: constants.DefaultAdmittedWorkloadsLimit,
+			},
+			wantErrMatch: errors.IsNotFound,
+		},
+		"negative offset": {
+			lqName: "lq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Offset: -1,
+				Limit:  constants.DefaultAdmittedWorkloadsLimit,
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
+		"negative limit": {
+			lqName: "lq-a",
+			opts: &visibility.AdmittedWorkloadOptions{
+				Limit: -1,
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewFakeClient()
+			cqCache := cache.New(cl)
+			manager := queue.NewManager(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding cluster queue %s to the cache: %v", cq.Name, err)
+			}
+			if err := manager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding cluster queue %s to the manager: %v", cq.Name, err)
+			}
+			for _, q := range queues {
+				if err := manager.AddLocalQueue(ctx, q); err != nil {
+					t.Fatalf("Adding queue %q: %v", q.Name, err)
+				}
+			}
+			for _, wl := range workloads {
+				cqCache.AddOrUpdateWorkload(wl)
+			}
+
+			ctx = request.WithNamespace(ctx, nsName)
+			info, err := NewAdmittedWorkloadsInLqREST(manager, cqCache).Get(ctx, tc.lqName, tc.opts)
+			if tc.wantErrMatch != nil {
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			summary, ok := info.(*visibility.AdmittedWorkloadsSummary)
+			if !ok {
+				t.Fatalf("Not an AdmittedWorkloadsSummary")
+			}
+			if diff := cmp.Diff(tc.want, summary.Items, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Admitted workloads differ: (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// topBlockingResourcesLimit is the maximum number of blocking resources
+// summarized for a ClusterQueue.
+const topBlockingResourcesLimit = 10
+
+// inadmissibilityWorkloadsLimit is the maximum number of pending workloads,
+// in queue order, to which flavors are assigned in a request.
+const inadmissibilityWorkloadsLimit = 1000
+
+// FlavorsDryRunner assigns flavors to pending workloads without admitting
+// them.
+type FlavorsDryRunner interface {
+	FlavorsDryRun(ctx context.Context, cqName kueue.ClusterQueueReference, wls []*workload.Info) ([]scheduler.FlavorsDryRunResult, error)
+}
+
+// DryRunner simulates the admission of pending workloads.
+type DryRunner interface {
+	PreemptionDryRunner
+	FlavorsDryRunner
+}
+
+// flavorsDryRun returns the flavors assigned to each of the first
+// inadmissibilityWorkloadsLimit workloads, or nil if the
+// InadmissibilityReasons feature gate is disabled or the dry run fails.
+func flavorsDryRun(ctx context.Context, log logr.Logger, dryRunner FlavorsDryRunner, cqName kueue.ClusterQueueReference, wls []*workload.Info) []scheduler.FlavorsDryRunResult {
+	if dryRunner == nil || len(wls) == 0 || !features.Enabled(features.InadmissibilityReasons) {
+		return nil
+	}
+	wls = wls[:min(len(wls), inadmissibilityWorkloadsLimit)]
+	results, err := dryRunner.FlavorsDryRun(ctx, cqName, wls)
+	if err != nil {
+		log.Error(err, "Failed to assign flavors to the pending workloads", "clusterQueue", cqName)
+		return nil
+	}
+	return results
+}
+
+// fits returns whether the workload fits in the unused quota.
+func fits(res *scheduler.FlavorsDryRunResult) bool {
+	return len(res.InadmissibleMsg) == 0 && res.Assignment.RepresentativeMode() == flavorassigner.Fit
+}
+
+// setInadmissibilityReasons sets on the pending workload why it doesn't fit,
+// if it doesn't.
+func setInadmissibilityReasons(wl *visibility.PendingWorkload, res *scheduler.FlavorsDryRunResult) {
+	if fits(res) {
+		return
+	}
+	wl.InadmissibleReason = res.InadmissibleMsg
+	wl.Blockers = podSetBlockers(res.Assignment.FlavorDecisions())
+}
+
+// podSetBlockers breaks down the flavor decisions by pod set and resource.
+func podSetBlockers(decisions []flavorassigner.FlavorDecision) []visibility.PodSetBlockers {
+	var result []visibility.PodSetBlockers
+	for _, d := range decisions {
+		if len(result) == 0 || result[len(result)-1].Name != string(d.PodSet) {
+			result = append(result, visibility.PodSetBlockers{Name: string(d.PodSet)})
+		}
+		ps := &result[len(result)-1]
+		for _, rName := range d.Resources {
+			blockers := visibility.ResourceBlockers{Name: rName}
+			for i := range d.Attempts {
+				blockers.Flavors = append(blockers.Flavors, flavorBlocker(rName, &d.Attempts[i]))
+			}
+			ps.Resources = append(ps.Resources, blockers)
+		}
+	}
+	return result
+}
+
+// flavorBlocker returns the evaluation of the flavor for the resource. A
+// flavor lacking quota for other resources of the group isn't reported as
+// lacking quota for this one.
+func flavorBlocker(rName corev1.ResourceName, attempt *flavorassigner.FlavorAttempt) visibility.FlavorBlocker {
+	blocker := visibility.FlavorBlocker{
+		Name:    string(attempt.Flavor),
+		Reason:  string(attempt.Rejection),
+		Message: attempt.Message,
+	}
+	quota := resourceQuotaCheck(rName, attempt)
+	if quota == nil {
+		return blocker
+	}
+	blocker.Requested = ptr.To(resources.ResourceQuantity(rName, quota.Requested))
+	blocker.Available = ptr.To(resources.ResourceQuantity(rName, quota.Available))
+	blocker.Borrowable = ptr.To(resources.ResourceQuantity(rName, quota.Borrowable))
+	if attempt.Rejection == flavorassigner.RejectedByQuota && quota.Requested <= quota.Available {
+		blocker.Reason = ""
+		blocker.Message = ""
+	}
+	return blocker
+}
+
+func resourceQuotaCheck(rName corev1.ResourceName, attempt *flavorassigner.FlavorAttempt) *flavorassigner.ResourceQuotaCheck {
+	if i := slices.IndexFunc(attempt.Quota, func(q flavorassigner.ResourceQuotaCheck) bool { return q.Resource == rName }); i >= 0 {
+		return &attempt.Quota[i]
+	}
+	return nil
+}
+
+// topBlockingResources returns the resources of the flavors lacking unused
+// quota for the most workloads which don't fit.
+func topBlockingResources(results []scheduler.FlavorsDryRunResult) []visibility.BlockingResource {
+	type blocking struct {
+		workloads int32
+		shortfall int64
+	}
+	byResource := make(map[resources.FlavorResource]*blocking)
+	for i := range results {
+		if fits(&results[i]) {
+			continue
+		}
+		// A workload lacking quota for a resource of a flavor for several
+		// pod sets is counted once, with its largest shortfall.
+		shortfalls := make(map[resources.FlavorResource]int64)
+		for _, d := range results[i].Assignment.FlavorDecisions() {
+			for _, attempt := range d.Attempts {
+				if attempt.Rejection != flavorassigner.RejectedByQuota {
+					continue
+				}
+				for _, q := range attempt.Quota {
+					if q.Requested > q.Available {
+						fr := resources.FlavorResource{Flavor: attempt.Flavor, Resource: q.Resource}
+						shortfalls[fr] = max(shortfalls[fr], q.Requested-q.Available)
+					}
+				}
+			}
+		}
+		for fr, shortfall := range shortfalls {
+			b, found := byResource[fr]
+			if !found {
+				b = &blocking{}
+				byResource[fr] = b
+			}
+			b.workloads++
+			b.shortfall = max(b.shortfall, shortfall)
+		}
+	}
+
+	result := make([]visibility.BlockingResource, 0, len(byResource))
+	for fr, b := range byResource {
+		result = append(result, visibility.BlockingResource{
+			Flavor:       string(fr.Flavor),
+			Resource:     fr.Resource,
+			Workloads:    b.workloads,
+			MaxShortfall: resources.ResourceQuantity(fr.Resource, b.shortfall),
+		})
+	}
+	slices.SortFunc(result, func(a, b visibility.BlockingResource) int {
+		return cmp.Or(
+			cmp.Compare(b.Workloads, a.Workloads),
+			cmp.Compare(a.Flavor, b.Flavor),
+			cmp.Compare(a.Resource, b.Resource),
+		)
+	})
+	if len(result) > topBlockingResourcesLimit {
+		result = result[:topBlockingResourcesLimit]
+	}
+	return result
+}
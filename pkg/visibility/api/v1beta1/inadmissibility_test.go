@@ -0,0 +1,265 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestPendingWorkloadsInadmissibilityReasons(t *testing.T) {
+	const (
+		nsName = "ns"
+		lqName = "lq"
+		cqName = "cq"
+	)
+
+	type inadmissibility struct {
+		Name     string
+		Reason   string
+		Blockers []visibility.PodSetBlockers
+	}
+
+	now := time.Now().Truncate(time.Second)
+	quantity := func(s string) *resource.Quantity {
+		return ptr.To(resource.MustParse(s))
+	}
+	taint := corev1.Taint{Key: "instance", Value: "spot", Effect: corev1.TaintEffectNoSchedule}
+	cq := utiltesting.MakeClusterQueue(cqName).
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("tainted").Resource(corev1.ResourceCPU, "10").Resource(corev1.ResourceMemory, "10Gi").Obj(),
+			*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Resource(corev1.ResourceMemory, "10Gi").Obj(),
+		).
+		Obj()
+	admitted := utiltesting.MakeWorkload("admitted", nsName).
+		Queue(lqName).
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuota(utiltesting.MakeAdmission(cqName).Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+		Obj()
+	pending := []*kueue.Workload{
+		utiltesting.MakeWorkload("fits", nsName).
+			Queue(lqName).
+			Priority(0).
+			Request(corev1.ResourceCPU, "1").
+			Creation(now.Add(-3 * time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("lacks-quota", nsName).
+			Queue(lqName).
+			Priority(0).
+			Request(corev1.ResourceCPU, "2").
+			Request(corev1.ResourceMemory, "1Gi").
+			Creation(now.Add(-2 * time.Second)).
+			Obj(),
+		utiltesting.MakeWorkload("too-big", nsName).
+			Queue(lqName).
+			Priority(0).
+			Request(corev1.ResourceCPU, "8").
+			Creation(now.Add(-time.Second)).
+			Obj(),
+	}
+	taintMessage := "untolerated taint {instance spot NoSchedule <nil>} in flavor tainted"
+	lacksQuota := inadmissibility{
+		Name:   "lacks-quota",
+		Reason: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed, " + taintMessage,
+		Blockers: []visibility.PodSetBlockers{{
+			Name: "main",
+			Resources: []visibility.ResourceBlockers{
+				{
+					Name: corev1.ResourceCPU,
+					Flavors: []visibility.FlavorBlocker{
+						{
+							Name:       "tainted",
+							Requested:  quantity("2"),
+							Available:  quantity("10"),
+							Borrowable: quantity("0"),
+							Reason:     "Taints",
+							Message:    taintMessage,
+						},
+						{
+							Name:       "default",
+							Requested:  quantity("2"),
+							Available:  quantity("1"),
+							Borrowable: quantity("0"),
+							Reason:     "Quota",
+							Message:    "insufficient unused quota for cpu in flavor default, 1 more needed",
+						},
+					},
+				},
+				{
+					Name: corev1.ResourceMemory,
+					Flavors: []visibility.FlavorBlocker{
+						{
+							Name:       "tainted",
+							Requested:  quantity("1Gi"),
+							Available:  quantity("10Gi"),
+							Borrowable: quantity("0"),
+							Reason:     "Taints",
+							Message:    taintMessage,
+						},
+						{
+							Name:       "default",
+							Requested:  quantity("1Gi"),
+							Available:  quantity("10Gi"),
+							Borrowable: quantity("0"),
+						},
+					},
+				},
+			},
+		}},
+	}
+	tooBig := inadmissibility{
+		Name:   "too-big",
+		Reason: "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor default, request > maximum capacity (8 > 4), " + taintMessage,
+		Blockers: []visibility.PodSetBlockers{{
+			Name: "main",
+			Resources: []visibility.ResourceBlockers{{
+				Name: corev1.ResourceCPU,
+				Flavors: []visibility.FlavorBlocker{
+					{
+						Name:       "tainted",
+						Requested:  quantity("8"),
+						Available:  quantity("10"),
+						Borrowable: quantity("0"),
+						Reason:     "Taints",
+						Message:    taintMessage,
+					},
+					{
+						Name:       "default",
+						Requested:  quantity("8"),
+						Available:  quantity("1"),
+						Borrowable: quantity("0"),
+						Reason:     "Quota",
+						Message:    "insufficient quota for cpu in flavor default, request > maximum capacity (8 > 4)",
+					},
+				},
+			}},
+		}},
+	}
+
+	cases := map[string]struct {
+		disableFeature bool
+		localQueue     bool
+		opts           *visibility.PendingWorkloadOptions
+		want           []inadmissibility
+		wantTop        []visibility.BlockingResource
+	}{
+		"ClusterQueue": {
+			want: []inadmissibility{{Name: "fits"}, lacksQuota, tooBig},
+			wantTop: []visibility.BlockingResource{{
+				Flavor:       "default",
+				Resource:     corev1.ResourceCPU,
+				Workloads:    2,
+				MaxShortfall: resource.MustParse("7"),
+			}},
+		},
+		"ClusterQueue with a limit summarizes all the pending workloads": {
+			opts: &visibility.PendingWorkloadOptions{Limit: 1},
+			want: []inadmissibility{{Name: "fits"}},
+			wantTop: []visibility.BlockingResource{{
+				Flavor:       "default",
+				Resource:     corev1.ResourceCPU,
+				Workloads:    2,
+				MaxShortfall: resource.MustParse("7"),
+			}},
+		},
+		"LocalQueue": {
+			localQueue: true,
+			opts:       &visibility.PendingWorkloadOptions{Offset: 1, Limit: constants.DefaultPendingWorkloadsLimit},
+			want:       []inadmissibility{lacksQuota, tooBig},
+		},
+		"feature disabled": {
+			disableFeature: true,
+			want:           []inadmissibility{{Name: "fits"}, {Name: "lacks-quota"}, {Name: "too-big"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.InadmissibilityReasons, !tc.disableFeature)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: []kueue.Workload{*admitted}}).
+				WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}).
+				Build()
+			cqCache := cache.New(cl)
+			manager := queue.NewManager(cl, cqCache)
+			go manager.CleanUpOnContext(ctx)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("tainted").Taint(taint).Obj())
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+			}
+			if err := manager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
+			}
+			if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lqName, nsName).ClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding queue %q: %v", lqName, err)
+			}
+			for _, wl := range pending {
+				if err := manager.AddOrUpdateWorkload(wl); err != nil {
+					t.Fatalf("Adding workload %q: %v", wl.Name, err)
+				}
+			}
+			sched := scheduler.New(manager, cqCache, cl, &utiltesting.EventRecorder{})
+
+			opts := tc.opts
+			if opts == nil {
+				opts = &visibility.PendingWorkloadOptions{Limit: constants.DefaultPendingWorkloadsLimit}
+			}
+			var info runtime.Object
+			var err error
+			if tc.localQueue {
+				info, err = NewPendingWorkloadsInLqREST(manager, cqCache, sched).Get(request.WithNamespace(ctx, nsName), lqName, opts)
+			} else {
+				info, err = NewPendingWorkloadsInCqREST(manager, cqCache, sched).Get(ctx, cqName, opts)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			summary := info.(*visibility.PendingWorkloadsSummary)
+			var got []inadmissibility
+			for _, wl := range summary.Items {
+				got = append(got, inadmissibility{Name: wl.Name, Reason: wl.InadmissibleReason, Blockers: wl.Blockers})
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected inadmissibility reasons (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantTop, summary.TopBlockingResources, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected top blocking resources (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
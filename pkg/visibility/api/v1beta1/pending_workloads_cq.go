@@ -28,6 +28,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/queue"
 
@@ -35,18 +36,22 @@ import (
 )
 
 type pendingWorkloadsInCqREST struct {
-	queueMgr *queue.Manager
-	log      logr.Logger
+	queueMgr  *queue.Manager
+	cache     *cache.Cache
+	dryRunner FlavorsDryRunner
+	log       logr.Logger
 }
 
 var _ rest.Storage = &pendingWorkloadsInCqREST{}
 var _ rest.GetterWithOptions = &pendingWorkloadsInCqREST{}
 var _ rest.Scoper = &pendingWorkloadsInCqREST{}
 
-func NewPendingWorkloadsInCqREST(kueueMgr *queue.Manager) *pendingWorkloadsInCqREST {
+func NewPendingWorkloadsInCqREST(kueueMgr *queue.Manager, cache *cache.Cache, dryRunner FlavorsDryRunner) *pendingWorkloadsInCqREST {
 	return &pendingWorkloadsInCqREST{
-		queueMgr: kueueMgr,
-		log:      ctrl.Log.WithName("pending-workload-in-cq"),
+		queueMgr:  kueueMgr,
+		cache:     cache,
+		dryRunner: dryRunner,
+		log:       ctrl.Log.WithName("pending-workload-in-cq"),
 	}
 }
 
@@ -60,7 +65,7 @@ func (m *pendingWorkloadsInCqREST) Destroy() {}
 
 // Get implements rest.GetterWithOptions interface
 // It fetches information about pending workloads and returns according to query params
-func (m *pendingWorkloadsInCqREST) Get(_ context.Context, name string, opts runtime.Object) (runtime.Object, error) {
+func (m *pendingWorkloadsInCqREST) Get(ctx context.Context, name string, opts runtime.Object) (runtime.Object, error) {
 	pendingWorkloadOpts, ok := opts.(*visibility.PendingWorkloadOptions)
 	if !ok {
 		return nil, fmt.Errorf("invalid options object: %#v", opts)
@@ -75,6 +80,10 @@ func (m *pendingWorkloadsInCqREST) Get(_ context.Context, name string, opts runt
 	}
 
 	localQueuePositions := make(map[string]int32, 0)
+	estimator := newStartTimeEstimator(m.cache, kueue.ClusterQueueReference(name))
+	// The pending workloads are evaluated from the head of the ClusterQueue,
+	// beyond the requested page, to summarize its blocking resources.
+	dryRunResults := flavorsDryRun(ctx, m.log, m.dryRunner, kueue.ClusterQueueReference(name), pendingWorkloadsInfo)
 
 	for index := 0; index < int(offset+limit) && index < len(pendingWorkloadsInfo); index++ {
 		// Update positions in LocalQueue
@@ -82,13 +91,24 @@ func (m *pendingWorkloadsInCqREST) Get(_ context.Context, name string, opts runt
 		queueName := wlInfo.Obj.Spec.QueueName
 		positionInLocalQueue := localQueuePositions[queueName]
 		localQueuePositions[queueName]++
+		startTime, confidence := estimator.next(wlInfo)
 
 		if index >= int(offset) {
 			// Add a workload to results
-			wls = append(wls, *newPendingWorkload(wlInfo, positionInLocalQueue, index))
+			wl := newPendingWorkload(wlInfo, effectivePriority(m.queueMgr, kueue.ClusterQueueReference(name), wlInfo), positionInLocalQueue, index)
+			wl.EstimatedStartTime = startTime
+			wl.EstimateConfidence = confidence
+			if index < len(dryRunResults) {
+				setInadmissibilityReasons(wl, &dryRunResults[index])
+			}
+			wls = append(wls, *wl)
 		}
 	}
-	return &visibility.PendingWorkloadsSummary{Items: wls}, nil
+	summary := &visibility.PendingWorkloadsSummary{Items: wls}
+	if dryRunResults != nil {
+		summary.TopBlockingResources = topBlockingResources(dryRunResults)
+	}
+	return summary, nil
 }
 
 // NewGetOptions creates a new options object
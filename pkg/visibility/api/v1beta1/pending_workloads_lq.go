@@ -28,25 +28,31 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
 
 	_ "k8s.io/metrics/pkg/apis/metrics/install"
 )
 
 type pendingWorkloadsInLqREST struct {
-	queueMgr *queue.Manager
-	log      logr.Logger
+	queueMgr  *queue.Manager
+	cache     *cache.Cache
+	dryRunner FlavorsDryRunner
+	log       logr.Logger
 }
 
 var _ rest.Storage = &pendingWorkloadsInLqREST{}
 var _ rest.GetterWithOptions = &pendingWorkloadsInLqREST{}
 var _ rest.Scoper = &pendingWorkloadsInLqREST{}
 
-func NewPendingWorkloadsInLqREST(kueueMgr *queue.Manager) *pendingWorkloadsInLqREST {
+func NewPendingWorkloadsInLqREST(kueueMgr *queue.Manager, cache *cache.Cache, dryRunner FlavorsDryRunner) *pendingWorkloadsInLqREST {
 	return &pendingWorkloadsInLqREST{
-		queueMgr: kueueMgr,
-		log:      ctrl.Log.WithName("pending-workload-in-lq"),
+		queueMgr:  kueueMgr,
+		cache:     cache,
+		dryRunner: dryRunner,
+		log:       ctrl.Log.WithName("pending-workload-in-lq"),
 	}
 }
 
@@ -75,20 +81,32 @@ func (m *pendingWorkloadsInLqREST) Get(ctx context.Context, name string, opts ru
 	}
 
 	wls := make([]visibility.PendingWorkload, 0, limit)
+	wlInfos := make([]*workload.Info, 0, limit)
 	skippedWls := 0
+	estimator := newStartTimeEstimator(m.cache, cqName)
 	for index, wlInfo := range m.queueMgr.PendingWorkloadsInfo(cqName) {
 		if len(wls) >= int(limit) {
 			break
 		}
+		startTime, confidence := estimator.next(wlInfo)
 		if wlInfo.Obj.Spec.QueueName == name {
 			if skippedWls < int(offset) {
 				skippedWls++
 			} else {
 				// Add a workload to results
-				wls = append(wls, *newPendingWorkload(wlInfo, int32(len(wls)+int(offset)), index))
+				wl := newPendingWorkload(wlInfo, effectivePriority(m.queueMgr, cqName, wlInfo), int32(len(wls)+int(offset)), index)
+				wl.EstimatedStartTime = startTime
+				wl.EstimateConfidence = confidence
+				wls = append(wls, *wl)
+				wlInfos = append(wlInfos, wlInfo)
 			}
 		}
 	}
+	if dryRunResults := flavorsDryRun(ctx, m.log, m.dryRunner, cqName, wlInfos); dryRunResults != nil {
+		for i := range dryRunResults {
+			setInadmissibilityReasons(&wls[i], &dryRunResults[i])
+		}
+	}
 
 	return &visibility.PendingWorkloadsSummary{Items: wls}, nil
 }
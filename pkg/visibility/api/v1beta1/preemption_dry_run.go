@@ -0,0 +1,147 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// PreemptionDryRunner simulates the nomination of a pending workload
+// without admitting it nor issuing preemptions.
+type PreemptionDryRunner interface {
+	PreemptionDryRun(ctx context.Context, wl workload.Info) (*scheduler.DryRunResult, error)
+}
+
+type preemptionDryRunREST struct {
+	queueMgr  *queue.Manager
+	dryRunner PreemptionDryRunner
+	log       logr.Logger
+}
+
+var _ rest.Storage = &preemptionDryRunREST{}
+var _ rest.Getter = &preemptionDryRunREST{}
+var _ rest.Scoper = &preemptionDryRunREST{}
+
+func NewPreemptionDryRunREST(kueueMgr *queue.Manager, dryRunner PreemptionDryRunner) *preemptionDryRunREST {
+	return &preemptionDryRunREST{
+		queueMgr:  kueueMgr,
+		dryRunner: dryRunner,
+		log:       ctrl.Log.WithName("preemption-dry-run"),
+	}
+}
+
+// New implements rest.Storage interface
+func (m *preemptionDryRunREST) New() runtime.Object {
+	return &visibility.PreemptionDryRun{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *preemptionDryRunREST) Destroy() {}
+
+// Get implements rest.Getter interface
+// It simulates the admission of the pending workload and returns the flavors
+// that would be assigned, and the workloads that would be preempted.
+func (m *preemptionDryRunREST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	namespace := genericapirequest.NamespaceValue(ctx)
+	wlInfo := m.queueMgr.PendingWorkloadInfo(queue.QueueKey(namespace, name))
+	if wlInfo == nil {
+		return nil, errors.NewNotFound(visibility.Resource("workload"), name)
+	}
+	res, err := m.dryRunner.PreemptionDryRun(ctx, *wlInfo)
+	if err != nil {
+		m.log.Error(err, "Failed to run preemption dry run", "workload", klog.KObj(wlInfo.Obj))
+		return nil, errors.NewInternalError(err)
+	}
+	return newPreemptionDryRun(wlInfo, res), nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *preemptionDryRunREST) NamespaceScoped() bool {
+	return true
+}
+
+func newPreemptionDryRun(wlInfo *workload.Info, res *scheduler.DryRunResult) *visibility.PreemptionDryRun {
+	dryRun := &visibility.PreemptionDryRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              wlInfo.Obj.Name,
+			Namespace:         wlInfo.Obj.Namespace,
+			CreationTimestamp: wlInfo.Obj.CreationTimestamp,
+		},
+		ClusterQueue:      string(wlInfo.ClusterQueue),
+		Mode:              res.Assignment.RepresentativeMode().String(),
+		Admissible:        res.Admissible(),
+		PodSets:           make([]visibility.PodSetFlavors, 0, len(res.Assignment.PodSets)),
+		PreemptionTargets: make([]visibility.PreemptionTarget, 0, len(res.PreemptionTargets)),
+		Message:           res.InadmissibleMsg,
+	}
+	for _, ps := range res.Assignment.PodSets {
+		psFlavors := visibility.PodSetFlavors{
+			Name:    string(ps.Name),
+			Count:   ps.Count,
+			Mode:    ps.RepresentativeMode().String(),
+			Message: ps.Status.Message(),
+		}
+		if len(ps.Flavors) > 0 {
+			psFlavors.Flavors = make(map[corev1.ResourceName]string, len(ps.Flavors))
+			for res, flv := range ps.Flavors {
+				psFlavors.Flavors[res] = string(flv.Name)
+			}
+		}
+		dryRun.PodSets = append(dryRun.PodSets, psFlavors)
+	}
+	for _, target := range res.PreemptionTargets {
+		dryRun.PreemptionTargets = append(dryRun.PreemptionTargets, visibility.PreemptionTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.WorkloadInfo.Obj.Name,
+				Namespace: target.WorkloadInfo.Obj.Namespace,
+				UID:       target.WorkloadInfo.Obj.UID,
+			},
+			ClusterQueue: string(target.WorkloadInfo.ClusterQueue),
+			Priority:     priority.Priority(target.WorkloadInfo.Obj),
+			Reason:       target.Reason,
+		})
+	}
+	for _, protected := range res.ProtectedWorkloads {
+		dryRun.ProtectedWorkloads = append(dryRun.ProtectedWorkloads, visibility.ProtectedWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      protected.WorkloadInfo.Obj.Name,
+				Namespace: protected.WorkloadInfo.Obj.Namespace,
+				UID:       protected.WorkloadInfo.Obj.UID,
+			},
+			ClusterQueue:   string(protected.WorkloadInfo.ClusterQueue),
+			Priority:       priority.Priority(protected.WorkloadInfo.Obj),
+			ProtectedUntil: metav1.NewTime(protected.Until),
+		})
+	}
+	return dryRun
+}
@@ -0,0 +1,199 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestPreemptionDryRun(t *testing.T) {
+	const (
+		nsName   = "ns"
+		cqName   = "cq"
+		lqName   = "lq"
+		lowPrio  = 50
+		highPrio = 100
+	)
+
+	now := time.Now()
+	cq := utiltesting.MakeClusterQueue(cqName).
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Preemption(kueue.ClusterQueuePreemption{
+			WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+		}).
+		Obj()
+	admitted := utiltesting.MakeWorkload("admitted", nsName).
+		Queue(lqName).
+		Priority(lowPrio).
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuota(utiltesting.MakeAdmission(cqName).Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+		Obj()
+
+	cases := map[string]struct {
+		workloads    []*kueue.Workload
+		wlName       string
+		wantDryRun   *visibility.PreemptionDryRun
+		wantErrMatch func(error) bool
+	}{
+		"workload fits": {
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("pending", nsName).Queue(lqName).Priority(lowPrio).Creation(now).
+					Request(corev1.ResourceCPU, "1").Obj(),
+			},
+			wlName: "pending",
+			wantDryRun: &visibility.PreemptionDryRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pending",
+					Namespace:         nsName,
+					CreationTimestamp: metav1.NewTime(now),
+				},
+				ClusterQueue: cqName,
+				Mode:         "Fit",
+				Admissible:   true,
+				PodSets: []visibility.PodSetFlavors{{
+					Name:    "main",
+					Count:   1,
+					Flavors: map[corev1.ResourceName]string{corev1.ResourceCPU: "default"},
+					Mode:    "Fit",
+				}},
+			},
+		},
+		"workload requires preemption": {
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("pending", nsName).Queue(lqName).Priority(highPrio).Creation(now).
+					Request(corev1.ResourceCPU, "2").Obj(),
+			},
+			wlName: "pending",
+			wantDryRun: &visibility.PreemptionDryRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pending",
+					Namespace:         nsName,
+					CreationTimestamp: metav1.NewTime(now),
+				},
+				ClusterQueue: cqName,
+				Mode:         "Preempt",
+				Admissible:   true,
+				PodSets: []visibility.PodSetFlavors{{
+					Name:    "main",
+					Count:   1,
+					Flavors: map[corev1.ResourceName]string{corev1.ResourceCPU: "default"},
+					Mode:    "Preempt",
+					Message: "insufficient unused quota for cpu in flavor default, 1 more needed",
+				}},
+				PreemptionTargets: []visibility.PreemptionTarget{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "admitted",
+						Namespace: nsName,
+					},
+					ClusterQueue: cqName,
+					Priority:     lowPrio,
+					Reason:       kueue.InClusterQueueReason,
+				}},
+				Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+			},
+		},
+		"workload doesn't fit and can't preempt": {
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("pending", nsName).Queue(lqName).Priority(lowPrio).Creation(now).
+					Request(corev1.ResourceCPU, "2").Obj(),
+			},
+			wlName: "pending",
+			wantDryRun: &visibility.PreemptionDryRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pending",
+					Namespace:         nsName,
+					CreationTimestamp: metav1.NewTime(now),
+				},
+				ClusterQueue: cqName,
+				Mode:         "Preempt",
+				PodSets: []visibility.PodSetFlavors{{
+					Name:    "main",
+					Count:   1,
+					Flavors: map[corev1.ResourceName]string{corev1.ResourceCPU: "default"},
+					Mode:    "Preempt",
+					Message: "insufficient unused quota for cpu in flavor default, 1 more needed",
+				}},
+				Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+			},
+		},
+		"workload not pending": {
+			wlName:       "admitted",
+			wantErrMatch: errors.IsNotFound,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: []kueue.Workload{*admitted}}).
+				WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName}}).
+				Build()
+			cqCache := cache.New(cl)
+			manager := queue.NewManager(cl, cqCache)
+			go manager.CleanUpOnContext(ctx)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+			}
+			if err := manager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
+			}
+			if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lqName, nsName).ClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding queue %q: %v", lqName, err)
+			}
+			for _, w := range tc.workloads {
+				if err := manager.AddOrUpdateWorkload(w); err != nil {
+					t.Fatalf("Failed to add or update workload :%v", err)
+				}
+			}
+			sched := scheduler.New(manager, cqCache, cl, &utiltesting.EventRecorder{})
+			preemptionDryRunRest := NewPreemptionDryRunREST(manager, sched)
+
+			ctx = request.WithNamespace(ctx, nsName)
+			got, err := preemptionDryRunRest.Get(ctx, tc.wlName, &metav1.GetOptions{})
+			switch {
+			case tc.wantErrMatch != nil:
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			case err != nil:
+				t.Error(err)
+			default:
+				if diff := cmp.Diff(tc.wantDryRun, got, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("Preemption dry run differs: (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+// SchedulingDecisionsLister lists the latest scheduling decisions recorded
+// by the scheduler.
+type SchedulingDecisionsLister interface {
+	List(match func(*visibility.SchedulingDecision) bool) []visibility.SchedulingDecision
+}
+
+// schedulingDecisionsREST serves the scheduling decisions of a workload, when
+// namespaced, or of a ClusterQueue.
+type schedulingDecisionsREST struct {
+	lister     SchedulingDecisionsLister
+	namespaced bool
+}
+
+var _ rest.Storage = &schedulingDecisionsREST{}
+var _ rest.Getter = &schedulingDecisionsREST{}
+var _ rest.Scoper = &schedulingDecisionsREST{}
+
+func NewSchedulingDecisionsInCqREST(lister SchedulingDecisionsLister) *schedulingDecisionsREST {
+	return &schedulingDecisionsREST{lister: lister}
+}
+
+func NewSchedulingDecisionsInWlREST(lister SchedulingDecisionsLister) *schedulingDecisionsREST {
+	return &schedulingDecisionsREST{lister: lister, namespaced: true}
+}
+
+// New implements rest.Storage interface
+func (m *schedulingDecisionsREST) New() runtime.Object {
+	return &visibility.SchedulingDecisions{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *schedulingDecisionsREST) Destroy() {}
+
+// Get implements rest.Getter interface
+// It returns the latest scheduling decisions recorded for the workload or the
+// ClusterQueue, oldest first.
+func (m *schedulingDecisionsREST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	namespace := genericapirequest.NamespaceValue(ctx)
+	match := func(d *visibility.SchedulingDecision) bool {
+		return d.ClusterQueue == name
+	}
+	if m.namespaced {
+		match = func(d *visibility.SchedulingDecision) bool {
+			return d.Workload.Namespace == namespace && d.Workload.Name == name
+		}
+	}
+	items := m.lister.List(match)
+	if items == nil {
+		items = []visibility.SchedulingDecision{}
+	}
+	return &visibility.SchedulingDecisions{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Items: items,
+	}, nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *schedulingDecisionsREST) NamespaceScoped() bool {
+	return m.namespaced
+}
@@ -0,0 +1,103 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/decisionlog"
+)
+
+func TestSchedulingDecisions(t *testing.T) {
+	decision := func(cycle int64, namespace, name, cq string) visibility.SchedulingDecision {
+		return visibility.SchedulingDecision{
+			SchedulingCycle: cycle,
+			Workload:        visibility.WorkloadReference{Namespace: namespace, Name: name},
+			ClusterQueue:    cq,
+		}
+	}
+	ring := decisionlog.NewRing(10)
+	ring.Write(context.Background(), []visibility.SchedulingDecision{
+		decision(1, "ns1", "a", "cq1"),
+		decision(1, "ns2", "a", "cq2"),
+	})
+	ring.Write(context.Background(), []visibility.SchedulingDecision{
+		decision(2, "ns1", "a", "cq1"),
+		decision(2, "ns1", "b", "cq2"),
+	})
+
+	cases := map[string]struct {
+		storage   *schedulingDecisionsREST
+		namespace string
+		name      string
+		want      *visibility.SchedulingDecisions
+	}{
+		"decisions of a ClusterQueue": {
+			storage: NewSchedulingDecisionsInCqREST(ring),
+			name:    "cq2",
+			want: &visibility.SchedulingDecisions{
+				ObjectMeta: metav1.ObjectMeta{Name: "cq2"},
+				Items: []visibility.SchedulingDecision{
+					decision(1, "ns2", "a", "cq2"),
+					decision(2, "ns1", "b", "cq2"),
+				},
+			},
+		},
+		"decisions of a workload": {
+			storage:   NewSchedulingDecisionsInWlREST(ring),
+			namespace: "ns1",
+			name:      "a",
+			want: &visibility.SchedulingDecisions{
+				ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1"},
+				Items: []visibility.SchedulingDecision{
+					decision(1, "ns1", "a", "cq1"),
+					decision(2, "ns1", "a", "cq1"),
+				},
+			},
+		},
+		"no decisions recorded": {
+			storage:   NewSchedulingDecisionsInWlREST(ring),
+			namespace: "ns2",
+			name:      "b",
+			want: &visibility.SchedulingDecisions{
+				ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns2"},
+				Items:      []visibility.SchedulingDecision{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.namespace != "" {
+				ctx = request.WithNamespace(ctx, tc.namespace)
+			}
+			got, err := tc.storage.Get(ctx, tc.name, &metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected response (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
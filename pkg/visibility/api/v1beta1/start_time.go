@@ -0,0 +1,199 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	estimateConfidenceHigh   = "High"
+	estimateConfidenceMedium = "Medium"
+	estimateConfidenceLow    = "Low"
+)
+
+// startTimeEstimator estimates when the pending workloads of a ClusterQueue
+// start, assuming they start in queue order, as the quota is released by the
+// workloads reserving it and by the pending workloads ahead of them.
+type startTimeEstimator struct {
+	forecast *cache.Forecast
+	// free is the quota available at the estimated start time of the
+	// previous workload.
+	free resources.FlavorResourceQuantities
+	// releases are the quota releases not accounted in free yet, in
+	// chronological order.
+	releases []cache.QuotaRelease
+	// last is the estimated start time of the previous workload.
+	last time.Time
+	// confidence is the lowest confidence of the releases accounted in free.
+	confidence string
+	// blocked indicates that the start time of the next workloads can't be
+	// estimated, because a workload ahead of them, in a StrictFIFO
+	// ClusterQueue, never fits.
+	blocked bool
+}
+
+// newStartTimeEstimator returns an estimator for the pending workloads of the
+// ClusterQueue, or nil if the start times aren't estimated.
+func newStartTimeEstimator(c *cache.Cache, cqName kueue.ClusterQueueReference) *startTimeEstimator {
+	if c == nil || !features.Enabled(features.StartTimeEstimation) {
+		return nil
+	}
+	forecast, err := c.Forecast(cqName)
+	if err != nil {
+		return nil
+	}
+	return &startTimeEstimator{
+		forecast:   forecast,
+		free:       maps.Clone(forecast.Available),
+		releases:   slices.Clone(forecast.Releases),
+		last:       forecast.Time,
+		confidence: estimateConfidenceHigh,
+	}
+}
+
+// next returns the estimated start time of the next pending workload, in
+// queue order, and the confidence of the estimate. It returns nil if the
+// start time can't be estimated.
+func (e *startTimeEstimator) next(wlInfo *workload.Info) (*metav1.Time, string) {
+	if e == nil || e.blocked {
+		return nil, ""
+	}
+	requests := totalRequests(wlInfo)
+	free := maps.Clone(e.free)
+	start := e.last
+	confidence := e.confidence
+	applied := 0
+	usage, fits := e.assignFlavors(requests, free)
+	for !fits {
+		if applied == len(e.releases) {
+			e.blocked = e.forecast.QueueingStrategy == kueue.StrictFIFO
+			return nil, ""
+		}
+		release := e.releases[applied]
+		for fr, q := range release.Usage {
+			free[fr] += q
+		}
+		if release.Time.After(start) {
+			start = release.Time
+		}
+		confidence = lowerConfidence(confidence, runtimeConfidence(release.Source))
+		applied++
+		usage, fits = e.assignFlavors(requests, free)
+	}
+
+	estimateConfidence := confidence
+	for fr, q := range usage {
+		if free[fr]-e.forecast.Borrowable[fr] < q {
+			estimateConfidence = estimateConfidenceLow
+		}
+		free[fr] -= q
+	}
+	e.free = free
+	e.releases = e.releases[applied:]
+	e.last = start
+	e.confidence = confidence
+	if runtime, source := e.forecast.Runtime(wlInfo.Obj); source != cache.RuntimeUnknown {
+		e.addRelease(cache.QuotaRelease{
+			Time:   start.Add(runtime),
+			Usage:  usage,
+			Source: source,
+		})
+	}
+	return &metav1.Time{Time: start}, estimateConfidence
+}
+
+// assignFlavors returns the usage of the requests in the first flavor of each
+// resource group in which the requested resources of the group fit, as the
+// flavor assigner does. It returns false if the requests don't fit.
+func (e *startTimeEstimator) assignFlavors(requests map[corev1.ResourceName]int64, free resources.FlavorResourceQuantities) (resources.FlavorResourceQuantities, bool) {
+	usage := make(resources.FlavorResourceQuantities, len(requests))
+	for _, rg := range e.forecast.ResourceGroups {
+		var groupRequests []corev1.ResourceName
+		for res := range requests {
+			if rg.CoveredResources.Has(res) {
+				groupRequests = append(groupRequests, res)
+			}
+		}
+		if len(groupRequests) == 0 {
+			continue
+		}
+		flavor := slices.IndexFunc(rg.Flavors, func(f kueue.ResourceFlavorReference) bool {
+			for _, res := range groupRequests {
+				if free[resources.FlavorResource{Flavor: f, Resource: res}] < requests[res] {
+					return false
+				}
+			}
+			return true
+		})
+		if flavor < 0 {
+			return nil, false
+		}
+		for _, res := range groupRequests {
+			usage[resources.FlavorResource{Flavor: rg.Flavors[flavor], Resource: res}] = requests[res]
+		}
+	}
+	// The resources not covered by any resource group never fit.
+	return usage, len(usage) == len(requests)
+}
+
+func (e *startTimeEstimator) addRelease(release cache.QuotaRelease) {
+	i := slices.IndexFunc(e.releases, func(r cache.QuotaRelease) bool {
+		return r.Time.After(release.Time)
+	})
+	if i < 0 {
+		i = len(e.releases)
+	}
+	e.releases = slices.Insert(e.releases, i, release)
+}
+
+// totalRequests returns the requests of the workload, summed over its pod sets.
+func totalRequests(wlInfo *workload.Info) map[corev1.ResourceName]int64 {
+	requests := make(map[corev1.ResourceName]int64)
+	for _, ps := range wlInfo.TotalRequests {
+		for res, q := range ps.Requests {
+			requests[res] += q
+		}
+	}
+	return requests
+}
+
+func runtimeConfidence(source cache.RuntimeSource) string {
+	if source == cache.RuntimeFromMaxExecutionTime {
+		return estimateConfidenceHigh
+	}
+	return estimateConfidenceMedium
+}
+
+func lowerConfidence(a, b string) string {
+	rank := map[string]int{estimateConfidenceLow: 0, estimateConfidenceMedium: 1, estimateConfidenceHigh: 2}
+	if rank[a] < rank[b] {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,239 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestPendingWorkloadsStartTime(t *testing.T) {
+	const (
+		nsName = "ns"
+		lqName = "lq"
+		cqName = "cq"
+	)
+
+	type estimate struct {
+		Name       string
+		StartTime  *metav1.Time
+		Confidence string
+	}
+
+	now := time.Now().Truncate(time.Second)
+	at := func(d time.Duration) *metav1.Time {
+		return ptr.To(metav1.NewTime(now.Add(d)))
+	}
+	cq := func() *utiltesting.ClusterQueueWrapper {
+		return utiltesting.MakeClusterQueue(cqName).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj())
+	}
+	running := func(name, cpu string, admittedAt time.Time) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, nsName).
+			Queue(lqName).
+			Request(corev1.ResourceCPU, cpu).
+			ReserveQuotaAt(utiltesting.MakeAdmission(cqName).Assignment(corev1.ResourceCPU, "default", cpu).Obj(), admittedAt).
+			AdmittedAt(true, admittedAt)
+	}
+	pending := func(name, cpu string, created time.Time) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, nsName).
+			Queue(lqName).
+			Priority(0).
+			Request(corev1.ResourceCPU, cpu).
+			Creation(created)
+	}
+
+	cases := map[string]struct {
+		disableFeature bool
+		clusterQueues  []*kueue.ClusterQueue
+		finished       []*kueue.Workload
+		admitted       []*kueue.Workload
+		pending        []*kueue.Workload
+		want           []estimate
+	}{
+		"maximum execution times": {
+			clusterQueues: []*kueue.ClusterQueue{cq().Obj()},
+			admitted: []*kueue.Workload{
+				running("running", "3", now.Add(-100*time.Second)).MaximumExecutionTimeSeconds(600).Obj(),
+			},
+			pending: []*kueue.Workload{
+				pending("fits-now", "1", now.Add(-4*time.Second)).Obj(),
+				pending("after-running", "2", now.Add(-3*time.Second)).MaximumExecutionTimeSeconds(60).Obj(),
+				pending("never-fits", "4", now.Add(-2*time.Second)).Obj(),
+				pending("behind", "1", now.Add(-time.Second)).Obj(),
+			},
+			want: []estimate{
+				{Name: "fits-now", StartTime: at(0), Confidence: estimateConfidenceHigh},
+				{Name: "after-running", StartTime: at(500 * time.Second), Confidence: estimateConfidenceHigh},
+				{Name: "never-fits"},
+				{Name: "behind", StartTime: at(500 * time.Second), Confidence: estimateConfidenceHigh},
+			},
+		},
+		"StrictFIFO holds the workloads behind one which never fits": {
+			clusterQueues: []*kueue.ClusterQueue{cq().QueueingStrategy(kueue.StrictFIFO).Obj()},
+			admitted: []*kueue.Workload{
+				running("running", "3", now.Add(-100*time.Second)).MaximumExecutionTimeSeconds(600).Obj(),
+			},
+			pending: []*kueue.Workload{
+				pending("fits-now", "1", now.Add(-3*time.Second)).Obj(),
+				pending("never-fits", "4", now.Add(-2*time.Second)).Obj(),
+				pending("behind", "1", now.Add(-time.Second)).Obj(),
+			},
+			want: []estimate{
+				{Name: "fits-now", StartTime: at(0), Confidence: estimateConfidenceHigh},
+				{Name: "never-fits"},
+				{Name: "behind"},
+			},
+		},
+		"the workloads fit in a single flavor of each resource group": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqName).
+					QueueingStrategy(kueue.StrictFIFO).
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "2").Obj(),
+						*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "2").Obj(),
+					).
+					Obj(),
+			},
+			pending: []*kueue.Workload{
+				pending("never-fits", "4", now.Add(-2*time.Second)).Obj(),
+				pending("behind", "1", now.Add(-time.Second)).Obj(),
+			},
+			want: []estimate{
+				{Name: "never-fits"},
+				{Name: "behind"},
+			},
+		},
+		"runtimes from the history of the LocalQueue": {
+			clusterQueues: []*kueue.ClusterQueue{cq().Obj()},
+			finished: []*kueue.Workload{
+				running("finished", "1", now.Add(-1000*time.Second)).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadFinished,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-800 * time.Second)),
+					}).
+					Obj(),
+			},
+			admitted: []*kueue.Workload{
+				running("running", "4", now.Add(-50*time.Second)).Obj(),
+			},
+			pending: []*kueue.Workload{
+				pending("first", "2", now.Add(-3*time.Second)).Obj(),
+				pending("second", "2", now.Add(-2*time.Second)).Obj(),
+				pending("third", "1", now.Add(-time.Second)).Obj(),
+			},
+			want: []estimate{
+				{Name: "first", StartTime: at(150 * time.Second), Confidence: estimateConfidenceMedium},
+				{Name: "second", StartTime: at(150 * time.Second), Confidence: estimateConfidenceMedium},
+				{Name: "third", StartTime: at(350 * time.Second), Confidence: estimateConfidenceMedium},
+			},
+		},
+		"borrowing from the cohort": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqName).
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("lender").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			pending: []*kueue.Workload{
+				pending("nominal", "1", now.Add(-2*time.Second)).Obj(),
+				pending("borrowing", "3", now.Add(-time.Second)).Obj(),
+			},
+			want: []estimate{
+				{Name: "nominal", StartTime: at(0), Confidence: estimateConfidenceHigh},
+				{Name: "borrowing", StartTime: at(0), Confidence: estimateConfidenceLow},
+			},
+		},
+		"feature disabled": {
+			disableFeature: true,
+			clusterQueues:  []*kueue.ClusterQueue{cq().Obj()},
+			pending: []*kueue.Workload{
+				pending("fits-now", "1", now.Add(-time.Second)).Obj(),
+			},
+			want: []estimate{
+				{Name: "fits-now"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.StartTimeEstimation, !tc.disableFeature)
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewFakeClient()
+			cqCache := cache.New(cl, cache.WithClock(testingclock.NewFakeClock(now)))
+			manager := queue.NewManager(cl, cqCache)
+			for _, rf := range []string{"default", "on-demand", "spot"} {
+				cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor(rf).Obj())
+			}
+			for _, cq := range tc.clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Adding cluster queue %s to the cache: %v", cq.Name, err)
+				}
+				if err := manager.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Adding cluster queue %s to the manager: %v", cq.Name, err)
+				}
+			}
+			if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lqName, nsName).ClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding queue %q: %v", lqName, err)
+			}
+			for _, wl := range tc.finished {
+				cqCache.ObserveFinishedWorkload(wl)
+			}
+			for _, wl := range tc.admitted {
+				cqCache.AddOrUpdateWorkload(wl)
+			}
+			for _, wl := range tc.pending {
+				if err := manager.AddOrUpdateWorkload(wl); err != nil {
+					t.Fatalf("Adding workload %q: %v", wl.Name, err)
+				}
+			}
+
+			info, err := NewPendingWorkloadsInCqREST(manager, cqCache, nil).Get(ctx, cqName, &visibility.PendingWorkloadOptions{
+				Limit: constants.DefaultPendingWorkloadsLimit,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var got []estimate
+			for _, wl := range info.(*visibility.PendingWorkloadsSummary).Items {
+				got = append(got, estimate{Name: wl.Name, StartTime: wl.EstimatedStartTime, Confidence: wl.EstimateConfidence})
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected estimates (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
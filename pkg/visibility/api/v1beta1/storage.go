@@ -19,14 +19,26 @@ package v1beta1
 import (
 	"k8s.io/apiserver/pkg/registry/rest"
 
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
-func NewStorage(mgr *queue.Manager) map[string]rest.Storage {
-	return map[string]rest.Storage{
-		"clusterqueues":                  NewCqREST(),
-		"clusterqueues/pendingworkloads": NewPendingWorkloadsInCqREST(mgr),
-		"localqueues":                    NewLqREST(),
-		"localqueues/pendingworkloads":   NewPendingWorkloadsInLqREST(mgr),
+// NewStorage returns the storages of the visibility API. The subresources
+// serving the scheduling decisions are only installed if they are recorded.
+func NewStorage(mgr *queue.Manager, cache *cache.Cache, dryRunner DryRunner, decisions SchedulingDecisionsLister) map[string]rest.Storage {
+	storage := map[string]rest.Storage{
+		"clusterqueues":                   NewCqREST(),
+		"clusterqueues/pendingworkloads":  NewPendingWorkloadsInCqREST(mgr, cache, dryRunner),
+		"clusterqueues/admittedworkloads": NewAdmittedWorkloadsInCqREST(cache),
+		"localqueues":                     NewLqREST(),
+		"localqueues/pendingworkloads":    NewPendingWorkloadsInLqREST(mgr, cache, dryRunner),
+		"localqueues/admittedworkloads":   NewAdmittedWorkloadsInLqREST(mgr, cache),
+		"workloads":                       NewWlREST(),
+		"workloads/preemptiondryrun":      NewPreemptionDryRunREST(mgr, dryRunner),
 	}
+	if decisions != nil {
+		storage["clusterqueues/schedulingdecisions"] = NewSchedulingDecisionsInCqREST(decisions)
+		storage["workloads/schedulingdecisions"] = NewSchedulingDecisionsInWlREST(decisions)
+	}
+	return storage
 }
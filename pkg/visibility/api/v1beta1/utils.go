@@ -17,32 +17,130 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
-func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq int) *visibility.PendingWorkload {
-	ownerReferences := make([]metav1.OwnerReference, 0, len(wlInfo.Obj.OwnerReferences))
-	for _, ref := range wlInfo.Obj.OwnerReferences {
-		ownerReferences = append(ownerReferences, metav1.OwnerReference{
-			APIVersion: ref.APIVersion,
-			Kind:       ref.Kind,
-			Name:       ref.Name,
-			UID:        ref.UID,
-		})
-	}
+func newPendingWorkload(wlInfo *workload.Info, effectivePriority *int32, positionInLq int32, positionInCq int) *visibility.PendingWorkload {
 	return &visibility.PendingWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              wlInfo.Obj.Name,
 			Namespace:         wlInfo.Obj.Namespace,
-			OwnerReferences:   ownerReferences,
+			OwnerReferences:   ownerReferences(wlInfo.Obj),
 			CreationTimestamp: wlInfo.Obj.CreationTimestamp,
 		},
 		PositionInClusterQueue: int32(positionInCq),
 		Priority:               *wlInfo.Obj.Spec.Priority,
+		EffectivePriority:      effectivePriority,
 		LocalQueueName:         wlInfo.Obj.Spec.QueueName,
 		PositionInLocalQueue:   positionInLq,
 	}
 }
+
+// ownerReferences returns the owner references of the workload, keeping
+// only the fields identifying the owners.
+func ownerReferences(wl *kueue.Workload) []metav1.OwnerReference {
+	refs := make([]metav1.OwnerReference, 0, len(wl.OwnerReferences))
+	for _, ref := range wl.OwnerReferences {
+		refs = append(refs, metav1.OwnerReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			UID:        ref.UID,
+		})
+	}
+	return refs
+}
+
+// effectivePriority returns the effective priority of the workload, or nil
+// if the ClusterQueue has no priority aging.
+func effectivePriority(queueMgr *queue.Manager, cqName kueue.ClusterQueueReference, wlInfo *workload.Info) *int32 {
+	if p, aging := queueMgr.EffectivePriority(cqName, wlInfo); aging {
+		return &p
+	}
+	return nil
+}
+
+// validateAdmittedWorkloadOptions rejects the negative offsets and limits.
+func validateAdmittedWorkloadOptions(opts *visibility.AdmittedWorkloadOptions) error {
+	if opts.Offset < 0 {
+		return apierrors.NewBadRequest(fmt.Sprintf("offset must be greater than or equal to 0, got %d", opts.Offset))
+	}
+	if opts.Limit < 0 {
+		return apierrors.NewBadRequest(fmt.Sprintf("limit must be greater than or equal to 0, got %d", opts.Limit))
+	}
+	return nil
+}
+
+// admittedWorkloadsPage returns the page of the admitted workloads selected by
+// the options, ordered by quota reservation time.
+func admittedWorkloadsPage(admitted []cache.AdmittedWorkload, opts *visibility.AdmittedWorkloadOptions) []visibility.AdmittedWorkload {
+	wls := make([]visibility.AdmittedWorkload, 0, len(admitted))
+	for _, aw := range admitted {
+		wls = append(wls, *newAdmittedWorkload(aw))
+	}
+	slices.SortFunc(wls, func(a, b visibility.AdmittedWorkload) int {
+		if c := a.QuotaReservationTime.Compare(b.QuotaReservationTime.Time); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	start := min(int(opts.Offset), len(wls))
+	end := min(start+int(opts.Limit), len(wls))
+	return wls[start:end]
+}
+
+func newAdmittedWorkload(aw cache.AdmittedWorkload) *visibility.AdmittedWorkload {
+	wl := aw.Info.Obj
+	result := &visibility.AdmittedWorkload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              wl.Name,
+			Namespace:         wl.Namespace,
+			OwnerReferences:   ownerReferences(wl),
+			CreationTimestamp: wl.CreationTimestamp,
+		},
+		Priority:       utilpriority.Priority(wl),
+		LocalQueueName: wl.Spec.QueueName,
+		ClusterQueue:   string(aw.ClusterQueue),
+		Borrowing:      aw.Borrowing,
+	}
+	if wl.Status.Admission != nil {
+		result.PodSets = make([]visibility.PodSetUsage, 0, len(wl.Status.Admission.PodSetAssignments))
+		for _, psa := range wl.Status.Admission.PodSetAssignments {
+			flavors := make(map[corev1.ResourceName]string, len(psa.Flavors))
+			for res, flv := range psa.Flavors {
+				flavors[res] = string(flv)
+			}
+			result.PodSets = append(result.PodSets, visibility.PodSetUsage{
+				Name:          string(psa.Name),
+				Count:         ptr.Deref(psa.Count, 0),
+				Flavors:       flavors,
+				ResourceUsage: psa.ResourceUsage.DeepCopy(),
+			})
+		}
+	}
+	if c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved); c != nil && c.Status == metav1.ConditionTrue {
+		result.QuotaReservationTime = c.LastTransitionTime
+	}
+	if c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted); c != nil && c.Status == metav1.ConditionTrue {
+		result.AdmissionTime = c.LastTransitionTime.DeepCopy()
+	}
+	return result
+}
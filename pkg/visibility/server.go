@@ -36,8 +36,10 @@ import (
 
 	generatedopenapi "sigs.k8s.io/kueue/apis/visibility/openapi"
 	visibilityv1beta1 "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/visibility/api"
+	apiv1beta1 "sigs.k8s.io/kueue/pkg/visibility/api/v1beta1"
 
 	_ "k8s.io/component-base/metrics/prometheus/restclient" // for client-go metrics registration
 )
@@ -58,8 +60,9 @@ var (
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas,verbs=list;watch
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas/status,verbs=patch
 
-// CreateAndStartVisibilityServer creates visibility server injecting KueueManager and starts it
-func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager) {
+// CreateAndStartVisibilityServer creates visibility server injecting KueueManager, the cache, the scheduler's
+// dry runner and the recorded scheduling decisions, if any, and starts it
+func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager, cache *cache.Cache, dryRunner apiv1beta1.DryRunner, decisions apiv1beta1.SchedulingDecisionsLister) {
 	config := newVisibilityServerConfig()
 	if err := applyVisibilityServerOptions(config); err != nil {
 		setupLog.Error(err, "Unable to apply VisibilityServerOptions")
@@ -72,7 +75,7 @@ func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager
 		os.Exit(1)
 	}
 
-	if err := api.Install(visibilityServer, kueueMgr); err != nil {
+	if err := api.Install(visibilityServer, kueueMgr, cache, dryRunner, decisions); err != nil {
 		setupLog.Error(err, "Unable to install visibility.kueue.x-k8s.io API")
 		os.Exit(1)
 	}
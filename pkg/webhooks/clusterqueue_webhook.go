@@ -18,6 +18,7 @@ package webhooks
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -109,6 +110,9 @@ func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 		allErrs = append(allErrs, validatePreemption(cq.Spec.Preemption, path.Child("preemption"))...)
 	}
 	allErrs = append(allErrs, validateFairSharing(cq.Spec.FairSharing, path.Child("fairSharing"))...)
+	if cq.Spec.PriorityAging != nil {
+		allErrs = append(allErrs, validatePriorityAging(cq.Spec.PriorityAging, path.Child("priorityAging"))...)
+	}
 	return allErrs
 }
 
@@ -116,6 +120,14 @@ func ValidateClusterQueueUpdate(newObj *kueue.ClusterQueue) field.ErrorList {
 	return ValidateClusterQueue(newObj)
 }
 
+func validatePriorityAging(aging *kueue.PriorityAging, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if aging.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("interval"), aging.Interval.Duration.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
 func validatePreemption(preemption *kueue.ClusterQueuePreemption, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	if preemption.ReclaimWithinCohort == kueue.PreemptionPolicyNever &&
@@ -187,6 +199,50 @@ func validateFlavorQuotas(flavorQuotas kueue.FlavorQuotas, coveredResources []co
 			allErrs = append(allErrs, validateLimit(*rq.LendingLimit, config, lendingLimitPath)...)
 			allErrs = append(allErrs, validateLendingLimit(*rq.LendingLimit, rq.NominalQuota, config, lendingLimitPath)...)
 		}
+		if features.Enabled(features.QuotaSchedules) {
+			allErrs = append(allErrs, validateQuotaSchedules(rq, config, path.Child("schedules"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateQuotaSchedules(rq kueue.ResourceQuota, config validationConfig, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, schedule := range rq.Schedules {
+		path := path.Index(i)
+		if _, err := time.Parse("15:04", schedule.StartTime); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("startTime"), schedule.StartTime, err.Error()))
+		}
+		if _, err := time.Parse("15:04", schedule.EndTime); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("endTime"), schedule.EndTime, err.Error()))
+		}
+		if schedule.TimeZone != nil {
+			if _, err := time.LoadLocation(*schedule.TimeZone); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), *schedule.TimeZone, err.Error()))
+			}
+		}
+		nominalQuota := rq.NominalQuota
+		if schedule.NominalQuota != nil {
+			nominalQuota = *schedule.NominalQuota
+			allErrs = append(allErrs, validateResourceQuantity(nominalQuota, path.Child("nominalQuota"))...)
+		}
+		if schedule.BorrowingLimit != nil {
+			borrowingLimitPath := path.Child("borrowingLimit")
+			allErrs = append(allErrs, validateLimit(*schedule.BorrowingLimit, config, borrowingLimitPath)...)
+			allErrs = append(allErrs, validateResourceQuantity(*schedule.BorrowingLimit, borrowingLimitPath)...)
+		}
+		if features.Enabled(features.LendingLimit) {
+			lendingLimit := rq.LendingLimit
+			if schedule.LendingLimit != nil {
+				lendingLimit = schedule.LendingLimit
+				lendingLimitPath := path.Child("lendingLimit")
+				allErrs = append(allErrs, validateResourceQuantity(*lendingLimit, lendingLimitPath)...)
+				allErrs = append(allErrs, validateLimit(*lendingLimit, config, lendingLimitPath)...)
+			}
+			if lendingLimit != nil {
+				allErrs = append(allErrs, validateLendingLimit(*lendingLimit, nominalQuota, config, path.Child("lendingLimit"))...)
+			}
+		}
 	}
 	return allErrs
 }
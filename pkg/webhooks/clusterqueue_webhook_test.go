@@ -18,6 +18,7 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -56,6 +57,28 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Invalid(resourceGroupsPath.Index(0).Child("coveredResources").Index(0), "@cpu", ""),
 			},
 		},
+		{
+			name: "valid priority aging",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				PriorityAging(kueue.PriorityAging{
+					Interval:     metav1.Duration{Duration: time.Minute},
+					Increment:    1,
+					MaxIncrement: 10,
+				}).
+				Obj(),
+		},
+		{
+			name: "invalid priority aging interval",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				PriorityAging(kueue.PriorityAging{
+					Increment:    1,
+					MaxIncrement: 10,
+				}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("priorityAging", "interval"), "0s", ""),
+			},
+		},
 		{
 			name: "admissionChecks defined",
 			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
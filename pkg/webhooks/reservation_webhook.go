@@ -0,0 +1,104 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+)
+
+const durationIsNotPositiveErrorMsg = "must be greater than 0"
+
+type ReservationWebhook struct{}
+
+func setupWebhookForReservation(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kueuealpha.Reservation{}).
+		WithValidator(&ReservationWebhook{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1alpha1-reservation,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=reservations,verbs=create;update,versions=v1alpha1,name=vreservation.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &ReservationWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ReservationWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	reservation := obj.(*kueuealpha.Reservation)
+	log := ctrl.LoggerFrom(ctx).WithName("reservation-webhook")
+	log.V(5).Info("Validating Reservation create")
+	return nil, ValidateReservation(reservation).ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ReservationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	reservation := newObj.(*kueuealpha.Reservation)
+	log := ctrl.LoggerFrom(ctx).WithName("reservation-webhook")
+	log.V(5).Info("Validating Reservation update")
+	return nil, ValidateReservation(reservation).ToAggregate()
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ReservationWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func ValidateReservation(r *kueuealpha.Reservation) field.ErrorList {
+	path := field.NewPath("spec")
+	var allErrs field.ErrorList
+	if r.Spec.ClusterQueue != "" {
+		allErrs = append(allErrs, validateReferenceName(string(r.Spec.ClusterQueue), path.Child("clusterQueue"))...)
+	}
+	if r.Spec.Cohort != "" {
+		allErrs = append(allErrs, validateReferenceName(string(r.Spec.Cohort), path.Child("cohort"))...)
+	}
+	for i, f := range r.Spec.Flavors {
+		flavorPath := path.Child("flavors").Index(i)
+		allErrs = append(allErrs, validateReferenceName(string(f.Name), flavorPath.Child("name"))...)
+		for _, name := range slices.Sorted(maps.Keys(f.Resources)) {
+			resourcePath := flavorPath.Child("resources").Key(string(name))
+			allErrs = append(allErrs, validateResourceName(name, resourcePath)...)
+			allErrs = append(allErrs, validateResourceQuantity(f.Resources[name], resourcePath)...)
+		}
+	}
+	if r.Spec.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("duration"), r.Spec.Duration.Duration.String(), durationIsNotPositiveErrorMsg))
+	}
+	if r.Spec.DrainDuration != nil && r.Spec.DrainDuration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("drainDuration"), r.Spec.DrainDuration.Duration.String(), apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	return allErrs
+}
+
+func validateReferenceName(name string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	return allErrs
+}
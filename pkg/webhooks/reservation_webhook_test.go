@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestValidateReservation(t *testing.T) {
+	now := time.Now()
+	specPath := field.NewPath("spec")
+	testcases := map[string]struct {
+		reservation *kueuealpha.Reservation
+		wantErr     field.ErrorList
+	}{
+		"valid ClusterQueue reservation": {
+			reservation: utiltesting.MakeReservation("reservation", now, time.Hour).
+				ClusterQueue("cq").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				DrainDuration(10 * time.Minute).
+				Obj(),
+		},
+		"valid Cohort reservation": {
+			reservation: utiltesting.MakeReservation("reservation", now, time.Hour).
+				Cohort("cohort").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				Obj(),
+		},
+		"invalid names": {
+			reservation: utiltesting.MakeReservation("reservation", now, time.Hour).
+				Cohort("Cohort").
+				Flavor("@default", corev1.ResourceList{"@cpu": resource.MustParse("4")}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("cohort"), "Cohort", ""),
+				field.Invalid(specPath.Child("flavors").Index(0).Child("name"), "@default", ""),
+				field.Invalid(specPath.Child("flavors").Index(0).Child("resources").Key("@cpu"), corev1.ResourceName("@cpu"), ""),
+			},
+		},
+		"negative quantity": {
+			reservation: utiltesting.MakeReservation("reservation", now, time.Hour).
+				ClusterQueue("cq").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("flavors").Index(0).Child("resources").Key("cpu"), "-1", ""),
+			},
+		},
+		"invalid durations": {
+			reservation: utiltesting.MakeReservation("reservation", now, 0).
+				ClusterQueue("cq").
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+				DrainDuration(-time.Minute).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("duration"), "0s", ""),
+				field.Invalid(specPath.Child("drainDuration"), "-1m0s", ""),
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := ValidateReservation(tc.reservation)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("ValidateReservation() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
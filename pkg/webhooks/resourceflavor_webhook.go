@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -93,6 +94,21 @@ func ValidateResourceFlavor(rf *kueue.ResourceFlavor) field.ErrorList {
 
 	allErrs = append(allErrs, validateNodeTaints(rf.Spec.NodeTaints, specPath.Child("nodeTaints"))...)
 	allErrs = append(allErrs, validateTolerations(rf.Spec.Tolerations, specPath.Child("tolerations"))...)
+	allErrs = append(allErrs, validateCosts(rf.Spec.Costs, specPath.Child("costs"))...)
+	return allErrs
+}
+
+func validateCosts(costs []kueue.ResourceCost, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, cost := range costs {
+		idxPath := fldPath.Index(i)
+		if cost.Price.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("price"), cost.Price.String(), apimachineryvalidation.IsNegativeErrorMsg))
+		}
+		if cost.Unit != nil && cost.Unit.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("unit"), cost.Unit.String(), "must be greater than 0"))
+		}
+	}
 	return allErrs
 }
 
@@ -22,7 +22,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -63,6 +65,28 @@ func TestValidateResourceFlavor(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "nodeLabels"), "@abc", ""),
 			},
 		},
+		{
+			name: "valid costs",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				Cost(corev1.ResourceCPU, "0.5").
+				Cost(corev1.ResourceMemory, "0").
+				Obj(),
+		},
+		{
+			name: "invalid costs",
+			rf: func() *kueue.ResourceFlavor {
+				rf := utiltesting.MakeResourceFlavor("resource-flavor").
+					Cost(corev1.ResourceCPU, "-1").
+					Cost(corev1.ResourceMemory, "1").
+					Obj()
+				rf.Spec.Costs[1].Unit = ptr.To(resource.MustParse("0"))
+				return rf
+			}(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "costs").Index(0).Child("price"), "-1", ""),
+				field.Invalid(field.NewPath("spec", "costs").Index(1).Child("unit"), "0", ""),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -39,5 +39,9 @@ func Setup(mgr ctrl.Manager) (string, error) {
 		return "Cohort", err
 	}
 
+	if err := setupWebhookForReservation(mgr); err != nil {
+		return "Reservation", err
+	}
+
 	return "", nil
 }
@@ -0,0 +1,120 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"slices"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+)
+
+// DependenciesState is the state of the dependencies of a workload.
+type DependenciesState int
+
+const (
+	// DependenciesPending means that some dependencies didn't finish.
+	DependenciesPending DependenciesState = iota
+	// DependenciesSucceeded means that all the dependencies finished successfully.
+	DependenciesSucceeded
+	// DependenciesFailed means that a dependency finished without succeeding.
+	DependenciesFailed
+)
+
+// Dependencies returns the names of the jobs, in the namespace of the
+// workload, whose workloads need to finish successfully before the workload
+// is queued.
+func Dependencies(wl *kueue.Workload) []string {
+	return indexer.IndexWorkloadDependencies(wl)
+}
+
+// WaitingForDependencies returns whether the workload declares dependencies
+// which weren't observed finished successfully yet.
+func WaitingForDependencies(wl *kueue.Workload) bool {
+	if len(Dependencies(wl)) == 0 {
+		return false
+	}
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadRequeued)
+	return cond == nil || (cond.Status == metav1.ConditionFalse && cond.Reason == kueue.WorkloadWaitingForDependencies)
+}
+
+// DependencyName returns the name under which the workload can be named as a
+// dependency: the name of the job owning it, or its own name if it isn't
+// owned by a job.
+func DependencyName(wl *kueue.Workload) string {
+	if owner := metav1.GetControllerOf(wl); owner != nil {
+		return owner.Name
+	}
+	return wl.Name
+}
+
+// DependsOn returns whether the workload names the other workload as a
+// dependency.
+func DependsOn(wl, other *kueue.Workload) bool {
+	return wl.Namespace == other.Namespace && slices.Contains(Dependencies(wl), DependencyName(other))
+}
+
+// DependenciesStatus returns the state of the dependencies of the workload
+// and, if the state is DependenciesFailed, the name of the failed dependency.
+// A dependency whose workload doesn't exist is considered pending.
+func DependenciesStatus(ctx context.Context, c client.Reader, wl *kueue.Workload) (DependenciesState, string, error) {
+	deps := Dependencies(wl)
+	if len(deps) == 0 {
+		return DependenciesSucceeded, "", nil
+	}
+	var workloads kueue.WorkloadList
+	if err := c.List(ctx, &workloads, client.InNamespace(wl.Namespace)); err != nil {
+		return DependenciesPending, "", err
+	}
+	succeeded := make(map[string]bool, len(deps))
+	failed := make(map[string]bool)
+	for i := range workloads.Items {
+		dep := &workloads.Items[i]
+		name := DependencyName(dep)
+		if !slices.Contains(deps, name) {
+			continue
+		}
+		cond := apimeta.FindStatusCondition(dep.Status.Conditions, kueue.WorkloadFinished)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			continue
+		}
+		switch cond.Reason {
+		case kueue.WorkloadFinishedReasonSucceeded:
+			succeeded[name] = true
+		case kueue.WorkloadFinishedReasonSliceReplaced:
+			// The job continues with the slice replacing this one.
+		default:
+			failed[name] = true
+		}
+	}
+	state := DependenciesSucceeded
+	for _, name := range deps {
+		switch {
+		case succeeded[name]:
+		case failed[name]:
+			return DependenciesFailed, name, nil
+		default:
+			state = DependenciesPending
+		}
+	}
+	return state, "", nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestDependencies(t *testing.T) {
+	cases := map[string]struct {
+		annotation string
+		want       []string
+	}{
+		"no annotation": {},
+		"single dependency": {
+			annotation: "prep",
+			want:       []string{"prep"},
+		},
+		"multiple dependencies": {
+			annotation: "prep, train,,prep",
+			want:       []string{"prep", "train"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := utiltesting.MakeWorkload("wl", "ns")
+			if tc.annotation != "" {
+				wl.Annotation(controllerconsts.DependsOnAnnotation, tc.annotation)
+			}
+			if diff := cmp.Diff(tc.want, Dependencies(wl.Obj())); diff != "" {
+				t.Errorf("Unexpected dependencies (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDependenciesStatus(t *testing.T) {
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	finished := func(name, owner, reason string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "ns").
+			ControllerReference(jobGVK, owner, owner+"-uid").
+			Condition(metav1.Condition{
+				Type:   kueue.WorkloadFinished,
+				Status: metav1.ConditionTrue,
+				Reason: reason,
+			}).
+			Obj()
+	}
+	cases := map[string]struct {
+		workloads  []client.Object
+		wantState  DependenciesState
+		wantFailed string
+	}{
+		"dependency not found": {
+			wantState: DependenciesPending,
+		},
+		"dependency running": {
+			workloads: []client.Object{
+				utiltesting.MakeWorkload("prep-wl", "ns").ControllerReference(jobGVK, "prep", "prep-uid").Obj(),
+				finished("train-wl", "train", kueue.WorkloadFinishedReasonSucceeded),
+			},
+			wantState: DependenciesPending,
+		},
+		"dependencies succeeded": {
+			workloads: []client.Object{
+				finished("prep-wl", "prep", kueue.WorkloadFinishedReasonSucceeded),
+				finished("train-wl", "train", kueue.WorkloadFinishedReasonSucceeded),
+			},
+			wantState: DependenciesSucceeded,
+		},
+		"dependency failed": {
+			workloads: []client.Object{
+				utiltesting.MakeWorkload("prep-wl", "ns").ControllerReference(jobGVK, "prep", "prep-uid").Obj(),
+				finished("train-wl", "train", kueue.WorkloadFinishedReasonFailed),
+			},
+			wantState:  DependenciesFailed,
+			wantFailed: "train",
+		},
+		"dependency in another namespace": {
+			workloads: []client.Object{
+				finished("prep-wl", "prep", kueue.WorkloadFinishedReasonSucceeded),
+				utiltesting.MakeWorkload("train-wl", "other").
+					ControllerReference(jobGVK, "train", "train-uid").
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadFinished,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadFinishedReasonSucceeded,
+					}).
+					Obj(),
+			},
+			wantState: DependenciesPending,
+		},
+		"replaced workload slice": {
+			workloads: []client.Object{
+				finished("prep-wl", "prep", kueue.WorkloadFinishedReasonSucceeded),
+				finished("train-wl-1", "train", kueue.WorkloadFinishedReasonSliceReplaced),
+				finished("train-wl-2", "train", kueue.WorkloadFinishedReasonSucceeded),
+			},
+			wantState: DependenciesSucceeded,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.workloads...).Build()
+			wl := utiltesting.MakeWorkload("wl", "ns").
+				Annotation(controllerconsts.DependsOnAnnotation, "prep,train").
+				Obj()
+			gotState, gotFailed, err := DependenciesStatus(context.Background(), cl, wl)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if gotState != tc.wantState {
+				t.Errorf("Unexpected state, want=%v, got=%v", tc.wantState, gotState)
+			}
+			if gotFailed != tc.wantFailed {
+				t.Errorf("Unexpected failed dependency, want=%q, got=%q", tc.wantFailed, gotFailed)
+			}
+		})
+	}
+}
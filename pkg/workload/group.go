@@ -0,0 +1,77 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"strconv"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// Group returns the name of the workload group of the workload, and the
+// number of workloads in the group. The name is empty if the workload
+// doesn't belong to a group.
+func Group(wl *kueue.Workload) (string, int) {
+	name := wl.Labels[controllerconsts.WorkloadGroupLabel]
+	if name == "" {
+		return "", 0
+	}
+	size, err := strconv.Atoi(wl.Annotations[controllerconsts.WorkloadGroupSizeAnnotation])
+	if err != nil || size < 1 {
+		size = 1
+	}
+	return name, size
+}
+
+// InSameGroup returns whether both workloads belong to the same workload group.
+func InSameGroup(wl, other *kueue.Workload) bool {
+	name, _ := Group(wl)
+	return name != "" && wl.Namespace == other.Namespace && other.Labels[controllerconsts.WorkloadGroupLabel] == name
+}
+
+// GroupPodSetName returns the name of the podset of a group member in the
+// workload combining the group.
+func GroupPodSetName(member *kueue.Workload, name kueue.PodSetReference) kueue.PodSetReference {
+	return kueue.PodSetReference(member.Name) + "/" + name
+}
+
+// MergeGroup returns the Info of a workload combining the podsets of the
+// members of a workload group, in order, so that the group can be assigned
+// flavors, and possibly preempt, as a whole. The podsets are renamed with
+// GroupPodSetName. The other fields are taken from the first member.
+func MergeGroup(members []*Info) *Info {
+	head := members[0]
+	merged := &Info{
+		Obj:          head.Obj.DeepCopy(),
+		ClusterQueue: head.ClusterQueue,
+	}
+	merged.Obj.Spec.PodSets = nil
+	for _, member := range members {
+		for _, ps := range member.Obj.Spec.PodSets {
+			ps := *ps.DeepCopy()
+			ps.Name = GroupPodSetName(member.Obj, ps.Name)
+			merged.Obj.Spec.PodSets = append(merged.Obj.Spec.PodSets, ps)
+		}
+		for _, psr := range member.TotalRequests {
+			psr.Name = GroupPodSetName(member.Obj, psr.Name)
+			psr.Requests = psr.Requests.Clone()
+			merged.TotalRequests = append(merged.TotalRequests, psr)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,115 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestGroup(t *testing.T) {
+	cases := map[string]struct {
+		workload *kueue.Workload
+		wantName string
+		wantSize int
+	}{
+		"not in a group": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Obj(),
+		},
+		"in a group": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "3").
+				Obj(),
+			wantName: "group",
+			wantSize: 3,
+		},
+		"invalid size": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Label(controllerconsts.WorkloadGroupLabel, "group").
+				Annotation(controllerconsts.WorkloadGroupSizeAnnotation, "NaN").
+				Obj(),
+			wantName: "group",
+			wantSize: 1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotName, gotSize := Group(tc.workload)
+			if gotName != tc.wantName || gotSize != tc.wantSize {
+				t.Errorf("Unexpected group, want=(%q, %d), got=(%q, %d)", tc.wantName, tc.wantSize, gotName, gotSize)
+			}
+		})
+	}
+}
+
+func TestMergeGroup(t *testing.T) {
+	server := NewInfo(utiltesting.MakeWorkload("server", "ns").
+		Label(controllerconsts.WorkloadGroupLabel, "group").
+		Request(corev1.ResourceCPU, "1").
+		Obj())
+	server.ClusterQueue = "cq"
+	workers := NewInfo(utiltesting.MakeWorkload("workers", "ns").
+		Label(controllerconsts.WorkloadGroupLabel, "group").
+		PodSets(
+			*utiltesting.MakePodSet("launcher", 1).Request(corev1.ResourceCPU, "1").Obj(),
+			*utiltesting.MakePodSet("worker", 4).Request(corev1.ResourceCPU, "2").Obj(),
+		).
+		Obj())
+
+	merged := MergeGroup([]*Info{server, workers})
+	if merged.ClusterQueue != "cq" {
+		t.Errorf("Unexpected ClusterQueue %q", merged.ClusterQueue)
+	}
+	wantRequests := []PodSetResources{
+		{
+			Name:     "server/main",
+			Requests: resources.Requests{corev1.ResourceCPU: 1000},
+			Count:    1,
+		},
+		{
+			Name:     "workers/launcher",
+			Requests: resources.Requests{corev1.ResourceCPU: 1000},
+			Count:    1,
+		},
+		{
+			Name:     "workers/worker",
+			Requests: resources.Requests{corev1.ResourceCPU: 8000},
+			Count:    4,
+		},
+	}
+	if diff := cmp.Diff(wantRequests, merged.TotalRequests); diff != "" {
+		t.Errorf("Unexpected total requests (-want,+got):\n%s", diff)
+	}
+	var gotPodSets []kueue.PodSetReference
+	for _, ps := range merged.Obj.Spec.PodSets {
+		gotPodSets = append(gotPodSets, ps.Name)
+	}
+	if diff := cmp.Diff([]kueue.PodSetReference{"server/main", "workers/launcher", "workers/worker"}, gotPodSets); diff != "" {
+		t.Errorf("Unexpected podsets (-want,+got):\n%s", diff)
+	}
+	if len(server.Obj.Spec.PodSets) != 1 || server.Obj.Spec.PodSets[0].Name != "main" {
+		t.Errorf("The member was modified: %v", server.Obj.Spec.PodSets)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"math"
+	"time"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+)
+
+// EffectivePriority returns the priority of the pending workload, raised by
+// the aging policy for the time the workload waited since its queue ordering
+// timestamp. Without an aging policy, it's the priority of the workload.
+func EffectivePriority(wl *kueue.Workload, aging *kueue.PriorityAging, ordering Ordering, now time.Time) int32 {
+	p := priority.Priority(wl)
+	if aging == nil || aging.Interval.Duration <= 0 {
+		return p
+	}
+	waited := now.Sub(ordering.GetQueueOrderTimestamp(wl).Time)
+	if waited < aging.Interval.Duration {
+		return p
+	}
+	raise := int64(aging.MaxIncrement)
+	if steps := int64(waited / aging.Interval.Duration); steps < raise {
+		raise = min(steps*int64(aging.Increment), raise)
+	}
+	return int32(min(int64(p)+raise, math.MaxInt32))
+}
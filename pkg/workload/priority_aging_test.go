@@ -0,0 +1,84 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestEffectivePriority(t *testing.T) {
+	now := time.Now()
+	aging := &kueue.PriorityAging{
+		Interval:     metav1.Duration{Duration: time.Minute},
+		Increment:    2,
+		MaxIncrement: 10,
+	}
+	cases := map[string]struct {
+		priority int32
+		aging    *kueue.PriorityAging
+		waited   time.Duration
+		want     int32
+	}{
+		"no aging": {
+			priority: 5,
+			waited:   time.Hour,
+			want:     5,
+		},
+		"waited less than an interval": {
+			priority: 5,
+			aging:    aging,
+			waited:   59 * time.Second,
+			want:     5,
+		},
+		"waited a few intervals": {
+			priority: 5,
+			aging:    aging,
+			waited:   3*time.Minute + time.Second,
+			want:     11,
+		},
+		"capped raise": {
+			priority: 5,
+			aging:    aging,
+			waited:   time.Hour,
+			want:     15,
+		},
+		"max priority": {
+			priority: math.MaxInt32 - 1,
+			aging:    aging,
+			waited:   time.Hour,
+			want:     math.MaxInt32,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := utiltesting.MakeWorkload("wl", "ns").
+				Priority(tc.priority).
+				Creation(now.Add(-tc.waited)).
+				Obj()
+			if got := EffectivePriority(wl, tc.aging, Ordering{}, now); got != tc.want {
+				t.Errorf("Unexpected effective priority %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -63,6 +63,7 @@ var (
 		kueue.WorkloadPreempted,
 		kueue.WorkloadRequeued,
 		kueue.WorkloadDeactivationTarget,
+		kueue.WorkloadPreemptionPending,
 	}
 )
 
@@ -499,6 +500,12 @@ func UnsetQuotaReservationWithCondition(wl *kueue.Workload, reason, message stri
 	if SyncAdmittedCondition(wl, now) {
 		changed = true
 	}
+
+	// The quota is released, so the pending preemption, if any, is no longer needed.
+	if IsPreemptionPending(wl) {
+		setPreemptionPendingFalse(wl, reason, message)
+		changed = true
+	}
 	return changed
 }
 
@@ -626,6 +633,48 @@ func SetDeactivationTarget(w *kueue.Workload, reason string, message string) {
 	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
 }
 
+// SetPreemptionPendingCondition marks the workload as selected for
+// preemption. The workload keeps its quota until the preemption is completed
+// by CompletePreemption.
+func SetPreemptionPendingCondition(w *kueue.Workload, reason string, message string) {
+	condition := metav1.Condition{
+		Type:               kueue.WorkloadPreemptionPending,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            api.TruncateConditionMessage(message),
+		ObservedGeneration: w.Generation,
+	}
+	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
+}
+
+func setPreemptionPendingFalse(w *kueue.Workload, reason string, message string) {
+	condition := metav1.Condition{
+		Type:               kueue.WorkloadPreemptionPending,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            api.TruncateConditionMessage(message),
+		ObservedGeneration: w.Generation,
+	}
+	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
+}
+
+// CompletePreemption evicts the workload whose preemption is pending, using
+// the reason and message of the preemption, and sets the PreemptionPending
+// condition to false with the given reason and message.
+// Returns whether the workload was evicted.
+func CompletePreemption(w *kueue.Workload, reason, message string, now time.Time) bool {
+	pending := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadPreemptionPending)
+	if pending == nil || pending.Status != metav1.ConditionTrue {
+		return false
+	}
+	preemptionReason, preemptionMessage := pending.Reason, pending.Message
+	SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, preemptionMessage)
+	ResetChecksOnEviction(w, now)
+	SetPreemptedCondition(w, preemptionReason, preemptionMessage)
+	setPreemptionPendingFalse(w, reason, message)
+	return true
+}
+
 func SetEvictedCondition(w *kueue.Workload, reason string, message string) {
 	condition := metav1.Condition{
 		Type:               kueue.WorkloadEvicted,
@@ -767,6 +816,18 @@ func IsAdmitted(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadAdmitted)
 }
 
+// MaxExecutionDeadline returns the time at which the admitted workload
+// exceeds its maximum execution time. It returns false if the workload isn't
+// admitted or doesn't declare a maximum execution time.
+func MaxExecutionDeadline(w *kueue.Workload) (time.Time, bool) {
+	admittedCondition := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadAdmitted)
+	if admittedCondition == nil || admittedCondition.Status != metav1.ConditionTrue || w.Spec.MaximumExecutionTimeSeconds == nil {
+		return time.Time{}, false
+	}
+	remaining := time.Duration(*w.Spec.MaximumExecutionTimeSeconds-ptr.Deref(w.Status.AccumulatedPastExexcutionTimeSeconds, 0)) * time.Second
+	return admittedCondition.LastTransitionTime.Add(remaining), true
+}
+
 // IsFinished returns true if the workload is finished.
 func IsFinished(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadFinished)
@@ -800,6 +861,12 @@ func IsEvictedByAdmissionCheck(w *kueue.Workload) (*metav1.Condition, bool) {
 	return cond, true
 }
 
+// IsPreemptionPending returns true if the workload was selected for
+// preemption, but it still holds its quota.
+func IsPreemptionPending(w *kueue.Workload) bool {
+	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPreemptionPending)
+}
+
 func IsEvicted(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionPresentAndEqual(w.Status.Conditions, kueue.WorkloadEvicted, metav1.ConditionTrue)
 }
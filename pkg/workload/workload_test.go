@@ -733,6 +733,70 @@ func TestIsEvictedByPodsReadyTimeout(t *testing.T) {
 	}
 }
 
+func TestCompletePreemption(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		workload    *kueue.Workload
+		wantChanged bool
+		want        *kueue.Workload
+	}{
+		"no pending preemption": {
+			workload: utiltesting.MakeWorkload("test", "test").Obj(),
+			want:     utiltesting.MakeWorkload("test", "test").Obj(),
+		},
+		"pending preemption": {
+			workload: utiltesting.MakeWorkload("test", "test").
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check", State: kueue.CheckStateReady}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPreemptionPending,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.InCohortReclamationReason,
+					Message: "Preempted to reclaim the quota",
+				}).
+				Obj(),
+			wantChanged: true,
+			want: utiltesting.MakeWorkload("test", "test").
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:               "check",
+					State:              kueue.CheckStatePending,
+					LastTransitionTime: metav1.NewTime(now),
+					Message:            "Reset to Pending after eviction. Previously: Ready",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPreemptionPending,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadGracefulStopAcknowledged,
+					Message: "The job acknowledged the graceful stop",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPreemption,
+					Message: "Preempted to reclaim the quota",
+				}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadPreempted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.InCohortReclamationReason,
+					Message: "Preempted to reclaim the quota",
+				}).
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := tc.workload.DeepCopy()
+			gotChanged := CompletePreemption(wl, kueue.WorkloadGracefulStopAcknowledged, "The job acknowledged the graceful stop", now)
+			if gotChanged != tc.wantChanged {
+				t.Errorf("Unexpected result from CompletePreemption, want %v, got %v", tc.wantChanged, gotChanged)
+			}
+			if diff := cmp.Diff(tc.want, wl, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected workload after CompletePreemption (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestFlavorResourceUsage(t *testing.T) {
 	cases := map[string]struct {
 		info *Info
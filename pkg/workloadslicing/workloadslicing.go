@@ -0,0 +1,131 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workloadslicing implements the in-place scaling of admitted jobs.
+//
+// An elastic job which is scaled up while admitted gets a new workload, called
+// a workload slice, for its new pod counts. The new slice replaces the admitted
+// one: once the new slice reserves quota, only the delta between the slices is
+// accounted, and once it's admitted, the replaced slice is finished.
+// When an elastic job is scaled down, the pods removed from the job are released
+// as reclaimable pods of its admitted slice.
+package workloadslicing
+
+import (
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	// EnabledAnnotationKey is the annotation which, set to EnabledAnnotationValue
+	// on a job, allows the job to be scaled while admitted.
+	EnabledAnnotationKey   = "kueue.x-k8s.io/elastic-job"
+	EnabledAnnotationValue = "true"
+
+	// ReplacementForAnnotationKey is the annotation set on a workload slice to
+	// the key of the admitted workload slice it replaces.
+	ReplacementForAnnotationKey = "kueue.x-k8s.io/workload-slice-replacement-for"
+
+	// SchedulingGate is the scheduling gate holding the pods of elastic jobs,
+	// until they are covered by the admitted workload slice.
+	SchedulingGate = "kueue.x-k8s.io/elastic-job"
+)
+
+// Enabled returns whether the object opted in to be scaled while admitted.
+func Enabled(obj metav1.Object) bool {
+	return features.Enabled(features.ElasticJobsViaWorkloadSlices) && obj.GetAnnotations()[EnabledAnnotationKey] == EnabledAnnotationValue
+}
+
+// ReplacementFor returns the key of the workload slice replaced by wl, if any.
+func ReplacementFor(wl *kueue.Workload) (string, bool) {
+	key, found := wl.Annotations[ReplacementForAnnotationKey]
+	return key, found && key != ""
+}
+
+// IsReplaced returns whether the workload slice was replaced by another slice.
+func IsReplaced(wl *kueue.Workload) bool {
+	c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadFinished)
+	return c != nil && c.Status == metav1.ConditionTrue && c.Reason == kueue.WorkloadFinishedReasonSliceReplaced
+}
+
+// ReplacedSlice returns the workload slice, among the workloads of a
+// ClusterQueue, which is replaced by wl.
+func ReplacedSlice(wl *kueue.Workload, workloads map[string]*workload.Info) *workload.Info {
+	key, found := ReplacementFor(wl)
+	if !found {
+		return nil
+	}
+	return workloads[key]
+}
+
+// ReclaimablePods returns the reclaimable pods of the admitted workload slice
+// of a job with the given PodSets: the pods removed by scaling the job down,
+// along with the reclaimable pods reported by the job.
+func ReclaimablePods(wl *kueue.Workload, podSets []kueue.PodSet, jobReclaimable []kueue.ReclaimablePod) []kueue.ReclaimablePod {
+	var result []kueue.ReclaimablePod
+	for i := range wl.Spec.PodSets {
+		ps := &wl.Spec.PodSets[i]
+		count := reclaimableCount(jobReclaimable, ps.Name)
+		if jobCount, found := podSetCount(podSets, ps.Name); found && jobCount < ps.Count {
+			count += ps.Count - jobCount
+		}
+		if count = min(count, ps.Count); count > 0 {
+			result = append(result, kueue.ReclaimablePod{Name: ps.Name, Count: count})
+		}
+	}
+	return result
+}
+
+// ScaledUp returns whether the job with the given PodSets needs more pods than
+// admitted for its workload slice. As the reclaimable pods of a workload can't
+// decrease, scaling a job up after scaling it down needs a new slice as well.
+func ScaledUp(wl *kueue.Workload, podSets []kueue.PodSet, jobReclaimable []kueue.ReclaimablePod) bool {
+	for i := range wl.Spec.PodSets {
+		ps := &wl.Spec.PodSets[i]
+		if jobCount, found := podSetCount(podSets, ps.Name); found && jobCount > ps.Count {
+			return true
+		}
+	}
+	reclaimable := ReclaimablePods(wl, podSets, jobReclaimable)
+	for _, rp := range wl.Status.ReclaimablePods {
+		if reclaimableCount(reclaimable, rp.Name) < rp.Count {
+			return true
+		}
+	}
+	return false
+}
+
+func podSetCount(podSets []kueue.PodSet, name kueue.PodSetReference) (int32, bool) {
+	for i := range podSets {
+		if podSets[i].Name == name {
+			return podSets[i].Count, true
+		}
+	}
+	return 0, false
+}
+
+func reclaimableCount(reclaimable []kueue.ReclaimablePod, name kueue.PodSetReference) int32 {
+	for _, rp := range reclaimable {
+		if rp.Name == name {
+			return rp.Count
+		}
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadslicing
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestReclaimablePods(t *testing.T) {
+	cases := map[string]struct {
+		workload       *kueue.Workload
+		podSets        []kueue.PodSet
+		jobReclaimable []kueue.ReclaimablePod
+		want           []kueue.ReclaimablePod
+		wantScaledUp   bool
+	}{
+		"unchanged job": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 4).Obj()).
+				Obj(),
+			podSets: []kueue.PodSet{*utiltesting.MakePodSet("main", 4).Obj()},
+		},
+		"scaled down": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).Obj(),
+					*utiltesting.MakePodSet("workers", 4).Obj(),
+				).
+				Obj(),
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("driver", 1).Obj(),
+				*utiltesting.MakePodSet("workers", 1).Obj(),
+			},
+			want: []kueue.ReclaimablePod{{Name: "workers", Count: 3}},
+		},
+		"scaled down, with reclaimable pods reported by the job": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 4).Obj()).
+				Obj(),
+			podSets:        []kueue.PodSet{*utiltesting.MakePodSet("main", 2).Obj()},
+			jobReclaimable: []kueue.ReclaimablePod{{Name: "main", Count: 3}},
+			want:           []kueue.ReclaimablePod{{Name: "main", Count: 4}},
+		},
+		"scaled up": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 4).Obj()).
+				Obj(),
+			podSets:      []kueue.PodSet{*utiltesting.MakePodSet("main", 6).Obj()},
+			wantScaledUp: true,
+		},
+		"scaled up after being scaled down": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 4).Obj()).
+				ReclaimablePods(kueue.ReclaimablePod{Name: "main", Count: 2}).
+				Obj(),
+			podSets:      []kueue.PodSet{*utiltesting.MakePodSet("main", 3).Obj()},
+			want:         []kueue.ReclaimablePod{{Name: "main", Count: 1}},
+			wantScaledUp: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ReclaimablePods(tc.workload, tc.podSets, tc.jobReclaimable)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected reclaimable pods (-want,+got):\n%s", diff)
+			}
+			if gotScaledUp := ScaledUp(tc.workload, tc.podSets, tc.jobReclaimable); gotScaledUp != tc.wantScaledUp {
+				t.Errorf("Unexpected scaled up, want=%v, got=%v", tc.wantScaledUp, gotScaledUp)
+			}
+		})
+	}
+}
@@ -95,6 +95,13 @@ rules:
   - get
   - patch
   - update
+- apiGroups:
+  - kueue.x-k8s.io
+  resources:
+  - clusterqueues
+  - localqueues
+  verbs:
+  - get
 - apiGroups:
   - kubeflow.org
   resources:
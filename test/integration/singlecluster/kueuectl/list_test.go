@@ -208,8 +208,8 @@ very-long-cluster-queue-name            0                   0
 
 			gomega.Expect(err).NotTo(gomega.HaveOccurred(), "%s: %s", err, output)
 			gomega.Expect(errOutput.String()).Should(gomega.BeEmpty())
-			gomega.Expect(output.String()).Should(gomega.Equal(fmt.Sprintf(`NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1                          lq1                         PENDING                                   %s
+			gomega.Expect(output.String()).Should(gomega.Equal(fmt.Sprintf(`NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+wl1                          lq1                         PENDING                                                     %s
 `,
 				duration.HumanDuration(executeTime.Sub(wl1.CreationTimestamp.Time)))))
 		})
@@ -227,10 +227,10 @@ wl1                          lq1                         PENDING
 
 			gomega.Expect(err).NotTo(gomega.HaveOccurred(), "%s: %s", err, output)
 			gomega.Expect(errOutput.String()).Should(gomega.BeEmpty())
-			gomega.Expect(output.String()).Should(gomega.Equal(fmt.Sprintf(`NAME                      JOB TYPE   JOB NAME   LOCALQUEUE                   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-very-long-workload-name                         lq1                                         PENDING                                   %s
-wl1                                             lq1                                         PENDING                                   %s
-wl2                                             very-long-local-queue-name                  PENDING                                   %s
+			gomega.Expect(output.String()).Should(gomega.Equal(fmt.Sprintf(`NAME                      JOB TYPE   JOB NAME   LOCALQUEUE                   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   ESTIMATED START   EXEC TIME   AGE
+very-long-workload-name                         lq1                                         PENDING                                                     %s
+wl1                                             lq1                                         PENDING                                                     %s
+wl2                                             very-long-local-queue-name                  PENDING                                                     %s
 `,
 				duration.HumanDuration(executeTime.Sub(wl3.CreationTimestamp.Time)),
 				duration.HumanDuration(executeTime.Sub(wl1.CreationTimestamp.Time)),
@@ -0,0 +1,183 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+const (
+	cohorts             = 100
+	queuesPerCohort     = 5
+	workloadsPerQueue   = 10
+	flavorsPerQueue     = 4
+	admissionTimeout    = 5 * time.Minute
+	admissionPollPeriod = 10 * time.Millisecond
+)
+
+// BenchmarkSchedule measures the time to admit the workloads pending in many
+// ClusterQueues of independent cohorts, with the cohort trees scheduled
+// sequentially or concurrently.
+func BenchmarkSchedule(b *testing.B) {
+	for _, parallel := range []bool{false, true} {
+		b.Run(fmt.Sprintf("parallel=%t", parallel), func(b *testing.B) {
+			features.SetFeatureGateDuringTest(b, features.ParallelScheduling, parallel)
+			for range b.N {
+				b.StopTimer()
+				env := newEnvironment(b)
+				b.StartTimer()
+				env.admitAll(b)
+			}
+		})
+	}
+}
+
+type environment struct {
+	cache         *cache.Cache
+	queues        *queue.Manager
+	scheduler     *scheduler.Scheduler
+	clusterQueues []*kueue.ClusterQueue
+}
+
+func newEnvironment(b *testing.B) *environment {
+	b.Helper()
+	ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+	var (
+		objs          []client.Object
+		flavors       []*kueue.ResourceFlavor
+		clusterQueues []*kueue.ClusterQueue
+		localQueues   []kueue.LocalQueue
+		workloads     []kueue.Workload
+	)
+	for f := range flavorsPerQueue {
+		flavors = append(flavors, utiltesting.MakeResourceFlavor(fmt.Sprintf("flavor-%d", f)).Obj())
+	}
+	for c := range cohorts {
+		for q := range queuesPerCohort {
+			name := fmt.Sprintf("cq-%d-%d", c, q)
+			objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			flavorQuotas := make([]kueue.FlavorQuotas, 0, flavorsPerQueue)
+			for _, rf := range flavors {
+				// Only the last flavor has enough quota, so that the flavor
+				// assignment goes through all of them.
+				quota := "1"
+				if rf == flavors[len(flavors)-1] {
+					quota = fmt.Sprint(workloadsPerQueue)
+				}
+				flavorQuotas = append(flavorQuotas, *utiltesting.MakeFlavorQuotas(rf.Name).
+					Resource(corev1.ResourceCPU, quota).
+					Resource(corev1.ResourceMemory, quota+"Gi").
+					Obj())
+			}
+			clusterQueues = append(clusterQueues, utiltesting.MakeClusterQueue(name).
+				Cohort(kueue.CohortReference(fmt.Sprintf("cohort-%d", c))).
+				NamespaceSelector(&metav1.LabelSelector{}).
+				ResourceGroup(flavorQuotas...).
+				Obj())
+			localQueues = append(localQueues, *utiltesting.MakeLocalQueue("main", name).ClusterQueue(name).Obj())
+			for w := range workloadsPerQueue {
+				workloads = append(workloads, *utiltesting.MakeWorkload(fmt.Sprintf("wl-%d", w), name).
+					Queue("main").
+					Request(corev1.ResourceCPU, "1").
+					Request(corev1.ResourceMemory, "1Gi").
+					Obj())
+			}
+		}
+	}
+	cl := utiltesting.NewClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+		WithObjects(objs...).
+		WithLists(&kueue.WorkloadList{Items: workloads}, &kueue.LocalQueueList{Items: localQueues}).
+		WithStatusSubresource(&kueue.Workload{}).
+		Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	for _, rf := range flavors {
+		cqCache.AddOrUpdateResourceFlavor(rf)
+	}
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			b.Fatalf("Inserting ClusterQueue %s in cache: %v", cq.Name, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+			b.Fatalf("Inserting ClusterQueue %s in manager: %v", cq.Name, err)
+		}
+	}
+	// The cache loads the LocalQueues of the ClusterQueues from the client,
+	// while the manager loads the workloads of the LocalQueues.
+	for i := range localQueues {
+		lq := &localQueues[i]
+		if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+			b.Fatalf("Inserting LocalQueue %s/%s in manager: %v", lq.Namespace, lq.Name, err)
+		}
+	}
+	return &environment{
+		cache:         cqCache,
+		queues:        qManager,
+		scheduler:     scheduler.New(qManager, cqCache, cl, &utiltesting.EventRecorder{}),
+		clusterQueues: clusterQueues,
+	}
+}
+
+// admitAll runs the scheduler until all the workloads are admitted.
+func (e *environment) admitAll(b *testing.B) {
+	b.Helper()
+	ctx, cancel := context.WithTimeout(ctrl.LoggerInto(context.Background(), logr.Discard()), admissionTimeout)
+	defer cancel()
+	go e.queues.CleanUpOnContext(ctx)
+	if err := e.scheduler.Start(ctx); err != nil {
+		b.Fatalf("Starting the scheduler: %v", err)
+	}
+	for !e.allAdmitted(b) {
+		select {
+		case <-ctx.Done():
+			b.Fatal("Timed out waiting for the workloads to be admitted")
+		case <-time.After(admissionPollPeriod):
+		}
+	}
+}
+
+func (e *environment) allAdmitted(b *testing.B) bool {
+	b.Helper()
+	for _, cq := range e.clusterQueues {
+		stats, err := e.cache.Usage(cq)
+		if err != nil {
+			b.Fatalf("Getting the usage of ClusterQueue %s: %v", cq.Name, err)
+		}
+		if stats.ReservingWorkloads < workloadsPerQueue {
+			return false
+		}
+	}
+	return true
+}